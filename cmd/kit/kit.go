@@ -167,6 +167,12 @@ func init() {
 
 	// set config defaults
 	viper.SetDefault("garbage-collect", false)
+	viper.SetDefault("controller.revision_history_limit", 10)
+	viper.SetDefault("controller.scheduler.strategy", "spread")
+	viper.SetDefault("controller.node.heartbeat_grace", 40)
+	viper.SetDefault("controller.node.eviction_timeout", 300)
+	viper.SetDefault("controller.metrics.host", "0.0.0.0")
+	viper.SetDefault("controller.metrics.port", 2968)
 
 	// local flags;
 	CLI.Flags().StringVarP(&config, "config", "c", "", "/path/to/config.yml")
@@ -19,6 +19,7 @@
 package api
 
 import (
+	"context"
 	"os"
 	"os/signal"
 	"syscall"
@@ -27,9 +28,11 @@ import (
 	"github.com/lastbackend/lastbackend/pkg/api/envs"
 	"github.com/lastbackend/lastbackend/pkg/api/http"
 	"github.com/lastbackend/lastbackend/pkg/api/runtime"
+	"github.com/lastbackend/lastbackend/pkg/distribution"
 	"github.com/lastbackend/lastbackend/pkg/distribution/types"
 	"github.com/lastbackend/lastbackend/pkg/log"
 	"github.com/lastbackend/lastbackend/pkg/storage"
+	"github.com/lastbackend/lastbackend/pkg/util/crypto"
 	"github.com/spf13/viper"
 )
 
@@ -42,13 +45,29 @@ func Daemon() bool {
 
 	log.Info("Start API server")
 
-	stg, err := storage.Get(viper.GetString("etcd"))
+	stg, err := storage.Get(viper.GetString("storage"))
 	if err != nil {
 		log.Fatalf("Cannot initialize storage: %v", err)
 	}
 
+	key := viper.GetString("api.secrets.key")
+	if key == "" {
+		log.Fatalf("Secrets encryption key is not set: generate it with `openssl rand -base64 32` " +
+			"and set it as api.secrets.key in config, the same key should be used by all api servers")
+	}
+
+	kp, err := crypto.NewAESGCMFromString(key)
+	if err != nil {
+		log.Fatalf("Cannot initialize secrets encryption key: %v", err)
+	}
+
+	if err := distribution.NewMigrationModel(context.Background(), stg).SecretsEncrypt(kp); err != nil {
+		log.Fatalf("Cannot migrate secrets: %v", err)
+	}
+
 	envs.Get().SetStorage(stg)
 	envs.Get().SetCache(cache.NewCache())
+	envs.Get().SetSecretKeyProvider(kp)
 
 	runtime.New().Run()
 
@@ -93,6 +93,20 @@ func (c *CacheNodeManifest) DelVolumeManifest(node, volume string) {
 	delete(c.manifests[node].Volumes, volume)
 }
 
+func (c *CacheNodeManifest) SetBuildManifest(node, build string, s *types.BuildManifest) {
+	log.Infof("%s:BuildManifestSet:> %s, %s, %#v", logCacheNode, node, build, s)
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.checkNode(node)
+
+	if c.manifests[node].Builds == nil {
+		c.manifests[node].Builds = make(map[string]*types.BuildManifest, 0)
+	}
+
+	c.manifests[node].Builds[build] = s
+}
+
 func (c *CacheNodeManifest) SetSubnetManifest(cidr string, s *types.SubnetManifest) {
 	c.lock.Lock()
 	defer c.lock.Unlock()
@@ -107,17 +121,50 @@ func (c *CacheNodeManifest) SetSubnetManifest(cidr string, s *types.SubnetManife
 	}
 }
 
-func (c *CacheNodeManifest) SetSecretManifest(name string, s *types.SecretManifest) {
+// SetSecretManifest sets secret manifest only for node with pods or builds using it
+func (c *CacheNodeManifest) SetSecretManifest(node, name string, s *types.SecretManifest) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if _, ok := c.manifests[node]; !ok {
+		return
+	}
+
+	if c.manifests[node].Secrets == nil {
+		c.manifests[node].Secrets = make(map[string]*types.SecretManifest)
+	}
+
+	c.manifests[node].Secrets[name] = s
+}
+
+// DelSecretManifest marks secret as destroyed for all nodes, manifest has no secret data
+func (c *CacheNodeManifest) DelSecretManifest(name string) {
 	c.lock.Lock()
 	defer c.lock.Unlock()
 
 	for n := range c.manifests {
 
-		if _, ok := c.manifests[n].Secrets[name]; !ok {
+		if c.manifests[n].Secrets == nil {
 			c.manifests[n].Secrets = make(map[string]*types.SecretManifest)
 		}
 
-		c.manifests[n].Secrets[name] = s
+		sm := new(types.SecretManifest)
+		sm.State = types.StateDestroyed
+		c.manifests[n].Secrets[name] = sm
+	}
+}
+
+func (c *CacheNodeManifest) SetConfigManifest(name string, s *types.ConfigManifest) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	for n := range c.manifests {
+
+		if c.manifests[n].Configs == nil {
+			c.manifests[n].Configs = make(map[string]*types.ConfigManifest)
+		}
+
+		c.manifests[n].Configs[name] = s
 	}
 }
 
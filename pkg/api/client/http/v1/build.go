@@ -0,0 +1,137 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+
+package v1
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+
+	rv1 "github.com/lastbackend/lastbackend/pkg/api/types/v1/request"
+	vv1 "github.com/lastbackend/lastbackend/pkg/api/types/v1/views"
+	"github.com/lastbackend/lastbackend/pkg/distribution/errors"
+	"github.com/lastbackend/lastbackend/pkg/util/http/request"
+)
+
+type BuildClient struct {
+	client *request.RESTClient
+
+	namespace string
+	name      string
+}
+
+func (bc *BuildClient) Create(ctx context.Context, opts *rv1.BuildCreateOptions) (*vv1.Build, error) {
+
+	body, err := opts.ToJson()
+	if err != nil {
+		return nil, err
+	}
+
+	var s *vv1.Build
+	var e *errors.Http
+
+	err = bc.client.Post(fmt.Sprintf("/namespace/%s/build", bc.namespace)).
+		AddHeader("Content-Type", "application/json").
+		Body(body).
+		JSON(&s, &e)
+
+	if err != nil {
+		return nil, err
+	}
+	if e != nil {
+		return nil, errors.New(e.Message)
+	}
+
+	return s, nil
+}
+
+func (bc *BuildClient) List(ctx context.Context) (*vv1.BuildList, error) {
+
+	var s *vv1.BuildList
+	var e *errors.Http
+
+	err := bc.client.Get(fmt.Sprintf("/namespace/%s/build", bc.namespace)).
+		AddHeader("Content-Type", "application/json").
+		JSON(&s, &e)
+
+	if err != nil {
+		return nil, err
+	}
+	if e != nil {
+		return nil, errors.New(e.Message)
+	}
+
+	if s == nil {
+		list := make(vv1.BuildList, 0)
+		s = &list
+	}
+
+	return s, nil
+}
+
+func (bc *BuildClient) Get(ctx context.Context) (*vv1.Build, error) {
+
+	var s *vv1.Build
+	var e *errors.Http
+
+	err := bc.client.Get(fmt.Sprintf("/namespace/%s/build/%s", bc.namespace, bc.name)).
+		AddHeader("Content-Type", "application/json").
+		JSON(&s, &e)
+
+	if err != nil {
+		return nil, err
+	}
+	if e != nil {
+		return nil, errors.New(e.Message)
+	}
+
+	return s, nil
+}
+
+func (bc *BuildClient) Remove(ctx context.Context, opts *rv1.BuildRemoveOptions) error {
+
+	req := bc.client.Delete(fmt.Sprintf("/namespace/%s/build/%s", bc.namespace, bc.name)).
+		AddHeader("Content-Type", "application/json")
+
+	if opts != nil {
+		if opts.Force {
+			req.Param("force", strconv.FormatBool(opts.Force))
+		}
+	}
+
+	var e *errors.Http
+
+	if err := req.JSON(nil, &e); err != nil {
+		return err
+	}
+	if e != nil {
+		return errors.New(e.Message)
+	}
+
+	return nil
+}
+
+func (bc *BuildClient) Logs(ctx context.Context) (io.ReadCloser, error) {
+	return bc.client.Get(fmt.Sprintf("/namespace/%s/build/%s/logs", bc.namespace, bc.name)).Stream()
+}
+
+func newBuildClient(req *request.RESTClient, namespace, name string) *BuildClient {
+	return &BuildClient{client: req, namespace: namespace, name: name}
+}
@@ -19,14 +19,59 @@
 package v1
 
 import (
+	"context"
+	"fmt"
+
+	rv1 "github.com/lastbackend/lastbackend/pkg/api/types/v1/request"
+	vv1 "github.com/lastbackend/lastbackend/pkg/api/types/v1/views"
+	"github.com/lastbackend/lastbackend/pkg/distribution/errors"
 	"github.com/lastbackend/lastbackend/pkg/util/http/request"
 )
 
 type EventsClient struct {
 	client *request.RESTClient
+
+	namespace string
+}
+
+func (ec *EventsClient) List(ctx context.Context, opts *rv1.EventListOptions) (*vv1.EventList, error) {
+
+	req := ec.client.Get(fmt.Sprintf("/namespace/%s/event", ec.namespace)).
+		AddHeader("Content-Type", "application/json")
+
+	if opts != nil {
+		if opts.Kind != "" {
+			req.Param("kind", opts.Kind)
+		}
+		if opts.Object != "" {
+			req.Param("object", opts.Object)
+		}
+		if opts.Reason != "" {
+			req.Param("reason", opts.Reason)
+		}
+		if opts.Severity != "" {
+			req.Param("severity", opts.Severity)
+		}
+	}
+
+	var s *vv1.EventList
+	var e *errors.Http
+
+	if err := req.JSON(&s, &e); err != nil {
+		return nil, err
+	}
+	if e != nil {
+		return nil, errors.New(e.Message)
+	}
+
+	if s == nil {
+		list := make(vv1.EventList, 0)
+		s = &list
+	}
+
+	return s, nil
 }
 
-func newEventsClient() *EventsClient {
-	s := new(EventsClient)
-	return s
+func newEventsClient(req *request.RESTClient, namespace string) *EventsClient {
+	return &EventsClient{client: req, namespace: namespace}
 }
@@ -81,6 +81,55 @@ func (nc *NamespaceClient) Volume(args ...string) types.VolumeClientV1 {
 	return newVolumeClient(nc.client, nc.name, name)
 }
 
+func (nc *NamespaceClient) Config(args ...string) types.ConfigClientV1 {
+	name := ""
+	// Get any parameters passed to us out of the args variable into "real"
+	// variables we created for them.
+	for i := range args {
+		switch i {
+		case 0: // hostname
+			name = args[0]
+		default:
+			panic("Wrong parameter count: (is allowed from 0 to 1)")
+		}
+	}
+	return newConfigClient(nc.client, nc.name, name)
+}
+
+func (nc *NamespaceClient) Secret(args ...string) types.SecretClientV1 {
+	name := ""
+	// Get any parameters passed to us out of the args variable into "real"
+	// variables we created for them.
+	for i := range args {
+		switch i {
+		case 0: // hostname
+			name = args[0]
+		default:
+			panic("Wrong parameter count: (is allowed from 0 to 1)")
+		}
+	}
+	return newSecretClient(nc.client, nc.name, name)
+}
+
+func (nc *NamespaceClient) Build(args ...string) types.BuildClientV1 {
+	name := ""
+	// Get any parameters passed to us out of the args variable into "real"
+	// variables we created for them.
+	for i := range args {
+		switch i {
+		case 0: // hostname
+			name = args[0]
+		default:
+			panic("Wrong parameter count: (is allowed from 0 to 1)")
+		}
+	}
+	return newBuildClient(nc.client, nc.name, name)
+}
+
+func (nc *NamespaceClient) Events() types.EventsClientV1 {
+	return newEventsClient(nc.client, nc.name)
+}
+
 func (nc *NamespaceClient) List(ctx context.Context) (*vv1.NamespaceList, error) {
 
 	var s *vv1.NamespaceList
@@ -142,3 +142,60 @@ func (nc NodeClient) Remove(ctx context.Context, opts *rv1.NodeRemoveOptions) er
 func newNodeClient(req *request.RESTClient, hostname string) *NodeClient {
 	return &NodeClient{client: req, hostname: hostname}
 }
+
+func (nc NodeClient) Cordon(ctx context.Context) (*vv1.Node, error) {
+
+	var s *vv1.Node
+	var e *errors.Http
+
+	err := nc.client.Put(fmt.Sprintf("/cluster/node/%s/cordon", nc.hostname)).
+		AddHeader("Content-Type", "application/json").
+		JSON(&s, &e)
+
+	if err != nil {
+		return nil, err
+	}
+	if e != nil {
+		return nil, errors.New(e.Message)
+	}
+
+	return s, nil
+}
+
+func (nc NodeClient) Uncordon(ctx context.Context) (*vv1.Node, error) {
+
+	var s *vv1.Node
+	var e *errors.Http
+
+	err := nc.client.Put(fmt.Sprintf("/cluster/node/%s/uncordon", nc.hostname)).
+		AddHeader("Content-Type", "application/json").
+		JSON(&s, &e)
+
+	if err != nil {
+		return nil, err
+	}
+	if e != nil {
+		return nil, errors.New(e.Message)
+	}
+
+	return s, nil
+}
+
+func (nc NodeClient) Drain(ctx context.Context) (*vv1.Node, error) {
+
+	var s *vv1.Node
+	var e *errors.Http
+
+	err := nc.client.Put(fmt.Sprintf("/cluster/node/%s/drain", nc.hostname)).
+		AddHeader("Content-Type", "application/json").
+		JSON(&s, &e)
+
+	if err != nil {
+		return nil, err
+	}
+	if e != nil {
+		return nil, errors.New(e.Message)
+	}
+
+	return s, nil
+}
@@ -184,6 +184,32 @@ func (sc *ServiceClient) Remove(ctx context.Context, opts *rv1.ServiceRemoveOpti
 	return nil
 }
 
+func (sc *ServiceClient) Rollback(ctx context.Context, opts *rv1.ServiceRollbackOptions) (*vv1.Service, error) {
+
+	req := sc.client.Post(fmt.Sprintf("/namespace/%s/service/%s/rollback", sc.namespace, sc.name)).
+		AddHeader("Content-Type", "application/json")
+
+	if opts != nil {
+		body, err := opts.ToJson()
+		if err != nil {
+			return nil, err
+		}
+		req.Body(body)
+	}
+
+	var s *vv1.Service
+	var e *errors.Http
+
+	if err := req.JSON(&s, &e); err != nil {
+		return nil, err
+	}
+	if e != nil {
+		return nil, errors.New(e.Message)
+	}
+
+	return s, nil
+}
+
 func (sc *ServiceClient) Logs(ctx context.Context, opts *rv1.ServiceLogsOptions) (io.ReadCloser, error) {
 
 	res := sc.client.Get(fmt.Sprintf("/namespace/%s/service/%s/logs", sc.namespace, sc.name))
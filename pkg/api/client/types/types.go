@@ -31,7 +31,6 @@ type ClientV1 interface {
 	Ingress(args ...string) IngressClientV1
 	Discovery(args ...string) DiscoveryClientV1
 	Namespace(args ...string) NamespaceClientV1
-	Secret(args ...string) SecretClientV1
 }
 
 type ClusterClientV1 interface {
@@ -47,6 +46,9 @@ type NodeClientV1 interface {
 	Get(ctx context.Context) (*vv1.Node, error)
 	SetStatus(ctx context.Context, opts *rv1.NodeStatusOptions) (*vv1.NodeManifest, error)
 	Remove(ctx context.Context, opts *rv1.NodeRemoveOptions) error
+	Cordon(ctx context.Context) (*vv1.Node, error)
+	Uncordon(ctx context.Context) (*vv1.Node, error)
+	Drain(ctx context.Context) (*vv1.Node, error)
 }
 
 type DiscoveryClientV1 interface {
@@ -67,6 +69,10 @@ type NamespaceClientV1 interface {
 	Service(args ...string) ServiceClientV1
 	Route(args ...string) RouteClientV1
 	Volume(args ...string) VolumeClientV1
+	Config(args ...string) ConfigClientV1
+	Secret(args ...string) SecretClientV1
+	Build(args ...string) BuildClientV1
+	Events() EventsClientV1
 	Create(ctx context.Context, opts *rv1.NamespaceCreateOptions) (*vv1.Namespace, error)
 	List(ctx context.Context) (*vv1.NamespaceList, error)
 	Get(ctx context.Context) (*vv1.Namespace, error)
@@ -83,6 +89,7 @@ type ServiceClientV1 interface {
 	Get(ctx context.Context) (*vv1.Service, error)
 	Update(ctx context.Context, opts *rv1.ServiceManifest) (*vv1.Service, error)
 	Remove(ctx context.Context, opts *rv1.ServiceRemoveOptions) error
+	Rollback(ctx context.Context, opts *rv1.ServiceRollbackOptions) (*vv1.Service, error)
 	Logs(ctx context.Context, opts *rv1.ServiceLogsOptions) (io.ReadCloser, error)
 }
 
@@ -101,6 +108,7 @@ type PodClientV1 interface {
 }
 
 type EventsClientV1 interface {
+	List(ctx context.Context, opts *rv1.EventListOptions) (*vv1.EventList, error)
 }
 
 type SecretClientV1 interface {
@@ -111,6 +119,14 @@ type SecretClientV1 interface {
 	Remove(ctx context.Context, opts *rv1.SecretRemoveOptions) error
 }
 
+type BuildClientV1 interface {
+	Create(ctx context.Context, opts *rv1.BuildCreateOptions) (*vv1.Build, error)
+	List(ctx context.Context) (*vv1.BuildList, error)
+	Get(ctx context.Context) (*vv1.Build, error)
+	Remove(ctx context.Context, opts *rv1.BuildRemoveOptions) error
+	Logs(ctx context.Context) (io.ReadCloser, error)
+}
+
 type RouteClientV1 interface {
 	Create(ctx context.Context, opts *rv1.RouteManifest) (*vv1.Route, error)
 	List(ctx context.Context) (*vv1.RouteList, error)
@@ -134,3 +150,11 @@ type VolumeClientV1 interface {
 	Update(ctx context.Context, opts *rv1.VolumeManifest) (*vv1.Volume, error)
 	Remove(ctx context.Context, opts *rv1.VolumeRemoveOptions) error
 }
+
+type ConfigClientV1 interface {
+	Create(ctx context.Context, opts *rv1.ConfigManifest) (*vv1.Config, error)
+	List(ctx context.Context) (*vv1.ConfigList, error)
+	Get(ctx context.Context) (*vv1.Config, error)
+	Update(ctx context.Context, opts *rv1.ConfigManifest) (*vv1.Config, error)
+	Remove(ctx context.Context, opts *rv1.ConfigRemoveOptions) error
+}
@@ -21,6 +21,7 @@ package envs
 import (
 	"github.com/lastbackend/lastbackend/pkg/api/cache"
 	"github.com/lastbackend/lastbackend/pkg/storage"
+	"github.com/lastbackend/lastbackend/pkg/util/crypto"
 )
 
 var e Env
@@ -28,6 +29,7 @@ var e Env
 type Env struct {
 	storage storage.Storage
 	cache   *cache.Cache
+	crypto  crypto.KeyProvider
 }
 
 func Get() *Env {
@@ -49,3 +51,11 @@ func (c *Env) SetCache(ch *cache.Cache) {
 func (c *Env) GetCache() *cache.Cache {
 	return c.cache
 }
+
+func (c *Env) SetSecretKeyProvider(p crypto.KeyProvider) {
+	c.crypto = p
+}
+
+func (c *Env) GetSecretKeyProvider() crypto.KeyProvider {
+	return c.crypto
+}
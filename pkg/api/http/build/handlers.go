@@ -0,0 +1,492 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+
+package build
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/lastbackend/lastbackend/pkg/api/envs"
+	"github.com/lastbackend/lastbackend/pkg/api/types/v1"
+	"github.com/lastbackend/lastbackend/pkg/distribution"
+	"github.com/lastbackend/lastbackend/pkg/distribution/errors"
+	"github.com/lastbackend/lastbackend/pkg/distribution/types"
+	"github.com/lastbackend/lastbackend/pkg/log"
+	"github.com/lastbackend/lastbackend/pkg/util/http/utils"
+)
+
+const (
+	logLevel    = 2
+	logPrefix   = "api:handler:build"
+	BUFFER_SIZE = 512
+)
+
+func BuildListH(w http.ResponseWriter, r *http.Request) {
+
+	// swagger:operation GET /namespace/{namespace}/build build buildList
+	//
+	// Shows a list of namespace builds
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	//   - name: namespace
+	//     in: path
+	//     description: namespace id
+	//     required: true
+	//     type: string
+	// responses:
+	//   '200':
+	//     description: Build list response
+	//     schema:
+	//       "$ref": "#/definitions/views_build_list"
+	//   '404':
+	//     description: Namespace not found
+	//   '500':
+	//     description: Internal server error
+
+	nid := utils.Vars(r)["namespace"]
+
+	log.V(logLevel).Debugf("%s:list:> get builds list for `%s`", logPrefix, nid)
+
+	var (
+		bm = distribution.NewBuildModel(r.Context(), envs.Get().GetStorage())
+	)
+
+	ns, e := getNamespace(r, nid)
+	if e != nil {
+		e.Http(w)
+		return
+	}
+
+	items, err := bm.ListByNamespace(ns.Meta.Name)
+	if err != nil {
+		log.V(logLevel).Errorf("%s:list:> find build list err: %s", logPrefix, err.Error())
+		errors.HTTP.InternalServerError(w)
+		return
+	}
+
+	response, err := v1.View().Build().NewList(items).ToJson()
+	if err != nil {
+		log.V(logLevel).Errorf("%s:list:> convert struct to json err: %s", logPrefix, err.Error())
+		errors.HTTP.InternalServerError(w)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(response); err != nil {
+		log.V(logLevel).Errorf("%s:list:> write response err: %s", logPrefix, err.Error())
+		return
+	}
+}
+
+func BuildInfoH(w http.ResponseWriter, r *http.Request) {
+
+	// swagger:operation GET /namespace/{namespace}/build/{build} build buildInfo
+	//
+	// Shows an info about build
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	//   - name: namespace
+	//     in: path
+	//     description: namespace id
+	//     required: true
+	//     type: string
+	//   - name: build
+	//     in: path
+	//     description: build id
+	//     required: true
+	//     type: string
+	// responses:
+	//   '200':
+	//     description: Build response
+	//     schema:
+	//       "$ref": "#/definitions/views_build"
+	//   '404':
+	//     description: Namespace not found / Build not found
+	//   '500':
+	//     description: Internal server error
+
+	nid := utils.Vars(r)["namespace"]
+	bid := utils.Vars(r)["build"]
+
+	log.V(logLevel).Debugf("%s:info:> get build `%s`", logPrefix, bid)
+
+	ns, e := getNamespace(r, nid)
+	if e != nil {
+		e.Http(w)
+		return
+	}
+
+	item, e := getBuild(r, ns, bid)
+	if e != nil {
+		e.Http(w)
+		return
+	}
+
+	response, err := v1.View().Build().New(item).ToJson()
+	if err != nil {
+		log.V(logLevel).Errorf("%s:info:> convert struct to json err: %s", logPrefix, err.Error())
+		errors.HTTP.InternalServerError(w)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(response); err != nil {
+		log.V(logLevel).Errorf("%s:info:> write response err: %s", logPrefix, err.Error())
+		return
+	}
+}
+
+func BuildCreateH(w http.ResponseWriter, r *http.Request) {
+
+	// swagger:operation POST /namespace/{namespace}/build build buildCreate
+	//
+	// Creates a build of image from git repository
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	//   - name: namespace
+	//     in: path
+	//     description: namespace id
+	//     required: true
+	//     type: string
+	//   - name: body
+	//     in: body
+	//     required: true
+	//     schema:
+	//       "$ref": "#/definitions/request_build_create"
+	// responses:
+	//   '200':
+	//     description: Build was successfully created
+	//     schema:
+	//       "$ref": "#/definitions/views_build"
+	//   '400':
+	//     description: Name is already in use / Bad parameter
+	//   '404':
+	//     description: Namespace not found / Service not found
+	//   '500':
+	//     description: Internal server error
+
+	nid := utils.Vars(r)["namespace"]
+
+	log.V(logLevel).Debugf("%s:create:> create build in `%s`", logPrefix, nid)
+
+	var (
+		bm   = distribution.NewBuildModel(r.Context(), envs.Get().GetStorage())
+		sm   = distribution.NewServiceModel(r.Context(), envs.Get().GetStorage())
+		opts = v1.Request().Build().CreateOptions()
+	)
+
+	// request body struct
+	if e := opts.DecodeAndValidate(r.Body); e != nil {
+		log.V(logLevel).Errorf("%s:create:> validation incoming data err: %s", logPrefix, e.Err())
+		e.Http(w)
+		return
+	}
+
+	ns, e := getNamespace(r, nid)
+	if e != nil {
+		e.Http(w)
+		return
+	}
+
+	if opts.Name != types.EmptyString {
+		item, err := bm.Get(ns.Meta.Name, opts.Name)
+		if err != nil {
+			log.V(logLevel).Errorf("%s:create:> check exists by name err: %s", logPrefix, err.Error())
+			errors.HTTP.InternalServerError(w)
+			return
+		}
+		if item != nil {
+			log.V(logLevel).Warnf("%s:create:> name `%s` not unique", logPrefix, opts.Name)
+			errors.New("build").NotUnique("name").Http(w)
+			return
+		}
+	}
+
+	if opts.Service != types.EmptyString {
+		svc, err := sm.Get(ns.Meta.Name, opts.Service)
+		if err != nil {
+			log.V(logLevel).Errorf("%s:create:> get service `%s` err: %s", logPrefix, opts.Service, err.Error())
+			errors.HTTP.InternalServerError(w)
+			return
+		}
+		if svc == nil {
+			log.V(logLevel).Warnf("%s:create:> service `%s` not found", logPrefix, opts.Service)
+			errors.New("service").NotFound().Http(w)
+			return
+		}
+	}
+
+	build := new(types.Build)
+	build.Meta.Namespace = ns.Meta.Name
+
+	opts.SetBuildMeta(build)
+	opts.SetBuildSpec(build)
+
+	if _, err := bm.Create(build); err != nil {
+		log.V(logLevel).Errorf("%s:create:> create build err: %s", logPrefix, err.Error())
+		errors.HTTP.InternalServerError(w)
+		return
+	}
+
+	response, err := v1.View().Build().New(build).ToJson()
+	if err != nil {
+		log.V(logLevel).Errorf("%s:create:> convert struct to json err: %s", logPrefix, err.Error())
+		errors.HTTP.InternalServerError(w)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(response); err != nil {
+		log.V(logLevel).Errorf("%s:create:> write response err: %s", logPrefix, err.Error())
+		return
+	}
+}
+
+func BuildRemoveH(w http.ResponseWriter, r *http.Request) {
+
+	// swagger:operation DELETE /namespace/{namespace}/build/{build} build buildRemove
+	//
+	// Removes build, running build is canceled on node
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	//   - name: namespace
+	//     in: path
+	//     description: namespace id
+	//     required: true
+	//     type: string
+	//   - name: build
+	//     in: path
+	//     description: build id
+	//     required: true
+	//     type: string
+	// responses:
+	//   '200':
+	//     description: Build was successfully removed
+	//   '404':
+	//     description: Namespace not found / Build not found
+	//   '500':
+	//     description: Internal server error
+
+	nid := utils.Vars(r)["namespace"]
+	bid := utils.Vars(r)["build"]
+
+	log.V(logLevel).Debugf("%s:remove:> remove build `%s`", logPrefix, bid)
+
+	var (
+		bm = distribution.NewBuildModel(r.Context(), envs.Get().GetStorage())
+	)
+
+	ns, e := getNamespace(r, nid)
+	if e != nil {
+		e.Http(w)
+		return
+	}
+
+	build, e := getBuild(r, ns, bid)
+	if e != nil {
+		e.Http(w)
+		return
+	}
+
+	if err := bm.Remove(build); err != nil {
+		log.V(logLevel).Errorf("%s:remove:> remove build `%s` err: %s", logPrefix, bid, err.Error())
+		errors.HTTP.InternalServerError(w)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write([]byte{}); err != nil {
+		log.V(logLevel).Errorf("%s:remove:> write response err: %s", logPrefix, err.Error())
+		return
+	}
+}
+
+func BuildLogsH(w http.ResponseWriter, r *http.Request) {
+
+	// swagger:operation GET /namespace/{namespace}/build/{build}/logs build buildLogs
+	//
+	// Shows logs of the build
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	//   - name: namespace
+	//     in: path
+	//     description: namespace id
+	//     required: true
+	//     type: string
+	//   - name: build
+	//     in: path
+	//     description: build id
+	//     required: true
+	//     type: string
+	// responses:
+	//   '200':
+	//     description: Build logs received
+	//   '400':
+	//     description: Build is not scheduled to node
+	//   '404':
+	//     description: Namespace not found / Build not found / Node not found
+	//   '500':
+	//     description: Internal server error
+
+	nid := utils.Vars(r)["namespace"]
+	bid := utils.Vars(r)["build"]
+
+	log.V(logLevel).Debugf("%s:logs:> get logs of build `%s` in namespace `%s`", logPrefix, bid, nid)
+
+	var (
+		nm = distribution.NewNodeModel(r.Context(), envs.Get().GetStorage())
+	)
+
+	ns, e := getNamespace(r, nid)
+	if e != nil {
+		e.Http(w)
+		return
+	}
+
+	build, e := getBuild(r, ns, bid)
+	if e != nil {
+		e.Http(w)
+		return
+	}
+
+	if build.Status.Node == types.EmptyString {
+		log.V(logLevel).Warnf("%s:logs:> build `%s` is not scheduled", logPrefix, build.SelfLink())
+		errors.New("build").BadRequest("build is not scheduled to node").Http(w)
+		return
+	}
+
+	node, err := nm.Get(build.Status.Node)
+	if err != nil {
+		log.V(logLevel).Errorf("%s:logs:> get node by name err: %s", logPrefix, err.Error())
+		errors.HTTP.InternalServerError(w)
+		return
+	}
+	if node == nil {
+		log.V(logLevel).Warnf("%s:logs:> node %s not found", logPrefix, build.Status.Node)
+		errors.New("node").NotFound().Http(w)
+		return
+	}
+
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("http://%s:%d/build/%s/logs", node.Meta.InternalIP, 2969, build.SelfLink()), nil)
+	if err != nil {
+		log.V(logLevel).Errorf("%s:logs:> create http client err: %s", logPrefix, err.Error())
+		errors.HTTP.InternalServerError(w)
+		return
+	}
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		log.V(logLevel).Errorf("%s:logs:> get build logs err: %s", logPrefix, err.Error())
+		errors.HTTP.InternalServerError(w)
+		return
+	}
+	defer res.Body.Close()
+
+	notify := w.(http.CloseNotifier).CloseNotify()
+	done := make(chan bool, 1)
+
+	go func() {
+		<-notify
+		log.V(logLevel).Debugf("%s:logs:> HTTP connection just closed.", logPrefix)
+		done <- true
+	}()
+
+	var buffer = make([]byte, BUFFER_SIZE)
+
+	for {
+		select {
+		case <-done:
+			return
+		default:
+
+			n, err := res.Body.Read(buffer)
+			if n > 0 {
+
+				if _, err := w.Write(buffer[0:n]); err != nil {
+					log.Errorf("Error write bytes to stream %s", err)
+					return
+				}
+
+				if f, ok := w.(http.Flusher); ok {
+					f.Flush()
+				}
+			}
+
+			if err != nil {
+
+				if err == context.Canceled {
+					log.V(logLevel).Debug("Stream is canceled")
+				}
+
+				// build finished and all logs are sent
+				return
+			}
+		}
+	}
+}
+
+func getNamespace(r *http.Request, name string) (*types.Namespace, *errors.Err) {
+
+	nsm := distribution.NewNamespaceModel(r.Context(), envs.Get().GetStorage())
+
+	ns, err := nsm.Get(name)
+	if err != nil {
+		log.V(logLevel).Errorf("%s:> get namespace `%s` err: %s", logPrefix, name, err.Error())
+		return nil, errors.New("namespace").Unknown(err)
+	}
+	if ns == nil {
+		log.V(logLevel).Warnf("%s:> namespace `%s` not found", logPrefix, name)
+		return nil, errors.New("namespace").NotFound()
+	}
+
+	return ns, nil
+}
+
+func getBuild(r *http.Request, ns *types.Namespace, name string) (*types.Build, *errors.Err) {
+
+	bm := distribution.NewBuildModel(r.Context(), envs.Get().GetStorage())
+
+	build, err := bm.Get(ns.Meta.Name, name)
+	if err != nil {
+		log.V(logLevel).Errorf("%s:> get build `%s` err: %s", logPrefix, name, err.Error())
+		return nil, errors.New("build").Unknown(err)
+	}
+	if build == nil {
+		log.V(logLevel).Warnf("%s:> build `%s` not found", logPrefix, name)
+		return nil, errors.New("build").NotFound()
+	}
+
+	return build, nil
+}
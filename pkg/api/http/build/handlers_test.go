@@ -0,0 +1,310 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+
+package build_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/lastbackend/lastbackend/pkg/api/envs"
+	"github.com/lastbackend/lastbackend/pkg/api/http/build"
+	"github.com/lastbackend/lastbackend/pkg/api/types/v1/request"
+	"github.com/lastbackend/lastbackend/pkg/api/types/v1/views"
+	"github.com/lastbackend/lastbackend/pkg/distribution/types"
+	"github.com/lastbackend/lastbackend/pkg/storage"
+	"github.com/stretchr/testify/assert"
+)
+
+// Testing BuildCreateH handler
+func TestBuildCreate(t *testing.T) {
+
+	stg, _ := storage.Get("mock")
+	envs.Get().SetStorage(stg)
+
+	ns1 := getNamespaceAsset("demo", "")
+	ns2 := getNamespaceAsset("test", "")
+	s1 := getServiceAsset(ns1.Meta.Name, "demo", "nginx:latest")
+	b1 := getBuildAsset(ns1.Meta.Name, "demo")
+
+	mf1, _ := getBuildOptions("", "https://github.com/lastbackend/demo.git", s1.Meta.Name).ToJson()
+	mf2, _ := getBuildOptions(b1.Meta.Name, "https://github.com/lastbackend/demo.git", s1.Meta.Name).ToJson()
+	mf3, _ := getBuildOptions("", "github.com/lastbackend/demo", s1.Meta.Name).ToJson()
+	mf4, _ := getBuildOptions("", "https://github.com/lastbackend/demo.git", "test").ToJson()
+
+	tests := []struct {
+		name         string
+		namespace    *types.Namespace
+		data         string
+		err          string
+		wantErr      bool
+		expectedCode int
+	}{
+		{
+			name:         "checking create build if namespace not found",
+			namespace:    ns2,
+			data:         string(mf1),
+			err:          "{\"code\":404,\"status\":\"Not Found\",\"message\":\"Namespace not found\"}",
+			wantErr:      true,
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "checking create build if name already exists",
+			namespace:    ns1,
+			data:         string(mf2),
+			err:          "{\"code\":400,\"status\":\"Not Unique\",\"message\":\"Name is already in use\"}",
+			wantErr:      true,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "checking create build if url is not git repository",
+			namespace:    ns1,
+			data:         string(mf3),
+			err:          "{\"code\":400,\"status\":\"Bad Parameter\",\"message\":\"Bad url parameter\"}",
+			wantErr:      true,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "checking create build if service not found",
+			namespace:    ns1,
+			data:         string(mf4),
+			err:          "{\"code\":404,\"status\":\"Not Found\",\"message\":\"Service not found\"}",
+			wantErr:      true,
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "checking create build successfully",
+			namespace:    ns1,
+			data:         string(mf1),
+			wantErr:      false,
+			expectedCode: http.StatusOK,
+		},
+	}
+
+	clear := func() {
+		err := stg.Del(context.Background(), stg.Collection().Namespace(), types.EmptyString)
+		assert.NoError(t, err)
+
+		err = stg.Del(context.Background(), stg.Collection().Service(), types.EmptyString)
+		assert.NoError(t, err)
+
+		err = stg.Del(context.Background(), stg.Collection().Build(), types.EmptyString)
+		assert.NoError(t, err)
+	}
+
+	for _, tc := range tests {
+
+		t.Run(tc.name, func(t *testing.T) {
+
+			clear()
+			defer clear()
+
+			err := stg.Put(context.Background(), stg.Collection().Namespace(), stg.Key().Namespace(ns1.Meta.Name), ns1, nil)
+			assert.NoError(t, err)
+
+			err = stg.Put(context.Background(), stg.Collection().Service(), stg.Key().Service(s1.Meta.Namespace, s1.Meta.Name), s1, nil)
+			assert.NoError(t, err)
+
+			err = stg.Put(context.Background(), stg.Collection().Build(), stg.Key().Build(b1.Meta.Namespace, b1.Meta.Name), b1, nil)
+			assert.NoError(t, err)
+
+			req, err := http.NewRequest("POST", fmt.Sprintf("/namespace/%s/build", tc.namespace.Meta.Name), strings.NewReader(tc.data))
+			assert.NoError(t, err)
+
+			r := mux.NewRouter()
+			r.HandleFunc("/namespace/{namespace}/build", build.BuildCreateH)
+
+			setRequestVars(r, req)
+
+			res := httptest.NewRecorder()
+			r.ServeHTTP(res, req)
+
+			assert.Equal(t, tc.expectedCode, res.Code, "status code not equal")
+
+			body, err := ioutil.ReadAll(res.Body)
+			assert.NoError(t, err)
+
+			if tc.wantErr {
+				assert.Equal(t, tc.err, string(body), "incorrect status code")
+				return
+			}
+
+			got := new(views.Build)
+			err = json.Unmarshal(body, &got)
+			assert.NoError(t, err)
+
+			assert.NotEmpty(t, got.Meta.Name, "build name should be generated")
+			assert.Equal(t, "master", got.Spec.Source.Ref, "build ref not match")
+			assert.Equal(t, "Dockerfile", got.Spec.Source.Dockerfile, "build dockerfile not match")
+			assert.Equal(t, s1.Meta.Name, got.Spec.Service, "build service not match")
+
+			item := new(types.Build)
+			err = stg.Get(context.Background(), stg.Collection().Build(), stg.Key().Build(ns1.Meta.Name, got.Meta.Name), item, nil)
+			assert.NoError(t, err)
+			assert.Equal(t, types.StateCreated, item.Status.State, "build state not match")
+		})
+	}
+
+}
+
+// Testing BuildListH handler
+func TestBuildList(t *testing.T) {
+
+	stg, _ := storage.Get("mock")
+	envs.Get().SetStorage(stg)
+
+	ns1 := getNamespaceAsset("demo", "")
+	ns2 := getNamespaceAsset("test", "")
+	b1 := getBuildAsset(ns1.Meta.Name, "demo")
+	b2 := getBuildAsset(ns1.Meta.Name, "test")
+	b3 := getBuildAsset(ns2.Meta.Name, "demo")
+
+	tests := []struct {
+		name         string
+		namespace    *types.Namespace
+		err          string
+		want         int
+		wantErr      bool
+		expectedCode int
+	}{
+		{
+			name:         "checking get builds list successfully",
+			namespace:    ns1,
+			want:         2,
+			wantErr:      false,
+			expectedCode: http.StatusOK,
+		},
+	}
+
+	clear := func() {
+		err := stg.Del(context.Background(), stg.Collection().Namespace(), types.EmptyString)
+		assert.NoError(t, err)
+
+		err = stg.Del(context.Background(), stg.Collection().Build(), types.EmptyString)
+		assert.NoError(t, err)
+	}
+
+	for _, tc := range tests {
+
+		t.Run(tc.name, func(t *testing.T) {
+
+			clear()
+			defer clear()
+
+			for _, n := range []*types.Namespace{ns1, ns2} {
+				err := stg.Put(context.Background(), stg.Collection().Namespace(), stg.Key().Namespace(n.Meta.Name), n, nil)
+				assert.NoError(t, err)
+			}
+
+			for _, b := range []*types.Build{b1, b2, b3} {
+				err := stg.Put(context.Background(), stg.Collection().Build(), stg.Key().Build(b.Meta.Namespace, b.Meta.Name), b, nil)
+				assert.NoError(t, err)
+			}
+
+			req, err := http.NewRequest("GET", fmt.Sprintf("/namespace/%s/build", tc.namespace.Meta.Name), nil)
+			assert.NoError(t, err)
+
+			r := mux.NewRouter()
+			r.HandleFunc("/namespace/{namespace}/build", build.BuildListH)
+
+			setRequestVars(r, req)
+
+			res := httptest.NewRecorder()
+			r.ServeHTTP(res, req)
+
+			assert.Equal(t, tc.expectedCode, res.Code, "status code not equal")
+
+			body, err := ioutil.ReadAll(res.Body)
+			assert.NoError(t, err)
+
+			if tc.wantErr {
+				assert.Equal(t, tc.err, string(body), "incorrect status code")
+				return
+			}
+
+			got := make(views.BuildList, 0)
+			err = json.Unmarshal(body, &got)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tc.want, len(got), "builds count not match")
+			for _, item := range got {
+				assert.Equal(t, ns1.Meta.Name, item.Meta.Namespace, "build namespace not match")
+			}
+		})
+	}
+
+}
+
+func getNamespaceAsset(name, desc string) *types.Namespace {
+	var n = types.Namespace{}
+	n.Meta.SetDefault()
+	n.Meta.Name = name
+	n.Meta.Description = desc
+	n.Meta.Endpoint = fmt.Sprintf("%s", name)
+	return &n
+}
+
+func getServiceAsset(namespace, name, image string) *types.Service {
+	var s = types.Service{}
+	s.Meta.SetDefault()
+	s.Meta.Namespace = namespace
+	s.Meta.Name = name
+	s.Spec.Replicas = 1
+	s.Spec.Template.Containers = append(s.Spec.Template.Containers, &types.SpecTemplateContainer{
+		Name:  "demo",
+		Image: types.SpecTemplateContainerImage{Name: image},
+	})
+	return &s
+}
+
+func getBuildAsset(namespace, name string) *types.Build {
+	var b = types.Build{}
+	b.Meta.SetDefault()
+	b.Meta.Namespace = namespace
+	b.Meta.Name = name
+	b.Spec.Source.Url = "https://github.com/lastbackend/demo.git"
+	b.Spec.Source.Ref = "master"
+	b.Spec.Image.Name = "lastbackend/demo:latest"
+	b.Status.State = types.StateCreated
+	b.SelfLink()
+	return &b
+}
+
+func getBuildOptions(name, url, service string) *request.BuildCreateOptions {
+	opts := new(request.BuildCreateOptions)
+	opts.Name = name
+	opts.Url = url
+	opts.Image = "lastbackend/demo:latest"
+	opts.Service = service
+	return opts
+}
+
+func setRequestVars(r *mux.Router, req *http.Request) {
+	var match mux.RouteMatch
+	// Take the request and match it
+	r.Match(req, &match)
+	// Push the variable onto the context
+	req = mux.SetURLVars(req, match.Vars)
+}
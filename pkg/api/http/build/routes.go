@@ -0,0 +1,33 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+
+package build
+
+import (
+	"github.com/lastbackend/lastbackend/pkg/util/http"
+	"github.com/lastbackend/lastbackend/pkg/util/http/middleware"
+)
+
+var Routes = []http.Route{
+	// Build handlers
+	{Path: "/namespace/{namespace}/build", Method: http.MethodPost, Middleware: []http.Middleware{middleware.Authenticate}, Handler: BuildCreateH},
+	{Path: "/namespace/{namespace}/build", Method: http.MethodGet, Middleware: []http.Middleware{middleware.Authenticate}, Handler: BuildListH},
+	{Path: "/namespace/{namespace}/build/{build}", Method: http.MethodGet, Middleware: []http.Middleware{middleware.Authenticate}, Handler: BuildInfoH},
+	{Path: "/namespace/{namespace}/build/{build}", Method: http.MethodDelete, Middleware: []http.Middleware{middleware.Authenticate}, Handler: BuildRemoveH},
+	{Path: "/namespace/{namespace}/build/{build}/logs", Method: http.MethodGet, Middleware: []http.Middleware{middleware.Authenticate}, Handler: BuildLogsH},
+}
@@ -65,6 +65,10 @@ func ClusterInfoH(w http.ResponseWriter, r *http.Request) {
 	if cl == nil {
 		cl = new(types.Cluster)
 		cl.Meta.SetDefault()
+	}
+
+	// cluster status can be stored by controller before cluster info is set
+	if cl.Meta.Name == types.EmptyString {
 		cl.Meta.Name = viper.GetString("name")
 		cl.Meta.SelfLink = cl.Meta.Name
 		cl.Meta.Description = viper.GetString("description")
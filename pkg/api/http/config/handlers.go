@@ -0,0 +1,464 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+
+package config
+
+import (
+	"net/http"
+
+	"github.com/lastbackend/lastbackend/pkg/api/envs"
+	"github.com/lastbackend/lastbackend/pkg/api/types/v1"
+	"github.com/lastbackend/lastbackend/pkg/distribution"
+	"github.com/lastbackend/lastbackend/pkg/distribution/errors"
+	"github.com/lastbackend/lastbackend/pkg/distribution/types"
+	"github.com/lastbackend/lastbackend/pkg/log"
+	"github.com/lastbackend/lastbackend/pkg/util/http/utils"
+)
+
+const (
+	logLevel  = 2
+	logPrefix = "api:handler:config"
+)
+
+func ConfigListH(w http.ResponseWriter, r *http.Request) {
+
+	// swagger:operation GET /namespace/{namespace}/config config configList
+	//
+	// Shows a list of configs
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	//   - name: namespace
+	//     in: path
+	//     description: namespace id
+	//     required: true
+	//     type: string
+	// responses:
+	//   '200':
+	//     description: Config list response
+	//     schema:
+	//       "$ref": "#/definitions/views_config_list"
+	//   '404':
+	//     description: Namespace not found
+	//   '500':
+	//     description: Internal server error
+
+	log.V(logLevel).Debugf("%s:list:> get configs list", logPrefix)
+
+	nid := utils.Vars(r)["namespace"]
+
+	var (
+		cm  = distribution.NewConfigModel(r.Context(), envs.Get().GetStorage())
+		nsm = distribution.NewNamespaceModel(r.Context(), envs.Get().GetStorage())
+	)
+
+	ns, err := nsm.Get(nid)
+	if err != nil {
+		log.V(logLevel).Errorf("%s:list:> get namespace err: %s", logPrefix, err.Error())
+		errors.HTTP.InternalServerError(w)
+		return
+	}
+	if ns == nil {
+		log.V(logLevel).Warnf("%s:list:> namespace `%s` not found", logPrefix, nid)
+		errors.New("namespace").NotFound().Http(w)
+		return
+	}
+
+	items, err := cm.ListByNamespace(ns.Meta.Name)
+	if err != nil {
+		log.V(logLevel).Errorf("%s:list:> find config list err: %s", logPrefix, err.Error())
+		errors.HTTP.InternalServerError(w)
+		return
+	}
+
+	response, err := v1.View().Config().NewList(items).ToJson()
+	if err != nil {
+		log.V(logLevel).Errorf("%s:list:> convert struct to json err: %s", logPrefix, err.Error())
+		errors.HTTP.InternalServerError(w)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(response); err != nil {
+		log.V(logLevel).Errorf("%s:list:> write response err: %s", logPrefix, err.Error())
+		return
+	}
+}
+
+func ConfigInfoH(w http.ResponseWriter, r *http.Request) {
+
+	// swagger:operation GET /namespace/{namespace}/config/{config} config configInfo
+	//
+	// Shows an info about config
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	//   - name: namespace
+	//     in: path
+	//     description: namespace id
+	//     required: true
+	//     type: string
+	//   - name: config
+	//     in: path
+	//     description: config id
+	//     required: true
+	//     type: string
+	// responses:
+	//   '200':
+	//     description: Config response
+	//     schema:
+	//       "$ref": "#/definitions/views_config"
+	//   '404':
+	//     description: Namespace not found / Config not found
+	//   '500':
+	//     description: Internal server error
+
+	nid := utils.Vars(r)["namespace"]
+	cid := utils.Vars(r)["config"]
+
+	log.V(logLevel).Debugf("%s:info:> get config `%s`", logPrefix, cid)
+
+	var (
+		cm  = distribution.NewConfigModel(r.Context(), envs.Get().GetStorage())
+		nsm = distribution.NewNamespaceModel(r.Context(), envs.Get().GetStorage())
+	)
+
+	ns, err := nsm.Get(nid)
+	if err != nil {
+		log.V(logLevel).Errorf("%s:info:> get namespace err: %s", logPrefix, err.Error())
+		errors.HTTP.InternalServerError(w)
+		return
+	}
+	if ns == nil {
+		log.V(logLevel).Warnf("%s:info:> namespace `%s` not found", logPrefix, nid)
+		errors.New("namespace").NotFound().Http(w)
+		return
+	}
+
+	item, err := cm.Get(ns.Meta.Name, cid)
+	if err != nil {
+		log.V(logLevel).Errorf("%s:info:> find config by id `%s` err: %s", logPrefix, cid, err.Error())
+		errors.HTTP.InternalServerError(w)
+		return
+	}
+	if item == nil {
+		log.V(logLevel).Warnf("%s:info:> config `%s` not found", logPrefix, cid)
+		errors.New("config").NotFound().Http(w)
+		return
+	}
+
+	response, err := v1.View().Config().New(item).ToJson()
+	if err != nil {
+		log.V(logLevel).Errorf("%s:info:> convert struct to json err: %s", logPrefix, err.Error())
+		errors.HTTP.InternalServerError(w)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(response); err != nil {
+		log.V(logLevel).Errorf("%s:info:> write response err: %s", logPrefix, err.Error())
+		return
+	}
+}
+
+func ConfigCreateH(w http.ResponseWriter, r *http.Request) {
+
+	// swagger:operation POST /namespace/{namespace}/config config configCreate
+	//
+	// Creates a config
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	//   - name: namespace
+	//     in: path
+	//     description: namespace id
+	//     required: true
+	//     type: string
+	//   - name: body
+	//     in: body
+	//     required: true
+	//     schema:
+	//       "$ref": "#/definitions/request_config_manifest"
+	// responses:
+	//   '200':
+	//     description: Config was successfully created
+	//     schema:
+	//       "$ref": "#/definitions/views_config"
+	//   '400':
+	//     description: Name is already in use / Bad parameter
+	//   '404':
+	//     description: Namespace not found
+	//   '500':
+	//     description: Internal server error
+
+	log.V(logLevel).Debugf("%s:create:> create config", logPrefix)
+
+	nid := utils.Vars(r)["namespace"]
+
+	var (
+		cm  = distribution.NewConfigModel(r.Context(), envs.Get().GetStorage())
+		nsm = distribution.NewNamespaceModel(r.Context(), envs.Get().GetStorage())
+		mf  = v1.Request().Config().Manifest()
+	)
+
+	// request body struct
+	if e := mf.DecodeAndValidate(r.Body); e != nil {
+		log.V(logLevel).Errorf("%s:create:> validation incoming data err: %s", logPrefix, e.Err())
+		e.Http(w)
+		return
+	}
+
+	ns, err := nsm.Get(nid)
+	if err != nil {
+		log.V(logLevel).Errorf("%s:create:> get namespace err: %s", logPrefix, err.Error())
+		errors.HTTP.InternalServerError(w)
+		return
+	}
+	if ns == nil {
+		log.V(logLevel).Warnf("%s:create:> namespace `%s` not found", logPrefix, nid)
+		errors.New("namespace").NotFound().Http(w)
+		return
+	}
+
+	item, err := cm.Get(ns.Meta.Name, *mf.Meta.Name)
+	if err != nil {
+		log.V(logLevel).Errorf("%s:create:> check exists by name err: %s", logPrefix, err.Error())
+		errors.HTTP.InternalServerError(w)
+		return
+	}
+	if item != nil {
+		log.V(logLevel).Warnf("%s:create:> name `%s` not unique", logPrefix, *mf.Meta.Name)
+		errors.New("config").NotUnique("name").Http(w)
+		return
+	}
+
+	cfg := new(types.Config)
+	cfg.Meta.SetDefault()
+	cfg.Meta.Namespace = ns.Meta.Name
+
+	mf.SetConfigMeta(cfg)
+	mf.SetConfigSpec(cfg)
+
+	if _, err := cm.Create(ns, cfg); err != nil {
+		log.V(logLevel).Errorf("%s:create:> create config err: %s", logPrefix, err.Error())
+		errors.HTTP.InternalServerError(w)
+		return
+	}
+
+	response, err := v1.View().Config().New(cfg).ToJson()
+	if err != nil {
+		log.V(logLevel).Errorf("%s:create:> convert struct to json err: %s", logPrefix, err.Error())
+		errors.HTTP.InternalServerError(w)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(response); err != nil {
+		log.V(logLevel).Errorf("%s:create:> write response err: %s", logPrefix, err.Error())
+		return
+	}
+}
+
+func ConfigUpdateH(w http.ResponseWriter, r *http.Request) {
+
+	// swagger:operation PUT /namespace/{namespace}/config/{config} config configUpdate
+	//
+	// Update config
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	//   - name: namespace
+	//     in: path
+	//     description: namespace id
+	//     required: true
+	//     type: string
+	//   - name: config
+	//     in: path
+	//     description: config id
+	//     required: true
+	//     type: string
+	//   - name: body
+	//     in: body
+	//     required: true
+	//     schema:
+	//       "$ref": "#/definitions/request_config_manifest"
+	// responses:
+	//   '200':
+	//     description: Config was successfully updated
+	//     schema:
+	//       "$ref": "#/definitions/views_config"
+	//   '400':
+	//     description: Bad parameter
+	//   '404':
+	//     description: Namespace not found / Config not found
+	//   '409':
+	//     description: Config was changed since revision
+	//   '500':
+	//     description: Internal server error
+
+	nid := utils.Vars(r)["namespace"]
+	cid := utils.Vars(r)["config"]
+
+	log.V(logLevel).Debugf("%s:update:> update config `%s`", logPrefix, cid)
+
+	var (
+		cm  = distribution.NewConfigModel(r.Context(), envs.Get().GetStorage())
+		nsm = distribution.NewNamespaceModel(r.Context(), envs.Get().GetStorage())
+		mf  = v1.Request().Config().Manifest()
+	)
+
+	// request body struct
+	if e := mf.DecodeAndValidate(r.Body); e != nil {
+		log.V(logLevel).Errorf("%s:update:> validation incoming data err: %s", logPrefix, e.Err())
+		e.Http(w)
+		return
+	}
+
+	ns, err := nsm.Get(nid)
+	if err != nil {
+		log.V(logLevel).Errorf("%s:update:> get namespace err: %s", logPrefix, err.Error())
+		errors.HTTP.InternalServerError(w)
+		return
+	}
+	if ns == nil {
+		log.V(logLevel).Warnf("%s:update:> namespace `%s` not found", logPrefix, nid)
+		errors.New("namespace").NotFound().Http(w)
+		return
+	}
+
+	cfg, err := cm.Get(ns.Meta.Name, cid)
+	if err != nil {
+		log.V(logLevel).Errorf("%s:update:> check config exists by name `%s` err: %s", logPrefix, cid, err.Error())
+		errors.HTTP.InternalServerError(w)
+		return
+	}
+	if cfg == nil {
+		log.V(logLevel).Warnf("%s:update:> config `%s` not found", logPrefix, cid)
+		errors.New("config").NotFound().Http(w)
+		return
+	}
+
+	mf.SetConfigMeta(cfg)
+	mf.SetConfigSpec(cfg)
+
+	if err := cm.Update(cfg); err != nil {
+		log.V(logLevel).Errorf("%s:update:> update config `%s` err: %s", logPrefix, cid, err.Error())
+		if errors.Storage().IsErrEntityRevision(err) {
+			errors.New("config").Conflict().Http(w)
+			return
+		}
+		errors.HTTP.InternalServerError(w)
+		return
+	}
+
+	response, err := v1.View().Config().New(cfg).ToJson()
+	if err != nil {
+		log.V(logLevel).Errorf("%s:update:> convert struct to json err: %s", logPrefix, err.Error())
+		errors.HTTP.InternalServerError(w)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(response); err != nil {
+		log.V(logLevel).Errorf("%s:update:> write response err: %s", logPrefix, err.Error())
+		return
+	}
+}
+
+func ConfigRemoveH(w http.ResponseWriter, r *http.Request) {
+
+	// swagger:operation DELETE /namespace/{namespace}/config/{config} config configRemove
+	//
+	// Removes config
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	//   - name: namespace
+	//     in: path
+	//     description: namespace id
+	//     required: true
+	//     type: string
+	//   - name: config
+	//     in: path
+	//     description: config id
+	//     required: true
+	//     type: string
+	// responses:
+	//   '200':
+	//     description: Config was successfully removed
+	//   '404':
+	//     description: Namespace not found / Config not found
+	//   '500':
+	//     description: Internal server error
+
+	nid := utils.Vars(r)["namespace"]
+	cid := utils.Vars(r)["config"]
+
+	log.V(logLevel).Debugf("%s:remove:> remove config `%s`", logPrefix, cid)
+
+	var (
+		cm  = distribution.NewConfigModel(r.Context(), envs.Get().GetStorage())
+		nsm = distribution.NewNamespaceModel(r.Context(), envs.Get().GetStorage())
+	)
+
+	ns, err := nsm.Get(nid)
+	if err != nil {
+		log.V(logLevel).Errorf("%s:remove:> get namespace err: %s", logPrefix, err.Error())
+		errors.HTTP.InternalServerError(w)
+		return
+	}
+	if ns == nil {
+		log.V(logLevel).Warnf("%s:remove:> namespace `%s` not found", logPrefix, nid)
+		errors.New("namespace").NotFound().Http(w)
+		return
+	}
+
+	cfg, err := cm.Get(ns.Meta.Name, cid)
+	if err != nil {
+		log.V(logLevel).Errorf("%s:remove:> get config by id `%s` err: %s", logPrefix, cid, err.Error())
+		errors.HTTP.InternalServerError(w)
+		return
+	}
+	if cfg == nil {
+		log.V(logLevel).Warnf("%s:remove:> config `%s` not found", logPrefix, cid)
+		errors.New("config").NotFound().Http(w)
+		return
+	}
+
+	if err := cm.Remove(cfg); err != nil {
+		log.V(logLevel).Errorf("%s:remove:> remove config `%s` err: %s", logPrefix, cid, err.Error())
+		errors.HTTP.InternalServerError(w)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write([]byte{}); err != nil {
+		log.V(logLevel).Errorf("%s:remove:> write response err: %s", logPrefix, err.Error())
+		return
+	}
+}
@@ -0,0 +1,607 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+
+package config_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/lastbackend/lastbackend/pkg/api/envs"
+	"github.com/lastbackend/lastbackend/pkg/api/http/config"
+	"github.com/lastbackend/lastbackend/pkg/api/types/v1/request"
+	"github.com/lastbackend/lastbackend/pkg/api/types/v1/views"
+	"github.com/lastbackend/lastbackend/pkg/distribution/errors"
+	"github.com/lastbackend/lastbackend/pkg/distribution/types"
+	"github.com/lastbackend/lastbackend/pkg/storage"
+	"github.com/stretchr/testify/assert"
+)
+
+// Testing ConfigInfoH handler
+func TestConfigInfo(t *testing.T) {
+
+	stg, _ := storage.Get("mock")
+	envs.Get().SetStorage(stg)
+
+	ns1 := getNamespaceAsset("demo", "")
+	ns2 := getNamespaceAsset("test", "")
+	c1 := getConfigAsset(ns1.Meta.Name, "demo")
+	c2 := getConfigAsset(ns1.Meta.Name, "test")
+
+	tests := []struct {
+		name         string
+		namespace    *types.Namespace
+		config       *types.Config
+		err          string
+		want         *types.Config
+		wantErr      bool
+		expectedCode int
+	}{
+		{
+			name:         "checking get config if not exists",
+			namespace:    ns1,
+			config:       c2,
+			err:          "{\"code\":404,\"status\":\"Not Found\",\"message\":\"Config not found\"}",
+			wantErr:      true,
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "checking get config if namespace not exists",
+			namespace:    ns2,
+			config:       c1,
+			err:          "{\"code\":404,\"status\":\"Not Found\",\"message\":\"Namespace not found\"}",
+			wantErr:      true,
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "checking get config successfully",
+			namespace:    ns1,
+			config:       c1,
+			want:         c1,
+			wantErr:      false,
+			expectedCode: http.StatusOK,
+		},
+	}
+
+	clear := func() {
+		err := stg.Del(context.Background(), stg.Collection().Namespace(), types.EmptyString)
+		assert.NoError(t, err)
+
+		err = stg.Del(context.Background(), stg.Collection().Config(), types.EmptyString)
+		assert.NoError(t, err)
+	}
+
+	for _, tc := range tests {
+
+		t.Run(tc.name, func(t *testing.T) {
+
+			clear()
+			defer clear()
+
+			err := stg.Put(context.Background(), stg.Collection().Namespace(), stg.Key().Namespace(ns1.Meta.Name), ns1, nil)
+			assert.NoError(t, err)
+
+			err = stg.Put(context.Background(), stg.Collection().Config(), stg.Key().Config(c1.Meta.Namespace, c1.Meta.Name), c1, nil)
+			assert.NoError(t, err)
+
+			req, err := http.NewRequest("GET", fmt.Sprintf("/namespace/%s/config/%s", tc.namespace.Meta.Name, tc.config.Meta.Name), nil)
+			assert.NoError(t, err)
+
+			r := mux.NewRouter()
+			r.HandleFunc("/namespace/{namespace}/config/{config}", config.ConfigInfoH)
+
+			setRequestVars(r, req)
+
+			res := httptest.NewRecorder()
+			r.ServeHTTP(res, req)
+
+			assert.Equal(t, tc.expectedCode, res.Code, "status code not equal")
+
+			body, err := ioutil.ReadAll(res.Body)
+			assert.NoError(t, err)
+
+			if tc.wantErr {
+				assert.Equal(t, tc.err, string(body), "incorrect status code")
+				return
+			}
+
+			got := new(views.Config)
+			err = json.Unmarshal(body, &got)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tc.want.Meta.Name, got.Meta.Name, "config name not match")
+			assert.Equal(t, tc.want.Meta.Namespace, got.Meta.Namespace, "config namespace not match")
+			assert.Equal(t, tc.want.Data, got.Data, "config data not match")
+		})
+	}
+
+}
+
+// Testing ConfigListH handler
+func TestConfigList(t *testing.T) {
+
+	stg, _ := storage.Get("mock")
+	envs.Get().SetStorage(stg)
+
+	ns1 := getNamespaceAsset("demo", "")
+	ns2 := getNamespaceAsset("test", "")
+	c1 := getConfigAsset(ns1.Meta.Name, "demo")
+	c2 := getConfigAsset(ns1.Meta.Name, "test")
+
+	tests := []struct {
+		name         string
+		namespace    *types.Namespace
+		err          string
+		want         int
+		wantErr      bool
+		expectedCode int
+	}{
+		{
+			name:         "checking get configs list if namespace not found",
+			namespace:    ns2,
+			err:          "{\"code\":404,\"status\":\"Not Found\",\"message\":\"Namespace not found\"}",
+			wantErr:      true,
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "checking get configs list successfully",
+			namespace:    ns1,
+			want:         2,
+			wantErr:      false,
+			expectedCode: http.StatusOK,
+		},
+	}
+
+	clear := func() {
+		err := stg.Del(context.Background(), stg.Collection().Namespace(), types.EmptyString)
+		assert.NoError(t, err)
+
+		err = stg.Del(context.Background(), stg.Collection().Config(), types.EmptyString)
+		assert.NoError(t, err)
+	}
+
+	for _, tc := range tests {
+
+		t.Run(tc.name, func(t *testing.T) {
+
+			clear()
+			defer clear()
+
+			err := stg.Put(context.Background(), stg.Collection().Namespace(), stg.Key().Namespace(ns1.Meta.Name), ns1, nil)
+			assert.NoError(t, err)
+
+			err = stg.Put(context.Background(), stg.Collection().Config(), stg.Key().Config(c1.Meta.Namespace, c1.Meta.Name), c1, nil)
+			assert.NoError(t, err)
+
+			err = stg.Put(context.Background(), stg.Collection().Config(), stg.Key().Config(c2.Meta.Namespace, c2.Meta.Name), c2, nil)
+			assert.NoError(t, err)
+
+			req, err := http.NewRequest("GET", fmt.Sprintf("/namespace/%s/config", tc.namespace.Meta.Name), nil)
+			assert.NoError(t, err)
+
+			r := mux.NewRouter()
+			r.HandleFunc("/namespace/{namespace}/config", config.ConfigListH)
+
+			setRequestVars(r, req)
+
+			res := httptest.NewRecorder()
+			r.ServeHTTP(res, req)
+
+			if !assert.Equal(t, tc.expectedCode, res.Code, "status code not equal") {
+				return
+			}
+
+			body, err := ioutil.ReadAll(res.Body)
+			assert.NoError(t, err)
+
+			if tc.wantErr {
+				assert.Equal(t, tc.err, string(body), "incorrect status code")
+				return
+			}
+
+			got := new(views.ConfigList)
+			err = json.Unmarshal(body, &got)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, len(*got), "configs count not equal")
+		})
+	}
+
+}
+
+// Testing ConfigCreateH handler
+func TestConfigCreate(t *testing.T) {
+
+	stg, _ := storage.Get("mock")
+	envs.Get().SetStorage(stg)
+
+	ns1 := getNamespaceAsset("demo", "")
+	ns2 := getNamespaceAsset("test", "")
+	c1 := getConfigAsset(ns1.Meta.Name, "demo")
+
+	mf1, _ := getConfigManifest("demo", "value").ToJson()
+	mf2, _ := getConfigManifest("test", "value").ToJson()
+	mf3, _ := getConfigManifest("", "value").ToJson()
+
+	tests := []struct {
+		name         string
+		namespace    *types.Namespace
+		data         string
+		err          string
+		want         string
+		wantErr      bool
+		expectedCode int
+	}{
+		{
+			name:         "checking create config if namespace not found",
+			namespace:    ns2,
+			data:         string(mf2),
+			err:          "{\"code\":404,\"status\":\"Not Found\",\"message\":\"Namespace not found\"}",
+			wantErr:      true,
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "checking create config if failed incoming json data",
+			namespace:    ns1,
+			data:         "{name:demo}",
+			err:          "{\"code\":400,\"status\":\"Incorrect Json\",\"message\":\"Incorrect json\"}",
+			wantErr:      true,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "checking create config if name is empty",
+			namespace:    ns1,
+			data:         string(mf3),
+			err:          "{\"code\":400,\"status\":\"Bad Parameter\",\"message\":\"Bad name parameter\"}",
+			wantErr:      true,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "checking create config if name already exists",
+			namespace:    ns1,
+			data:         string(mf1),
+			err:          "{\"code\":400,\"status\":\"Not Unique\",\"message\":\"Name is already in use\"}",
+			wantErr:      true,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "checking create config successfully",
+			namespace:    ns1,
+			data:         string(mf2),
+			want:         "test",
+			wantErr:      false,
+			expectedCode: http.StatusOK,
+		},
+	}
+
+	clear := func() {
+		err := stg.Del(context.Background(), stg.Collection().Namespace(), types.EmptyString)
+		assert.NoError(t, err)
+
+		err = stg.Del(context.Background(), stg.Collection().Config(), types.EmptyString)
+		assert.NoError(t, err)
+	}
+
+	for _, tc := range tests {
+
+		t.Run(tc.name, func(t *testing.T) {
+
+			clear()
+			defer clear()
+
+			err := stg.Put(context.Background(), stg.Collection().Namespace(), stg.Key().Namespace(ns1.Meta.Name), ns1, nil)
+			assert.NoError(t, err)
+
+			err = stg.Put(context.Background(), stg.Collection().Config(), stg.Key().Config(c1.Meta.Namespace, c1.Meta.Name), c1, nil)
+			assert.NoError(t, err)
+
+			req, err := http.NewRequest("POST", fmt.Sprintf("/namespace/%s/config", tc.namespace.Meta.Name), strings.NewReader(tc.data))
+			assert.NoError(t, err)
+
+			r := mux.NewRouter()
+			r.HandleFunc("/namespace/{namespace}/config", config.ConfigCreateH)
+
+			setRequestVars(r, req)
+
+			res := httptest.NewRecorder()
+			r.ServeHTTP(res, req)
+
+			body, err := ioutil.ReadAll(res.Body)
+			assert.NoError(t, err)
+
+			if !assert.Equal(t, tc.expectedCode, res.Code, "status code not equal") {
+				t.Error(string(body))
+				return
+			}
+
+			if tc.wantErr {
+				assert.Equal(t, tc.err, string(body), "incorrect code message")
+				return
+			}
+
+			got := new(types.Config)
+			err = stg.Get(context.Background(), stg.Collection().Config(), stg.Key().Config(tc.namespace.Meta.Name, tc.want), got, nil)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tc.want, got.Meta.Name, "names mismatch")
+			assert.Equal(t, types.KindConfigText, got.Meta.Kind, "kind mismatch")
+
+			value, err := got.DecodeConfigTextData("key")
+			assert.NoError(t, err)
+			assert.Equal(t, "value", value, "data mismatch")
+		})
+	}
+
+}
+
+// Testing ConfigUpdateH handler
+func TestConfigUpdate(t *testing.T) {
+
+	stg, _ := storage.Get("mock")
+	envs.Get().SetStorage(stg)
+
+	ns1 := getNamespaceAsset("demo", "")
+	ns2 := getNamespaceAsset("test", "")
+	c1 := getConfigAsset(ns1.Meta.Name, "demo")
+	c2 := getConfigAsset(ns1.Meta.Name, "test")
+
+	mf1, _ := getConfigManifest("demo", "updated").ToJson()
+
+	tests := []struct {
+		name         string
+		namespace    *types.Namespace
+		config       *types.Config
+		data         string
+		err          string
+		want         string
+		wantErr      bool
+		expectedCode int
+	}{
+		{
+			name:         "checking update config if not exists",
+			namespace:    ns1,
+			config:       c2,
+			data:         string(mf1),
+			err:          "{\"code\":404,\"status\":\"Not Found\",\"message\":\"Config not found\"}",
+			wantErr:      true,
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "checking update config if namespace not found",
+			namespace:    ns2,
+			config:       c1,
+			data:         string(mf1),
+			err:          "{\"code\":404,\"status\":\"Not Found\",\"message\":\"Namespace not found\"}",
+			wantErr:      true,
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "checking update config if failed incoming json data",
+			namespace:    ns1,
+			config:       c1,
+			data:         "{name:demo}",
+			err:          "{\"code\":400,\"status\":\"Incorrect Json\",\"message\":\"Incorrect json\"}",
+			wantErr:      true,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "checking update config successfully",
+			namespace:    ns1,
+			config:       c1,
+			data:         string(mf1),
+			want:         "updated",
+			wantErr:      false,
+			expectedCode: http.StatusOK,
+		},
+	}
+
+	clear := func() {
+		err := stg.Del(context.Background(), stg.Collection().Namespace(), types.EmptyString)
+		assert.NoError(t, err)
+
+		err = stg.Del(context.Background(), stg.Collection().Config(), types.EmptyString)
+		assert.NoError(t, err)
+	}
+
+	for _, tc := range tests {
+
+		t.Run(tc.name, func(t *testing.T) {
+
+			clear()
+			defer clear()
+
+			err := stg.Put(context.Background(), stg.Collection().Namespace(), stg.Key().Namespace(ns1.Meta.Name), ns1, nil)
+			assert.NoError(t, err)
+
+			err = stg.Put(context.Background(), stg.Collection().Config(), stg.Key().Config(c1.Meta.Namespace, c1.Meta.Name), c1, nil)
+			assert.NoError(t, err)
+
+			req, err := http.NewRequest("PUT", fmt.Sprintf("/namespace/%s/config/%s", tc.namespace.Meta.Name, tc.config.Meta.Name), strings.NewReader(tc.data))
+			assert.NoError(t, err)
+
+			r := mux.NewRouter()
+			r.HandleFunc("/namespace/{namespace}/config/{config}", config.ConfigUpdateH)
+
+			setRequestVars(r, req)
+
+			res := httptest.NewRecorder()
+			r.ServeHTTP(res, req)
+
+			body, err := ioutil.ReadAll(res.Body)
+			assert.NoError(t, err)
+
+			if !assert.Equal(t, tc.expectedCode, res.Code, "status code not equal") {
+				t.Error(string(body))
+				return
+			}
+
+			if tc.wantErr {
+				assert.Equal(t, tc.err, string(body), "incorrect code message")
+				return
+			}
+
+			got := new(types.Config)
+			err = stg.Get(context.Background(), stg.Collection().Config(), stg.Key().Config(tc.namespace.Meta.Name, tc.config.Meta.Name), got, nil)
+			assert.NoError(t, err)
+
+			value, err := got.DecodeConfigTextData("key")
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, value, "data mismatch")
+		})
+	}
+
+}
+
+// Testing ConfigRemoveH handler
+func TestConfigRemove(t *testing.T) {
+
+	stg, _ := storage.Get("mock")
+	envs.Get().SetStorage(stg)
+
+	ns1 := getNamespaceAsset("demo", "")
+	ns2 := getNamespaceAsset("test", "")
+	c1 := getConfigAsset(ns1.Meta.Name, "demo")
+	c2 := getConfigAsset(ns1.Meta.Name, "test")
+
+	tests := []struct {
+		name         string
+		namespace    *types.Namespace
+		config       *types.Config
+		err          string
+		wantErr      bool
+		expectedCode int
+	}{
+		{
+			name:         "checking remove config if not exists",
+			namespace:    ns1,
+			config:       c2,
+			err:          "{\"code\":404,\"status\":\"Not Found\",\"message\":\"Config not found\"}",
+			wantErr:      true,
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "checking remove config if namespace not exists",
+			namespace:    ns2,
+			config:       c1,
+			err:          "{\"code\":404,\"status\":\"Not Found\",\"message\":\"Namespace not found\"}",
+			wantErr:      true,
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "checking remove config successfully",
+			namespace:    ns1,
+			config:       c1,
+			wantErr:      false,
+			expectedCode: http.StatusOK,
+		},
+	}
+
+	clear := func() {
+		err := stg.Del(context.Background(), stg.Collection().Namespace(), types.EmptyString)
+		assert.NoError(t, err)
+
+		err = stg.Del(context.Background(), stg.Collection().Config(), types.EmptyString)
+		assert.NoError(t, err)
+	}
+
+	for _, tc := range tests {
+
+		t.Run(tc.name, func(t *testing.T) {
+
+			clear()
+			defer clear()
+
+			err := stg.Put(context.Background(), stg.Collection().Namespace(), stg.Key().Namespace(ns1.Meta.Name), ns1, nil)
+			assert.NoError(t, err)
+
+			err = stg.Put(context.Background(), stg.Collection().Config(), stg.Key().Config(c1.Meta.Namespace, c1.Meta.Name), c1, nil)
+			assert.NoError(t, err)
+
+			req, err := http.NewRequest("DELETE", fmt.Sprintf("/namespace/%s/config/%s", tc.namespace.Meta.Name, tc.config.Meta.Name), nil)
+			assert.NoError(t, err)
+
+			r := mux.NewRouter()
+			r.HandleFunc("/namespace/{namespace}/config/{config}", config.ConfigRemoveH)
+
+			setRequestVars(r, req)
+
+			res := httptest.NewRecorder()
+			r.ServeHTTP(res, req)
+
+			if !assert.Equal(t, tc.expectedCode, res.Code, "status code not equal") {
+				return
+			}
+
+			body, err := ioutil.ReadAll(res.Body)
+			assert.NoError(t, err)
+
+			if tc.wantErr {
+				assert.Equal(t, tc.err, string(body), "incorrect status code")
+				return
+			}
+
+			got := new(types.Config)
+			err = stg.Get(context.Background(), stg.Collection().Config(), stg.Key().Config(tc.namespace.Meta.Name, tc.config.Meta.Name), got, nil)
+			assert.True(t, errors.Storage().IsErrEntityNotFound(err), "config not removed")
+			assert.Equal(t, "", string(body), "response not empty")
+		})
+	}
+
+}
+
+func getNamespaceAsset(name, desc string) *types.Namespace {
+	var n = types.Namespace{}
+	n.Meta.SetDefault()
+	n.Meta.Name = name
+	n.Meta.Description = desc
+	n.Meta.Endpoint = fmt.Sprintf("%s", name)
+	return &n
+}
+
+func getConfigAsset(namespace, name string) *types.Config {
+	var c = types.Config{}
+	c.Meta.SetDefault()
+	c.Meta.Namespace = namespace
+	c.Meta.Name = name
+	c.Meta.Kind = types.KindConfigText
+	getConfigManifest(name, "value").SetConfigSpec(&c)
+	c.SelfLink()
+	return &c
+}
+
+func getConfigManifest(name, value string) *request.ConfigManifest {
+	var mf = new(request.ConfigManifest)
+	mf.Meta.Name = &name
+	mf.Spec.Type = types.KindConfigText
+	mf.Spec.Data = append(mf.Spec.Data, &request.ConfigManifestData{Key: "key", Value: value})
+	return mf
+}
+
+func setRequestVars(r *mux.Router, req *http.Request) {
+	var match mux.RouteMatch
+	// Take the request and match it
+	r.Match(req, &match)
+	// Push the variable onto the context
+	req = mux.SetURLVars(req, match.Vars)
+}
@@ -273,6 +273,8 @@ func DeploymentUpdateH(w http.ResponseWriter, r *http.Request) {
 	//       "$ref": "#/definitions/views_deployment"
 	//   '404':
 	//     description: Namespace not found / Service not found
+	//   '409':
+	//     description: Deployment was changed since revision
 	//   '500':
 	//     description: Internal server error
 
@@ -322,8 +324,16 @@ func DeploymentUpdateH(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if opts.Revision != nil {
+		dp.System.Revision = *opts.Revision
+	}
+
 	if err := dm.Update(dp); err != nil {
 		log.V(logLevel).Errorf("%s:update:> update deployment err: %s", logPrefix, err.Error())
+		if errors.Storage().IsErrEntityRevision(err) {
+			errors.New("deployment").Conflict().Http(w)
+			return
+		}
 		errors.HTTP.InternalServerError(w)
 		return
 	}
@@ -19,16 +19,19 @@
 package events
 
 import (
+	"context"
 	"net/http"
-
 	"time"
 
 	"github.com/gorilla/websocket"
 	"github.com/lastbackend/lastbackend/pkg/api/envs"
 	"github.com/lastbackend/lastbackend/pkg/api/types/v1"
+	"github.com/lastbackend/lastbackend/pkg/api/types/v1/views"
 	"github.com/lastbackend/lastbackend/pkg/distribution"
+	"github.com/lastbackend/lastbackend/pkg/distribution/errors"
 	"github.com/lastbackend/lastbackend/pkg/distribution/types"
 	"github.com/lastbackend/lastbackend/pkg/log"
+	"github.com/lastbackend/lastbackend/pkg/util/http/utils"
 )
 
 const (
@@ -51,9 +54,35 @@ type Event struct {
 	Data   interface{} `json:"data"`
 }
 
-//EventSubscribeH - realtime subscribe handler
+// EventSubscribeH - realtime subscribe handler
 func EventSubscribeH(w http.ResponseWriter, r *http.Request) {
 
+	// swagger:operation GET /events event eventSubscribe
+	//
+	// Subscribes on cluster objects changes with websocket connection
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	//   - name: entity
+	//     in: query
+	//     description: entity kind to subscribe, can be set many times or as comma separated list
+	//     type: string
+	//   - name: namespace
+	//     in: query
+	//     description: namespace to subscribe
+	//     type: string
+	//   - name: name
+	//     in: query
+	//     description: entity name to subscribe
+	//     type: string
+	// responses:
+	//   '101':
+	//     description: Switching protocols to websocket
+	//   '401':
+	//     description: Unauthorized
+
 	log.V(logLevel).Debugf("%s:subscribe:> subscribe on subscribe", logPrefix)
 
 	if r.Method != "GET" {
@@ -61,12 +90,16 @@ func EventSubscribeH(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	log.V(logLevel).Debugf("%s:subscribe:> watch all events", logPrefix)
+	opts := v1.Request().Events().SubscribeOptions()
+	opts.DecodeQuery(r.URL.Query())
+
+	log.V(logLevel).Debugf("%s:subscribe:> watch events: %v in namespace `%s`", logPrefix, opts.Entity, opts.Namespace)
+
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
 
 	var (
-		sm   = distribution.NewServiceModel(r.Context(), envs.Get().GetStorage())
-		nm   = distribution.NewNamespaceModel(r.Context(), envs.Get().GetStorage())
-		cm   = distribution.NewClusterModel(r.Context(), envs.Get().GetStorage())
+		stg  = envs.Get().GetStorage()
 		done = make(chan bool, 1)
 	)
 
@@ -75,13 +108,20 @@ func EventSubscribeH(w http.ResponseWriter, r *http.Request) {
 		log.V(logLevel).Debugf("%s:subscribe:> set websocket upgrade err: %s", logPrefix, err.Error())
 		return
 	}
+	defer conn.Close()
 
 	ticker := time.NewTicker(time.Second)
 	defer ticker.Stop()
 
-	var serviceEvents = make(chan types.ServiceEvent)
-	var namespaceEvents = make(chan types.NamespaceEvent)
-	var clusterEvents = make(chan types.ClusterEvent)
+	var (
+		clusterEvents    = make(chan types.ClusterEvent)
+		namespaceEvents  = make(chan types.NamespaceEvent)
+		serviceEvents    = make(chan types.ServiceEvent)
+		deploymentEvents = make(chan types.DeploymentEvent)
+		podEvents        = make(chan types.PodEvent)
+		routeEvents      = make(chan types.RouteEvent)
+		volumeEvents     = make(chan types.VolumeEvent)
+	)
 
 	notify := w.(http.CloseNotifier).CloseNotify()
 
@@ -91,88 +131,179 @@ func EventSubscribeH(w http.ResponseWriter, r *http.Request) {
 		done <- true
 	}()
 
-	go func() {
-		for {
-			select {
-			case <-done:
-				close(serviceEvents)
-				close(namespaceEvents)
-				close(clusterEvents)
-				return
-			case e := <-clusterEvents:
-
-				var data interface{}
-				if e.Data == nil {
-					data = nil
-				} else {
-					data = v1.View().Cluster().New(e.Data)
-				}
-
-				event := Event{
-					Entity: "cluster",
-					Action: e.Action,
-					Name:   e.Name,
-					Data:   data,
-				}
-
-				if err = conn.WriteJSON(event); err != nil {
-					log.Errorf("%s:subscribe:> write cluster event to socket error.", logPrefix)
-				}
-			case e := <-serviceEvents:
-
-				var data interface{}
-				if e.Data == nil {
-					data = nil
-				} else {
-					data = v1.View().Service().New(e.Data)
-				}
-
-				event := Event{
-					Entity: "service",
-					Action: e.Action,
-					Name:   e.Name,
-					Data:   data,
-				}
-
-				if err = conn.WriteJSON(event); err != nil {
-					log.Errorf("%s:subscribe:> write service event to socket error.", logPrefix)
-				}
-			case e := <-namespaceEvents:
-
-				var data interface{}
-				if e.Data == nil {
-					data = nil
-				} else {
-					data = v1.View().Namespace().New(e.Data)
-				}
-
-				event := Event{
-					Entity: "namespace",
-					Action: e.Action,
-					Name:   e.Name,
-					Data:   data,
-				}
-
-				if err = conn.WriteJSON(event); err != nil {
-					log.Errorf("%s:subscribe:> write namespace event to socket error.", logPrefix)
-				}
-			}
+	write := func(entity, action, name, namespace, object string, data interface{}) bool {
+
+		if !opts.Match(entity, namespace, object) {
+			return true
+		}
+
+		event := Event{
+			Entity: entity,
+			Action: action,
+			Name:   name,
+			Data:   data,
 		}
-	}()
 
-	go cm.Watch(clusterEvents)
-	go sm.Watch(serviceEvents, nil)
-	go nm.Watch(namespaceEvents)
+		if err := conn.WriteJSON(event); err != nil {
+			log.Errorf("%s:subscribe:> write %s event to socket error.", logPrefix, entity)
+			return false
+		}
 
-	go func() {
-		for range ticker.C {
+		return true
+	}
+
+	if opts.Subscribed(types.KindCluster) {
+		go distribution.NewClusterModel(ctx, stg).Watch(clusterEvents)
+	}
+	if opts.Subscribed(types.KindNamespace) {
+		go distribution.NewNamespaceModel(ctx, stg).Watch(namespaceEvents)
+	}
+	if opts.Subscribed(types.KindService) {
+		go distribution.NewServiceModel(ctx, stg).Watch(serviceEvents, nil)
+	}
+	if opts.Subscribed(types.KindDeployment) {
+		go distribution.NewDeploymentModel(ctx, stg).Watch(deploymentEvents, nil)
+	}
+	if opts.Subscribed(types.KindPod) {
+		go distribution.NewPodModel(ctx, stg).Watch(podEvents, nil)
+	}
+	if opts.Subscribed(types.KindRoute) {
+		go distribution.NewRouteModel(ctx, stg).Watch(routeEvents, nil)
+	}
+	if opts.Subscribed(types.KindVolume) {
+		go distribution.NewVolumeModel(ctx, stg).Watch(volumeEvents, nil)
+	}
+
+	for {
+
+		var ok = true
+
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
 			if err := conn.WriteMessage(websocket.TextMessage, []byte{}); err != nil {
 				log.Errorf("%s:subscribe:> writing to the client websocket err: %s", logPrefix, err.Error())
-				done <- true
-				break
+				return
 			}
+		case e := <-clusterEvents:
+			ok = write(types.KindCluster, e.Action, e.Name, types.EmptyString, e.Data.Meta.Name,
+				v1.View().Cluster().New(e.Data))
+		case e := <-namespaceEvents:
+			ok = write(types.KindNamespace, e.Action, e.Name, e.Data.Meta.Name, e.Data.Meta.Name,
+				v1.View().Namespace().New(e.Data))
+		case e := <-serviceEvents:
+			ok = write(types.KindService, e.Action, e.Name, e.Data.Meta.Namespace, e.Data.Meta.Name,
+				v1.View().Service().New(e.Data))
+		case e := <-deploymentEvents:
+			ok = write(types.KindDeployment, e.Action, e.Name, e.Data.Meta.Namespace, e.Data.Meta.Name,
+				v1.View().Deployment().New(e.Data, nil))
+		case e := <-podEvents:
+			ok = write(types.KindPod, e.Action, e.Name, e.Data.Meta.Namespace, e.Data.Meta.Name,
+				new(views.PodViewHelper).New(e.Data))
+		case e := <-routeEvents:
+			ok = write(types.KindRoute, e.Action, e.Name, e.Data.Meta.Namespace, e.Data.Meta.Name,
+				v1.View().Route().New(e.Data))
+		case e := <-volumeEvents:
+			ok = write(types.KindVolume, e.Action, e.Name, e.Data.Meta.Namespace, e.Data.Meta.Name,
+				v1.View().Volume().New(e.Data))
 		}
-	}()
 
-	<-done
+		if !ok {
+			return
+		}
+	}
+}
+
+func EventListH(w http.ResponseWriter, r *http.Request) {
+
+	// swagger:operation GET /namespace/{namespace}/event event eventList
+	//
+	// Shows a list of namespace events
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	//   - name: namespace
+	//     in: path
+	//     description: namespace id
+	//     required: true
+	//     type: string
+	//   - name: kind
+	//     in: query
+	//     description: involved object kind
+	//     type: string
+	//   - name: object
+	//     in: query
+	//     description: involved object self link
+	//     type: string
+	//   - name: reason
+	//     in: query
+	//     description: event reason
+	//     type: string
+	//   - name: severity
+	//     in: query
+	//     description: event severity
+	//     type: string
+	// responses:
+	//   '200':
+	//     description: Event list response
+	//     schema:
+	//       "$ref": "#/definitions/views_event_list"
+	//   '404':
+	//     description: Namespace not found
+	//   '500':
+	//     description: Internal server error
+
+	nid := utils.Vars(r)["namespace"]
+
+	log.V(logLevel).Debugf("%s:list:> get events list for `%s`", logPrefix, nid)
+
+	var (
+		nsm  = distribution.NewNamespaceModel(r.Context(), envs.Get().GetStorage())
+		em   = distribution.NewEventModel(r.Context(), envs.Get().GetStorage())
+		opts = v1.Request().Events().ListOptions()
+	)
+
+	opts.DecodeQuery(r.URL.Query())
+
+	ns, err := nsm.Get(nid)
+	if err != nil {
+		log.V(logLevel).Errorf("%s:list:> get namespace `%s` err: %s", logPrefix, nid, err.Error())
+		errors.HTTP.InternalServerError(w)
+		return
+	}
+	if ns == nil {
+		log.V(logLevel).Warnf("%s:list:> namespace `%s` not found", logPrefix, nid)
+		errors.New("namespace").NotFound().Http(w)
+		return
+	}
+
+	items, err := em.ListByNamespace(ns.Meta.Name)
+	if err != nil {
+		log.V(logLevel).Errorf("%s:list:> find event list err: %s", logPrefix, err.Error())
+		errors.HTTP.InternalServerError(w)
+		return
+	}
+
+	list := types.NewEventRecordList()
+	for _, e := range items.Items {
+		if opts.Match(e) {
+			list.Items = append(list.Items, e)
+		}
+	}
+
+	response, err := v1.View().Event().NewList(list).ToJson()
+	if err != nil {
+		log.V(logLevel).Errorf("%s:list:> convert struct to json err: %s", logPrefix, err.Error())
+		errors.HTTP.InternalServerError(w)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(response); err != nil {
+		log.V(logLevel).Errorf("%s:list:> write response err: %s", logPrefix, err.Error())
+		return
+	}
 }
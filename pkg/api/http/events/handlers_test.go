@@ -17,3 +17,158 @@
 //
 
 package events_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/lastbackend/lastbackend/pkg/api/envs"
+	"github.com/lastbackend/lastbackend/pkg/api/http/events"
+	"github.com/lastbackend/lastbackend/pkg/api/types/v1/views"
+	"github.com/lastbackend/lastbackend/pkg/distribution"
+	"github.com/lastbackend/lastbackend/pkg/distribution/types"
+	"github.com/lastbackend/lastbackend/pkg/storage"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEventList(t *testing.T) {
+
+	stg, _ := storage.Get("mock")
+	envs.Get().SetStorage(stg)
+
+	ns1 := getNamespaceAsset("demo", "")
+	ns2 := getNamespaceAsset("test", "")
+
+	pod := types.EventObject{Kind: types.KindPod, SelfLink: "demo:demo:demo:demo"}
+	dp := types.EventObject{Kind: types.KindDeployment, SelfLink: "demo:demo:demo"}
+
+	tests := []struct {
+		name         string
+		namespace    string
+		query        string
+		err          string
+		want         int
+		wantErr      bool
+		expectedCode int
+	}{
+		{
+			name:         "checking get events list if namespace not found",
+			namespace:    "unknown",
+			err:          "{\"code\":404,\"status\":\"Not Found\",\"message\":\"Namespace not found\"}",
+			wantErr:      true,
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "checking get events list successfully",
+			namespace:    ns1.Meta.Name,
+			want:         3,
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "checking get events list filtered by object",
+			namespace:    ns1.Meta.Name,
+			query:        fmt.Sprintf("?kind=%s&object=%s", pod.Kind, pod.SelfLink),
+			want:         2,
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "checking get events list filtered by reason",
+			namespace:    ns1.Meta.Name,
+			query:        fmt.Sprintf("?reason=%s", types.EventReasonRolledOut),
+			want:         1,
+			expectedCode: http.StatusOK,
+		},
+	}
+
+	clear := func() {
+		err := stg.Del(context.Background(), stg.Collection().Namespace(), types.EmptyString)
+		assert.NoError(t, err)
+
+		err = stg.Del(context.Background(), stg.Collection().Event(), types.EmptyString)
+		assert.NoError(t, err)
+	}
+
+	for _, tc := range tests {
+
+		t.Run(tc.name, func(t *testing.T) {
+
+			clear()
+			defer clear()
+
+			for _, n := range []*types.Namespace{ns1, ns2} {
+				err := stg.Put(context.Background(), stg.Collection().Namespace(), stg.Key().Namespace(n.Meta.Name), n, nil)
+				assert.NoError(t, err)
+			}
+
+			em := distribution.NewEventModel(context.Background(), stg)
+
+			_, err := em.Record(ns1.Meta.Name, pod, types.EventSeverityNormal, types.EventReasonScheduled, "pod scheduled")
+			assert.NoError(t, err)
+			_, err = em.Record(ns1.Meta.Name, pod, types.EventSeverityWarning, types.EventReasonOOMKilled, "oom killed")
+			assert.NoError(t, err)
+			_, err = em.Record(ns1.Meta.Name, pod, types.EventSeverityWarning, types.EventReasonOOMKilled, "oom killed")
+			assert.NoError(t, err)
+			_, err = em.Record(ns1.Meta.Name, dp, types.EventSeverityNormal, types.EventReasonRolledOut, "rolled out")
+			assert.NoError(t, err)
+			_, err = em.Record(ns2.Meta.Name, dp, types.EventSeverityNormal, types.EventReasonRolledOut, "rolled out")
+			assert.NoError(t, err)
+
+			req, err := http.NewRequest("GET", fmt.Sprintf("/namespace/%s/event%s", tc.namespace, tc.query), nil)
+			assert.NoError(t, err)
+
+			r := mux.NewRouter()
+			r.HandleFunc("/namespace/{namespace}/event", events.EventListH)
+
+			setRequestVars(r, req)
+
+			res := httptest.NewRecorder()
+			r.ServeHTTP(res, req)
+
+			assert.Equal(t, tc.expectedCode, res.Code, "status code not equal")
+
+			body, err := ioutil.ReadAll(res.Body)
+			assert.NoError(t, err)
+
+			if tc.wantErr {
+				assert.Equal(t, tc.err, string(body), "incorrect status code")
+				return
+			}
+
+			got := make(views.EventList, 0)
+			err = json.Unmarshal(body, &got)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tc.want, len(got), "events count not match")
+			for _, item := range got {
+				assert.Equal(t, ns1.Meta.Name, item.Meta.Namespace, "event namespace not match")
+				if item.Reason == types.EventReasonOOMKilled {
+					assert.Equal(t, 2, item.Count, "event count not match")
+				}
+			}
+		})
+	}
+
+}
+
+func getNamespaceAsset(name, desc string) *types.Namespace {
+	var n = types.Namespace{}
+	n.Meta.SetDefault()
+	n.Meta.Name = name
+	n.Meta.Description = desc
+	n.Meta.Endpoint = fmt.Sprintf("%s", name)
+	return &n
+}
+
+func setRequestVars(r *mux.Router, req *http.Request) {
+	var match mux.RouteMatch
+	// Take the request and match it
+	r.Match(req, &match)
+	// Push the variable onto the context
+	req = mux.SetURLVars(req, match.Vars)
+}
@@ -25,5 +25,6 @@ import (
 
 var Routes = []http.Route{
 	// Events handlers
-	{Path: "/events", Method: http.MethodGet, Middleware: []http.Middleware{middleware.Context}, Handler: EventSubscribeH},
+	{Path: "/events", Method: http.MethodGet, Middleware: []http.Middleware{middleware.Authenticate}, Handler: EventSubscribeH},
+	{Path: "/namespace/{namespace}/event", Method: http.MethodGet, Middleware: []http.Middleware{middleware.Authenticate}, Handler: EventListH},
 }
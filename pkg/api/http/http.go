@@ -20,7 +20,9 @@ package http
 
 import (
 	"github.com/gorilla/mux"
+	"github.com/lastbackend/lastbackend/pkg/api/http/build"
 	"github.com/lastbackend/lastbackend/pkg/api/http/cluster"
+	"github.com/lastbackend/lastbackend/pkg/api/http/config"
 	"github.com/lastbackend/lastbackend/pkg/api/http/deployment"
 	"github.com/lastbackend/lastbackend/pkg/api/http/discovery"
 	"github.com/lastbackend/lastbackend/pkg/api/http/events"
@@ -35,6 +37,7 @@ import (
 	"github.com/lastbackend/lastbackend/pkg/log"
 	"github.com/lastbackend/lastbackend/pkg/util/http"
 	"github.com/lastbackend/lastbackend/pkg/util/http/cors"
+	"github.com/lastbackend/lastbackend/pkg/util/metrics"
 )
 
 const (
@@ -73,14 +76,21 @@ func init() {
 	AddRoutes(service.Routes)
 	AddRoutes(deployment.Routes)
 	AddRoutes(volume.Routes)
+	AddRoutes(config.Routes)
 	AddRoutes(ingress.Routes)
 	AddRoutes(discovery.Routes)
 
 	//triggers
 	AddRoutes(trigger.Routes)
 
+	// builds
+	AddRoutes(build.Routes)
+
 	// events
 	AddRoutes(events.Routes)
+
+	// metrics
+	AddRoutes([]http.Route{{Path: "/metrics", Method: http.MethodGet, Handler: metrics.Handler}})
 }
 
 func Listen(host string, port int, opts *HttpOpts) error {
@@ -102,7 +112,7 @@ func Listen(host string, port int, opts *HttpOpts) error {
 
 	for _, route := range Routes {
 		log.V(logLevel).Debugf("%s:> init route: %s", logPrefix, route.Path)
-		r.Handle(route.Path, http.Handle(route.Handler, route.Middleware...)).Methods(route.Method)
+		r.Handle(route.Path, instrument(route.Path, route.Method, http.Handle(route.Handler, route.Middleware...))).Methods(route.Method)
 	}
 
 	if opts.Insecure {
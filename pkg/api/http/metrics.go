@@ -0,0 +1,88 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+
+package http
+
+import (
+	"bufio"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/lastbackend/lastbackend/pkg/util/metrics"
+)
+
+var (
+	requestsTotal = metrics.NewCounter("lb_api_http_requests_total",
+		"Count of API HTTP requests by route, method and response code", "route", "method", "code")
+	requestsDuration = metrics.NewHistogram("lb_api_http_request_duration_seconds",
+		"API HTTP requests latency by route and method", nil, "route", "method")
+)
+
+// instrument collects requests count and latency of route handler
+func instrument(route, method string, h http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var (
+			started = time.Now()
+			rw      = &responseWriter{ResponseWriter: w, code: http.StatusOK}
+		)
+
+		h.ServeHTTP(rw, r)
+
+		requestsTotal.Inc(route, method, strconv.Itoa(rw.code))
+		requestsDuration.Observe(time.Since(started).Seconds(), route, method)
+	}
+}
+
+// responseWriter keeps response status code,
+// handlers use close notifier, flusher and hijacker of original writer for streams and websockets
+type responseWriter struct {
+	http.ResponseWriter
+	code    int
+	written bool
+}
+
+func (w *responseWriter) WriteHeader(code int) {
+	if !w.written {
+		w.code = code
+		w.written = true
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *responseWriter) Write(b []byte) (int, error) {
+	w.written = true
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *responseWriter) CloseNotify() <-chan bool {
+	return w.ResponseWriter.(http.CloseNotifier).CloseNotify()
+}
+
+func (w *responseWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+func (w *responseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	w.code = http.StatusSwitchingProtocols
+	w.written = true
+	return w.ResponseWriter.(http.Hijacker).Hijack()
+}
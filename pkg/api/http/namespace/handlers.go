@@ -235,6 +235,8 @@ func NamespaceUpdateH(w http.ResponseWriter, r *http.Request) {
 	//       "$ref": "#/definitions/views_namespace"
 	//   '404':
 	//     description: Namespace not found
+	//   '409':
+	//     description: Namespace was changed since revision
 	//   '500':
 	//     description: Internal server error
 
@@ -268,6 +270,10 @@ func NamespaceUpdateH(w http.ResponseWriter, r *http.Request) {
 
 	if err := nsm.Update(ns, opts); err != nil {
 		log.V(logLevel).Errorf("%s:update:> update namespace `%s` err: %s", logPrefix, nid, err.Error())
+		if errors.Storage().IsErrEntityRevision(err) {
+			errors.New("namespace").Conflict().Http(w)
+			return
+		}
 		errors.HTTP.InternalServerError(w)
 		return
 	}
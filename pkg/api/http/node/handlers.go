@@ -23,6 +23,7 @@ import (
 	"net/http"
 
 	"strings"
+	"time"
 
 	"github.com/lastbackend/lastbackend/pkg/api/envs"
 	"github.com/lastbackend/lastbackend/pkg/api/types/v1"
@@ -31,6 +32,7 @@ import (
 	"github.com/lastbackend/lastbackend/pkg/distribution/errors"
 	"github.com/lastbackend/lastbackend/pkg/distribution/types"
 	"github.com/lastbackend/lastbackend/pkg/log"
+	"github.com/lastbackend/lastbackend/pkg/util/crypto"
 	"github.com/lastbackend/lastbackend/pkg/util/http/utils"
 )
 
@@ -470,6 +472,7 @@ func NodeSetStatusH(w http.ResponseWriter, r *http.Request) {
 	var (
 		nm = distribution.NewNodeModel(r.Context(), envs.Get().GetStorage())
 		pm = distribution.NewPodModel(r.Context(), envs.Get().GetStorage())
+		bm = distribution.NewBuildModel(r.Context(), envs.Get().GetStorage())
 
 		nid = utils.Vars(r)["node"]
 	)
@@ -496,6 +499,7 @@ func NodeSetStatusH(w http.ResponseWriter, r *http.Request) {
 
 	node.Status.State = opts.State
 	node.Status.Online = true
+	node.Status.Heartbeat = time.Now().UTC()
 	node.Status.Capacity = opts.Resources.Capacity
 
 	if err := nm.Set(node); err != nil {
@@ -540,6 +544,11 @@ func NodeSetStatusH(w http.ResponseWriter, r *http.Request) {
 		pod.Status.Network = s.Network
 		pod.Status.Steps = s.Steps
 
+		if u, ok := opts.Usage[p]; ok && u != nil {
+			pod.Status.Usage = *u
+			delete(opts.Usage, p)
+		}
+
 		if err := pm.Update(pod); err != nil {
 			log.V(logLevel).Errorf("%s:setpodstatus:> get nodes list err: %s", logPrefix, err.Error())
 			errors.HTTP.InternalServerError(w)
@@ -547,6 +556,78 @@ func NodeSetStatusH(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	for p, u := range opts.Usage {
+
+		if u == nil {
+			continue
+		}
+
+		keys := strings.Split(p, ":")
+		if len(keys) != 4 {
+			log.V(logLevel).Errorf("%s:setpodusage:> invalid pod selflink err: %s", logPrefix, p)
+			continue
+		}
+
+		pod, err := pm.Get(keys[0], keys[1], keys[2], keys[3])
+		if err != nil {
+			log.V(logLevel).Errorf("%s:setpodusage:> get pod `%s` err: %s", logPrefix, p, err.Error())
+			errors.HTTP.InternalServerError(w)
+			return
+		}
+		if pod == nil {
+			continue
+		}
+
+		pod.Status.Usage = *u
+
+		if err := pm.Update(pod); err != nil {
+			log.V(logLevel).Errorf("%s:setpodusage:> update pod `%s` err: %s", logPrefix, p, err.Error())
+			errors.HTTP.InternalServerError(w)
+			return
+		}
+	}
+
+	for b, s := range opts.Builds {
+
+		if s == nil {
+			continue
+		}
+
+		keys := strings.Split(b, ":")
+		if len(keys) != 2 {
+			log.V(logLevel).Errorf("%s:setbuildstatus:> invalid build selflink err: %s", logPrefix, b)
+			continue
+		}
+
+		build, err := bm.Get(keys[0], keys[1])
+		if err != nil {
+			log.V(logLevel).Errorf("%s:setbuildstatus:> get build `%s` err: %s", logPrefix, b, err.Error())
+			errors.HTTP.InternalServerError(w)
+			return
+		}
+
+		// skip removed, finished or moved to another node builds
+		if build == nil || build.Done() || build.Status.Node != node.SelfLink() {
+			continue
+		}
+
+		if build.Status.State == s.State && build.Status.Message == s.Message && build.Status.Started.Equal(s.Started) {
+			continue
+		}
+
+		status := build.Status
+		status.State = s.State
+		status.Message = s.Message
+		status.Started = s.Started
+		status.Finished = s.Finished
+
+		if err := bm.SetStatus(build, &status); err != nil {
+			log.V(logLevel).Errorf("%s:setbuildstatus:> set build `%s` status err: %s", logPrefix, b, err.Error())
+			errors.HTTP.InternalServerError(w)
+			return
+		}
+	}
+
 
 	spec, err := getNodeSpec(r.Context(), node)
 	if err != nil {
@@ -630,6 +711,147 @@ func NodeRemoveH(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+func NodeCordonH(w http.ResponseWriter, r *http.Request) {
+
+	// swagger:operation PUT /cluster/node/{node}/cordon node nodeCordon
+	//
+	// Mark node as unschedulable
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	//   - name: node
+	//     in: path
+	//     description: node id
+	//     required: true
+	//     type: string
+	// responses:
+	//   '200':
+	//     description: Node response
+	//     schema:
+	//       "$ref": "#/definitions/views_node"
+	//   '404':
+	//     description: Node not found
+	//   '500':
+	//     description: Internal server error
+
+	log.V(logLevel).Debugf("%s:cordon:> cordon node", logPrefix)
+
+	nodeSpecUpdate(w, r, "cordon", func(n *types.Node) {
+		n.Spec.Unschedulable = true
+	})
+}
+
+func NodeUncordonH(w http.ResponseWriter, r *http.Request) {
+
+	// swagger:operation PUT /cluster/node/{node}/uncordon node nodeUncordon
+	//
+	// Mark node as schedulable and stop node drain
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	//   - name: node
+	//     in: path
+	//     description: node id
+	//     required: true
+	//     type: string
+	// responses:
+	//   '200':
+	//     description: Node response
+	//     schema:
+	//       "$ref": "#/definitions/views_node"
+	//   '404':
+	//     description: Node not found
+	//   '500':
+	//     description: Internal server error
+
+	log.V(logLevel).Debugf("%s:uncordon:> uncordon node", logPrefix)
+
+	nodeSpecUpdate(w, r, "uncordon", func(n *types.Node) {
+		n.Spec.Unschedulable = false
+		n.Spec.Drain = false
+		n.Status.Drained = false
+	})
+}
+
+func NodeDrainH(w http.ResponseWriter, r *http.Request) {
+
+	// swagger:operation PUT /cluster/node/{node}/drain node nodeDrain
+	//
+	// Mark node as unschedulable and move node pods to other nodes
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	//   - name: node
+	//     in: path
+	//     description: node id
+	//     required: true
+	//     type: string
+	// responses:
+	//   '200':
+	//     description: Node response
+	//     schema:
+	//       "$ref": "#/definitions/views_node"
+	//   '404':
+	//     description: Node not found
+	//   '500':
+	//     description: Internal server error
+
+	log.V(logLevel).Debugf("%s:drain:> drain node", logPrefix)
+
+	nodeSpecUpdate(w, r, "drain", func(n *types.Node) {
+		n.Spec.Unschedulable = true
+		n.Spec.Drain = true
+	})
+}
+
+// nodeSpecUpdate applies scheduling changes to node and writes node view to response
+func nodeSpecUpdate(w http.ResponseWriter, r *http.Request, action string, update func(n *types.Node)) {
+
+	var (
+		nm  = distribution.NewNodeModel(r.Context(), envs.Get().GetStorage())
+		nid = utils.Vars(r)["node"]
+	)
+
+	n, err := nm.Get(nid)
+	if err != nil {
+		log.V(logLevel).Errorf("%s:%s:> get node err: %s", logPrefix, action, err.Error())
+		errors.HTTP.InternalServerError(w)
+		return
+	}
+	if n == nil {
+		log.V(logLevel).Warnf("%s:%s:> node `%s` not found", logPrefix, action, nid)
+		errors.New("node").NotFound().Http(w)
+		return
+	}
+
+	update(n)
+
+	if err := nm.Set(n); err != nil {
+		log.V(logLevel).Errorf("%s:%s:> update node err: %s", logPrefix, action, err.Error())
+		errors.HTTP.InternalServerError(w)
+		return
+	}
+
+	response, err := v1.View().Node().New(n).ToJson()
+	if err != nil {
+		log.V(logLevel).Errorf("%s:%s:> convert struct to json err: %s", logPrefix, action, err.Error())
+		errors.HTTP.InternalServerError(w)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(response); err != nil {
+		log.Errorf("%s:%s:> write response err: %s", logPrefix, action, err.Error())
+		return
+	}
+}
+
 func getNodeSpec(ctx context.Context, n *types.Node) (*types.NodeManifest, error){
 
 	var (
@@ -640,6 +862,9 @@ func getNodeSpec(ctx context.Context, n *types.Node) (*types.NodeManifest, error
 		vm  = distribution.NewVolumeModel(ctx, stg)
 		em  = distribution.NewEndpointModel(ctx, stg)
 		ns  = distribution.NewNetworkModel(ctx, stg)
+		cm  = distribution.NewConfigModel(ctx, stg)
+		sm  = distribution.NewSecretModel(ctx, stg)
+		bm  = distribution.NewBuildModel(ctx, stg)
 	)
 
 	if spec == nil {
@@ -675,6 +900,63 @@ func getNodeSpec(ctx context.Context, n *types.Node) (*types.NodeManifest, error
 		}
 
 		spec.Network = subnets.Items
+
+		configs, err := cm.List()
+		if err != nil {
+			log.V(logLevel).Errorf("%s:getmanifest:> get config manifests for node err: %s", logPrefix, err.Error())
+			return spec, err
+		}
+
+		spec.Configs = make(map[string]*types.ConfigManifest, 0)
+		for _, c := range configs.Items {
+			m := new(types.ConfigManifest)
+			m.Set(c)
+			spec.Configs[c.SelfLink()] = m
+		}
+
+		builds, err := bm.List()
+		if err != nil {
+			log.V(logLevel).Errorf("%s:getmanifest:> get build manifests for node err: %s", logPrefix, err.Error())
+			return spec, err
+		}
+
+		spec.Builds = make(map[string]*types.BuildManifest, 0)
+		for _, b := range builds.Items {
+			if b.Status.Node != n.SelfLink() || b.Done() {
+				continue
+			}
+
+			m := new(types.BuildManifest)
+			m.Set(b)
+			spec.Builds[b.SelfLink()] = m
+		}
+
+		secrets, err := sm.List(types.EmptyString)
+		if err != nil {
+			log.V(logLevel).Errorf("%s:getmanifest:> get secret manifests for node err: %s", logPrefix, err.Error())
+			return spec, err
+		}
+
+		// send only secrets used by pods and builds on the node
+		used := spec.GetSecrets()
+
+		spec.Secrets = make(map[string]*types.SecretManifest, 0)
+		for _, s := range secrets.Items {
+
+			if !used[s.SelfLink()] {
+				continue
+			}
+
+			data, err := crypto.DecryptMap(envs.Get().GetSecretKeyProvider(), s.SelfLink(), s.Data)
+			if err != nil {
+				log.V(logLevel).Errorf("%s:getmanifest:> decrypt secret `%s` data err: %s", logPrefix, s.SelfLink(), err.Error())
+				continue
+			}
+
+			m := new(types.SecretManifest)
+			m.Set(s, data)
+			spec.Secrets[s.SelfLink()] = m
+		}
 	}
 	cache.Flush(n.Meta.Name)
 
@@ -20,6 +20,8 @@ package node_test
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"net/http"
@@ -34,6 +36,7 @@ import (
 	"github.com/lastbackend/lastbackend/pkg/api/types/v1"
 	"github.com/lastbackend/lastbackend/pkg/distribution/types"
 	"github.com/lastbackend/lastbackend/pkg/storage"
+	"github.com/lastbackend/lastbackend/pkg/util/crypto"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 )
@@ -314,6 +317,80 @@ func TestNodeGetManifestH(t *testing.T) {
 	}
 }
 
+func TestNodeGetManifestSecretsH(t *testing.T) {
+	stg, _ := storage.Get("mock")
+	cg := cache.NewCache()
+
+	envs.Get().SetStorage(stg)
+	envs.Get().SetCache(cg)
+	envs.Get().SetSecretKeyProvider(getKeyProvider())
+
+	viper.Set("verbose", 0)
+
+	var (
+		n1 = getNodeAsset("test1", "", true)
+		p1 = "demo:demo:demo:demo"
+		pm = getPodManifest()
+	)
+
+	pm.Template.Containers = types.SpecTemplateContainers{
+		{EnvVars: types.SpecTemplateContainerEnvs{{Name: "DB_PASSWORD", Secret: types.SpecTemplateContainerEnvSecret{Name: "db", Key: "password"}}}},
+	}
+
+	s1 := getSecretAsset(t, "demo", "db")
+	s2 := getSecretAsset(t, "demo", "other")
+	s3 := getSecretAsset(t, "test", "db")
+
+	err := envs.Get().GetStorage().Del(context.Background(), stg.Collection().Node().Info(), types.EmptyString)
+	assert.NoError(t, err)
+
+	err = envs.Get().GetStorage().Del(context.Background(), stg.Collection().Manifest().Pod(n1.Meta.Name), types.EmptyString)
+	assert.NoError(t, err)
+
+	err = envs.Get().GetStorage().Del(context.Background(), stg.Collection().Secret(), types.EmptyString)
+	assert.NoError(t, err)
+
+	err = stg.Put(context.Background(), stg.Collection().Node().Info(), stg.Key().Node(n1.Meta.Name), &n1, nil)
+	assert.NoError(t, err)
+
+	err = stg.Put(context.Background(), stg.Collection().Manifest().Pod(n1.Meta.Name), p1, pm, nil)
+	assert.NoError(t, err)
+
+	for _, s := range []*types.Secret{s1, s2, s3} {
+		err = stg.Put(context.Background(), stg.Collection().Secret(), stg.Key().Secret(s.Meta.Namespace, s.Meta.Name), s, nil)
+		assert.NoError(t, err)
+	}
+
+	req, err := http.NewRequest("GET", fmt.Sprintf("/cluster/node/%s/spec", n1.Meta.Name), nil)
+	assert.NoError(t, err)
+
+	r := mux.NewRouter()
+	r.HandleFunc("/cluster/node/{node}/spec", node.NodeGetSpecH)
+
+	setRequestVars(r, req)
+
+	res := httptest.NewRecorder()
+	r.ServeHTTP(res, req)
+
+	if !assert.Equal(t, http.StatusOK, res.Code, "status code not equal") {
+		return
+	}
+
+	got := new(types.NodeManifest)
+	err = json.NewDecoder(res.Body).Decode(got)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	if !assert.Len(t, got.Secrets, 1, "only secrets used by node pods should be sent") {
+		return
+	}
+
+	if assert.Contains(t, got.Secrets, s1.SelfLink(), "pod secret not sent") {
+		assert.Equal(t, []byte("secret"), got.Secrets[s1.SelfLink()].Data["password"], "secret data is not decrypted")
+	}
+}
+
 func TestNodeRemoveH(t *testing.T) {
 	stg, _ := storage.Get("mock")
 	envs.Get().SetStorage(stg)
@@ -501,6 +578,133 @@ func TestNodeSetMetaH(t *testing.T) {
 	}
 }
 
+func TestNodeDrainH(t *testing.T) {
+	stg, _ := storage.Get("mock")
+	envs.Get().SetStorage(stg)
+	viper.Set("verbose", 0)
+
+	var (
+		ctx = context.Background()
+
+		n1 = getNodeAsset("test1", "", true)
+		n2 = getNodeAsset("test2", "", true)
+		nc = getNodeAsset("test1", "", true)
+		nd = getNodeAsset("test1", "", true)
+	)
+
+	nc.Spec.Unschedulable = true
+
+	nd.Spec.Unschedulable = true
+	nd.Spec.Drain = true
+
+	vn, err := v1.View().Node().New(&n1).ToJson()
+	assert.NoError(t, err)
+
+	vc, err := v1.View().Node().New(&nc).ToJson()
+	assert.NoError(t, err)
+
+	vd, err := v1.View().Node().New(&nd).ToJson()
+	assert.NoError(t, err)
+
+	type args struct {
+		ctx    context.Context
+		node   string
+		action string
+	}
+
+	tests := []struct {
+		name         string
+		args         args
+		stored       types.Node
+		handler      func(http.ResponseWriter, *http.Request)
+		expected     types.NodeSpec
+		expectedBody string
+		expectedCode int
+	}{
+		{
+			name:         "checking drain node failed: not found",
+			args:         args{ctx, n2.Meta.Name, "drain"},
+			stored:       n1,
+			handler:      node.NodeDrainH,
+			expectedBody: "{\"code\":404,\"status\":\"Not Found\",\"message\":\"Node not found\"}",
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "checking cordon node successfully",
+			args:         args{ctx, n1.Meta.Name, "cordon"},
+			stored:       n1,
+			handler:      node.NodeCordonH,
+			expected:     nc.Spec,
+			expectedBody: string(vc),
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "checking drain node successfully",
+			args:         args{ctx, n1.Meta.Name, "drain"},
+			stored:       n1,
+			handler:      node.NodeDrainH,
+			expected:     nd.Spec,
+			expectedBody: string(vd),
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "checking uncordon node successfully",
+			args:         args{ctx, n1.Meta.Name, "uncordon"},
+			stored:       nd,
+			handler:      node.NodeUncordonH,
+			expected:     n1.Spec,
+			expectedBody: string(vn),
+			expectedCode: http.StatusOK,
+		},
+	}
+
+	for _, tc := range tests {
+
+		err = envs.Get().GetStorage().Del(context.Background(), stg.Collection().Node().Info(), types.EmptyString)
+		assert.NoError(t, err)
+
+		err = stg.Put(context.Background(), stg.Collection().Node().Info(), stg.Key().Node(tc.stored.Meta.Name), &tc.stored, nil)
+		assert.NoError(t, err)
+
+		t.Run(tc.name, func(t *testing.T) {
+
+			req, err := http.NewRequest("PUT", fmt.Sprintf("/cluster/node/%s/%s", tc.args.node, tc.args.action), nil)
+			assert.NoError(t, err)
+
+			r := mux.NewRouter()
+			r.HandleFunc(fmt.Sprintf("/cluster/node/{node}/%s", tc.args.action), tc.handler)
+
+			setRequestVars(r, req)
+
+			// We create assert ResponseRecorder (which satisfies http.ResponseWriter) to record the response.
+			res := httptest.NewRecorder()
+
+			// Our handlers satisfy http.Handler, so we can call their ServeHTTP method
+			// directly and pass in our Request and ResponseRecorder.
+			r.ServeHTTP(res, req)
+
+			// Check the status code is what we expect.
+			assert.Equal(t, tc.expectedCode, res.Code, "status code not equal")
+
+			body, err := ioutil.ReadAll(res.Body)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedBody, string(body), "incorrect status code")
+
+			if tc.expectedCode == http.StatusOK {
+				got := new(types.Node)
+				err = envs.Get().GetStorage().Get(context.Background(), stg.Collection().Node().Info(), envs.Get().GetStorage().Key().Node(tc.args.node), got, nil)
+				assert.NoError(t, err)
+				if !assert.NotNil(t, got, "node should not be empty") {
+					return
+				}
+				assert.Equal(t, tc.expected.Unschedulable, got.Spec.Unschedulable, "unschedulable not equal")
+				assert.Equal(t, tc.expected.Drain, got.Spec.Drain, "drain not equal")
+			}
+
+		})
+	}
+}
+
 func TestNodeConnectH(t *testing.T) {
 	stg, _ := storage.Get("mock")
 	envs.Get().SetStorage(stg)
@@ -752,6 +956,24 @@ func getNodeAsset(name, desc string, online bool) types.Node {
 	return n
 }
 
+func getSecretAsset(t *testing.T, namespace, name string) *types.Secret {
+	s := new(types.Secret)
+	s.Meta.SetDefault()
+	s.Meta.Namespace = namespace
+	s.Meta.Name = name
+
+	data, err := crypto.EncryptMap(envs.Get().GetSecretKeyProvider(), s.SelfLink(), map[string][]byte{"password": []byte("secret")})
+	assert.NoError(t, err)
+
+	s.Data = data
+	return s
+}
+
+func getKeyProvider() crypto.KeyProvider {
+	p, _ := crypto.NewAESGCMFromString(base64.StdEncoding.EncodeToString([]byte("0123456789abcdef0123456789abcdef")))
+	return p
+}
+
 func getPodManifest() *types.PodManifest {
 	p := types.PodManifest{}
 	return &p
@@ -31,4 +31,7 @@ var Routes = []http.Route{
 	{Path: "/cluster/node/{node}", Method: http.MethodPut, Middleware: []http.Middleware{middleware.Authenticate}, Handler: NodeConnectH},
 	{Path: "/cluster/node/{node}/meta", Method: http.MethodPut, Middleware: []http.Middleware{middleware.Authenticate}, Handler: NodeSetMetaH},
 	{Path: "/cluster/node/{node}/status", Method: http.MethodPut, Middleware: []http.Middleware{middleware.Authenticate}, Handler: NodeSetStatusH},
+	{Path: "/cluster/node/{node}/cordon", Method: http.MethodPut, Middleware: []http.Middleware{middleware.Authenticate}, Handler: NodeCordonH},
+	{Path: "/cluster/node/{node}/uncordon", Method: http.MethodPut, Middleware: []http.Middleware{middleware.Authenticate}, Handler: NodeUncordonH},
+	{Path: "/cluster/node/{node}/drain", Method: http.MethodPut, Middleware: []http.Middleware{middleware.Authenticate}, Handler: NodeDrainH},
 }
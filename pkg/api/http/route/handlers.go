@@ -209,7 +209,7 @@ func RouteCreateH(w http.ResponseWriter, r *http.Request) {
 	//     schema:
 	//       "$ref": "#/definitions/views_route"
 	//   '400':
-	//     description: Bad rules parameter
+	//     description: Bad rules parameter / Namespace quota exceeded
 	//   '404':
 	//     description: Namespace not found
 	//   '500':
@@ -267,6 +267,19 @@ func RouteCreateH(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	rl, err := rm.ListByNamespace(ns.Meta.Name)
+	if err != nil {
+		log.V(logLevel).Errorf("%s:create:> get routes err: %s", logPrefix, err.Error())
+		errors.HTTP.InternalServerError(w)
+		return
+	}
+
+	if err := ns.CheckQuotas(types.NamespaceResources{Routes: len(rl.Items) + 1}); err != nil {
+		log.V(logLevel).Errorf("%s:create:> check namespace quotas err: %s", logPrefix, err.Error())
+		errors.New("namespace").BadRequest(err.Error(), err).Http(w)
+		return
+	}
+
 	if _, err := rm.Create(ns, rs); err != nil {
 		log.V(logLevel).Errorf("%s:create:> create route err: %s", logPrefix, ns.Meta.Name, err.Error())
 		errors.HTTP.InternalServerError(w)
@@ -322,6 +335,8 @@ func RouteUpdateH(w http.ResponseWriter, r *http.Request) {
 	//     description: Bad rules parameter
 	//   '404':
 	//     description: Namespace not found / Route not found
+	//   '409':
+	//     description: Route was changed since revision
 	//   '500':
 	//     description: Internal server error
 
@@ -390,7 +405,12 @@ func RouteUpdateH(w http.ResponseWriter, r *http.Request) {
 	rs, err = rm.Update(rs)
 	if err != nil {
 		log.V(logLevel).Errorf("%s:update:> update route `%s` err: %s", logPrefix, ns.Meta.Name, err.Error())
+		if errors.Storage().IsErrEntityRevision(err) {
+			errors.New("route").Conflict().Http(w)
+			return
+		}
 		errors.HTTP.InternalServerError(w)
+		return
 	}
 
 	response, err := v1.View().Route().New(rs).ToJson()
@@ -308,10 +308,14 @@ func TestRouteCreate(t *testing.T) {
 
 	ns1 := getNamespaceAsset("demo", "")
 	ns2 := getNamespaceAsset("test", "")
+	ns3 := getNamespaceAsset("quota", "")
+	ns3.Spec.Quotas.Routes = 1
 
 	sv1 := getServiceAsset(ns1.Meta.Name, "demo", "")
+	sv3 := getServiceAsset(ns3.Meta.Name, "demo", "")
 
 	r1 := getRouteAsset(ns1.Meta.Name, "demo")
+	r3 := getRouteAsset(ns3.Meta.Name, "test")
 
 	sl := new(types.ServiceList)
 	sl.Items = append(sl.Items, sv1)
@@ -375,6 +379,16 @@ func TestRouteCreate(t *testing.T) {
 			wantErr:      true,
 			expectedCode: http.StatusBadRequest,
 		},
+		{
+			name:         "check create route if namespace routes quota exceeded",
+			args:         args{ctx, ns3},
+			fields:       fields{stg},
+			handler:      route.RouteCreateH,
+			data:         string(mf1),
+			err:          "{\"code\":400,\"status\":\"Bad Request\",\"message\":\"namespace routes quota exceeded: requested 2 of 1 routes\"}",
+			wantErr:      true,
+			expectedCode: http.StatusBadRequest,
+		},
 		// TODO: need checking incoming data for validity
 		{
 			name:         "check create route success",
@@ -411,6 +425,15 @@ func TestRouteCreate(t *testing.T) {
 			err = tc.fields.stg.Put(context.Background(), stg.Collection().Service(), stg.Key().Service(sv1.Meta.Namespace, sv1.Meta.Name), sv1, nil)
 			assert.NoError(t, err)
 
+			err = tc.fields.stg.Put(context.Background(), stg.Collection().Namespace(), tc.fields.stg.Key().Namespace(ns3.Meta.Name), ns3, nil)
+			assert.NoError(t, err)
+
+			err = tc.fields.stg.Put(context.Background(), stg.Collection().Service(), stg.Key().Service(sv3.Meta.Namespace, sv3.Meta.Name), sv3, nil)
+			assert.NoError(t, err)
+
+			err = tc.fields.stg.Put(context.Background(), stg.Collection().Route(), stg.Key().Route(r3.Meta.Namespace, r3.Meta.Name), r3, nil)
+			assert.NoError(t, err)
+
 			// Create assert request to pass to our handler. We don't have any query parameters for now, so we'll
 			// pass 'nil' as the third parameter.
 			req, err := http.NewRequest("POST", fmt.Sprintf("/namespace/%s/route", tc.args.namespace.Meta.Name), strings.NewReader(tc.data))
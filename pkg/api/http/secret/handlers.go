@@ -19,14 +19,16 @@
 package secret
 
 import (
+	"net/http"
+
 	"github.com/lastbackend/lastbackend/pkg/api/envs"
 	"github.com/lastbackend/lastbackend/pkg/api/types/v1"
 	"github.com/lastbackend/lastbackend/pkg/distribution"
 	"github.com/lastbackend/lastbackend/pkg/distribution/errors"
 	"github.com/lastbackend/lastbackend/pkg/distribution/types"
 	"github.com/lastbackend/lastbackend/pkg/log"
+	"github.com/lastbackend/lastbackend/pkg/util/crypto"
 	"github.com/lastbackend/lastbackend/pkg/util/http/utils"
-	"net/http"
 )
 
 const (
@@ -36,9 +38,9 @@ const (
 
 func SecretGetH(w http.ResponseWriter, r *http.Request) {
 
-	// swagger:operation GET /namespace/{namespace}/secret secret secretList
+	// swagger:operation GET /namespace/{namespace}/secret/{secret} secret secretInfo
 	//
-	// Shows a list of secrets
+	// Shows a secret info, secret data values are not exposed
 	//
 	// ---
 	// produces:
@@ -49,46 +51,59 @@ func SecretGetH(w http.ResponseWriter, r *http.Request) {
 	//     description: namespace id
 	//     required: true
 	//     type: string
+	//   - name: secret
+	//     in: path
+	//     description: secret id
+	//     required: true
+	//     type: string
 	// responses:
 	//   '200':
-	//     description: Secret list response
+	//     description: Secret response
 	//     schema:
-	//       "$ref": "#/definitions/views_secret_list"
+	//       "$ref": "#/definitions/views_secret"
 	//   '404':
-	//     description: Namespace not found
+	//     description: Namespace not found / Secret not found
 	//   '500':
 	//     description: Internal server error
 
-	log.V(logLevel).Debugf("%s:list:> get secret", logPrefix)
+	nid := utils.Vars(r)["namespace"]
+	sid := utils.Vars(r)["secret"]
+
+	log.V(logLevel).Debugf("%s:get:> get secret `%s` in namespace `%s`", logPrefix, sid, nid)
 
 	var (
-		sid = utils.Vars(r)["secret"]
-		rm  = distribution.NewSecretModel(r.Context(), envs.Get().GetStorage())
+		sm = distribution.NewSecretModel(r.Context(), envs.Get().GetStorage())
 	)
 
-	item, err := rm.Get(sid)
+	ns, e := getNamespace(r, nid)
+	if e != nil {
+		e.Http(w)
+		return
+	}
+
+	item, err := sm.Get(ns.Meta.Name, sid)
 	if err != nil {
-		log.V(logLevel).Errorf("%s:list:> find secret list err: %s", logPrefix, err.Error())
+		log.V(logLevel).Errorf("%s:get:> find secret err: %s", logPrefix, err.Error())
 		errors.HTTP.InternalServerError(w)
 		return
 	}
 
 	if item == nil {
-		log.V(logLevel).Warnf("%s:update:> secret `%s` not found", logPrefix, sid)
+		log.V(logLevel).Warnf("%s:get:> secret `%s` not found", logPrefix, sid)
 		errors.New("secret").NotFound().Http(w)
 		return
 	}
 
 	response, err := v1.View().Secret().New(item).ToJson()
 	if err != nil {
-		log.V(logLevel).Errorf("%s:list:> convert struct to json err: %s", logPrefix, err.Error())
+		log.V(logLevel).Errorf("%s:get:> convert struct to json err: %s", logPrefix, err.Error())
 		errors.HTTP.InternalServerError(w)
 		return
 	}
 
 	w.WriteHeader(http.StatusOK)
 	if _, err := w.Write(response); err != nil {
-		log.V(logLevel).Errorf("%s:list:> write response err: %s", logPrefix, err.Error())
+		log.V(logLevel).Errorf("%s:get:> write response err: %s", logPrefix, err.Error())
 		return
 	}
 }
@@ -118,13 +133,21 @@ func SecretListH(w http.ResponseWriter, r *http.Request) {
 	//   '500':
 	//     description: Internal server error
 
-	log.V(logLevel).Debugf("%s:list:> get secrets list", logPrefix)
+	nid := utils.Vars(r)["namespace"]
+
+	log.V(logLevel).Debugf("%s:list:> get secrets list in namespace `%s`", logPrefix, nid)
 
 	var (
-		rm = distribution.NewSecretModel(r.Context(), envs.Get().GetStorage())
+		sm = distribution.NewSecretModel(r.Context(), envs.Get().GetStorage())
 	)
 
-	items, err := rm.List(types.EmptyString)
+	ns, e := getNamespace(r, nid)
+	if e != nil {
+		e.Http(w)
+		return
+	}
+
+	items, err := sm.List(ns.Meta.Name)
 	if err != nil {
 		log.V(logLevel).Errorf("%s:list:> find secret list err: %s", logPrefix, err.Error())
 		errors.HTTP.InternalServerError(w)
@@ -170,15 +193,19 @@ func SecretCreateH(w http.ResponseWriter, r *http.Request) {
 	//     description: Secret was successfully created
 	//     schema:
 	//       "$ref": "#/definitions/views_secret"
+	//   '400':
+	//     description: Name is already in use
 	//   '404':
 	//     description: Namespace not found
 	//   '500':
 	//     description: Internal server error
 
-	log.V(logLevel).Debugf("%s:create:> create secret", logPrefix)
+	nid := utils.Vars(r)["namespace"]
+
+	log.V(logLevel).Debugf("%s:create:> create secret in namespace `%s`", logPrefix, nid)
 
 	var (
-		rm = distribution.NewSecretModel(r.Context(), envs.Get().GetStorage())
+		sm = distribution.NewSecretModel(r.Context(), envs.Get().GetStorage())
 	)
 
 	// request body struct
@@ -189,7 +216,32 @@ func SecretCreateH(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	rs, err := rm.Create(opts)
+	ns, e := getNamespace(r, nid)
+	if e != nil {
+		e.Http(w)
+		return
+	}
+
+	item, err := sm.Get(ns.Meta.Name, opts.Name)
+	if err != nil {
+		log.V(logLevel).Errorf("%s:create:> check exists by name err: %s", logPrefix, err.Error())
+		errors.HTTP.InternalServerError(w)
+		return
+	}
+	if item != nil {
+		log.V(logLevel).Warnf("%s:create:> name `%s` not unique", logPrefix, opts.Name)
+		errors.New("secret").NotUnique("name").Http(w)
+		return
+	}
+
+	opts.Data, err = crypto.EncryptMap(envs.Get().GetSecretKeyProvider(), new(types.Secret).CreateSelfLink(ns.Meta.Name, opts.Name), opts.Data)
+	if err != nil {
+		log.V(logLevel).Errorf("%s:create:> encrypt secret data err: %s", logPrefix, err.Error())
+		errors.HTTP.InternalServerError(w)
+		return
+	}
+
+	rs, err := sm.Create(ns.Meta.Name, opts)
 	if err != nil {
 		log.V(logLevel).Errorf("%s:create:> create secret err: %s", logPrefix, err.Error())
 		errors.HTTP.InternalServerError(w)
@@ -214,7 +266,7 @@ func SecretUpdateH(w http.ResponseWriter, r *http.Request) {
 
 	// swagger:operation PUT /namespace/{namespace}/secret/{secret} secret secretUpdate
 	//
-	// Create secret
+	// Update secret
 	//
 	// ---
 	// produces:
@@ -242,15 +294,18 @@ func SecretUpdateH(w http.ResponseWriter, r *http.Request) {
 	//       "$ref": "#/definitions/views_secret"
 	//   '404':
 	//     description: Namespace not found / Secret not found
+	//   '409':
+	//     description: Secret was changed since revision
 	//   '500':
 	//     description: Internal server error
 
+	nid := utils.Vars(r)["namespace"]
 	sid := utils.Vars(r)["secret"]
 
-	log.V(logLevel).Debugf("%s:update:> update secret `%s`", logPrefix, sid)
+	log.V(logLevel).Debugf("%s:update:> update secret `%s` in namespace `%s`", logPrefix, sid, nid)
 
 	var (
-		rm = distribution.NewSecretModel(r.Context(), envs.Get().GetStorage())
+		sm = distribution.NewSecretModel(r.Context(), envs.Get().GetStorage())
 	)
 
 	// request body struct
@@ -261,7 +316,13 @@ func SecretUpdateH(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	ss, err := rm.Get(sid)
+	ns, e := getNamespace(r, nid)
+	if e != nil {
+		e.Http(w)
+		return
+	}
+
+	ss, err := sm.Get(ns.Meta.Name, sid)
 	if err != nil {
 		log.V(logLevel).Errorf("%s:update:> check secret exists by selflink err: %s", logPrefix, err.Error())
 		errors.HTTP.InternalServerError(w)
@@ -273,10 +334,22 @@ func SecretUpdateH(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	ss, err = rm.Update(ss, opts)
+	opts.Data, err = crypto.EncryptMap(envs.Get().GetSecretKeyProvider(), ss.SelfLink(), opts.Data)
 	if err != nil {
-		log.V(logLevel).Errorf("%s:update:> update secret `%s` err: %s", logPrefix, ss.Meta.SelfLink, err.Error())
+		log.V(logLevel).Errorf("%s:update:> encrypt secret data err: %s", logPrefix, err.Error())
 		errors.HTTP.InternalServerError(w)
+		return
+	}
+
+	ss, err = sm.Update(ss, opts)
+	if err != nil {
+		log.V(logLevel).Errorf("%s:update:> update secret `%s` err: %s", logPrefix, sid, err.Error())
+		if errors.Storage().IsErrEntityRevision(err) {
+			errors.New("secret").Conflict().Http(w)
+			return
+		}
+		errors.HTTP.InternalServerError(w)
+		return
 	}
 
 	response, err := v1.View().Secret().New(ss).ToJson()
@@ -321,15 +394,22 @@ func SecretRemoveH(w http.ResponseWriter, r *http.Request) {
 	//   '500':
 	//     description: Internal server error
 
+	nid := utils.Vars(r)["namespace"]
 	sid := utils.Vars(r)["secret"]
 
-	log.V(logLevel).Debugf("%s:remove:> remove secret %s", logPrefix, sid)
+	log.V(logLevel).Debugf("%s:remove:> remove secret `%s` in namespace `%s`", logPrefix, sid, nid)
 
 	var (
 		sm = distribution.NewSecretModel(r.Context(), envs.Get().GetStorage())
 	)
 
-	ss, err := sm.Get(sid)
+	ns, e := getNamespace(r, nid)
+	if e != nil {
+		e.Http(w)
+		return
+	}
+
+	ss, err := sm.Get(ns.Meta.Name, sid)
 	if err != nil {
 		log.V(logLevel).Errorf("%s:remove:> get secret by id `%s` err: %s", logPrefix, sid, err.Error())
 		errors.HTTP.InternalServerError(w)
@@ -354,3 +434,21 @@ func SecretRemoveH(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 }
+
+func getNamespace(r *http.Request, name string) (*types.Namespace, *errors.Err) {
+
+	nsm := distribution.NewNamespaceModel(r.Context(), envs.Get().GetStorage())
+
+	ns, err := nsm.Get(name)
+	if err != nil {
+		log.V(logLevel).Errorf("%s:> get namespace `%s` err: %s", logPrefix, name, err.Error())
+		return nil, errors.New("namespace").Unknown(err)
+	}
+
+	if ns == nil {
+		log.V(logLevel).Warnf("%s:> namespace `%s` not found", logPrefix, name)
+		return nil, errors.New("namespace").NotFound()
+	}
+
+	return ns, nil
+}
@@ -20,6 +20,7 @@ package secret_test
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -37,6 +38,7 @@ import (
 	"github.com/lastbackend/lastbackend/pkg/distribution/errors"
 	"github.com/lastbackend/lastbackend/pkg/distribution/types"
 	"github.com/lastbackend/lastbackend/pkg/storage"
+	"github.com/lastbackend/lastbackend/pkg/util/crypto"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -47,9 +49,13 @@ func TestSecretList(t *testing.T) {
 
 	stg, _ := storage.Get("mock")
 	envs.Get().SetStorage(stg)
+	envs.Get().SetSecretKeyProvider(getKeyProvider())
 
-	r1 := getSecretAsset("demo")
-	r2 := getSecretAsset("test")
+	ns1 := getNamespaceAsset("demo", "")
+	ns2 := getNamespaceAsset("test", "")
+
+	r1 := getSecretAsset(ns1.Meta.Name, "demo")
+	r2 := getSecretAsset(ns1.Meta.Name, "test")
 
 	r1.Data["demo"] = []byte("demo")
 	r2.Data["test"] = []byte("test")
@@ -63,7 +69,8 @@ func TestSecretList(t *testing.T) {
 	}
 
 	type args struct {
-		ctx context.Context
+		ctx       context.Context
+		namespace *types.Namespace
 	}
 
 	tests := []struct {
@@ -77,9 +84,18 @@ func TestSecretList(t *testing.T) {
 		wantErr      bool
 		expectedCode int
 	}{
+		{
+			name:         "checking get secrets list if namespace not found",
+			args:         args{ctx, ns2},
+			fields:       fields{stg},
+			handler:      secret.SecretListH,
+			err:          "{\"code\":404,\"status\":\"Not Found\",\"message\":\"Namespace not found\"}",
+			wantErr:      true,
+			expectedCode: http.StatusNotFound,
+		},
 		{
 			name:         "checking get secrets list successfully",
-			args:         args{ctx},
+			args:         args{ctx, ns1},
 			fields:       fields{stg},
 			handler:      secret.SecretListH,
 			want:         rl,
@@ -103,15 +119,18 @@ func TestSecretList(t *testing.T) {
 			clear()
 			defer clear()
 
-			err := stg.Put(context.Background(), stg.Collection().Secret(), stg.Key().Secret(r1.Meta.Name), &r1, nil)
+			err := stg.Put(context.Background(), stg.Collection().Namespace(), stg.Key().Namespace(ns1.Meta.Name), ns1, nil)
+			assert.NoError(t, err)
+
+			err = stg.Put(context.Background(), stg.Collection().Secret(), stg.Key().Secret(r1.Meta.Namespace, r1.Meta.Name), &r1, nil)
 			assert.NoError(t, err)
 
-			err = stg.Put(context.Background(), stg.Collection().Secret(), stg.Key().Secret(r2.Meta.Name), &r2, nil)
+			err = stg.Put(context.Background(), stg.Collection().Secret(), stg.Key().Secret(r2.Meta.Namespace, r2.Meta.Name), &r2, nil)
 			assert.NoError(t, err)
 
 			// Create assert request to pass to our handler. We don't have any query parameters for now, so we'll
 			// pass 'nil' as the third parameter.
-			req, err := http.NewRequest("GET", fmt.Sprintf("/secret"), nil)
+			req, err := http.NewRequest("GET", fmt.Sprintf("/namespace/%s/secret", tc.args.namespace.Meta.Name), nil)
 			assert.NoError(t, err)
 
 			if tc.headers != nil {
@@ -121,7 +140,7 @@ func TestSecretList(t *testing.T) {
 			}
 
 			r := mux.NewRouter()
-			r.HandleFunc("/secret", tc.handler)
+			r.HandleFunc("/namespace/{namespace}/secret", tc.handler)
 
 			setRequestVars(r, req)
 
@@ -146,6 +165,7 @@ func TestSecretList(t *testing.T) {
 				err := json.Unmarshal(body, &r)
 				assert.NoError(t, err)
 
+				assert.Equal(t, len(tc.want.Items), len(*r), "secrets count mismatch")
 				for _, item := range *r {
 					if _, ok := tc.want.Items[item.Meta.SelfLink]; !ok {
 						assert.Error(t, errors.New("not equals"))
@@ -181,17 +201,26 @@ func TestSecretCreate(t *testing.T) {
 
 	stg, _ := storage.Get("mock")
 	envs.Get().SetStorage(stg)
+	envs.Get().SetSecretKeyProvider(getKeyProvider())
 
-	r1 := getSecretAsset("demo")
+	ns1 := getNamespaceAsset("demo", "")
+	ns2 := getNamespaceAsset("test", "")
+
+	r1 := getSecretAsset(ns1.Meta.Name, "demo")
 	r1.Meta.Kind = types.KindSecretText
 	r1.Data["demo"] = []byte("demo")
 
+	r2 := getSecretAsset(ns1.Meta.Name, "test")
+	r2.Meta.Kind = types.KindSecretText
+	r2.Data["test"] = []byte("test")
+
 	type fields struct {
 		stg storage.Storage
 	}
 
 	type args struct {
-		ctx context.Context
+		ctx       context.Context
+		namespace *types.Namespace
 	}
 
 	tests := []struct {
@@ -202,13 +231,13 @@ func TestSecretCreate(t *testing.T) {
 		handler      func(http.ResponseWriter, *http.Request)
 		data         string
 		err          string
-		want         *views.Secret
+		want         *types.Secret
 		wantErr      bool
 		expectedCode int
 	}{
 		{
 			name:         "check create secret if failed incoming json data",
-			args:         args{ctx},
+			args:         args{ctx, ns1},
 			fields:       fields{stg},
 			handler:      secret.SecretCreateH,
 			data:         "{name:demo}",
@@ -216,21 +245,43 @@ func TestSecretCreate(t *testing.T) {
 			wantErr:      true,
 			expectedCode: http.StatusBadRequest,
 		},
-		// TODO: need checking incoming data for validity
 		{
-			name:         "check create secret success",
-			args:         args{ctx},
+			name:         "check create secret if namespace not found",
+			args:         args{ctx, ns2},
+			fields:       fields{stg},
+			handler:      secret.SecretCreateH,
+			data:         createSecretCreateOptions(r2).toJson(),
+			err:          "{\"code\":404,\"status\":\"Not Found\",\"message\":\"Namespace not found\"}",
+			wantErr:      true,
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "check create secret if name already exists",
+			args:         args{ctx, ns1},
 			fields:       fields{stg},
 			handler:      secret.SecretCreateH,
 			data:         createSecretCreateOptions(r1).toJson(),
-			want:         v1.View().Secret().New(r1),
+			err:          "{\"code\":400,\"status\":\"Not Unique\",\"message\":\"Name is already in use\"}",
+			wantErr:      true,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "check create secret success",
+			args:         args{ctx, ns1},
+			fields:       fields{stg},
+			handler:      secret.SecretCreateH,
+			data:         createSecretCreateOptions(r2).toJson(),
+			want:         r2,
 			wantErr:      false,
 			expectedCode: http.StatusOK,
 		},
 	}
 
 	clear := func() {
-		err := envs.Get().GetStorage().Del(context.Background(), stg.Collection().Secret(), types.EmptyString)
+		err := envs.Get().GetStorage().Del(context.Background(), stg.Collection().Namespace(), types.EmptyString)
+		assert.NoError(t, err)
+
+		err = envs.Get().GetStorage().Del(context.Background(), stg.Collection().Secret(), types.EmptyString)
 		assert.NoError(t, err)
 	}
 
@@ -240,9 +291,15 @@ func TestSecretCreate(t *testing.T) {
 			clear()
 			defer clear()
 
+			err := stg.Put(context.Background(), stg.Collection().Namespace(), stg.Key().Namespace(ns1.Meta.Name), ns1, nil)
+			assert.NoError(t, err)
+
+			err = stg.Put(context.Background(), stg.Collection().Secret(), stg.Key().Secret(r1.Meta.Namespace, r1.Meta.Name), &r1, nil)
+			assert.NoError(t, err)
+
 			// Create assert request to pass to our handler. We don't have any query parameters for now, so we'll
 			// pass 'nil' as the third parameter.
-			req, err := http.NewRequest("POST", fmt.Sprintf("/secret"), strings.NewReader(tc.data))
+			req, err := http.NewRequest("POST", fmt.Sprintf("/namespace/%s/secret", tc.args.namespace.Meta.Name), strings.NewReader(tc.data))
 			assert.NoError(t, err)
 
 			if tc.headers != nil {
@@ -252,7 +309,7 @@ func TestSecretCreate(t *testing.T) {
 			}
 
 			r := mux.NewRouter()
-			r.HandleFunc("/secret", tc.handler)
+			r.HandleFunc("/namespace/{namespace}/secret", tc.handler)
 
 			setRequestVars(r, req)
 
@@ -275,17 +332,26 @@ func TestSecretCreate(t *testing.T) {
 				assert.Equal(t, tc.err, string(body), "incorrect status code")
 			} else {
 
+				s := new(views.Secret)
+				err := json.Unmarshal(body, &s)
+				assert.NoError(t, err)
+				assert.Equal(t, []string{"test"}, s.Keys, "secret keys mismatch")
+
 				got := new(types.Secret)
-				err := tc.fields.stg.Get(tc.args.ctx, stg.Collection().Secret(), tc.fields.stg.Key().Secret(tc.want.Meta.Name), got, nil)
+				err = tc.fields.stg.Get(tc.args.ctx, stg.Collection().Secret(), tc.fields.stg.Key().Secret(tc.args.namespace.Meta.Name, tc.want.Meta.Name), got, nil)
 				assert.NoError(t, err)
 
-				if !assert.Equal(t, r1.Meta.Kind, got.Meta.Kind, "secret kind different") {
+				if !assert.Equal(t, tc.want.Meta.Kind, got.Meta.Kind, "secret kind different") {
 					return
 				}
 
-				if !assert.Equal(t, r1.Data, got.Data, "secret kind different") {
+				if !assert.NotEqual(t, tc.want.Data, got.Data, "secret data is not encrypted") {
 					return
 				}
+
+				data, err := crypto.DecryptMap(envs.Get().GetSecretKeyProvider(), got.SelfLink(), got.Data)
+				assert.NoError(t, err)
+				assert.Equal(t, tc.want.Data, data, "secret data different")
 			}
 		})
 	}
@@ -315,9 +381,13 @@ func TestSecretUpdate(t *testing.T) {
 
 	stg, _ := storage.Get("mock")
 	envs.Get().SetStorage(stg)
+	envs.Get().SetSecretKeyProvider(getKeyProvider())
 
-	r1 := getSecretAsset("demo")
-	r2 := getSecretAsset("test")
+	ns1 := getNamespaceAsset("demo", "")
+	ns2 := getNamespaceAsset("test", "")
+
+	r1 := getSecretAsset(ns1.Meta.Name, "demo")
+	r2 := getSecretAsset(ns1.Meta.Name, "test")
 
 	r1.Data["demo"] = []byte("demo")
 	r2.Data["test"] = []byte("test")
@@ -327,8 +397,9 @@ func TestSecretUpdate(t *testing.T) {
 	}
 
 	type args struct {
-		ctx    context.Context
-		secret *types.Secret
+		ctx       context.Context
+		namespace *types.Namespace
+		secret    *types.Secret
 	}
 
 	tests := []struct {
@@ -344,8 +415,28 @@ func TestSecretUpdate(t *testing.T) {
 		expectedCode int
 	}{
 		{
-			name:         "checking update secret if name not exists",
-			args:         args{ctx, r1},
+			name:         "checking update secret if namespace not found",
+			args:         args{ctx, ns2, r1},
+			fields:       fields{stg},
+			handler:      secret.SecretUpdateH,
+			data:         createSecretUpdateOptions(r2.Meta.Kind, r2.Data).toJson(),
+			err:          "{\"code\":404,\"status\":\"Not Found\",\"message\":\"Namespace not found\"}",
+			wantErr:      true,
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "checking update secret if not exists",
+			args:         args{ctx, ns1, r2},
+			fields:       fields{stg},
+			handler:      secret.SecretUpdateH,
+			data:         createSecretUpdateOptions(r2.Meta.Kind, r2.Data).toJson(),
+			err:          "{\"code\":404,\"status\":\"Not Found\",\"message\":\"Secret not found\"}",
+			wantErr:      true,
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "checking update secret successfully",
+			args:         args{ctx, ns1, r1},
 			fields:       fields{stg},
 			handler:      secret.SecretUpdateH,
 			data:         createSecretUpdateOptions(r2.Meta.Kind, r2.Data).toJson(),
@@ -356,7 +447,10 @@ func TestSecretUpdate(t *testing.T) {
 	}
 
 	clear := func() {
-		err := envs.Get().GetStorage().Del(context.Background(), stg.Collection().Secret(), types.EmptyString)
+		err := envs.Get().GetStorage().Del(context.Background(), stg.Collection().Namespace(), types.EmptyString)
+		assert.NoError(t, err)
+
+		err = envs.Get().GetStorage().Del(context.Background(), stg.Collection().Secret(), types.EmptyString)
 		assert.NoError(t, err)
 	}
 
@@ -366,12 +460,15 @@ func TestSecretUpdate(t *testing.T) {
 			clear()
 			defer clear()
 
-			err := stg.Put(context.Background(), stg.Collection().Secret(), stg.Key().Secret(r1.Meta.Name), &r1, nil)
+			err := stg.Put(context.Background(), stg.Collection().Namespace(), stg.Key().Namespace(ns1.Meta.Name), ns1, nil)
+			assert.NoError(t, err)
+
+			err = stg.Put(context.Background(), stg.Collection().Secret(), stg.Key().Secret(r1.Meta.Namespace, r1.Meta.Name), &r1, nil)
 			assert.NoError(t, err)
 
 			// Create assert request to pass to our handler. We don't have any query parameters for now, so we'll
 			// pass 'nil' as the third parameter.
-			req, err := http.NewRequest("PUT", fmt.Sprintf("/secret/%s", tc.args.secret.Meta.Name), strings.NewReader(tc.data))
+			req, err := http.NewRequest("PUT", fmt.Sprintf("/namespace/%s/secret/%s", tc.args.namespace.Meta.Name, tc.args.secret.Meta.Name), strings.NewReader(tc.data))
 			assert.NoError(t, err)
 
 			if tc.headers != nil {
@@ -381,7 +478,7 @@ func TestSecretUpdate(t *testing.T) {
 			}
 
 			r := mux.NewRouter()
-			r.HandleFunc("/secret/{secret}", tc.handler)
+			r.HandleFunc("/namespace/{namespace}/secret/{secret}", tc.handler)
 
 			setRequestVars(r, req)
 
@@ -408,7 +505,15 @@ func TestSecretUpdate(t *testing.T) {
 				err := json.Unmarshal(body, &s)
 				assert.NoError(t, err)
 
-				assert.Equal(t, tc.want.Data, s.Data, "secret data mismatch")
+				assert.Equal(t, tc.want.Keys, s.Keys, "secret keys mismatch")
+
+				got := new(types.Secret)
+				err = tc.fields.stg.Get(tc.args.ctx, stg.Collection().Secret(), tc.fields.stg.Key().Secret(tc.args.namespace.Meta.Name, tc.args.secret.Meta.Name), got, nil)
+				assert.NoError(t, err)
+
+				data, err := crypto.DecryptMap(envs.Get().GetSecretKeyProvider(), got.SelfLink(), got.Data)
+				assert.NoError(t, err)
+				assert.Equal(t, r2.Data, data, "secret data mismatch")
 			}
 		})
 	}
@@ -422,9 +527,13 @@ func TestSecretRemove(t *testing.T) {
 
 	stg, _ := storage.Get("mock")
 	envs.Get().SetStorage(stg)
+	envs.Get().SetSecretKeyProvider(getKeyProvider())
 
-	r1 := getSecretAsset("demo")
-	r2 := getSecretAsset("test")
+	ns1 := getNamespaceAsset("demo", "")
+	ns2 := getNamespaceAsset("test", "")
+
+	r1 := getSecretAsset(ns1.Meta.Name, "demo")
+	r2 := getSecretAsset(ns1.Meta.Name, "test")
 
 	r1.Data["demo"] = []byte("demo")
 	r2.Data["test"] = []byte("test")
@@ -434,8 +543,9 @@ func TestSecretRemove(t *testing.T) {
 	}
 
 	type args struct {
-		ctx    context.Context
-		secret *types.Secret
+		ctx       context.Context
+		namespace *types.Namespace
+		secret    *types.Secret
 	}
 
 	tests := []struct {
@@ -450,8 +560,17 @@ func TestSecretRemove(t *testing.T) {
 		expectedCode int
 	}{
 		{
-			name:         "checking get secret if not exists",
-			args:         args{ctx, r2},
+			name:         "checking remove secret if namespace not found",
+			args:         args{ctx, ns2, r1},
+			fields:       fields{stg},
+			handler:      secret.SecretRemoveH,
+			err:          "{\"code\":404,\"status\":\"Not Found\",\"message\":\"Namespace not found\"}",
+			wantErr:      true,
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "checking remove secret if not exists",
+			args:         args{ctx, ns1, r2},
 			fields:       fields{stg},
 			handler:      secret.SecretRemoveH,
 			err:          "{\"code\":404,\"status\":\"Not Found\",\"message\":\"Secret not found\"}",
@@ -459,8 +578,8 @@ func TestSecretRemove(t *testing.T) {
 			expectedCode: http.StatusNotFound,
 		},
 		{
-			name:         "checking get secret successfully",
-			args:         args{ctx, r1},
+			name:         "checking remove secret successfully",
+			args:         args{ctx, ns1, r1},
 			fields:       fields{stg},
 			handler:      secret.SecretRemoveH,
 			want:         "",
@@ -470,7 +589,10 @@ func TestSecretRemove(t *testing.T) {
 	}
 
 	clear := func() {
-		err := envs.Get().GetStorage().Del(context.Background(), stg.Collection().Secret(), types.EmptyString)
+		err := envs.Get().GetStorage().Del(context.Background(), stg.Collection().Namespace(), types.EmptyString)
+		assert.NoError(t, err)
+
+		err = envs.Get().GetStorage().Del(context.Background(), stg.Collection().Secret(), types.EmptyString)
 		assert.NoError(t, err)
 	}
 
@@ -481,12 +603,15 @@ func TestSecretRemove(t *testing.T) {
 			clear()
 			defer clear()
 
-			err := stg.Put(context.Background(), stg.Collection().Secret(), stg.Key().Secret(r1.Meta.Name), &r1, nil)
+			err := stg.Put(context.Background(), stg.Collection().Namespace(), stg.Key().Namespace(ns1.Meta.Name), ns1, nil)
+			assert.NoError(t, err)
+
+			err = stg.Put(context.Background(), stg.Collection().Secret(), stg.Key().Secret(r1.Meta.Namespace, r1.Meta.Name), &r1, nil)
 			assert.NoError(t, err)
 
 			// Create assert request to pass to our handler. We don't have any query parameters for now, so we'll
 			// pass 'nil' as the third parameter.
-			req, err := http.NewRequest("DELETE", fmt.Sprintf("/secret/%s", tc.args.secret.Meta.Name), nil)
+			req, err := http.NewRequest("DELETE", fmt.Sprintf("/namespace/%s/secret/%s", tc.args.namespace.Meta.Name, tc.args.secret.Meta.Name), nil)
 			assert.NoError(t, err)
 
 			if tc.headers != nil {
@@ -497,7 +622,7 @@ func TestSecretRemove(t *testing.T) {
 			}
 
 			r := mux.NewRouter()
-			r.HandleFunc("/secret/{secret}", tc.handler)
+			r.HandleFunc("/namespace/{namespace}/secret/{secret}", tc.handler)
 
 			setRequestVars(r, req)
 
@@ -521,10 +646,8 @@ func TestSecretRemove(t *testing.T) {
 			} else {
 
 				got := new(types.Secret)
-				err := tc.fields.stg.Get(tc.args.ctx, stg.Collection().Secret(), tc.fields.stg.Key().Secret(tc.args.secret.Meta.Name), got, nil)
-				if err != nil && !errors.Storage().IsErrEntityNotFound(err) {
-					assert.NoError(t, err)
-				}
+				err := tc.fields.stg.Get(tc.args.ctx, stg.Collection().Secret(), tc.fields.stg.Key().Secret(tc.args.namespace.Meta.Name, tc.args.secret.Meta.Name), got, nil)
+				assert.True(t, errors.Storage().IsErrEntityNotFound(err), "secret not removed")
 
 				assert.Equal(t, tc.want, string(body), "response not empty")
 			}
@@ -533,14 +656,30 @@ func TestSecretRemove(t *testing.T) {
 
 }
 
-func getSecretAsset(name string) *types.Secret {
+func getNamespaceAsset(name, desc string) *types.Namespace {
+	var n = types.Namespace{}
+	n.Meta.SetDefault()
+	n.Meta.Name = name
+	n.Meta.Description = desc
+	n.Meta.Endpoint = fmt.Sprintf("%s", name)
+	return &n
+}
+
+func getSecretAsset(namespace, name string) *types.Secret {
 	var r = types.Secret{}
 	r.Meta.SetDefault()
+	r.Meta.Namespace = namespace
 	r.Meta.Name = name
 	r.Data = make(map[string][]byte, 0)
+	r.SelfLink()
 	return &r
 }
 
+func getKeyProvider() crypto.KeyProvider {
+	p, _ := crypto.NewAESGCMFromString(base64.StdEncoding.EncodeToString([]byte("0123456789abcdef0123456789abcdef")))
+	return p
+}
+
 func setRequestVars(r *mux.Router, req *http.Request) {
 	var match mux.RouteMatch
 	// Take the request and match it
@@ -20,10 +20,14 @@ package service
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
 	"strings"
+	"time"
 
+	"github.com/gorilla/websocket"
 	"github.com/lastbackend/lastbackend/pkg/api/envs"
 	"github.com/lastbackend/lastbackend/pkg/api/types/v1"
 	"github.com/lastbackend/lastbackend/pkg/distribution"
@@ -39,6 +43,13 @@ const (
 	BUFFER_SIZE = 512
 )
 
+// upgrader keeps the default origin check: cli and api proxy clients send no
+// Origin header and are accepted, browsers are accepted from the same host only
+var upgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 1024,
+}
+
 func ServiceListH(w http.ResponseWriter, r *http.Request) {
 
 	// swagger:operation GET /namespace/{namespace}/service service serviceList
@@ -149,6 +160,8 @@ func ServiceInfoH(w http.ResponseWriter, r *http.Request) {
 	//     description: Service list response
 	//     schema:
 	//       "$ref": "#/definitions/views_service"
+	//   '400':
+	//     description: Namespace quota exceeded
 	//   '404':
 	//     description: Namespace not found / Service not found
 	//   '500':
@@ -245,7 +258,7 @@ func ServiceCreateH(w http.ResponseWriter, r *http.Request) {
 	//     schema:
 	//       "$ref": "#/definitions/views_service"
 	//   '400':
-	//     description: Name is already in use
+	//     description: Name is already in use / Namespace quota exceeded
 	//   '404':
 	//     description: Namespace not found
 	//   '500':
@@ -304,6 +317,12 @@ func ServiceCreateH(w http.ResponseWriter, r *http.Request) {
 
 	opts.SetServiceSpec(svc)
 
+	if e := serviceQuotaCheck(sm, ns, svc); e != nil {
+		log.V(logLevel).Errorf("%s:create:> check namespace quotas err: %s", logPrefix, e.Err())
+		e.Http(w)
+		return
+	}
+
 	srv, err := sm.Create(ns, svc)
 	if err != nil {
 		log.V(logLevel).Errorf("%s:create:> create service err: %s", logPrefix, err.Error())
@@ -355,8 +374,12 @@ func ServiceUpdateH(w http.ResponseWriter, r *http.Request) {
 	//     description: Service was successfully updated
 	//     schema:
 	//       "$ref": "#/definitions/views_service"
+	//   '400':
+	//     description: Namespace quota exceeded
 	//   '404':
 	//     description: Namespace not found / Service not found
+	//   '409':
+	//     description: Service was changed since revision
 	//   '500':
 	//     description: Internal server error
 
@@ -403,13 +426,27 @@ func ServiceUpdateH(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	req := svc.Spec.GetResourceRequest()
+
 	opts.SetServiceMeta(svc)
 	svc.Meta.Endpoint = fmt.Sprintf("%s.%s", strings.ToLower(svc.Meta.Name), ns.Meta.Endpoint)
 	opts.SetServiceSpec(svc)
 
+	if svc.Spec.GetResourceRequest().RAM > req.RAM {
+		if e := serviceQuotaCheck(sm, ns, svc); e != nil {
+			log.V(logLevel).Errorf("%s:update:> check namespace quotas err: %s", logPrefix, e.Err())
+			e.Http(w)
+			return
+		}
+	}
+
 	srv, err := sm.Update(svc)
 	if err != nil {
 		log.V(logLevel).Errorf("%s:update:> update service err: %s", logPrefix, err.Error())
+		if errors.Storage().IsErrEntityRevision(err) {
+			errors.New("service").Conflict().Http(w)
+			return
+		}
 		errors.HTTP.InternalServerError(w)
 		return
 	}
@@ -428,6 +465,149 @@ func ServiceUpdateH(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+func ServiceRollbackH(w http.ResponseWriter, r *http.Request) {
+
+	// swagger:operation POST /namespace/{namespace}/service/{service}/rollback service serviceRollback
+	//
+	// Rollback service to previous deployment version
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	//   - name: namespace
+	//     in: path
+	//     description: namespace id
+	//     required: true
+	//     type: string
+	//   - name: service
+	//     in: path
+	//     description: service id
+	//     required: true
+	//     type: string
+	//   - name: body
+	//     in: body
+	//     required: false
+	//     schema:
+	//       "$ref": "#/definitions/request_service_rollback"
+	// responses:
+	//   '200':
+	//     description: Service was successfully rolled back
+	//     schema:
+	//       "$ref": "#/definitions/views_service"
+	//   '400':
+	//     description: Service is already at this version
+	//   '404':
+	//     description: Namespace not found / Service not found / Deployment not found
+	//   '500':
+	//     description: Internal server error
+
+	nid := utils.Vars(r)["namespace"]
+	sid := utils.Vars(r)["service"]
+
+	log.V(logLevel).Debugf("%s:rollback:> rollback service `%s` in namespace `%s`", logPrefix, sid, nid)
+
+	var (
+		nm = distribution.NewNamespaceModel(r.Context(), envs.Get().GetStorage())
+		sm = distribution.NewServiceModel(r.Context(), envs.Get().GetStorage())
+		dm = distribution.NewDeploymentModel(r.Context(), envs.Get().GetStorage())
+	)
+
+	// request body struct
+	opts := v1.Request().Service().RollbackOptions()
+	if e := opts.DecodeAndValidate(r.Body); e != nil {
+		log.V(logLevel).Errorf("%s:rollback:> validation incoming data err: %s", logPrefix, e.Err())
+		e.Http(w)
+		return
+	}
+
+	ns, err := nm.Get(nid)
+	if err != nil {
+		log.V(logLevel).Errorf("%s:rollback:> get namespace err: %s", logPrefix, err.Error())
+		errors.HTTP.InternalServerError(w)
+		return
+	}
+	if ns == nil {
+		log.V(logLevel).Warnf("%s:rollback:> namespace `%s` not found", logPrefix, nid)
+		errors.New("namespace").NotFound().Http(w)
+		return
+	}
+
+	svc, err := sm.Get(ns.Meta.Name, sid)
+	if err != nil {
+		log.V(logLevel).Errorf("%s:rollback:> get service by name `%s` err: %s", logPrefix, sid, err.Error())
+		errors.HTTP.InternalServerError(w)
+		return
+	}
+	if svc == nil {
+		log.V(logLevel).Warnf("%s:rollback:> service name `%s` in namespace `%s` not found", logPrefix, sid, ns.Meta.Name)
+		errors.New("service").NotFound().Http(w)
+		return
+	}
+
+	dl, err := dm.ListByService(svc.Meta.Namespace, svc.Meta.Name)
+	if err != nil {
+		log.V(logLevel).Errorf("%s:rollback:> get deployments list err: %s", logPrefix, err.Error())
+		errors.HTTP.InternalServerError(w)
+		return
+	}
+
+	d := serviceRollbackDeployment(svc, dl.Items, opts.Version)
+	if d == nil {
+		log.V(logLevel).Warnf("%s:rollback:> deployment for rollback service `%s` not found", logPrefix, svc.SelfLink())
+		errors.New("deployment").NotFound().Http(w)
+		return
+	}
+
+	if d.Spec.Template.Updated.Equal(svc.Spec.Template.Updated) {
+		log.V(logLevel).Warnf("%s:rollback:> service `%s` is already at deployment `%s` version %d",
+			logPrefix, svc.SelfLink(), d.SelfLink(), d.Meta.Version)
+		errors.New("service").BadRequest("already at this version").Http(w)
+		return
+	}
+
+	log.V(logLevel).Debugf("%s:rollback:> rollback service `%s` to deployment `%s` version %d",
+		logPrefix, svc.SelfLink(), d.SelfLink(), d.Meta.Version)
+
+	req := svc.Spec.GetResourceRequest()
+
+	svc.Spec.Template = d.Spec.Template
+	svc.Spec.Template.Updated = time.Now()
+	svc.Status.State = types.StateProvision
+
+	if svc.Spec.GetResourceRequest().RAM > req.RAM {
+		if e := serviceQuotaCheck(sm, ns, svc); e != nil {
+			log.V(logLevel).Errorf("%s:rollback:> check namespace quotas err: %s", logPrefix, e.Err())
+			e.Http(w)
+			return
+		}
+	}
+
+	srv, err := sm.Update(svc)
+	if err != nil {
+		log.V(logLevel).Errorf("%s:rollback:> update service err: %s", logPrefix, err.Error())
+		if errors.Storage().IsErrEntityRevision(err) {
+			errors.New("service").Conflict().Http(w)
+			return
+		}
+		errors.HTTP.InternalServerError(w)
+		return
+	}
+
+	response, err := v1.View().Service().NewWithDeployment(srv, nil, nil).ToJson()
+	if err != nil {
+		log.V(logLevel).Errorf("%s:rollback:> convert struct to json err: %s", logPrefix, err.Error())
+		errors.HTTP.InternalServerError(w)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if _, err = w.Write(response); err != nil {
+		log.V(logLevel).Errorf("%s:rollback:> write response err: %s", logPrefix, err.Error())
+		return
+	}
+}
+
 func ServiceRemoveH(w http.ResponseWriter, r *http.Request) {
 
 	// swagger:operation DELETE /namespace/{namespace}/service/{service} service serviceRemove
@@ -624,7 +804,7 @@ func ServiceLogsH(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("http://%s:%d/pod/%s/%s/logs", node.Meta.InternalIP, 2969, pod.Meta.Name, cid), nil)
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("http://%s:%d/pod/%s/%s/logs", node.Meta.InternalIP, 2969, pod.SelfLink(), cid), nil)
 	if err != nil {
 		log.V(logLevel).Errorf("%s:logs:> create http client err: %s", logPrefix, err.Error())
 		errors.HTTP.InternalServerError(w)
@@ -695,3 +875,446 @@ func ServiceLogsH(w http.ResponseWriter, r *http.Request) {
 	}
 
 }
+
+func ServiceStatsH(w http.ResponseWriter, r *http.Request) {
+
+	// swagger:operation GET /namespace/{namespace}/service/{service}/deployment/{deployment}/pod/{pod}/stats service servicePodStats
+	//
+	// Shows service pod usage samples collected by node in stats window
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	//   - name: namespace
+	//     in: path
+	//     description: namespace id
+	//     required: true
+	//     type: string
+	//   - name: service
+	//     in: path
+	//     description: service id
+	//     required: true
+	//     type: string
+	//   - name: deployment
+	//     in: path
+	//     description: deployment id
+	//     required: true
+	//     type: string
+	//   - name: pod
+	//     in: path
+	//     description: pod id
+	//     required: true
+	//     type: string
+	// responses:
+	//   '200':
+	//     description: Pod usage samples
+	//     schema:
+	//       "$ref": "#/definitions/views_pod_usage_list"
+	//   '404':
+	//     description: Namespace not found / Service not found / Deployment not found / Pod not found
+	//   '500':
+	//     description: Internal server error
+
+	nid := utils.Vars(r)["namespace"]
+	sid := utils.Vars(r)["service"]
+	did := utils.Vars(r)["deployment"]
+	pid := utils.Vars(r)["pod"]
+
+	log.V(logLevel).Debugf("%s:stats:> get service `%s` pod `%s` stats in namespace `%s`", logPrefix, sid, pid, nid)
+
+	var (
+		nsm = distribution.NewNamespaceModel(r.Context(), envs.Get().GetStorage())
+		sm  = distribution.NewServiceModel(r.Context(), envs.Get().GetStorage())
+		pm  = distribution.NewPodModel(r.Context(), envs.Get().GetStorage())
+		dm  = distribution.NewDeploymentModel(r.Context(), envs.Get().GetStorage())
+		nm  = distribution.NewNodeModel(r.Context(), envs.Get().GetStorage())
+	)
+
+	ns, err := nsm.Get(nid)
+	if err != nil {
+		log.V(logLevel).Errorf("%s:stats:> get namespace err: %s", logPrefix, err.Error())
+		errors.HTTP.InternalServerError(w)
+		return
+	}
+	if ns == nil {
+		log.V(logLevel).Warnf("%s:stats:> namespace `%s` not found", logPrefix, nid)
+		errors.New("namespace").NotFound().Http(w)
+		return
+	}
+
+	svc, err := sm.Get(ns.Meta.Name, sid)
+	if err != nil {
+		log.V(logLevel).Errorf("%s:stats:> get service by name `%s` err: %s", logPrefix, sid, err.Error())
+		errors.HTTP.InternalServerError(w)
+		return
+	}
+	if svc == nil {
+		log.V(logLevel).Warnf("%s:stats:> service name `%s` in namespace `%s` not found", logPrefix, sid, ns.Meta.Name)
+		errors.New("service").NotFound().Http(w)
+		return
+	}
+
+	deployment, err := dm.Get(ns.Meta.Name, svc.Meta.Name, did)
+	if err != nil {
+		log.V(logLevel).Errorf("%s:stats:> get deployment by name err: %s", logPrefix, err.Error())
+		errors.HTTP.InternalServerError(w)
+		return
+	}
+	if deployment == nil {
+		log.V(logLevel).Warnf("%s:stats:> deployment `%s` not found", logPrefix, did)
+		errors.New("deployment").NotFound().Http(w)
+		return
+	}
+
+	pod, err := pm.Get(ns.Meta.Name, svc.Meta.Name, deployment.Meta.Name, pid)
+	if err != nil {
+		log.V(logLevel).Errorf("%s:stats:> get pod by name err: %s", logPrefix, err.Error())
+		errors.HTTP.InternalServerError(w)
+		return
+	}
+	if pod == nil {
+		log.V(logLevel).Warnf("%s:stats:> pod `%s` not found", logPrefix, pid)
+		errors.New("pod").NotFound().Http(w)
+		return
+	}
+
+	node, err := nm.Get(pod.Meta.Node)
+	if err != nil {
+		log.V(logLevel).Errorf("%s:stats:> get node by name err: %s", logPrefix, err.Error())
+		errors.HTTP.InternalServerError(w)
+		return
+	}
+	if node == nil {
+		log.V(logLevel).Warnf("%s:stats:> node %s not found", logPrefix, pod.Meta.Node)
+		errors.New("node").NotFound().Http(w)
+		return
+	}
+
+	// node keeps pods state by pod self link
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("http://%s:%d/pod/%s/stats", node.Meta.InternalIP, 2969, pod.SelfLink()), nil)
+	if err != nil {
+		log.V(logLevel).Errorf("%s:stats:> create http request err: %s", logPrefix, err.Error())
+		errors.HTTP.InternalServerError(w)
+		return
+	}
+
+	req = req.WithContext(r.Context())
+	if types.SecretAccessToken != "" {
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", types.SecretAccessToken))
+	}
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		log.V(logLevel).Errorf("%s:stats:> get pod stats err: %s", logPrefix, err.Error())
+		errors.HTTP.InternalServerError(w)
+		return
+	}
+	defer res.Body.Close()
+
+	usage := make([]*types.PodUsage, 0)
+
+	switch res.StatusCode {
+	case http.StatusOK:
+		if err := json.NewDecoder(res.Body).Decode(&usage); err != nil {
+			log.V(logLevel).Errorf("%s:stats:> parse pod stats err: %s", logPrefix, err.Error())
+			errors.HTTP.InternalServerError(w)
+			return
+		}
+	case http.StatusNotFound:
+		// pod is not running on node yet or anymore, so there is no usage collected
+	default:
+		log.V(logLevel).Errorf("%s:stats:> get pod stats: node responded with status %d", logPrefix, res.StatusCode)
+		errors.HTTP.InternalServerError(w)
+		return
+	}
+
+	response, err := v1.View().Pod().NewUsageList(usage).ToJson()
+	if err != nil {
+		log.V(logLevel).Errorf("%s:stats:> convert struct to json err: %s", logPrefix, err.Error())
+		errors.HTTP.InternalServerError(w)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if _, err = w.Write(response); err != nil {
+		log.V(logLevel).Errorf("%s:stats:> write response err: %s", logPrefix, err.Error())
+		return
+	}
+}
+
+func ServiceExecH(w http.ResponseWriter, r *http.Request) {
+
+	// swagger:operation GET /namespace/{namespace}/service/{service}/deployment/{deployment}/pod/{pod}/{container}/exec service serviceExec
+	//
+	// Runs interactive command in service pod container over websocket connection
+	//
+	// ---
+	// produces:
+	// - application/octet-stream
+	// parameters:
+	//   - name: namespace
+	//     in: path
+	//     description: namespace id
+	//     required: true
+	//     type: string
+	//   - name: service
+	//     in: path
+	//     description: service id
+	//     required: true
+	//     type: string
+	//   - name: deployment
+	//     in: path
+	//     description: deployment id
+	//     required: true
+	//     type: string
+	//   - name: pod
+	//     in: path
+	//     description: pod id
+	//     required: true
+	//     type: string
+	//   - name: container
+	//     in: path
+	//     description: container id
+	//     required: true
+	//     type: string
+	//   - name: command
+	//     in: query
+	//     description: command with arguments, repeated for every argument
+	//     required: true
+	//     type: array
+	//     items:
+	//       type: string
+	//   - name: tty
+	//     in: query
+	//     description: allocate pseudo-TTY
+	//     required: false
+	//     type: boolean
+	//   - name: stdin
+	//     in: query
+	//     description: attach command stdin
+	//     required: false
+	//     type: boolean
+	// responses:
+	//   '101':
+	//     description: Switching protocols to websocket exec stream
+	//   '400':
+	//     description: Bad command parameter
+	//   '404':
+	//     description: Namespace not found / Service not found / Deployment not found / Pod not found
+	//   '500':
+	//     description: Internal server error
+
+	nid := utils.Vars(r)["namespace"]
+	sid := utils.Vars(r)["service"]
+	did := utils.Vars(r)["deployment"]
+	pid := utils.Vars(r)["pod"]
+	cid := utils.Vars(r)["container"]
+
+	log.V(logLevel).Debugf("%s:exec:> exec in service `%s` pod `%s` in namespace `%s`", logPrefix, sid, pid, nid)
+
+	var (
+		nsm = distribution.NewNamespaceModel(r.Context(), envs.Get().GetStorage())
+		sm  = distribution.NewServiceModel(r.Context(), envs.Get().GetStorage())
+		pm  = distribution.NewPodModel(r.Context(), envs.Get().GetStorage())
+		dm  = distribution.NewDeploymentModel(r.Context(), envs.Get().GetStorage())
+		nm  = distribution.NewNodeModel(r.Context(), envs.Get().GetStorage())
+	)
+
+	command := r.URL.Query()["command"]
+	if len(command) == 0 {
+		log.V(logLevel).Warnf("%s:exec:> command is empty", logPrefix)
+		errors.New("exec").BadParameter("command").Http(w)
+		return
+	}
+
+	ns, err := nsm.Get(nid)
+	if err != nil {
+		log.V(logLevel).Errorf("%s:exec:> get namespace err: %s", logPrefix, err.Error())
+		errors.HTTP.InternalServerError(w)
+		return
+	}
+	if ns == nil {
+		log.V(logLevel).Warnf("%s:exec:> namespace `%s` not found", logPrefix, nid)
+		errors.New("namespace").NotFound().Http(w)
+		return
+	}
+
+	svc, err := sm.Get(ns.Meta.Name, sid)
+	if err != nil {
+		log.V(logLevel).Errorf("%s:exec:> get service by name `%s` err: %s", logPrefix, sid, err.Error())
+		errors.HTTP.InternalServerError(w)
+		return
+	}
+	if svc == nil {
+		log.V(logLevel).Warnf("%s:exec:> service name `%s` in namespace `%s` not found", logPrefix, sid, ns.Meta.Name)
+		errors.New("service").NotFound().Http(w)
+		return
+	}
+
+	deployment, err := dm.Get(ns.Meta.Name, svc.Meta.Name, did)
+	if err != nil {
+		log.V(logLevel).Errorf("%s:exec:> get deployment by name err: %s", logPrefix, err.Error())
+		errors.HTTP.InternalServerError(w)
+		return
+	}
+	if deployment == nil {
+		log.V(logLevel).Warnf("%s:exec:> deployment `%s` not found", logPrefix, did)
+		errors.New("deployment").NotFound().Http(w)
+		return
+	}
+
+	pod, err := pm.Get(ns.Meta.Name, svc.Meta.Name, deployment.Meta.Name, pid)
+	if err != nil {
+		log.V(logLevel).Errorf("%s:exec:> get pod by name err: %s", logPrefix, err.Error())
+		errors.HTTP.InternalServerError(w)
+		return
+	}
+	if pod == nil {
+		log.V(logLevel).Warnf("%s:exec:> pod `%s` not found", logPrefix, pid)
+		errors.New("pod").NotFound().Http(w)
+		return
+	}
+
+	node, err := nm.Get(pod.Meta.Node)
+	if err != nil {
+		log.V(logLevel).Errorf("%s:exec:> get node by name err: %s", logPrefix, err.Error())
+		errors.HTTP.InternalServerError(w)
+		return
+	}
+	if node == nil {
+		log.V(logLevel).Warnf("%s:exec:> node %s not found", logPrefix, pod.Meta.Node)
+		errors.New("node").NotFound().Http(w)
+		return
+	}
+
+	query := url.Values{}
+	query["command"] = command
+	query.Set("tty", r.URL.Query().Get("tty"))
+	query.Set("stdin", r.URL.Query().Get("stdin"))
+
+	// node keeps pods state by pod self link
+	endpoint := fmt.Sprintf("ws://%s:%d/pod/%s/%s/exec?%s", node.Meta.InternalIP, 2969, pod.SelfLink(), cid, query.Encode())
+
+	header := http.Header{}
+	if types.SecretAccessToken != "" {
+		header.Set("Authorization", fmt.Sprintf("Bearer %s", types.SecretAccessToken))
+	}
+
+	backend, res, err := websocket.DefaultDialer.Dial(endpoint, header)
+	if err != nil {
+		log.V(logLevel).Errorf("%s:exec:> dial node exec err: %s", logPrefix, err.Error())
+		if res != nil && res.StatusCode == http.StatusNotFound {
+			errors.New("pod").NotFound().Http(w)
+			return
+		}
+		errors.HTTP.InternalServerError(w)
+		return
+	}
+	defer backend.Close()
+
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		log.V(logLevel).Errorf("%s:exec:> set websocket upgrade err: %s", logPrefix, err.Error())
+		return
+	}
+	defer conn.Close()
+
+	done := make(chan bool, 2)
+
+	go serviceExecProxy(conn, backend, done)
+	go serviceExecProxy(backend, conn, done)
+
+	<-done
+}
+
+// serviceExecProxy copies websocket messages from src to dst connection until one of them is closed
+func serviceExecProxy(dst, src *websocket.Conn, done chan bool) {
+
+	defer func() {
+		done <- true
+	}()
+
+	for {
+		mt, msg, err := src.ReadMessage()
+		if err != nil {
+			if ce, ok := err.(*websocket.CloseError); ok {
+				dst.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(ce.Code, ce.Text))
+			}
+			return
+		}
+
+		if err := dst.WriteMessage(mt, msg); err != nil {
+			log.V(logLevel).Debugf("%s:exec:> proxy websocket message err: %s", logPrefix, err.Error())
+			return
+		}
+	}
+}
+
+// serviceRollbackDeployment returns deployment with provided version
+// or latest deployment version before current service template
+func serviceRollbackDeployment(svc *types.Service, dl []*types.Deployment, version *int) *types.Deployment {
+
+	if version != nil {
+		for _, d := range dl {
+			if d.Meta.Version == *version {
+				return d
+			}
+		}
+		return nil
+	}
+
+	var current, target *types.Deployment
+
+	for _, d := range dl {
+		if d.Spec.Template.Updated.Equal(svc.Spec.Template.Updated) {
+			if current == nil || current.Meta.Version < d.Meta.Version {
+				current = d
+			}
+		}
+	}
+
+	for _, d := range dl {
+
+		if d.Spec.Template.Updated.Equal(svc.Spec.Template.Updated) {
+			continue
+		}
+
+		if current != nil && d.Meta.Version > current.Meta.Version {
+			continue
+		}
+
+		if target == nil || target.Meta.Version < d.Meta.Version {
+			target = d
+		}
+	}
+
+	return target
+}
+
+// serviceQuotaCheck checks that namespace ram quota allows to apply service spec
+// together with specs of other namespace services
+func serviceQuotaCheck(sm *distribution.Service, ns *types.Namespace, svc *types.Service) *errors.Err {
+
+	sl, err := sm.List(ns.Meta.Name)
+	if err != nil {
+		return errors.New("service").Unknown(err)
+	}
+
+	var usage types.NamespaceResources
+
+	for _, s := range sl.Items {
+		if s.Meta.Name == svc.Meta.Name || s.Spec.State.Destroy {
+			continue
+		}
+		usage.RAM += s.Spec.GetResourceRequest().RAM
+	}
+
+	usage.RAM += svc.Spec.GetResourceRequest().RAM
+
+	if err := ns.CheckQuotas(usage); err != nil {
+		return errors.New("namespace").BadRequest(err.Error(), err)
+	}
+
+	return nil
+}
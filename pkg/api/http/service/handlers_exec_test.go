@@ -0,0 +1,140 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+
+package service
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+)
+
+// Testing serviceExecProxy messages and close codes propagation
+func TestServiceExecProxy(t *testing.T) {
+
+	// backend echoes messages and closes connection with status on "exit" message
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		for {
+			mt, msg, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+
+			if string(msg) == "exit" {
+				conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, "exited"))
+				return
+			}
+
+			if err := conn.WriteMessage(mt, append([]byte("echo:"), msg...)); err != nil {
+				return
+			}
+		}
+	}))
+	defer backend.Close()
+
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+		dst, _, err := websocket.DefaultDialer.Dial(toWS(backend.URL), nil)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		defer dst.Close()
+
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		done := make(chan bool, 2)
+
+		go serviceExecProxy(conn, dst, done)
+		go serviceExecProxy(dst, conn, done)
+
+		<-done
+	}))
+	defer proxy.Close()
+
+	conn, _, err := websocket.DefaultDialer.Dial(toWS(proxy.URL), nil)
+	if !assert.NoError(t, err, "websocket dial") {
+		return
+	}
+	defer conn.Close()
+
+	tests := []struct {
+		name string
+		mt   int
+		msg  string
+		want string
+	}{
+		{
+			name: "proxy text message",
+			mt:   websocket.TextMessage,
+			msg:  "ls",
+			want: "echo:ls",
+		},
+		{
+			name: "proxy binary message",
+			mt:   websocket.BinaryMessage,
+			msg:  "\x00data",
+			want: "echo:\x00data",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+
+			assert.NoError(t, conn.WriteMessage(tc.mt, []byte(tc.msg)))
+
+			mt, msg, err := conn.ReadMessage()
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			assert.Equal(t, tc.mt, mt, "message type not equal")
+			assert.Equal(t, tc.want, string(msg), "message not equal")
+		})
+	}
+
+	assert.NoError(t, conn.WriteMessage(websocket.TextMessage, []byte("exit")))
+
+	_, _, err = conn.ReadMessage()
+	if assert.Error(t, err) {
+		ce, ok := err.(*websocket.CloseError)
+		if assert.True(t, ok, "close error expected, got: %v", err) {
+			assert.Equal(t, websocket.CloseNormalClosure, ce.Code, "close code not equal")
+			assert.Equal(t, "exited", ce.Text, "close text not equal")
+		}
+	}
+}
+
+func toWS(url string) string {
+	return "ws" + strings.TrimPrefix(url, "http")
+}
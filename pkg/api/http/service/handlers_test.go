@@ -27,6 +27,7 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/lastbackend/lastbackend/pkg/api/envs"
@@ -314,10 +315,36 @@ func TestServiceCreate(t *testing.T) {
 
 	ns1 := getNamespaceAsset("demo", "")
 	ns2 := getNamespaceAsset("test", "")
+	ns3 := getNamespaceAsset("quota", "")
+	ns3.Spec.Quotas.RAM = 512
 
 	s1 := getServiceAsset(ns1.Meta.Name, "demo", "")
 	s2 := getServiceAsset(ns1.Meta.Name, "test", "")
 	s3 := getServiceAsset(ns1.Meta.Name, "new_demo", "")
+	s4 := getServiceAsset(ns3.Meta.Name, "new_demo", "")
+
+	m4 := getServiceManifest("new_demo", "redis")
+	m4.Spec.Template.Containers[0].Probes.LiveProbe = &request.ManifestSpecTemplateContainerProbe{
+		HTTP: &request.ManifestSpecTemplateContainerProbeHTTP{
+			Path: "healthz",
+			Port: 8080,
+		},
+	}
+
+	m5 := getServiceManifest("new_demo", "redis")
+	m5.Spec.Template.Containers[0].Probes.ReadProbe = &request.ManifestSpecTemplateContainerProbe{
+		HTTP: &request.ManifestSpecTemplateContainerProbeHTTP{
+			Path: "/healthz",
+			Port: 8080,
+			Status: request.ManifestSpecTemplateContainerProbeHTTPStatus{
+				Min: 300,
+				Max: 200,
+			},
+		},
+	}
+
+	m6 := getServiceManifest("new_demo", "redis")
+	m6.Spec.Template.Containers[0].Resources.Request.RAM = 1024
 
 	type fields struct {
 		stg storage.Storage
@@ -371,6 +398,36 @@ func TestServiceCreate(t *testing.T) {
 			wantErr:      true,
 			expectedCode: http.StatusBadRequest,
 		},
+		{
+			name:         "check create service if bad parameter live probe path",
+			args:         args{ctx, ns1, s3},
+			fields:       fields{stg},
+			handler:      service.ServiceCreateH,
+			data:         m4,
+			err:          "{\"code\":400,\"status\":\"Bad Parameter\",\"message\":\"Bad live_probe parameter\"}",
+			wantErr:      true,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "check create service if bad parameter read probe status range",
+			args:         args{ctx, ns1, s3},
+			fields:       fields{stg},
+			handler:      service.ServiceCreateH,
+			data:         m5,
+			err:          "{\"code\":400,\"status\":\"Bad Parameter\",\"message\":\"Bad read_probe parameter\"}",
+			wantErr:      true,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "check create service if namespace ram quota exceeded",
+			args:         args{ctx, ns3, s4},
+			fields:       fields{stg},
+			handler:      service.ServiceCreateH,
+			data:         m6,
+			err:          "{\"code\":400,\"status\":\"Bad Request\",\"message\":\"namespace ram quota exceeded: requested 1024 MB of 512 MB\"}",
+			wantErr:      true,
+			expectedCode: http.StatusBadRequest,
+		},
 		// TODO: check another spec parameters
 		{
 			name:         "check create service success",
@@ -401,6 +458,9 @@ func TestServiceCreate(t *testing.T) {
 			err := tc.fields.stg.Put(context.Background(), stg.Collection().Namespace(), tc.fields.stg.Key().Namespace(ns1.Meta.Name), ns1, nil)
 			assert.NoError(t, err)
 
+			err = tc.fields.stg.Put(context.Background(), stg.Collection().Namespace(), tc.fields.stg.Key().Namespace(ns3.Meta.Name), ns3, nil)
+			assert.NoError(t, err)
+
 			err = tc.fields.stg.Put(context.Background(), stg.Collection().Service(), tc.fields.stg.Key().Service(s1.Meta.Namespace, s1.Meta.Name), s1, nil)
 			assert.NoError(t, err)
 
@@ -487,6 +547,11 @@ func TestServiceUpdate(t *testing.T) {
 
 	m3.SetServiceSpec(s3)
 
+	// revision of service changed by another request
+	rev := int64(1)
+	m4 := getServiceManifest(s3.Meta.Name, "redis")
+	m4.Meta.Revision = &rev
+
 	type fields struct {
 		stg storage.Storage
 	}
@@ -529,6 +594,16 @@ func TestServiceUpdate(t *testing.T) {
 			wantErr:      true,
 			expectedCode: http.StatusNotFound,
 		},
+		{
+			name:         "checking update service if revision conflict",
+			fields:       fields{stg},
+			args:         args{ctx, ns1, s1},
+			handler:      service.ServiceUpdateH,
+			data:         m4,
+			err:          "{\"code\":409,\"status\":\"Conflict\",\"message\":\"Service revision conflict\"}",
+			wantErr:      true,
+			expectedCode: http.StatusConflict,
+		},
 		// TODO: check another spec parameters
 		{
 			name:         "check update service success",
@@ -860,6 +935,420 @@ func TestServiceRemove(t *testing.T) {
 
 }
 
+// Testing ServiceRollbackH handler
+func TestServiceRollback(t *testing.T) {
+
+	var ctx = context.Background()
+
+	stg, _ := storage.Get("mock")
+	envs.Get().SetStorage(stg)
+
+	ns1 := getNamespaceAsset("demo", "")
+	ns2 := getNamespaceAsset("test", "")
+
+	s1 := getServiceAsset(ns1.Meta.Name, "demo", "")
+	s2 := getServiceAsset(ns1.Meta.Name, "test", "")
+
+	d1 := getDeploymentAsset(s1, 1, "redis:3")
+	d2 := getDeploymentAsset(s1, 2, "redis:4")
+	d3 := getDeploymentAsset(s1, 3, "redis:5")
+
+	s1.Spec.Template = d3.Spec.Template
+
+	var (
+		ver1 = 1
+		ver3 = 3
+		ver5 = 5
+	)
+
+	type fields struct {
+		stg storage.Storage
+	}
+
+	type args struct {
+		ctx       context.Context
+		namespace *types.Namespace
+		service   *types.Service
+	}
+
+	tests := []struct {
+		name         string
+		fields       fields
+		args         args
+		headers      map[string]string
+		handler      func(http.ResponseWriter, *http.Request)
+		data         *request.ServiceRollbackOptions
+		want         string
+		wantErr      bool
+		err          string
+		expectedCode int
+	}{
+		{
+			name:         "checking rollback service if name not exists",
+			fields:       fields{stg},
+			args:         args{ctx, ns1, s2},
+			handler:      service.ServiceRollbackH,
+			err:          "{\"code\":404,\"status\":\"Not Found\",\"message\":\"Service not found\"}",
+			wantErr:      true,
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "checking rollback service if namespace not found",
+			fields:       fields{stg},
+			args:         args{ctx, ns2, s1},
+			handler:      service.ServiceRollbackH,
+			err:          "{\"code\":404,\"status\":\"Not Found\",\"message\":\"Namespace not found\"}",
+			wantErr:      true,
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "checking rollback service if version not found",
+			fields:       fields{stg},
+			args:         args{ctx, ns1, s1},
+			handler:      service.ServiceRollbackH,
+			data:         &request.ServiceRollbackOptions{Version: &ver5},
+			err:          "{\"code\":404,\"status\":\"Not Found\",\"message\":\"Deployment not found\"}",
+			wantErr:      true,
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "checking rollback service to active version",
+			fields:       fields{stg},
+			args:         args{ctx, ns1, s1},
+			handler:      service.ServiceRollbackH,
+			data:         &request.ServiceRollbackOptions{Version: &ver3},
+			err:          "{\"code\":400,\"status\":\"Bad Request\",\"message\":\"already at this version\"}",
+			wantErr:      true,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "check rollback service to previous version success",
+			fields:       fields{stg},
+			args:         args{ctx, ns1, s1},
+			handler:      service.ServiceRollbackH,
+			want:         "redis:4",
+			wantErr:      false,
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "check rollback service to provided version success",
+			fields:       fields{stg},
+			args:         args{ctx, ns1, s1},
+			handler:      service.ServiceRollbackH,
+			data:         &request.ServiceRollbackOptions{Version: &ver1},
+			want:         "redis:3",
+			wantErr:      false,
+			expectedCode: http.StatusOK,
+		},
+	}
+
+	clear := func() {
+		err := envs.Get().GetStorage().Del(context.Background(), stg.Collection().Namespace(), types.EmptyString)
+		assert.NoError(t, err)
+
+		err = envs.Get().GetStorage().Del(context.Background(), stg.Collection().Service(), types.EmptyString)
+		assert.NoError(t, err)
+
+		err = envs.Get().GetStorage().Del(context.Background(), stg.Collection().Deployment(), types.EmptyString)
+		assert.NoError(t, err)
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+
+			clear()
+			defer clear()
+
+			err := tc.fields.stg.Put(context.Background(), stg.Collection().Namespace(), tc.fields.stg.Key().Namespace(ns1.Meta.Name), ns1, nil)
+			assert.NoError(t, err)
+
+			err = tc.fields.stg.Put(context.Background(), stg.Collection().Service(), tc.fields.stg.Key().Service(s1.Meta.Namespace, s1.Meta.Name), s1, nil)
+			assert.NoError(t, err)
+
+			for _, d := range []*types.Deployment{d1, d2, d3} {
+				err = tc.fields.stg.Put(context.Background(), stg.Collection().Deployment(), tc.fields.stg.Key().Deployment(d.Meta.Namespace, d.Meta.Service, d.Meta.Name), d, nil)
+				assert.NoError(t, err)
+			}
+
+			var body = ""
+			if tc.data != nil {
+				bd, err := tc.data.ToJson()
+				assert.NoError(t, err)
+				body = string(bd)
+			}
+
+			// Create assert request to pass to our handler. We don't have any query parameters for now, so we'll
+			// pass 'nil' as the third parameter.
+			req, err := http.NewRequest("POST", fmt.Sprintf("/namespace/%s/service/%s/rollback", tc.args.namespace.Meta.Name, tc.args.service.Meta.Name), strings.NewReader(body))
+			assert.NoError(t, err)
+
+			if tc.headers != nil {
+				for key, val := range tc.headers {
+					req.Header.Set(key, val)
+				}
+			}
+
+			r := mux.NewRouter()
+			r.HandleFunc("/namespace/{namespace}/service/{service}/rollback", tc.handler)
+
+			setRequestVars(r, req)
+
+			// We create assert ResponseRecorder (which satisfies http.ResponseWriter) to record the response.
+			res := httptest.NewRecorder()
+
+			// Our handlers satisfy http.Handler, so we can call their ServeHTTP method
+			// directly and pass in our Request and ResponseRecorder.
+			r.ServeHTTP(res, req)
+
+			// Check the status code is what we expect.
+			if !assert.Equal(t, tc.expectedCode, res.Code, "status code not equal") {
+				return
+			}
+
+			got, err := ioutil.ReadAll(res.Body)
+			assert.NoError(t, err)
+
+			if tc.wantErr && res.Code != 200 {
+				assert.Equal(t, tc.err, string(got), "incorrect status code")
+			} else {
+				s := new(views.Service)
+				err := json.Unmarshal(got, &s)
+				assert.NoError(t, err)
+
+				if !assert.Equal(t, 1, len(s.Spec.Template.Containers), "container spec count not equal") {
+					return
+				}
+
+				assert.Equal(t, tc.want, s.Spec.Template.Containers[0].Image.Name, "container spec image not equal")
+				assert.Equal(t, types.StateProvision, s.Status.State, "service state not provision")
+			}
+		})
+	}
+
+}
+
+// Testing ServiceStatsH handler
+func TestServiceStats(t *testing.T) {
+
+	stg, _ := storage.Get("mock")
+	envs.Get().SetStorage(stg)
+
+	ns1 := getNamespaceAsset("demo", "")
+	s1 := getServiceAsset(ns1.Meta.Name, "demo", "")
+	d1 := getDeploymentAsset(s1, 1, "redis")
+	p1 := getPodAsset(d1, "demo", "unknown")
+
+	tests := []struct {
+		name         string
+		url          string
+		err          string
+		expectedCode int
+	}{
+		{
+			name:         "checking get pod stats if namespace not exists",
+			url:          fmt.Sprintf("/namespace/%s/service/%s/deployment/%s/pod/%s/stats", "test", s1.Meta.Name, d1.Meta.Name, p1.Meta.Name),
+			err:          "{\"code\":404,\"status\":\"Not Found\",\"message\":\"Namespace not found\"}",
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "checking get pod stats if deployment not exists",
+			url:          fmt.Sprintf("/namespace/%s/service/%s/deployment/%s/pod/%s/stats", ns1.Meta.Name, s1.Meta.Name, "test", p1.Meta.Name),
+			err:          "{\"code\":404,\"status\":\"Not Found\",\"message\":\"Deployment not found\"}",
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "checking get pod stats if pod not exists",
+			url:          fmt.Sprintf("/namespace/%s/service/%s/deployment/%s/pod/%s/stats", ns1.Meta.Name, s1.Meta.Name, d1.Meta.Name, "test"),
+			err:          "{\"code\":404,\"status\":\"Not Found\",\"message\":\"Pod not found\"}",
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "checking get pod stats if pod node not exists",
+			url:          fmt.Sprintf("/namespace/%s/service/%s/deployment/%s/pod/%s/stats", ns1.Meta.Name, s1.Meta.Name, d1.Meta.Name, p1.Meta.Name),
+			err:          "{\"code\":404,\"status\":\"Not Found\",\"message\":\"Node not found\"}",
+			expectedCode: http.StatusNotFound,
+		},
+	}
+
+	clear := func() {
+		err := envs.Get().GetStorage().Del(context.Background(), stg.Collection().Namespace(), types.EmptyString)
+		assert.NoError(t, err)
+
+		err = envs.Get().GetStorage().Del(context.Background(), stg.Collection().Service(), types.EmptyString)
+		assert.NoError(t, err)
+
+		err = envs.Get().GetStorage().Del(context.Background(), stg.Collection().Deployment(), types.EmptyString)
+		assert.NoError(t, err)
+
+		err = envs.Get().GetStorage().Del(context.Background(), stg.Collection().Pod(), types.EmptyString)
+		assert.NoError(t, err)
+	}
+
+	for _, tc := range tests {
+
+		t.Run(tc.name, func(t *testing.T) {
+
+			clear()
+			defer clear()
+
+			err := stg.Put(context.Background(), stg.Collection().Namespace(), stg.Key().Namespace(ns1.Meta.Name), ns1, nil)
+			assert.NoError(t, err)
+
+			err = stg.Put(context.Background(), stg.Collection().Service(), stg.Key().Service(s1.Meta.Namespace, s1.Meta.Name), s1, nil)
+			assert.NoError(t, err)
+
+			err = stg.Put(context.Background(), stg.Collection().Deployment(), stg.Key().Deployment(d1.Meta.Namespace, d1.Meta.Service, d1.Meta.Name), d1, nil)
+			assert.NoError(t, err)
+
+			err = stg.Put(context.Background(), stg.Collection().Pod(), stg.Key().Pod(p1.Meta.Namespace, p1.Meta.Service, p1.Meta.Deployment, p1.Meta.Name), p1, nil)
+			assert.NoError(t, err)
+
+			req, err := http.NewRequest("GET", tc.url, nil)
+			assert.NoError(t, err)
+
+			r := mux.NewRouter()
+			r.HandleFunc("/namespace/{namespace}/service/{service}/deployment/{deployment}/pod/{pod}/stats", service.ServiceStatsH)
+
+			setRequestVars(r, req)
+
+			res := httptest.NewRecorder()
+			r.ServeHTTP(res, req)
+
+			if !assert.Equal(t, tc.expectedCode, res.Code, "status code not equal") {
+				return
+			}
+
+			body, err := ioutil.ReadAll(res.Body)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.err, string(body), "incorrect error message")
+		})
+	}
+
+}
+
+// Testing ServiceExecH handler
+func TestServiceExec(t *testing.T) {
+
+	stg, _ := storage.Get("mock")
+	envs.Get().SetStorage(stg)
+
+	ns1 := getNamespaceAsset("demo", "")
+	s1 := getServiceAsset(ns1.Meta.Name, "demo", "")
+	d1 := getDeploymentAsset(s1, 1, "redis")
+	p1 := getPodAsset(d1, "demo", "node")
+	p2 := getPodAsset(d1, "test", "unknown")
+
+	type args struct {
+		namespace  string
+		service    string
+		deployment string
+		pod        string
+		query      string
+	}
+
+	tests := []struct {
+		name         string
+		args         args
+		err          string
+		expectedCode int
+	}{
+		{
+			name:         "checking exec if command is empty",
+			args:         args{ns1.Meta.Name, s1.Meta.Name, d1.Meta.Name, p1.Meta.Name, ""},
+			err:          "{\"code\":400,\"status\":\"Bad Parameter\",\"message\":\"Bad command parameter\"}",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "checking exec if namespace not exists",
+			args:         args{"test", s1.Meta.Name, d1.Meta.Name, p1.Meta.Name, "command=ls"},
+			err:          "{\"code\":404,\"status\":\"Not Found\",\"message\":\"Namespace not found\"}",
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "checking exec if service not exists",
+			args:         args{ns1.Meta.Name, "test", d1.Meta.Name, p1.Meta.Name, "command=ls"},
+			err:          "{\"code\":404,\"status\":\"Not Found\",\"message\":\"Service not found\"}",
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "checking exec if deployment not exists",
+			args:         args{ns1.Meta.Name, s1.Meta.Name, "test", p1.Meta.Name, "command=ls"},
+			err:          "{\"code\":404,\"status\":\"Not Found\",\"message\":\"Deployment not found\"}",
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "checking exec if pod not exists",
+			args:         args{ns1.Meta.Name, s1.Meta.Name, d1.Meta.Name, "unknown", "command=ls"},
+			err:          "{\"code\":404,\"status\":\"Not Found\",\"message\":\"Pod not found\"}",
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "checking exec if pod node not exists",
+			args:         args{ns1.Meta.Name, s1.Meta.Name, d1.Meta.Name, p2.Meta.Name, "command=ls"},
+			err:          "{\"code\":404,\"status\":\"Not Found\",\"message\":\"Node not found\"}",
+			expectedCode: http.StatusNotFound,
+		},
+	}
+
+	clear := func() {
+		err := envs.Get().GetStorage().Del(context.Background(), stg.Collection().Namespace(), types.EmptyString)
+		assert.NoError(t, err)
+
+		err = envs.Get().GetStorage().Del(context.Background(), stg.Collection().Service(), types.EmptyString)
+		assert.NoError(t, err)
+
+		err = envs.Get().GetStorage().Del(context.Background(), stg.Collection().Deployment(), types.EmptyString)
+		assert.NoError(t, err)
+
+		err = envs.Get().GetStorage().Del(context.Background(), stg.Collection().Pod(), types.EmptyString)
+		assert.NoError(t, err)
+	}
+
+	for _, tc := range tests {
+
+		t.Run(tc.name, func(t *testing.T) {
+
+			clear()
+			defer clear()
+
+			err := stg.Put(context.Background(), stg.Collection().Namespace(), stg.Key().Namespace(ns1.Meta.Name), ns1, nil)
+			assert.NoError(t, err)
+
+			err = stg.Put(context.Background(), stg.Collection().Service(), stg.Key().Service(s1.Meta.Namespace, s1.Meta.Name), s1, nil)
+			assert.NoError(t, err)
+
+			err = stg.Put(context.Background(), stg.Collection().Deployment(), stg.Key().Deployment(d1.Meta.Namespace, d1.Meta.Service, d1.Meta.Name), d1, nil)
+			assert.NoError(t, err)
+
+			for _, p := range []*types.Pod{p1, p2} {
+				err = stg.Put(context.Background(), stg.Collection().Pod(), stg.Key().Pod(p.Meta.Namespace, p.Meta.Service, p.Meta.Deployment, p.Meta.Name), p, nil)
+				assert.NoError(t, err)
+			}
+
+			req, err := http.NewRequest("GET", fmt.Sprintf("/namespace/%s/service/%s/deployment/%s/pod/%s/%s/exec?%s",
+				tc.args.namespace, tc.args.service, tc.args.deployment, tc.args.pod, "demo", tc.args.query), nil)
+			assert.NoError(t, err)
+
+			r := mux.NewRouter()
+			r.HandleFunc("/namespace/{namespace}/service/{service}/deployment/{deployment}/pod/{pod}/{container}/exec", service.ServiceExecH)
+
+			setRequestVars(r, req)
+
+			res := httptest.NewRecorder()
+			r.ServeHTTP(res, req)
+
+			if !assert.Equal(t, tc.expectedCode, res.Code, "status code not equal") {
+				return
+			}
+
+			body, err := ioutil.ReadAll(res.Body)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.err, string(body), "incorrect error message")
+		})
+	}
+
+}
+
 func getNamespaceAsset(name, desc string) *types.Namespace {
 	var n = types.Namespace{}
 	n.Meta.SetDefault()
@@ -878,6 +1367,34 @@ func getServiceAsset(namespace, name, desc string) *types.Service {
 	return &s
 }
 
+func getDeploymentAsset(svc *types.Service, version int, image string) *types.Deployment {
+	var d = types.Deployment{}
+	d.Meta.SetDefault()
+	d.Meta.Namespace = svc.Meta.Namespace
+	d.Meta.Service = svc.Meta.Name
+	d.Meta.Name = fmt.Sprintf("%s-%d", svc.Meta.Name, version)
+	d.Meta.Version = version
+	d.Status.State = types.StateDestroyed
+	d.Spec.Replicas = svc.Spec.Replicas
+	d.Spec.Template.Containers = append(d.Spec.Template.Containers, &types.SpecTemplateContainer{
+		Name:  "demo",
+		Image: types.SpecTemplateContainerImage{Name: image},
+	})
+	d.Spec.Template.Updated = time.Now().Add(time.Duration(version) * time.Second)
+	return &d
+}
+
+func getPodAsset(d *types.Deployment, name, node string) *types.Pod {
+	var p = types.Pod{}
+	p.Meta.SetDefault()
+	p.Meta.Namespace = d.Meta.Namespace
+	p.Meta.Service = d.Meta.Service
+	p.Meta.Deployment = d.Meta.Name
+	p.Meta.Name = name
+	p.Meta.Node = node
+	return &p
+}
+
 func setRequestVars(r *mux.Router, req *http.Request) {
 	var match mux.RouteMatch
 	// Take the request and match it
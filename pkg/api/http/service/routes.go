@@ -29,5 +29,8 @@ var Routes = []http.Route{
 	{Path: "/namespace/{namespace}/service/{service}", Method: http.MethodGet, Middleware: []http.Middleware{middleware.Authenticate}, Handler: ServiceInfoH},
 	{Path: "/namespace/{namespace}/service/{service}", Method: http.MethodPut, Middleware: []http.Middleware{middleware.Authenticate}, Handler: ServiceUpdateH},
 	{Path: "/namespace/{namespace}/service/{service}", Method: http.MethodDelete, Middleware: []http.Middleware{middleware.Authenticate}, Handler: ServiceRemoveH},
+	{Path: "/namespace/{namespace}/service/{service}/rollback", Method: http.MethodPost, Middleware: []http.Middleware{middleware.Authenticate}, Handler: ServiceRollbackH},
 	{Path: "/namespace/{namespace}/service/{service}/logs", Method: http.MethodGet, Middleware: []http.Middleware{middleware.Authenticate}, Handler: ServiceLogsH},
+	{Path: "/namespace/{namespace}/service/{service}/deployment/{deployment}/pod/{pod}/stats", Method: http.MethodGet, Middleware: []http.Middleware{middleware.Authenticate}, Handler: ServiceStatsH},
+	{Path: "/namespace/{namespace}/service/{service}/deployment/{deployment}/pod/{pod}/{container}/exec", Method: http.MethodGet, Middleware: []http.Middleware{middleware.Authenticate}, Handler: ServiceExecH},
 }
@@ -19,8 +19,18 @@
 package trigger
 
 import (
-	"github.com/lastbackend/lastbackend/pkg/log"
+	"io/ioutil"
 	"net/http"
+	"strings"
+	"time"
+
+	"github.com/lastbackend/lastbackend/pkg/api/envs"
+	"github.com/lastbackend/lastbackend/pkg/api/types/v1"
+	"github.com/lastbackend/lastbackend/pkg/distribution"
+	"github.com/lastbackend/lastbackend/pkg/distribution/errors"
+	"github.com/lastbackend/lastbackend/pkg/distribution/types"
+	"github.com/lastbackend/lastbackend/pkg/log"
+	"github.com/lastbackend/lastbackend/pkg/util/http/utils"
 )
 
 const (
@@ -28,11 +38,420 @@ const (
 	logPrefix = "api:handler:trigger"
 )
 
+func TriggerListH(w http.ResponseWriter, r *http.Request) {
+
+	// swagger:operation GET /namespace/{namespace}/service/{service}/trigger trigger triggerList
+	//
+	// Shows a list of service triggers
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	//   - name: namespace
+	//     in: path
+	//     description: namespace id
+	//     required: true
+	//     type: string
+	//   - name: service
+	//     in: path
+	//     description: service id
+	//     required: true
+	//     type: string
+	// responses:
+	//   '200':
+	//     description: Trigger list response
+	//     schema:
+	//       "$ref": "#/definitions/views_trigger_list"
+	//   '404':
+	//     description: Namespace not found / Service not found
+	//   '500':
+	//     description: Internal server error
+
+	nid := utils.Vars(r)["namespace"]
+	sid := utils.Vars(r)["service"]
+
+	log.V(logLevel).Debugf("%s:list:> get triggers list for `%s:%s`", logPrefix, nid, sid)
+
+	var (
+		tm = distribution.NewTriggerModel(r.Context(), envs.Get().GetStorage())
+	)
+
+	svc, e := getService(r, nid, sid)
+	if e != nil {
+		e.Http(w)
+		return
+	}
+
+	items, err := tm.ListByService(svc.Meta.Namespace, svc.Meta.Name)
+	if err != nil {
+		log.V(logLevel).Errorf("%s:list:> find trigger list err: %s", logPrefix, err.Error())
+		errors.HTTP.InternalServerError(w)
+		return
+	}
+
+	response, err := v1.View().Trigger().NewList(items).ToJson()
+	if err != nil {
+		log.V(logLevel).Errorf("%s:list:> convert struct to json err: %s", logPrefix, err.Error())
+		errors.HTTP.InternalServerError(w)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(response); err != nil {
+		log.V(logLevel).Errorf("%s:list:> write response err: %s", logPrefix, err.Error())
+		return
+	}
+}
+
+func TriggerInfoH(w http.ResponseWriter, r *http.Request) {
+
+	// swagger:operation GET /namespace/{namespace}/service/{service}/trigger/{trigger} trigger triggerInfo
+	//
+	// Shows an info about service trigger
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	//   - name: namespace
+	//     in: path
+	//     description: namespace id
+	//     required: true
+	//     type: string
+	//   - name: service
+	//     in: path
+	//     description: service id
+	//     required: true
+	//     type: string
+	//   - name: trigger
+	//     in: path
+	//     description: trigger id
+	//     required: true
+	//     type: string
+	// responses:
+	//   '200':
+	//     description: Trigger response
+	//     schema:
+	//       "$ref": "#/definitions/views_trigger"
+	//   '404':
+	//     description: Namespace not found / Service not found / Trigger not found
+	//   '500':
+	//     description: Internal server error
+
+	nid := utils.Vars(r)["namespace"]
+	sid := utils.Vars(r)["service"]
+	tid := utils.Vars(r)["trigger"]
+
+	log.V(logLevel).Debugf("%s:info:> get trigger `%s`", logPrefix, tid)
+
+	svc, e := getService(r, nid, sid)
+	if e != nil {
+		e.Http(w)
+		return
+	}
+
+	item, e := getTrigger(r, svc, tid)
+	if e != nil {
+		e.Http(w)
+		return
+	}
+
+	response, err := v1.View().Trigger().New(item).ToJson()
+	if err != nil {
+		log.V(logLevel).Errorf("%s:info:> convert struct to json err: %s", logPrefix, err.Error())
+		errors.HTTP.InternalServerError(w)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(response); err != nil {
+		log.V(logLevel).Errorf("%s:info:> write response err: %s", logPrefix, err.Error())
+		return
+	}
+}
+
+func TriggerCreateH(w http.ResponseWriter, r *http.Request) {
+
+	// swagger:operation POST /namespace/{namespace}/service/{service}/trigger trigger triggerCreate
+	//
+	// Creates a service trigger
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	//   - name: namespace
+	//     in: path
+	//     description: namespace id
+	//     required: true
+	//     type: string
+	//   - name: service
+	//     in: path
+	//     description: service id
+	//     required: true
+	//     type: string
+	//   - name: body
+	//     in: body
+	//     required: true
+	//     schema:
+	//       "$ref": "#/definitions/request_trigger_create"
+	// responses:
+	//   '200':
+	//     description: Trigger was successfully created
+	//     schema:
+	//       "$ref": "#/definitions/views_trigger"
+	//   '400':
+	//     description: Name is already in use / Bad parameter
+	//   '404':
+	//     description: Namespace not found / Service not found
+	//   '500':
+	//     description: Internal server error
+
+	nid := utils.Vars(r)["namespace"]
+	sid := utils.Vars(r)["service"]
+
+	log.V(logLevel).Debugf("%s:create:> create trigger for `%s:%s`", logPrefix, nid, sid)
+
+	var (
+		tm   = distribution.NewTriggerModel(r.Context(), envs.Get().GetStorage())
+		opts = v1.Request().Trigger().CreateOptions()
+	)
+
+	// request body struct
+	if e := opts.DecodeAndValidate(r.Body); e != nil {
+		log.V(logLevel).Errorf("%s:create:> validation incoming data err: %s", logPrefix, e.Err())
+		e.Http(w)
+		return
+	}
+
+	svc, e := getService(r, nid, sid)
+	if e != nil {
+		e.Http(w)
+		return
+	}
+
+	item, err := tm.Get(svc.Meta.Namespace, svc.Meta.Name, opts.Name)
+	if err != nil {
+		log.V(logLevel).Errorf("%s:create:> check exists by name err: %s", logPrefix, err.Error())
+		errors.HTTP.InternalServerError(w)
+		return
+	}
+	if item != nil {
+		log.V(logLevel).Warnf("%s:create:> name `%s` not unique", logPrefix, opts.Name)
+		errors.New("trigger").NotUnique("name").Http(w)
+		return
+	}
+
+	trigger := new(types.Trigger)
+	trigger.Meta.SetDefault()
+	trigger.Meta.Namespace = svc.Meta.Namespace
+	trigger.Meta.Service = svc.Meta.Name
+
+	opts.SetTriggerMeta(trigger)
+	opts.SetTriggerSpec(trigger)
+
+	if _, err := tm.Create(trigger); err != nil {
+		log.V(logLevel).Errorf("%s:create:> create trigger err: %s", logPrefix, err.Error())
+		errors.HTTP.InternalServerError(w)
+		return
+	}
+
+	response, err := v1.View().Trigger().New(trigger).ToJson()
+	if err != nil {
+		log.V(logLevel).Errorf("%s:create:> convert struct to json err: %s", logPrefix, err.Error())
+		errors.HTTP.InternalServerError(w)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(response); err != nil {
+		log.V(logLevel).Errorf("%s:create:> write response err: %s", logPrefix, err.Error())
+		return
+	}
+}
+
+func TriggerUpdateH(w http.ResponseWriter, r *http.Request) {
+
+	// swagger:operation PUT /namespace/{namespace}/service/{service}/trigger/{trigger} trigger triggerUpdate
+	//
+	// Updates service trigger
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	//   - name: namespace
+	//     in: path
+	//     description: namespace id
+	//     required: true
+	//     type: string
+	//   - name: service
+	//     in: path
+	//     description: service id
+	//     required: true
+	//     type: string
+	//   - name: trigger
+	//     in: path
+	//     description: trigger id
+	//     required: true
+	//     type: string
+	//   - name: body
+	//     in: body
+	//     required: true
+	//     schema:
+	//       "$ref": "#/definitions/request_trigger_update"
+	// responses:
+	//   '200':
+	//     description: Trigger was successfully updated
+	//     schema:
+	//       "$ref": "#/definitions/views_trigger"
+	//   '400':
+	//     description: Bad parameter
+	//   '404':
+	//     description: Namespace not found / Service not found / Trigger not found
+	//   '409':
+	//     description: Trigger was changed since revision
+	//   '500':
+	//     description: Internal server error
+
+	nid := utils.Vars(r)["namespace"]
+	sid := utils.Vars(r)["service"]
+	tid := utils.Vars(r)["trigger"]
+
+	log.V(logLevel).Debugf("%s:update:> update trigger `%s`", logPrefix, tid)
+
+	var (
+		tm   = distribution.NewTriggerModel(r.Context(), envs.Get().GetStorage())
+		opts = v1.Request().Trigger().UpdateOptions()
+	)
+
+	// request body struct
+	if e := opts.DecodeAndValidate(r.Body); e != nil {
+		log.V(logLevel).Errorf("%s:update:> validation incoming data err: %s", logPrefix, e.Err())
+		e.Http(w)
+		return
+	}
+
+	svc, e := getService(r, nid, sid)
+	if e != nil {
+		e.Http(w)
+		return
+	}
+
+	trigger, e := getTrigger(r, svc, tid)
+	if e != nil {
+		e.Http(w)
+		return
+	}
+
+	if e := opts.SetTriggerSpec(trigger); e != nil {
+		log.V(logLevel).Errorf("%s:update:> validation incoming data err: %s", logPrefix, e.Err())
+		e.Http(w)
+		return
+	}
+
+	if opts.Revision != nil {
+		trigger.System.Revision = *opts.Revision
+	}
+
+	if _, err := tm.Update(trigger); err != nil {
+		log.V(logLevel).Errorf("%s:update:> update trigger `%s` err: %s", logPrefix, tid, err.Error())
+		if errors.Storage().IsErrEntityRevision(err) {
+			errors.New("trigger").Conflict().Http(w)
+			return
+		}
+		errors.HTTP.InternalServerError(w)
+		return
+	}
+
+	response, err := v1.View().Trigger().New(trigger).ToJson()
+	if err != nil {
+		log.V(logLevel).Errorf("%s:update:> convert struct to json err: %s", logPrefix, err.Error())
+		errors.HTTP.InternalServerError(w)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(response); err != nil {
+		log.V(logLevel).Errorf("%s:update:> write response err: %s", logPrefix, err.Error())
+		return
+	}
+}
+
+func TriggerRemoveH(w http.ResponseWriter, r *http.Request) {
+
+	// swagger:operation DELETE /namespace/{namespace}/service/{service}/trigger/{trigger} trigger triggerRemove
+	//
+	// Removes service trigger
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	//   - name: namespace
+	//     in: path
+	//     description: namespace id
+	//     required: true
+	//     type: string
+	//   - name: service
+	//     in: path
+	//     description: service id
+	//     required: true
+	//     type: string
+	//   - name: trigger
+	//     in: path
+	//     description: trigger id
+	//     required: true
+	//     type: string
+	// responses:
+	//   '200':
+	//     description: Trigger was successfully removed
+	//   '404':
+	//     description: Namespace not found / Service not found / Trigger not found
+	//   '500':
+	//     description: Internal server error
+
+	nid := utils.Vars(r)["namespace"]
+	sid := utils.Vars(r)["service"]
+	tid := utils.Vars(r)["trigger"]
+
+	log.V(logLevel).Debugf("%s:remove:> remove trigger `%s`", logPrefix, tid)
+
+	var (
+		tm = distribution.NewTriggerModel(r.Context(), envs.Get().GetStorage())
+	)
+
+	svc, e := getService(r, nid, sid)
+	if e != nil {
+		e.Http(w)
+		return
+	}
+
+	trigger, e := getTrigger(r, svc, tid)
+	if e != nil {
+		e.Http(w)
+		return
+	}
+
+	if err := tm.Remove(trigger); err != nil {
+		log.V(logLevel).Errorf("%s:remove:> remove trigger `%s` err: %s", logPrefix, tid, err.Error())
+		errors.HTTP.InternalServerError(w)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write([]byte{}); err != nil {
+		log.V(logLevel).Errorf("%s:remove:> write response err: %s", logPrefix, err.Error())
+		return
+	}
+}
+
 func HookExecuteH(w http.ResponseWriter, r *http.Request) {
 
 	// swagger:operation POST /hook/{id} trigger hookExecute
 	//
-	// Execute hook
+	// Execute hook: updates service image and provisions new deployment
 	//
 	// ---
 	// produces:
@@ -40,22 +459,172 @@ func HookExecuteH(w http.ResponseWriter, r *http.Request) {
 	// parameters:
 	//   - name: id
 	//     in: path
-	//     description: string
+	//     description: trigger self link
 	//     required: true
 	//     type: string
 	// responses:
 	//   '200':
 	//     description: Hook was successfully executed
+	//   '400':
+	//     description: Bad payload
+	//   '403':
+	//     description: Hook signature is not valid
+	//   '404':
+	//     description: Trigger not found / Service not found
+	//   '500':
+	//     description: Internal server error
+
+	id := utils.Vars(r)["id"]
 
-	log.V(logLevel).Debugf("%s:execute:> execute hook", logPrefix)
+	log.V(logLevel).Debugf("%s:execute:> execute hook `%s`", logPrefix, id)
 
 	var (
-		err error
+		tm = distribution.NewTriggerModel(r.Context(), envs.Get().GetStorage())
+		sm = distribution.NewServiceModel(r.Context(), envs.Get().GetStorage())
 	)
 
+	parts := strings.Split(id, ":")
+	if len(parts) != 3 {
+		log.V(logLevel).Warnf("%s:execute:> trigger `%s` not found", logPrefix, id)
+		errors.New("trigger").NotFound().Http(w)
+		return
+	}
+
+	trigger, err := tm.Get(parts[0], parts[1], parts[2])
+	if err != nil {
+		log.V(logLevel).Errorf("%s:execute:> get trigger `%s` err: %s", logPrefix, id, err.Error())
+		errors.HTTP.InternalServerError(w)
+		return
+	}
+	if trigger == nil {
+		log.V(logLevel).Warnf("%s:execute:> trigger `%s` not found", logPrefix, id)
+		errors.New("trigger").NotFound().Http(w)
+		return
+	}
+
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		log.V(logLevel).Errorf("%s:execute:> read hook payload err: %s", logPrefix, err.Error())
+		errors.HTTP.InternalServerError(w)
+		return
+	}
+
+	if !hookVerify(trigger, r, body) {
+		log.V(logLevel).Warnf("%s:execute:> hook `%s` signature is not valid", logPrefix, id)
+		errors.New("trigger").Forbidden().Http(w)
+		return
+	}
+
+	h, err := hookParse(trigger, body)
+	if err != nil {
+		log.V(logLevel).Warnf("%s:execute:> parse hook `%s` payload err: %s", logPrefix, id, err.Error())
+		errors.New("trigger").BadRequest("payload is not valid").Http(w)
+		return
+	}
+
+	if !trigger.Spec.Match(h.ref) {
+		log.V(logLevel).Debugf("%s:execute:> skip hook `%s`: `%s` does not match filter", logPrefix, id, h.ref)
+		w.WriteHeader(http.StatusOK)
+		if _, err := w.Write([]byte{}); err != nil {
+			log.V(logLevel).Errorf("%s:execute:> write response err: %s", logPrefix, err.Error())
+		}
+		return
+	}
+
+	svc, err := sm.Get(trigger.Meta.Namespace, trigger.Meta.Service)
+	if err != nil {
+		log.V(logLevel).Errorf("%s:execute:> get service err: %s", logPrefix, err.Error())
+		errors.HTTP.InternalServerError(w)
+		return
+	}
+	if svc == nil || svc.Spec.State.Destroy {
+		log.V(logLevel).Warnf("%s:execute:> service `%s` not found", logPrefix, trigger.Meta.Service)
+		errors.New("service").NotFound().Http(w)
+		return
+	}
+
+	status := trigger.Status
+	status.Ref = h.ref
+
+	if hookApply(trigger, svc, h) {
+
+		log.V(logLevel).Debugf("%s:execute:> provision service `%s` by hook `%s`", logPrefix, svc.SelfLink(), id)
+
+		svc.Spec.Template.Updated = time.Now()
+		svc.Status.State = types.StateProvision
+
+		if _, err := sm.Update(svc); err != nil {
+			log.V(logLevel).Errorf("%s:execute:> update service err: %s", logPrefix, err.Error())
+			if errors.Storage().IsErrEntityRevision(err) {
+				errors.New("service").Conflict().Http(w)
+				return
+			}
+			errors.HTTP.InternalServerError(w)
+			return
+		}
+
+		status.Executed = time.Now()
+		status.Message = types.EmptyString
+	} else {
+		status.Message = "no service containers matched the hook"
+	}
+
+	if err := tm.SetStatus(trigger, &status); err != nil {
+		log.V(logLevel).Errorf("%s:execute:> set trigger status err: %s", logPrefix, err.Error())
+		errors.HTTP.InternalServerError(w)
+		return
+	}
+
 	w.WriteHeader(http.StatusOK)
 	if _, err = w.Write([]byte{}); err != nil {
-		log.Errorf("%s:execute:> write response err: %s", logPrefix, err.Error())
+		log.V(logLevel).Errorf("%s:execute:> write response err: %s", logPrefix, err.Error())
 		return
 	}
 }
+
+func getService(r *http.Request, namespace, name string) (*types.Service, *errors.Err) {
+
+	var (
+		nsm = distribution.NewNamespaceModel(r.Context(), envs.Get().GetStorage())
+		sm  = distribution.NewServiceModel(r.Context(), envs.Get().GetStorage())
+	)
+
+	ns, err := nsm.Get(namespace)
+	if err != nil {
+		log.V(logLevel).Errorf("%s:> get namespace `%s` err: %s", logPrefix, namespace, err.Error())
+		return nil, errors.New("namespace").Unknown(err)
+	}
+	if ns == nil {
+		log.V(logLevel).Warnf("%s:> namespace `%s` not found", logPrefix, namespace)
+		return nil, errors.New("namespace").NotFound()
+	}
+
+	svc, err := sm.Get(ns.Meta.Name, name)
+	if err != nil {
+		log.V(logLevel).Errorf("%s:> get service `%s` err: %s", logPrefix, name, err.Error())
+		return nil, errors.New("service").Unknown(err)
+	}
+	if svc == nil {
+		log.V(logLevel).Warnf("%s:> service `%s` not found", logPrefix, name)
+		return nil, errors.New("service").NotFound()
+	}
+
+	return svc, nil
+}
+
+func getTrigger(r *http.Request, svc *types.Service, name string) (*types.Trigger, *errors.Err) {
+
+	tm := distribution.NewTriggerModel(r.Context(), envs.Get().GetStorage())
+
+	trigger, err := tm.Get(svc.Meta.Namespace, svc.Meta.Name, name)
+	if err != nil {
+		log.V(logLevel).Errorf("%s:> get trigger `%s` err: %s", logPrefix, name, err.Error())
+		return nil, errors.New("trigger").Unknown(err)
+	}
+	if trigger == nil {
+		log.V(logLevel).Warnf("%s:> trigger `%s` not found", logPrefix, name)
+		return nil, errors.New("trigger").NotFound()
+	}
+
+	return trigger, nil
+}
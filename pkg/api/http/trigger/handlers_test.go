@@ -17,3 +17,471 @@
 //
 
 package trigger_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/lastbackend/lastbackend/pkg/api/envs"
+	"github.com/lastbackend/lastbackend/pkg/api/http/trigger"
+	"github.com/lastbackend/lastbackend/pkg/api/types/v1/request"
+	"github.com/lastbackend/lastbackend/pkg/api/types/v1/views"
+	"github.com/lastbackend/lastbackend/pkg/distribution/types"
+	"github.com/lastbackend/lastbackend/pkg/storage"
+	"github.com/stretchr/testify/assert"
+)
+
+// Testing TriggerCreateH handler
+func TestTriggerCreate(t *testing.T) {
+
+	stg, _ := storage.Get("mock")
+	envs.Get().SetStorage(stg)
+
+	ns1 := getNamespaceAsset("demo", "")
+	ns2 := getNamespaceAsset("test", "")
+	s1 := getServiceAsset(ns1.Meta.Name, "demo", "nginx:latest")
+	s2 := getServiceAsset(ns1.Meta.Name, "test", "nginx:latest")
+	t1 := getTriggerAsset(s1, "demo", types.TriggerSourceVCS, types.TriggerVendorGitHub)
+
+	mf1, _ := getTriggerOptions("github", types.TriggerSourceVCS, types.TriggerVendorGitHub).ToJson()
+	mf2, _ := getTriggerOptions(t1.Meta.Name, types.TriggerSourceVCS, types.TriggerVendorGitHub).ToJson()
+	mf3, _ := getTriggerOptions("github", types.TriggerSourceRegistry, types.TriggerVendorGitHub).ToJson()
+	mf4, _ := getTriggerOptions("docker", types.TriggerSourceRegistry, types.TriggerVendorDocker).ToJson()
+
+	tests := []struct {
+		name         string
+		namespace    *types.Namespace
+		service      *types.Service
+		data         string
+		err          string
+		wantErr      bool
+		expectedCode int
+	}{
+		{
+			name:         "checking create trigger if namespace not found",
+			namespace:    ns2,
+			service:      s1,
+			data:         string(mf1),
+			err:          "{\"code\":404,\"status\":\"Not Found\",\"message\":\"Namespace not found\"}",
+			wantErr:      true,
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "checking create trigger if service not found",
+			namespace:    ns1,
+			service:      s2,
+			data:         string(mf1),
+			err:          "{\"code\":404,\"status\":\"Not Found\",\"message\":\"Service not found\"}",
+			wantErr:      true,
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "checking create trigger if name already exists",
+			namespace:    ns1,
+			service:      s1,
+			data:         string(mf2),
+			err:          "{\"code\":400,\"status\":\"Not Unique\",\"message\":\"Name is already in use\"}",
+			wantErr:      true,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "checking create trigger if vendor not match source",
+			namespace:    ns1,
+			service:      s1,
+			data:         string(mf3),
+			err:          "{\"code\":400,\"status\":\"Bad Parameter\",\"message\":\"Bad vendor parameter\"}",
+			wantErr:      true,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "checking create trigger successfully",
+			namespace:    ns1,
+			service:      s1,
+			data:         string(mf4),
+			wantErr:      false,
+			expectedCode: http.StatusOK,
+		},
+	}
+
+	clear := func() {
+		err := stg.Del(context.Background(), stg.Collection().Namespace(), types.EmptyString)
+		assert.NoError(t, err)
+
+		err = stg.Del(context.Background(), stg.Collection().Service(), types.EmptyString)
+		assert.NoError(t, err)
+
+		err = stg.Del(context.Background(), stg.Collection().Trigger(), types.EmptyString)
+		assert.NoError(t, err)
+	}
+
+	for _, tc := range tests {
+
+		t.Run(tc.name, func(t *testing.T) {
+
+			clear()
+			defer clear()
+
+			err := stg.Put(context.Background(), stg.Collection().Namespace(), stg.Key().Namespace(ns1.Meta.Name), ns1, nil)
+			assert.NoError(t, err)
+
+			err = stg.Put(context.Background(), stg.Collection().Service(), stg.Key().Service(s1.Meta.Namespace, s1.Meta.Name), s1, nil)
+			assert.NoError(t, err)
+
+			err = stg.Put(context.Background(), stg.Collection().Trigger(), stg.Key().Trigger(t1.Meta.Namespace, t1.Meta.Service, t1.Meta.Name), t1, nil)
+			assert.NoError(t, err)
+
+			req, err := http.NewRequest("POST", fmt.Sprintf("/namespace/%s/service/%s/trigger", tc.namespace.Meta.Name, tc.service.Meta.Name), strings.NewReader(tc.data))
+			assert.NoError(t, err)
+
+			r := mux.NewRouter()
+			r.HandleFunc("/namespace/{namespace}/service/{service}/trigger", trigger.TriggerCreateH)
+
+			setRequestVars(r, req)
+
+			res := httptest.NewRecorder()
+			r.ServeHTTP(res, req)
+
+			assert.Equal(t, tc.expectedCode, res.Code, "status code not equal")
+
+			body, err := ioutil.ReadAll(res.Body)
+			assert.NoError(t, err)
+
+			if tc.wantErr {
+				assert.Equal(t, tc.err, string(body), "incorrect status code")
+				return
+			}
+
+			got := new(views.Trigger)
+			err = json.Unmarshal(body, &got)
+			assert.NoError(t, err)
+
+			assert.Equal(t, "docker", got.Meta.Name, "trigger name not match")
+			assert.Equal(t, types.TriggerVendorDocker, got.Spec.Vendor, "trigger vendor not match")
+			assert.Equal(t, "/hook/demo:demo:docker", got.Spec.Hook, "trigger hook not match")
+
+			item := new(types.Trigger)
+			err = stg.Get(context.Background(), stg.Collection().Trigger(), stg.Key().Trigger(s1.Meta.Namespace, s1.Meta.Name, "docker"), item, nil)
+			assert.NoError(t, err)
+			assert.Equal(t, "secret", item.Spec.Secret, "trigger secret not match")
+		})
+	}
+
+}
+
+// Testing TriggerListH handler
+func TestTriggerList(t *testing.T) {
+
+	stg, _ := storage.Get("mock")
+	envs.Get().SetStorage(stg)
+
+	ns1 := getNamespaceAsset("demo", "")
+	s1 := getServiceAsset(ns1.Meta.Name, "demo", "nginx:latest")
+	s2 := getServiceAsset(ns1.Meta.Name, "test", "nginx:latest")
+	t1 := getTriggerAsset(s1, "github", types.TriggerSourceVCS, types.TriggerVendorGitHub)
+	t2 := getTriggerAsset(s1, "docker", types.TriggerSourceRegistry, types.TriggerVendorDocker)
+	t3 := getTriggerAsset(s2, "gitlab", types.TriggerSourceVCS, types.TriggerVendorGitLab)
+
+	tests := []struct {
+		name         string
+		service      *types.Service
+		err          string
+		want         int
+		wantErr      bool
+		expectedCode int
+	}{
+		{
+			name:         "checking get triggers list successfully",
+			service:      s1,
+			want:         2,
+			wantErr:      false,
+			expectedCode: http.StatusOK,
+		},
+	}
+
+	clear := func() {
+		err := stg.Del(context.Background(), stg.Collection().Namespace(), types.EmptyString)
+		assert.NoError(t, err)
+
+		err = stg.Del(context.Background(), stg.Collection().Service(), types.EmptyString)
+		assert.NoError(t, err)
+
+		err = stg.Del(context.Background(), stg.Collection().Trigger(), types.EmptyString)
+		assert.NoError(t, err)
+	}
+
+	for _, tc := range tests {
+
+		t.Run(tc.name, func(t *testing.T) {
+
+			clear()
+			defer clear()
+
+			err := stg.Put(context.Background(), stg.Collection().Namespace(), stg.Key().Namespace(ns1.Meta.Name), ns1, nil)
+			assert.NoError(t, err)
+
+			for _, s := range []*types.Service{s1, s2} {
+				err = stg.Put(context.Background(), stg.Collection().Service(), stg.Key().Service(s.Meta.Namespace, s.Meta.Name), s, nil)
+				assert.NoError(t, err)
+			}
+
+			for _, tr := range []*types.Trigger{t1, t2, t3} {
+				err = stg.Put(context.Background(), stg.Collection().Trigger(), stg.Key().Trigger(tr.Meta.Namespace, tr.Meta.Service, tr.Meta.Name), tr, nil)
+				assert.NoError(t, err)
+			}
+
+			req, err := http.NewRequest("GET", fmt.Sprintf("/namespace/%s/service/%s/trigger", tc.service.Meta.Namespace, tc.service.Meta.Name), nil)
+			assert.NoError(t, err)
+
+			r := mux.NewRouter()
+			r.HandleFunc("/namespace/{namespace}/service/{service}/trigger", trigger.TriggerListH)
+
+			setRequestVars(r, req)
+
+			res := httptest.NewRecorder()
+			r.ServeHTTP(res, req)
+
+			assert.Equal(t, tc.expectedCode, res.Code, "status code not equal")
+
+			body, err := ioutil.ReadAll(res.Body)
+			assert.NoError(t, err)
+
+			if tc.wantErr {
+				assert.Equal(t, tc.err, string(body), "incorrect status code")
+				return
+			}
+
+			got := make(views.TriggerList, 0)
+			err = json.Unmarshal(body, &got)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tc.want, len(got), "triggers count not match")
+			for _, item := range got {
+				assert.Equal(t, s1.Meta.Name, item.Meta.Service, "trigger service not match")
+			}
+		})
+	}
+
+}
+
+// Testing HookExecuteH handler
+func TestHookExecute(t *testing.T) {
+
+	stg, _ := storage.Get("mock")
+	envs.Get().SetStorage(stg)
+
+	ns1 := getNamespaceAsset("demo", "")
+	s1 := getServiceAsset(ns1.Meta.Name, "demo", "lastbackend/demo:latest")
+	t1 := getTriggerAsset(s1, "github", types.TriggerSourceVCS, types.TriggerVendorGitHub)
+	t1.Spec.Filter = "release-*"
+	t2 := getTriggerAsset(s1, "docker", types.TriggerSourceRegistry, types.TriggerVendorDocker)
+
+	github := []byte(`{"ref":"refs/heads/release-1","head_commit":{"id":"3fa2d1"}}`)
+	docker := []byte(`{"push_data":{"tag":"v1.2"},"repository":{"repo_name":"lastbackend/demo"}}`)
+	feature := []byte(fmt.Sprintf(`{"ref":"refs/heads/release-1:a+b@%s","head_commit":{"id":"3fa2d1"}}`, strings.Repeat("x", 200)))
+	invalid := []byte(`{"ref":"refs/heads/@{+}","head_commit":{"id":"3fa2d1"}}`)
+
+	tests := []struct {
+		name         string
+		url          string
+		payload      []byte
+		headers      map[string]string
+		err          string
+		want         string
+		wantErr      bool
+		expectedCode int
+	}{
+		{
+			name:         "checking execute hook if trigger not found",
+			url:          "/hook/demo:demo:gitlab",
+			payload:      github,
+			err:          "{\"code\":404,\"status\":\"Not Found\",\"message\":\"Trigger not found\"}",
+			wantErr:      true,
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "checking execute hook if signature not valid",
+			url:          fmt.Sprintf("/hook/%s", t1.SelfLink()),
+			payload:      github,
+			headers:      map[string]string{"X-Hub-Signature": "sha1=0000"},
+			err:          "{\"code\":403,\"status\":\"Forbidden\",\"message\":\"Forbidden\"}",
+			wantErr:      true,
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name:         "checking execute hook if branch not match filter",
+			url:          fmt.Sprintf("/hook/%s", t1.SelfLink()),
+			payload:      []byte(`{"ref":"refs/heads/master","head_commit":{"id":"3fa2d1"}}`),
+			headers:      map[string]string{"X-Hub-Signature": sign([]byte(`{"ref":"refs/heads/master","head_commit":{"id":"3fa2d1"}}`))},
+			want:         "lastbackend/demo:latest",
+			wantErr:      false,
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "checking execute vcs hook successfully",
+			url:          fmt.Sprintf("/hook/%s", t1.SelfLink()),
+			payload:      github,
+			headers:      map[string]string{"X-Hub-Signature": sign(github)},
+			want:         "lastbackend/demo:release-1",
+			wantErr:      false,
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "checking execute vcs hook with branch not valid as image tag",
+			url:          fmt.Sprintf("/hook/%s", t1.SelfLink()),
+			payload:      feature,
+			headers:      map[string]string{"X-Hub-Signature": sign(feature)},
+			want:         fmt.Sprintf("lastbackend/demo:release-1-a-b-%s", strings.Repeat("x", 114)),
+			wantErr:      false,
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "checking execute vcs hook if branch can not be used as image tag",
+			url:          fmt.Sprintf("/hook/%s", t1.SelfLink()),
+			payload:      invalid,
+			headers:      map[string]string{"X-Hub-Signature": sign(invalid)},
+			err:          "{\"code\":400,\"status\":\"Bad Request\",\"message\":\"payload is not valid\"}",
+			wantErr:      true,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "checking execute registry hook successfully",
+			url:          fmt.Sprintf("/hook/%s?secret=secret", t2.SelfLink()),
+			payload:      docker,
+			want:         "lastbackend/demo:v1.2",
+			wantErr:      false,
+			expectedCode: http.StatusOK,
+		},
+	}
+
+	clear := func() {
+		err := stg.Del(context.Background(), stg.Collection().Namespace(), types.EmptyString)
+		assert.NoError(t, err)
+
+		err = stg.Del(context.Background(), stg.Collection().Service(), types.EmptyString)
+		assert.NoError(t, err)
+
+		err = stg.Del(context.Background(), stg.Collection().Trigger(), types.EmptyString)
+		assert.NoError(t, err)
+	}
+
+	for _, tc := range tests {
+
+		t.Run(tc.name, func(t *testing.T) {
+
+			clear()
+			defer clear()
+
+			err := stg.Put(context.Background(), stg.Collection().Namespace(), stg.Key().Namespace(ns1.Meta.Name), ns1, nil)
+			assert.NoError(t, err)
+
+			err = stg.Put(context.Background(), stg.Collection().Service(), stg.Key().Service(s1.Meta.Namespace, s1.Meta.Name), s1, nil)
+			assert.NoError(t, err)
+
+			for _, tr := range []*types.Trigger{t1, t2} {
+				err = stg.Put(context.Background(), stg.Collection().Trigger(), stg.Key().Trigger(tr.Meta.Namespace, tr.Meta.Service, tr.Meta.Name), tr, nil)
+				assert.NoError(t, err)
+			}
+
+			req, err := http.NewRequest("POST", tc.url, bytes.NewReader(tc.payload))
+			assert.NoError(t, err)
+
+			for k, v := range tc.headers {
+				req.Header.Set(k, v)
+			}
+
+			r := mux.NewRouter()
+			r.HandleFunc("/hook/{id}", trigger.HookExecuteH)
+
+			setRequestVars(r, req)
+
+			res := httptest.NewRecorder()
+			r.ServeHTTP(res, req)
+
+			assert.Equal(t, tc.expectedCode, res.Code, "status code not equal")
+
+			body, err := ioutil.ReadAll(res.Body)
+			assert.NoError(t, err)
+
+			if tc.wantErr {
+				assert.Equal(t, tc.err, string(body), "incorrect status code")
+				return
+			}
+
+			got := new(types.Service)
+			err = stg.Get(context.Background(), stg.Collection().Service(), stg.Key().Service(s1.Meta.Namespace, s1.Meta.Name), got, nil)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tc.want, got.Spec.Template.Containers[0].Image.Name, "service image not match")
+		})
+	}
+
+}
+
+func getNamespaceAsset(name, desc string) *types.Namespace {
+	var n = types.Namespace{}
+	n.Meta.SetDefault()
+	n.Meta.Name = name
+	n.Meta.Description = desc
+	n.Meta.Endpoint = fmt.Sprintf("%s", name)
+	return &n
+}
+
+func getServiceAsset(namespace, name, image string) *types.Service {
+	var s = types.Service{}
+	s.Meta.SetDefault()
+	s.Meta.Namespace = namespace
+	s.Meta.Name = name
+	s.Spec.Replicas = 1
+	s.Spec.Template.Containers = append(s.Spec.Template.Containers, &types.SpecTemplateContainer{
+		Name:  "demo",
+		Image: types.SpecTemplateContainerImage{Name: image},
+	})
+	return &s
+}
+
+func getTriggerAsset(svc *types.Service, name, source, vendor string) *types.Trigger {
+	var t = types.Trigger{}
+	t.Meta.SetDefault()
+	t.Meta.Namespace = svc.Meta.Namespace
+	t.Meta.Service = svc.Meta.Name
+	t.Meta.Name = name
+	t.Spec.Source = source
+	t.Spec.Vendor = vendor
+	t.Spec.Secret = "secret"
+	t.SelfLink()
+	return &t
+}
+
+func getTriggerOptions(name, source, vendor string) *request.TriggerCreateOptions {
+	opts := new(request.TriggerCreateOptions)
+	opts.Name = name
+	opts.Source = source
+	opts.Vendor = vendor
+	opts.Secret = "secret"
+	return opts
+}
+
+func sign(payload []byte) string {
+	mac := hmac.New(sha1.New, []byte("secret"))
+	mac.Write(payload)
+	return "sha1=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func setRequestVars(r *mux.Router, req *http.Request) {
+	var match mux.RouteMatch
+	// Take the request and match it
+	r.Match(req, &match)
+	// Push the variable onto the context
+	req = mux.SetURLVars(req, match.Vars)
+}
@@ -0,0 +1,198 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+
+package trigger
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"net/http"
+	"strings"
+
+	"github.com/lastbackend/lastbackend/pkg/distribution/types"
+	"github.com/lastbackend/lastbackend/pkg/vendors"
+	"github.com/lastbackend/lastbackend/pkg/vendors/docker"
+	"github.com/lastbackend/lastbackend/pkg/vendors/interfaces"
+)
+
+// imageTagMaxLength - docker image tag length limit
+const imageTagMaxLength = 128
+
+// hook - branch or tag and image pushed to registry parsed from hook payload,
+// tag is a ref converted into valid image tag
+type hook struct {
+	ref   string
+	tag   string
+	image string
+}
+
+// hookVerify checks that hook request is signed with trigger secret
+func hookVerify(t *types.Trigger, r *http.Request, body []byte) bool {
+
+	if t.Spec.Secret == types.EmptyString {
+		return false
+	}
+
+	switch t.Spec.Vendor {
+	case types.TriggerVendorGitHub:
+		if sign := r.Header.Get("X-Hub-Signature-256"); sign != types.EmptyString {
+			return hookSignatureValid(sha256.New, t.Spec.Secret, "sha256=", sign, body)
+		}
+		return hookSignatureValid(sha1.New, t.Spec.Secret, "sha1=", r.Header.Get("X-Hub-Signature"), body)
+	case types.TriggerVendorGitLab:
+		return hookSecretEqual(t.Spec.Secret, r.Header.Get("X-Gitlab-Token"))
+	default:
+		// bitbucket and docker hub do not sign hooks, secret should be passed in hook url
+		return hookSecretEqual(t.Spec.Secret, r.URL.Query().Get("secret"))
+	}
+}
+
+func hookSignatureValid(h func() hash.Hash, secret, prefix, sign string, body []byte) bool {
+
+	if !strings.HasPrefix(sign, prefix) {
+		return false
+	}
+
+	expected, err := hex.DecodeString(strings.TrimPrefix(sign, prefix))
+	if err != nil {
+		return false
+	}
+
+	mac := hmac.New(h, []byte(secret))
+	mac.Write(body)
+
+	return hmac.Equal(mac.Sum(nil), expected)
+}
+
+func hookSecretEqual(secret, value string) bool {
+	return subtle.ConstantTimeCompare([]byte(secret), []byte(value)) == 1
+}
+
+// hookParse returns pushed branch or tag from vendor hook payload
+func hookParse(t *types.Trigger, body []byte) (*hook, error) {
+
+	var (
+		h   = new(hook)
+		vcs interfaces.IVCS
+	)
+
+	switch t.Spec.Vendor {
+	case types.TriggerVendorGitHub:
+		vcs = vendors.GetGitHub(types.EmptyString)
+	case types.TriggerVendorGitLab:
+		vcs = vendors.GetGitLab(types.EmptyString)
+	case types.TriggerVendorBitBucket:
+		vcs = vendors.GetBitBucket(types.EmptyString)
+	case types.TriggerVendorDocker:
+		push, err := docker.PushPayload(body)
+		if err != nil {
+			return nil, err
+		}
+		h.ref = push.Tag
+		h.image = push.Repository
+	default:
+		return nil, errors.New("unsupported trigger vendor")
+	}
+
+	if vcs != nil {
+		branch, err := vcs.PushPayload(body)
+		if err != nil {
+			return nil, err
+		}
+		h.ref = branch.Name
+	}
+
+	if h.ref == types.EmptyString {
+		return nil, errors.New("branch or tag not found in payload")
+	}
+
+	h.tag = imageTag(h.ref)
+	if h.tag == types.EmptyString {
+		return nil, fmt.Errorf("branch or tag `%s` can not be used as image tag", h.ref)
+	}
+
+	return h, nil
+}
+
+// hookApply sets pushed image or branch tag to trigger containers
+// and returns false if no service container was changed
+func hookApply(t *types.Trigger, svc *types.Service, h *hook) bool {
+
+	var updated bool
+
+	for _, c := range svc.Spec.Template.Containers {
+
+		if t.Spec.Container != types.EmptyString && c.Name != t.Spec.Container {
+			continue
+		}
+
+		repo := imageRepository(c.Image.Name)
+
+		// registry hook updates only containers running pushed repository
+		if h.image != types.EmptyString && t.Spec.Container == types.EmptyString && repo != h.image {
+			continue
+		}
+
+		if h.image != types.EmptyString {
+			repo = h.image
+		}
+
+		c.Image.Name = fmt.Sprintf("%s:%s", repo, h.tag)
+		updated = true
+	}
+
+	return updated
+}
+
+// imageTag converts branch or tag name into image tag: characters other than
+// [A-Za-z0-9_.-] are replaced with dash, tag can not start with dot or dash
+// and is trimmed to 128 characters
+func imageTag(ref string) string {
+
+	tag := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '_', r == '.', r == '-':
+			return r
+		default:
+			return '-'
+		}
+	}, ref)
+
+	tag = strings.TrimLeft(tag, ".-")
+	if len(tag) > imageTagMaxLength {
+		tag = tag[:imageTagMaxLength]
+	}
+
+	return tag
+}
+
+// imageRepository returns image name without tag
+func imageRepository(image string) string {
+
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		return image[:i]
+	}
+
+	return image
+}
@@ -24,5 +24,13 @@ import (
 )
 
 var Routes = []http.Route{
+	// Trigger handlers
+	{Path: "/namespace/{namespace}/service/{service}/trigger", Method: http.MethodPost, Middleware: []http.Middleware{middleware.Authenticate}, Handler: TriggerCreateH},
+	{Path: "/namespace/{namespace}/service/{service}/trigger", Method: http.MethodGet, Middleware: []http.Middleware{middleware.Authenticate}, Handler: TriggerListH},
+	{Path: "/namespace/{namespace}/service/{service}/trigger/{trigger}", Method: http.MethodGet, Middleware: []http.Middleware{middleware.Authenticate}, Handler: TriggerInfoH},
+	{Path: "/namespace/{namespace}/service/{service}/trigger/{trigger}", Method: http.MethodPut, Middleware: []http.Middleware{middleware.Authenticate}, Handler: TriggerUpdateH},
+	{Path: "/namespace/{namespace}/service/{service}/trigger/{trigger}", Method: http.MethodDelete, Middleware: []http.Middleware{middleware.Authenticate}, Handler: TriggerRemoveH},
+
+	// Hook handlers
 	{Path: "/hook/{id}", Method: http.MethodPost, Middleware: []http.Middleware{middleware.Context}, Handler: HookExecuteH},
 }
@@ -307,6 +307,8 @@ func VolumeUpdateH(w http.ResponseWriter, r *http.Request) {
 	//     description: Bad rules parameter
 	//   '404':
 	//     description: Namespace not found / Volume not found
+	//   '409':
+	//     description: Volume was changed since revision
 	//   '500':
 	//     description: Internal server error
 
@@ -358,7 +360,12 @@ func VolumeUpdateH(w http.ResponseWriter, r *http.Request) {
 
 	if err = rm.Update(rs); err != nil {
 		log.V(logLevel).Errorf("%s:update:> update volume `%s` err: %s", logPrefix, ns.Meta.Name, err.Error())
+		if errors.Storage().IsErrEntityRevision(err) {
+			errors.New("volume").Conflict().Http(w)
+			return
+		}
 		errors.HTTP.InternalServerError(w)
+		return
 	}
 
 	response, err := v1.View().Volume().New(rs).ToJson()
@@ -20,10 +20,17 @@ package runtime
 
 import (
 	"context"
+	"strings"
 
 	"github.com/lastbackend/lastbackend/pkg/api/envs"
 	"github.com/lastbackend/lastbackend/pkg/distribution"
 	"github.com/lastbackend/lastbackend/pkg/distribution/types"
+	"github.com/lastbackend/lastbackend/pkg/log"
+	"github.com/lastbackend/lastbackend/pkg/util/crypto"
+)
+
+const (
+	logPrefix = "api:runtime"
 )
 
 type Runtime struct {
@@ -43,6 +50,8 @@ func (r *Runtime) Run() {
 	go r.subnetManifestWatch(ctx, nil)
 
 	go r.secretWatch(ctx, nil)
+	go r.configWatch(ctx, nil)
+	go r.buildWatch(ctx, nil)
 	go r.nodeWatch(ctx, nil)
 	go r.ingressWatch(ctx, nil)
 
@@ -89,6 +98,7 @@ func (r *Runtime) podManifestWatch(ctx context.Context, rev *int64) {
 				}
 
 				c.Node().SetPodManifest(w.Node, w.SelfLink, w.Data)
+				secretsSet(ctx, w.Node, w.Data.GetSecrets(w.SelfLink))
 			}
 		}
 	}()
@@ -218,18 +228,108 @@ func (r *Runtime) secretWatch(ctx context.Context, rev *int64) {
 					continue
 				}
 
+				if w.IsActionRemove() {
+					c.Node().DelSecretManifest(w.Data.SelfLink())
+					continue
+				}
+
+				nodes, err := secretNodes(ctx, w.Data.SelfLink())
+				if err != nil {
+					log.Errorf("%s:secret:> get nodes for secret `%s` err: %s", logPrefix, w.Data.SelfLink(), err.Error())
+					continue
+				}
+
+				if len(nodes) == 0 {
+					continue
+				}
+
+				data, err := crypto.DecryptMap(envs.Get().GetSecretKeyProvider(), w.Data.SelfLink(), w.Data.Data)
+				if err != nil {
+					log.Errorf("%s:secret:> decrypt secret `%s` data err: %s", logPrefix, w.Data.SelfLink(), err.Error())
+					continue
+				}
+
 				sm := new(types.SecretManifest)
-				sm.Kind = w.Data.Meta.Kind
-				sm.Created = w.Data.Meta.Created
-				sm.Updated = w.Data.Meta.Updated
-				sm.State = types.StateUpdated
+				sm.Set(w.Data, data)
+
+				for _, node := range nodes {
+					c.Node().SetSecretManifest(node, w.Data.SelfLink(), sm)
+				}
+			}
+		}
+	}()
+
+	mm.Watch(n, rev)
+}
+
+func (r *Runtime) configWatch(ctx context.Context, rev *int64) {
+
+	var (
+		n = make(chan types.ConfigEvent)
+		c = envs.Get().GetCache()
+	)
+
+	mm := distribution.NewConfigModel(ctx, envs.Get().GetStorage())
+
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case w := <-n:
+
+				if w.Data == nil {
+					continue
+				}
+
+				cm := new(types.ConfigManifest)
+				cm.Set(w.Data)
+
+				if w.IsActionRemove() {
+					cm.State = types.StateDestroyed
+				}
+
+				c.Node().SetConfigManifest(w.Data.SelfLink(), cm)
+			}
+		}
+	}()
+
+	mm.Watch(n, rev)
+}
+
+func (r *Runtime) buildWatch(ctx context.Context, rev *int64) {
+
+	// Watch builds change to send them to builder nodes
+	var (
+		n = make(chan types.BuildEvent)
+		c = envs.Get().GetCache()
+	)
+
+	mm := distribution.NewBuildModel(ctx, envs.Get().GetStorage())
+
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case w := <-n:
+
+				if w.Data == nil || w.Data.Status.Node == types.EmptyString {
+					continue
+				}
+
+				bm := new(types.BuildManifest)
+				bm.Set(w.Data)
 
 				if w.IsActionRemove() {
-					sm := new(types.SecretManifest)
-					sm.State = types.StateDestroyed
+					bm.State = types.StateDestroyed
 				}
 
-				c.Node().SetSecretManifest(w.Data.Meta.Name, sm)
+				c.Node().SetBuildManifest(w.Data.Status.Node, w.Data.SelfLink(), bm)
+
+				if !w.IsActionRemove() && bm.Image.Secret != types.EmptyString {
+					secretsSet(ctx, w.Data.Status.Node, []string{new(types.Secret).CreateSelfLink(w.Data.Meta.Namespace, bm.Image.Secret)})
+				}
 			}
 		}
 	}()
@@ -378,3 +478,89 @@ func (r *Runtime) routeWatch(ctx context.Context, rev *int64) {
 
 	im.Watch(n, rev)
 }
+
+// secretsSet sends secrets used by pod or build to the node
+func secretsSet(ctx context.Context, node string, secrets []string) {
+
+	var (
+		c  = envs.Get().GetCache()
+		sm = distribution.NewSecretModel(ctx, envs.Get().GetStorage())
+	)
+
+	for _, link := range secrets {
+
+		parts := strings.SplitN(link, ":", 2)
+		if len(parts) != 2 {
+			continue
+		}
+
+		s, err := sm.Get(parts[0], parts[1])
+		if err != nil {
+			log.Errorf("%s:secret:> get secret `%s` err: %s", logPrefix, link, err.Error())
+			continue
+		}
+		if s == nil {
+			continue
+		}
+
+		data, err := crypto.DecryptMap(envs.Get().GetSecretKeyProvider(), s.SelfLink(), s.Data)
+		if err != nil {
+			log.Errorf("%s:secret:> decrypt secret `%s` data err: %s", logPrefix, s.SelfLink(), err.Error())
+			continue
+		}
+
+		m := new(types.SecretManifest)
+		m.Set(s, data)
+		c.Node().SetSecretManifest(node, s.SelfLink(), m)
+	}
+}
+
+// secretNodes returns nodes with pods or builds using the secret
+func secretNodes(ctx context.Context, secret string) ([]string, error) {
+
+	var (
+		stg   = envs.Get().GetStorage()
+		nm    = distribution.NewNodeModel(ctx, stg)
+		pm    = distribution.NewPodModel(ctx, stg)
+		bm    = distribution.NewBuildModel(ctx, stg)
+		nodes = make([]string, 0)
+	)
+
+	nl, err := nm.List()
+	if err != nil {
+		return nil, err
+	}
+
+	bl, err := bm.List()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, n := range nl.Items {
+
+		pods, err := pm.ManifestMap(n.Meta.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		spec := new(types.NodeManifest)
+		spec.Pods = pods.Items
+		spec.Builds = make(map[string]*types.BuildManifest, 0)
+
+		for _, b := range bl.Items {
+			if b.Status.Node != n.SelfLink() || b.Done() {
+				continue
+			}
+
+			m := new(types.BuildManifest)
+			m.Set(b)
+			spec.Builds[b.SelfLink()] = m
+		}
+
+		if spec.GetSecrets()[secret] {
+			nodes = append(nodes, n.Meta.Name)
+		}
+	}
+
+	return nodes, nil
+}
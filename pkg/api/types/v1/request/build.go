@@ -0,0 +1,35 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+
+package request
+
+// swagger:model request_build_create
+type BuildCreateOptions struct {
+	Name       string `json:"name,omitempty"`
+	Url        string `json:"url"`
+	Ref        string `json:"ref,omitempty"`
+	Dockerfile string `json:"dockerfile,omitempty"`
+	Image      string `json:"image"`
+	Secret     string `json:"secret,omitempty"`
+	Service    string `json:"service,omitempty"`
+	Container  string `json:"container,omitempty"`
+}
+
+type BuildRemoveOptions struct {
+	Force bool
+}
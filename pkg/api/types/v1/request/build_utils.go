@@ -0,0 +1,110 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+
+package request
+
+import (
+	"encoding/json"
+	"io"
+	"io/ioutil"
+
+	"github.com/lastbackend/lastbackend/pkg/distribution/errors"
+	"github.com/lastbackend/lastbackend/pkg/distribution/types"
+	"github.com/lastbackend/lastbackend/pkg/util/validator"
+)
+
+const (
+	defaultBuildRef        = "master"
+	defaultBuildDockerfile = "Dockerfile"
+)
+
+type BuildRequest struct{}
+
+func (BuildRequest) CreateOptions() *BuildCreateOptions {
+	return new(BuildCreateOptions)
+}
+
+func (b *BuildCreateOptions) Validate() *errors.Err {
+	switch true {
+	case b.Name != types.EmptyString && !validator.IsServiceName(b.Name):
+		return errors.New("build").BadParameter("name")
+	case !validator.IsGitUrl(b.Url):
+		return errors.New("build").BadParameter("url")
+	case b.Image == types.EmptyString:
+		return errors.New("build").BadParameter("image")
+	case b.Container != types.EmptyString && b.Service == types.EmptyString:
+		return errors.New("build").BadParameter("service")
+	}
+	return nil
+}
+
+func (b *BuildCreateOptions) DecodeAndValidate(reader io.Reader) *errors.Err {
+
+	if reader == nil {
+		err := errors.New("data body can not be null")
+		return errors.New("build").IncorrectJSON(err)
+	}
+
+	body, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return errors.New("build").Unknown(err)
+	}
+
+	err = json.Unmarshal(body, b)
+	if err != nil {
+		return errors.New("build").IncorrectJSON(err)
+	}
+
+	return b.Validate()
+}
+
+func (b *BuildCreateOptions) ToJson() ([]byte, error) {
+	return json.Marshal(b)
+}
+
+func (b *BuildCreateOptions) SetBuildMeta(build *types.Build) {
+	build.Meta.Name = b.Name
+}
+
+func (b *BuildCreateOptions) SetBuildSpec(build *types.Build) {
+
+	build.Spec.Source.Url = b.Url
+	build.Spec.Source.Ref = b.Ref
+	build.Spec.Source.Dockerfile = b.Dockerfile
+
+	if build.Spec.Source.Ref == types.EmptyString {
+		build.Spec.Source.Ref = defaultBuildRef
+	}
+
+	if build.Spec.Source.Dockerfile == types.EmptyString {
+		build.Spec.Source.Dockerfile = defaultBuildDockerfile
+	}
+
+	build.Spec.Image.Name = b.Image
+	build.Spec.Image.Secret = b.Secret
+	build.Spec.Service = b.Service
+	build.Spec.Container = b.Container
+}
+
+func (BuildRequest) RemoveOptions() *BuildRemoveOptions {
+	return new(BuildRemoveOptions)
+}
+
+func (b *BuildRemoveOptions) Validate() *errors.Err {
+	return nil
+}
@@ -19,6 +19,7 @@
 package request
 
 import (
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"github.com/lastbackend/lastbackend/pkg/distribution/types"
@@ -26,6 +27,7 @@ import (
 	"io/ioutil"
 )
 
+// swagger:model request_config_manifest
 type ConfigManifest struct {
 	Meta ConfigManifestMeta `json:"meta,omitempty" yaml:"meta,omitempty"`
 	Spec ConfigManifestSpec `json:"spec,omitempty" yaml:"spec,omitempty"`
@@ -65,36 +67,65 @@ func (v *ConfigManifest) ToYaml() ([]byte, error) {
 	return yaml.Marshal(v)
 }
 
-func (v *ConfigManifest) SetConfigMeta(vol *types.Config) {
+func (v *ConfigManifest) SetConfigMeta(cfg *types.Config) {
 
-	if vol.Meta.Name == types.EmptyString {
-		vol.Meta.Name = *v.Meta.Name
+	if cfg.Meta.Name == types.EmptyString {
+		cfg.Meta.Name = *v.Meta.Name
 	}
 
 	if v.Meta.Description != nil {
-		vol.Meta.Description = *v.Meta.Description
+		cfg.Meta.Description = *v.Meta.Description
 	}
 
 	if v.Meta.Labels != nil {
-		vol.Meta.Labels = v.Meta.Labels
+		cfg.Meta.Labels = v.Meta.Labels
 	}
 
-}
+	if v.Meta.Revision != nil {
+		cfg.System.Revision = *v.Meta.Revision
+	}
 
-func (v *ConfigManifest) SetConfigSpec(vol *types.Config) {
+}
 
+func (v *ConfigManifest) SetConfigSpec(cfg *types.Config) {
+	cfg.Meta.Kind = v.getKind()
+	cfg.Data = v.getData()
 }
 
 func (v *ConfigManifest) GetManifest() *types.ConfigManifest {
 	cfg := new(types.ConfigManifest)
-	cfg.Kind = v.Spec.Type
-	cfg.Data = make(map[string][]byte, 0)
-	for _, data := range v.Spec.Data {
-		cfg.Data[data.Key] = data.Data
-	}
+	cfg.Kind = v.getKind()
+	cfg.Data = v.getData()
 	return cfg
 }
 
+func (v *ConfigManifest) getKind() string {
+	if v.Spec.Type == types.EmptyString {
+		return types.KindConfigText
+	}
+	return v.Spec.Type
+}
+
+// getData returns config data: text values are stored base64 encoded,
+// files are stored as is
+func (v *ConfigManifest) getData() map[string][]byte {
+	data := make(map[string][]byte, 0)
+	for _, d := range v.Spec.Data {
+		if v.getKind() == types.KindConfigText {
+			data[d.Key] = []byte(base64.StdEncoding.EncodeToString([]byte(d.Value)))
+			continue
+		}
+
+		if len(d.Data) == 0 {
+			data[d.Key] = []byte(d.Value)
+			continue
+		}
+
+		data[d.Key] = d.Data
+	}
+	return data
+}
+
 func (v *ConfigManifest) ReadData() error {
 	for _, f := range v.Spec.Data {
 		if f.File != types.EmptyString {
@@ -24,6 +24,7 @@ import (
 	"io/ioutil"
 
 	"github.com/lastbackend/lastbackend/pkg/distribution/errors"
+	"github.com/lastbackend/lastbackend/pkg/distribution/types"
 )
 
 type ConfigRequest struct{}
@@ -33,6 +34,19 @@ func (ConfigRequest) Manifest() *ConfigManifest {
 }
 
 func (v *ConfigManifest) Validate() *errors.Err {
+	switch true {
+	case v.Meta.Name == nil || *v.Meta.Name == types.EmptyString:
+		return errors.New("config").BadParameter("name")
+	case v.Spec.Type != types.EmptyString && v.Spec.Type != types.KindConfigText && v.Spec.Type != types.KindConfigFile:
+		return errors.New("config").BadParameter("type")
+	}
+
+	for _, d := range v.Spec.Data {
+		if d.Key == types.EmptyString {
+			return errors.New("config").BadParameter("data")
+		}
+	}
+
 	return nil
 }
 
@@ -40,22 +54,17 @@ func (v *ConfigManifest) DecodeAndValidate(reader io.Reader) *errors.Err {
 
 	if reader == nil {
 		err := errors.New("data body can not be null")
-		return errors.New("volume").IncorrectJSON(err)
-	}
-
-	if reader == nil {
-		err := errors.New("data body can not be null")
-		return errors.New("volume").IncorrectJSON(err)
+		return errors.New("config").IncorrectJSON(err)
 	}
 
 	body, err := ioutil.ReadAll(reader)
 	if err != nil {
-		return errors.New("volume").Unknown(err)
+		return errors.New("config").Unknown(err)
 	}
 
 	err = json.Unmarshal(body, v)
 	if err != nil {
-		return errors.New("volume").IncorrectJSON(err)
+		return errors.New("config").IncorrectJSON(err)
 	}
 
 	return v.Validate()
@@ -31,4 +31,7 @@ type DeploymentUpdateOptions struct {
 		State   string `json:"state"`
 		Message string `json:"message"`
 	} `json:"status"`
+	// Deployment storage revision, deployment is updated only if it was not changed since this revision
+	// required: false
+	Revision *int64 `json:"revision,omitempty"`
 }
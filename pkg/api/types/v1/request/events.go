@@ -17,3 +17,25 @@
 //
 
 package request
+
+// swagger:ignore
+type EventListOptions struct {
+	// Involved object kind
+	Kind string
+	// Involved object self link
+	Object string
+	// Event reason
+	Reason string
+	// Event severity
+	Severity string
+}
+
+// swagger:ignore
+type EventSubscribeOptions struct {
+	// Entity kinds to subscribe, all entities if empty
+	Entity []string
+	// Namespace to subscribe
+	Namespace string
+	// Entity name to subscribe
+	Name string
+}
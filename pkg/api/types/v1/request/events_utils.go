@@ -18,4 +18,86 @@
 
 package request
 
+import (
+	"net/url"
+	"strings"
+
+	"github.com/lastbackend/lastbackend/pkg/distribution/types"
+)
+
 type EventsRequest struct{}
+
+func (EventsRequest) ListOptions() *EventListOptions {
+	return new(EventListOptions)
+}
+
+func (EventsRequest) SubscribeOptions() *EventSubscribeOptions {
+	return new(EventSubscribeOptions)
+}
+
+// DecodeQuery reads list filters from request query
+func (e *EventListOptions) DecodeQuery(q url.Values) {
+	e.Kind = q.Get("kind")
+	e.Object = q.Get("object")
+	e.Reason = q.Get("reason")
+	e.Severity = q.Get("severity")
+}
+
+// Match checks that event record passes list filters
+func (e *EventListOptions) Match(event *types.EventRecord) bool {
+	switch true {
+	case e.Kind != types.EmptyString && e.Kind != event.Object.Kind:
+		return false
+	case e.Object != types.EmptyString && e.Object != event.Object.SelfLink:
+		return false
+	case e.Reason != types.EmptyString && e.Reason != event.Reason:
+		return false
+	case e.Severity != types.EmptyString && e.Severity != event.Severity:
+		return false
+	}
+	return true
+}
+
+// DecodeQuery reads subscription filters from request query
+// entity can be passed many times or as comma separated list
+func (e *EventSubscribeOptions) DecodeQuery(q url.Values) {
+	e.Entity = make([]string, 0)
+	for _, v := range q["entity"] {
+		for _, kind := range strings.Split(v, ",") {
+			if kind = strings.TrimSpace(kind); kind != types.EmptyString {
+				e.Entity = append(e.Entity, kind)
+			}
+		}
+	}
+	e.Namespace = q.Get("namespace")
+	e.Name = q.Get("name")
+}
+
+// Subscribed checks that entity kind is subscribed
+func (e *EventSubscribeOptions) Subscribed(entity string) bool {
+	if len(e.Entity) == 0 {
+		return true
+	}
+
+	for _, kind := range e.Entity {
+		if kind == entity {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Match checks that event passes subscription filters
+// cluster events have no namespace and are skipped if namespace filter is set
+func (e *EventSubscribeOptions) Match(entity, namespace, name string) bool {
+	switch true {
+	case !e.Subscribed(entity):
+		return false
+	case e.Namespace != types.EmptyString && e.Namespace != namespace:
+		return false
+	case e.Name != types.EmptyString && e.Name != name:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,246 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+
+package request
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/lastbackend/lastbackend/pkg/distribution/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEventSubscribeOptionsDecodeQuery(t *testing.T) {
+
+	var tests = []struct {
+		name  string
+		query string
+		want  *EventSubscribeOptions
+	}{
+		{
+			name:  "check empty query",
+			query: "",
+			want:  &EventSubscribeOptions{Entity: []string{}},
+		},
+		{
+			name:  "check entities passed many times",
+			query: "entity=service&entity=pod",
+			want:  &EventSubscribeOptions{Entity: []string{"service", "pod"}},
+		},
+		{
+			name:  "check entities passed as comma separated list",
+			query: "entity=service,%20pod,deployment",
+			want:  &EventSubscribeOptions{Entity: []string{"service", "pod", "deployment"}},
+		},
+		{
+			name:  "check empty entity values are skipped",
+			query: "entity=&entity=,,%20,&entity=pod,",
+			want:  &EventSubscribeOptions{Entity: []string{"pod"}},
+		},
+		{
+			name:  "check namespace and name filters",
+			query: "namespace=demo&name=demo:redis&entity=service",
+			want:  &EventSubscribeOptions{Entity: []string{"service"}, Namespace: "demo", Name: "demo:redis"},
+		},
+		{
+			name:  "check first namespace value is used",
+			query: "namespace=demo&namespace=test",
+			want:  &EventSubscribeOptions{Entity: []string{}, Namespace: "demo"},
+		},
+		{
+			name:  "check unknown parameters are ignored",
+			query: "kind=pod&filter=demo",
+			want:  &EventSubscribeOptions{Entity: []string{}},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+
+			q, err := url.ParseQuery(tc.query)
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			opts := new(EventSubscribeOptions)
+			opts.DecodeQuery(q)
+
+			assert.Equal(t, tc.want, opts, "options not equal")
+		})
+	}
+}
+
+func TestEventSubscribeOptionsMatch(t *testing.T) {
+
+	type args struct {
+		entity    string
+		namespace string
+		name      string
+	}
+
+	var tests = []struct {
+		name       string
+		opts       *EventSubscribeOptions
+		args       args
+		subscribed bool
+		want       bool
+	}{
+		{
+			name:       "check empty filter matches any event",
+			opts:       &EventSubscribeOptions{},
+			args:       args{types.KindPod, "demo", "demo:redis:redis-1:pod"},
+			subscribed: true,
+			want:       true,
+		},
+		{
+			name:       "check empty filter matches cluster event",
+			opts:       &EventSubscribeOptions{},
+			args:       args{types.KindCluster, types.EmptyString, "cluster"},
+			subscribed: true,
+			want:       true,
+		},
+		{
+			name:       "check subscribed entity kind",
+			opts:       &EventSubscribeOptions{Entity: []string{types.KindService, types.KindPod}},
+			args:       args{types.KindPod, "demo", "demo:redis:redis-1:pod"},
+			subscribed: true,
+			want:       true,
+		},
+		{
+			name:       "check not subscribed entity kind",
+			opts:       &EventSubscribeOptions{Entity: []string{types.KindService}},
+			args:       args{types.KindPod, "demo", "demo:redis:redis-1:pod"},
+			subscribed: false,
+			want:       false,
+		},
+		{
+			name:       "check namespace match",
+			opts:       &EventSubscribeOptions{Namespace: "demo"},
+			args:       args{types.KindService, "demo", "demo:redis"},
+			subscribed: true,
+			want:       true,
+		},
+		{
+			name:       "check namespace mismatch",
+			opts:       &EventSubscribeOptions{Namespace: "demo"},
+			args:       args{types.KindService, "test", "test:redis"},
+			subscribed: true,
+			want:       false,
+		},
+		{
+			name:       "check cluster event skipped with namespace filter",
+			opts:       &EventSubscribeOptions{Namespace: "demo"},
+			args:       args{types.KindCluster, types.EmptyString, "cluster"},
+			subscribed: true,
+			want:       false,
+		},
+		{
+			name:       "check name match",
+			opts:       &EventSubscribeOptions{Namespace: "demo", Name: "demo:redis"},
+			args:       args{types.KindService, "demo", "demo:redis"},
+			subscribed: true,
+			want:       true,
+		},
+		{
+			name:       "check name mismatch",
+			opts:       &EventSubscribeOptions{Name: "demo:redis"},
+			args:       args{types.KindService, "demo", "demo:nginx"},
+			subscribed: true,
+			want:       false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.subscribed, tc.opts.Subscribed(tc.args.entity), "subscribed not equal")
+			assert.Equal(t, tc.want, tc.opts.Match(tc.args.entity, tc.args.namespace, tc.args.name), "match not equal")
+		})
+	}
+}
+
+func TestEventListOptionsMatch(t *testing.T) {
+
+	event := &types.EventRecord{
+		Object:   types.EventObject{Kind: types.KindPod, SelfLink: "demo:redis:redis-1:pod"},
+		Reason:   "Failed",
+		Severity: "warning",
+	}
+
+	var tests = []struct {
+		name  string
+		query string
+		want  bool
+	}{
+		{
+			name:  "check empty filter",
+			query: "",
+			want:  true,
+		},
+		{
+			name:  "check kind match",
+			query: "kind=pod",
+			want:  true,
+		},
+		{
+			name:  "check kind mismatch",
+			query: "kind=service",
+			want:  false,
+		},
+		{
+			name:  "check object self link match",
+			query: "kind=pod&object=demo:redis:redis-1:pod",
+			want:  true,
+		},
+		{
+			name:  "check object self link mismatch",
+			query: "object=demo:redis:redis-2:pod",
+			want:  false,
+		},
+		{
+			name:  "check reason and severity match",
+			query: "reason=Failed&severity=warning",
+			want:  true,
+		},
+		{
+			name:  "check severity mismatch",
+			query: "reason=Failed&severity=normal",
+			want:  false,
+		},
+		{
+			name:  "check empty values are not used as filters",
+			query: "kind=&object=&reason=&severity=",
+			want:  true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+
+			q, err := url.ParseQuery(tc.query)
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			opts := new(EventListOptions)
+			opts.DecodeQuery(q)
+
+			assert.Equal(t, tc.want, opts.Match(event), "match not equal")
+		})
+	}
+}
@@ -24,7 +24,21 @@ import (
 )
 
 type ManifestSpecSelector struct {
-	Node   string            `json:"node,omitempty" yaml:"node,omitempty"`
+	Node     string                        `json:"node,omitempty" yaml:"node,omitempty"`
+	Labels   map[string]string             `json:"labels,omitempty" yaml:"labels,omitempty"`
+	Affinity *ManifestSpecSelectorAffinity `json:"affinity,omitempty" yaml:"affinity,omitempty"`
+}
+
+type ManifestSpecSelectorAffinity struct {
+	Node     []ManifestSpecSelectorAffinityTerm `json:"node,omitempty" yaml:"node,omitempty"`
+	NodeAnti []ManifestSpecSelectorAffinityTerm `json:"node_anti,omitempty" yaml:"node_anti,omitempty"`
+	Pod      []ManifestSpecSelectorAffinityTerm `json:"pod,omitempty" yaml:"pod,omitempty"`
+	PodAnti  []ManifestSpecSelectorAffinityTerm `json:"pod_anti,omitempty" yaml:"pod_anti,omitempty"`
+}
+
+type ManifestSpecSelectorAffinityTerm struct {
+	Type   string            `json:"type,omitempty" yaml:"type,omitempty"`
+	Weight int               `json:"weight,omitempty" yaml:"weight,omitempty"`
 	Labels map[string]string `json:"labels,omitempty" yaml:"labels,omitempty"`
 }
 
@@ -34,7 +48,24 @@ type ManifestSpecNetwork struct {
 }
 
 type ManifestSpecStrategy struct {
-	Type *string `json:"type,omitempty" yaml:"type,omitempty"`
+	Type            *string                      `json:"type,omitempty" yaml:"type,omitempty"`
+	Rolling         *ManifestSpecStrategyRolling `json:"rolling,omitempty" yaml:"rolling,omitempty"`
+	Deadline        *int                         `json:"deadline,omitempty" yaml:"deadline,omitempty"`
+	RestartOnChange *bool                        `json:"restart_on_change,omitempty" yaml:"restart_on_change,omitempty"`
+}
+
+type ManifestSpecStrategyRolling struct {
+	Interval       *int `json:"interval,omitempty" yaml:"interval,omitempty"`
+	Timeout        *int `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+	MaxUnavailable *int `json:"max_unavailable,omitempty" yaml:"max_unavailable,omitempty"`
+	MaxSurge       *int `json:"max_surge,omitempty" yaml:"max_surge,omitempty"`
+}
+
+type ManifestSpecAutoscaler struct {
+	MinReplicas int `json:"min_replicas,omitempty" yaml:"min_replicas,omitempty"`
+	MaxReplicas int `json:"max_replicas,omitempty" yaml:"max_replicas,omitempty"`
+	TargetCPU   int `json:"target_cpu,omitempty" yaml:"target_cpu,omitempty"`
+	TargetRAM   int `json:"target_ram,omitempty" yaml:"target_ram,omitempty"`
 }
 
 type ManifestSpecTemplate struct {
@@ -54,6 +85,7 @@ type ManifestSpecTemplateContainer struct {
 	Resources     ManifestSpecTemplateContainerResources `json:"resources,omitempty" yaml:"resources,omitempty"`
 	Volumes       []ManifestSpecTemplateContainerVolume  `json:"volumes,omitempty" yaml:"volumes,omitempty"`
 	RestartPolicy ManifestSpecTemplateRestartPolicy      `json:"restart,omitempty" yaml:"restart,omitempty"`
+	Probes        ManifestSpecTemplateContainerProbes    `json:"probes,omitempty" yaml:"probes,omitempty"`
 }
 
 type ManifestSpecTemplateContainerEnv struct {
@@ -95,6 +127,53 @@ type ManifestSpecTemplateContainerResource struct {
 	RAM int64 `json:"ram,omitempty" yaml:"ram,omitempty"`
 }
 
+type ManifestSpecTemplateContainerProbes struct {
+	LiveProbe *ManifestSpecTemplateContainerProbe `json:"live_probe,omitempty" yaml:"live_probe,omitempty"`
+	ReadProbe *ManifestSpecTemplateContainerProbe `json:"read_probe,omitempty" yaml:"read_probe,omitempty"`
+}
+
+type ManifestSpecTemplateContainerProbe struct {
+	// Exec command check
+	Exec *ManifestSpecTemplateContainerProbeExec `json:"exec,omitempty" yaml:"exec,omitempty"`
+	// Socket connection check
+	Socket *ManifestSpecTemplateContainerProbeSocket `json:"socket,omitempty" yaml:"socket,omitempty"`
+	// HTTP GET request check
+	HTTP *ManifestSpecTemplateContainerProbeHTTP `json:"http,omitempty" yaml:"http,omitempty"`
+
+	InitialDelaySeconds int `json:"initial_delay,omitempty" yaml:"initial_delay,omitempty"`
+	TimeoutSeconds      int `json:"timeout_seconds,omitempty" yaml:"timeout_seconds,omitempty"`
+	PeriodSeconds       int `json:"period_seconds,omitempty" yaml:"period_seconds,omitempty"`
+	ThresholdSuccess    int `json:"threshold_success,omitempty" yaml:"threshold_success,omitempty"`
+	ThresholdFailure    int `json:"threshold_failure,omitempty" yaml:"threshold_failure,omitempty"`
+}
+
+type ManifestSpecTemplateContainerProbeExec struct {
+	Command []string `json:"command,omitempty" yaml:"command,omitempty"`
+}
+
+type ManifestSpecTemplateContainerProbeSocket struct {
+	Protocol string `json:"protocol,omitempty" yaml:"protocol,omitempty"`
+	Port     int    `json:"port,omitempty" yaml:"port,omitempty"`
+}
+
+type ManifestSpecTemplateContainerProbeHTTP struct {
+	// Request path
+	Path string `json:"path,omitempty" yaml:"path,omitempty"`
+	// Container port
+	Port int `json:"port,omitempty" yaml:"port,omitempty"`
+	// Request scheme: http or https
+	Scheme string `json:"scheme,omitempty" yaml:"scheme,omitempty"`
+	// Request headers
+	Headers map[string]string `json:"headers,omitempty" yaml:"headers,omitempty"`
+	// Expected response status range
+	Status ManifestSpecTemplateContainerProbeHTTPStatus `json:"status,omitempty" yaml:"status,omitempty"`
+}
+
+type ManifestSpecTemplateContainerProbeHTTPStatus struct {
+	Min int `json:"min,omitempty" yaml:"min,omitempty"`
+	Max int `json:"max,omitempty" yaml:"max,omitempty"`
+}
+
 type ManifestSpecTemplateVolume struct {
 	// Template volume name
 	Name string `json:"name,omitempty" yaml:"name,omitempty"`
@@ -131,6 +210,112 @@ func (m ManifestSpecSelector) GetSpec() types.SpecSelector {
 	s.Node = m.Node
 	s.Labels = m.Labels
 
+	if m.Affinity != nil {
+		s.Affinity = m.Affinity.GetSpec()
+	}
+
+	return s
+}
+
+func (m ManifestSpecSelector) Valid() bool {
+
+	if m.Affinity == nil {
+		return true
+	}
+
+	var terms = make([]ManifestSpecSelectorAffinityTerm, 0)
+	terms = append(terms, m.Affinity.Node...)
+	terms = append(terms, m.Affinity.NodeAnti...)
+	terms = append(terms, m.Affinity.Pod...)
+	terms = append(terms, m.Affinity.PodAnti...)
+
+	for _, t := range terms {
+
+		switch t.Type {
+		case types.EmptyString, types.SpecAffinityTypeRequired, types.SpecAffinityTypePreferred:
+		default:
+			return false
+		}
+
+		if t.Weight < 0 || len(t.Labels) == 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (m ManifestSpecAutoscaler) GetSpec() types.SpecAutoscaler {
+	s := types.SpecAutoscaler{
+		MinReplicas: m.MinReplicas,
+		MaxReplicas: m.MaxReplicas,
+		TargetCPU:   m.TargetCPU,
+		TargetRAM:   m.TargetRAM,
+	}
+
+	if s.MaxReplicas > 0 && s.MinReplicas == 0 {
+		s.MinReplicas = 1
+	}
+
+	return s
+}
+
+// Valid checks that autoscaler replicas range is correct and utilisation target is set,
+// empty autoscaler disables automatic scaling
+func (m ManifestSpecAutoscaler) Valid() bool {
+
+	if m == (ManifestSpecAutoscaler{}) {
+		return true
+	}
+
+	if m.MinReplicas < 0 || m.MaxReplicas < 1 || m.MinReplicas > m.MaxReplicas {
+		return false
+	}
+
+	if m.TargetCPU < 0 || m.TargetRAM < 0 {
+		return false
+	}
+
+	return m.TargetCPU > 0 || m.TargetRAM > 0
+}
+
+func (m ManifestSpecSelectorAffinity) GetSpec() types.SpecSelectorAffinity {
+
+	var (
+		s     = types.SpecSelectorAffinity{}
+		terms = func(mt []ManifestSpecSelectorAffinityTerm) []types.SpecSelectorAffinityTerm {
+			var st = make([]types.SpecSelectorAffinityTerm, 0)
+			for _, t := range mt {
+				st = append(st, t.GetSpec())
+			}
+			return st
+		}
+	)
+
+	s.Node = terms(m.Node)
+	s.NodeAnti = terms(m.NodeAnti)
+	s.Pod = terms(m.Pod)
+	s.PodAnti = terms(m.PodAnti)
+
+	return s
+}
+
+func (m ManifestSpecSelectorAffinityTerm) GetSpec() types.SpecSelectorAffinityTerm {
+
+	var s = types.SpecSelectorAffinityTerm{
+		Type:   m.Type,
+		Weight: m.Weight,
+		Labels: m.Labels,
+	}
+
+	if s.Type == types.EmptyString {
+		s.Type = types.SpecAffinityTypeRequired
+	}
+
+	if s.Type == types.SpecAffinityTypePreferred && s.Weight == 0 {
+		s.Weight = 1
+	}
+
 	return s
 }
 
@@ -217,5 +402,168 @@ func (m ManifestSpecTemplateContainer) GetSpec() types.SpecTemplateContainer {
 		})
 	}
 
+	s.Probes = m.Probes.GetSpec()
+
 	return s
 }
+
+func (m ManifestSpecTemplateContainerProbes) GetSpec() types.SpecTemplateContainerProbes {
+	s := types.SpecTemplateContainerProbes{}
+
+	if m.LiveProbe != nil {
+		s.LiveProbe = m.LiveProbe.GetSpec()
+	}
+
+	if m.ReadProbe != nil {
+		s.ReadProbe = m.ReadProbe.GetSpec()
+	}
+
+	if s.LiveProbe.Exec.Command == nil {
+		s.LiveProbe.Exec.Command = make([]string, 0)
+	}
+
+	if s.ReadProbe.Exec.Command == nil {
+		s.ReadProbe.Exec.Command = make([]string, 0)
+	}
+
+	return s
+}
+
+func (m ManifestSpecTemplateContainerProbe) GetSpec() types.SpecTemplateContainerProbe {
+	s := types.SpecTemplateContainerProbe{}
+
+	if m.Exec != nil {
+		s.Exec.Command = m.Exec.Command
+	}
+
+	if m.Socket != nil {
+		s.Socket.Protocol = m.Socket.Protocol
+		s.Socket.Port = m.Socket.Port
+	}
+
+	if m.HTTP != nil {
+		s.HTTP.Path = m.HTTP.Path
+		s.HTTP.Port = m.HTTP.Port
+		s.HTTP.Scheme = m.HTTP.Scheme
+		s.HTTP.Headers = m.HTTP.Headers
+		s.HTTP.Status.Min = m.HTTP.Status.Min
+		s.HTTP.Status.Max = m.HTTP.Status.Max
+	}
+
+	s.InitialDelaySeconds = m.InitialDelaySeconds
+	s.TimeoutSeconds = m.TimeoutSeconds
+	s.PeriodSeconds = m.PeriodSeconds
+	s.ThresholdSuccess = m.ThresholdSuccess
+	s.ThresholdFailure = m.ThresholdFailure
+
+	return s
+}
+
+// Valid checks that cpu resources are set in millicores and are not less than minimal value
+func (m ManifestSpecTemplateContainerResources) Valid() bool {
+	for _, cpu := range []int64{m.Request.CPU, m.Limits.CPU} {
+		if cpu != 0 && cpu < DEFAULT_CPU_MIN {
+			return false
+		}
+	}
+	return true
+}
+
+// Valid checks that probe has exactly one check type with correct options
+func (m ManifestSpecTemplateContainerProbe) Valid() bool {
+
+	var checks = 0
+
+	if m.Exec != nil {
+		if len(m.Exec.Command) == 0 {
+			return false
+		}
+		checks++
+	}
+
+	if m.Socket != nil {
+		if m.Socket.Port <= 0 || m.Socket.Port > 65535 {
+			return false
+		}
+
+		switch m.Socket.Protocol {
+		case types.EmptyString, "tcp", "udp":
+		default:
+			return false
+		}
+		checks++
+	}
+
+	if m.HTTP != nil {
+		if m.HTTP.Port <= 0 || m.HTTP.Port > 65535 {
+			return false
+		}
+
+		if m.HTTP.Path != types.EmptyString && !strings.HasPrefix(m.HTTP.Path, "/") {
+			return false
+		}
+
+		switch m.HTTP.Scheme {
+		case types.EmptyString, "http", "https":
+		default:
+			return false
+		}
+
+		if m.HTTP.Status.Min != 0 && (m.HTTP.Status.Min < 100 || m.HTTP.Status.Min > 599) {
+			return false
+		}
+
+		if m.HTTP.Status.Max != 0 && (m.HTTP.Status.Max < 100 || m.HTTP.Status.Max > 599) {
+			return false
+		}
+
+		// range bound which is not set is replaced by default on node
+		min, max := m.HTTP.Status.Min, m.HTTP.Status.Max
+		if min == 0 {
+			min = types.SpecProbeHTTPStatusMin
+		}
+		if max == 0 {
+			max = types.SpecProbeHTTPStatusMax
+		}
+
+		if min > max {
+			return false
+		}
+		checks++
+	}
+
+	if m.InitialDelaySeconds < 0 || m.TimeoutSeconds < 0 || m.PeriodSeconds < 0 ||
+		m.ThresholdSuccess < 0 || m.ThresholdFailure < 0 {
+		return false
+	}
+
+	return checks == 1
+}
+
+// Valid checks that strategy options are not negative
+func (m ManifestSpecStrategy) Valid() bool {
+
+	if m.Type != nil {
+		switch *m.Type {
+		case types.EmptyString, types.SpecStrategyTypeRolling:
+		default:
+			return false
+		}
+	}
+
+	if m.Deadline != nil && *m.Deadline < 0 {
+		return false
+	}
+
+	if m.Rolling == nil {
+		return true
+	}
+
+	for _, v := range []*int{m.Rolling.Interval, m.Rolling.Timeout, m.Rolling.MaxUnavailable, m.Rolling.MaxSurge} {
+		if v != nil && *v < 0 {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,52 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+
+package request
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestManifestSpecTemplateContainerProbeHTTPValid(t *testing.T) {
+
+	var tests = []struct {
+		name   string
+		status ManifestSpecTemplateContainerProbeHTTPStatus
+		want   bool
+	}{
+		{"check default status range", ManifestSpecTemplateContainerProbeHTTPStatus{}, true},
+		{"check status range", ManifestSpecTemplateContainerProbeHTTPStatus{Min: 200, Max: 299}, true},
+		{"check min below default max", ManifestSpecTemplateContainerProbeHTTPStatus{Min: 300}, true},
+		{"check max above default min", ManifestSpecTemplateContainerProbeHTTPStatus{Max: 204}, true},
+		{"check min above default max", ManifestSpecTemplateContainerProbeHTTPStatus{Min: 404}, false},
+		{"check max below default min", ManifestSpecTemplateContainerProbeHTTPStatus{Max: 150}, false},
+		{"check min above max", ManifestSpecTemplateContainerProbeHTTPStatus{Min: 500, Max: 404}, false},
+		{"check status out of range", ManifestSpecTemplateContainerProbeHTTPStatus{Min: 99}, false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			probe := ManifestSpecTemplateContainerProbe{
+				HTTP: &ManifestSpecTemplateContainerProbeHTTP{Port: 80, Status: tc.status},
+			}
+			assert.Equal(t, tc.want, probe.Valid(), "probe validation result mismatch")
+		})
+	}
+}
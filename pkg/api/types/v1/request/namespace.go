@@ -31,6 +31,7 @@ type NamespaceUpdateOptions struct {
 	Description *string                 `json:"description"`
 	Domain      *string                 `json:"domain"`
 	Quotas      *NamespaceQuotasOptions `json:"quotas"`
+	Revision    *int64                  `json:"revision,omitempty"`
 }
 
 // swagger:model request_namespace_remove
@@ -126,6 +126,7 @@ func (n *NamespaceUpdateOptions) DecodeAndValidate(reader io.Reader) (*types.Nam
 
 	opts := new(types.NamespaceUpdateOptions)
 	opts.Description = n.Description
+	opts.Revision = n.Revision
 
 	if n.Quotas != nil {
 		opts.Quotas = new(types.NamespaceQuotasOptions)
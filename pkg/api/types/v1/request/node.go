@@ -18,7 +18,11 @@
 
 package request
 
-import "github.com/lastbackend/lastbackend/pkg/distribution/types"
+import (
+	"time"
+
+	"github.com/lastbackend/lastbackend/pkg/distribution/types"
+)
 
 // swagger:model request_node_meta
 type NodeMetaOptions struct {
@@ -48,6 +52,10 @@ type NodeStatusOptions struct {
 	Pods map[string]*NodePodStatusOptions `json:"pods"`
 	// Node resources
 	Resources NodeResourcesOptions `json:"resources"`
+	// Pods containers resources usage
+	Usage map[string]*types.PodUsage `json:"usage"`
+	// Builds statuses
+	Builds map[string]*NodeBuildStatusOptions `json:"builds"`
 }
 
 // swagger:model request_node_resources
@@ -76,6 +84,18 @@ type NodePodStatusOptions struct {
 	Containers map[string]*types.PodContainer `json:"containers" yaml:"containers"`
 }
 
+// swagger:model request_node_build_status
+type NodeBuildStatusOptions struct {
+	// Build state
+	State string `json:"state" yaml:"state"`
+	// Build state message
+	Message string `json:"message" yaml:"message"`
+	// Build start time
+	Started time.Time `json:"started" yaml:"started"`
+	// Build finish time
+	Finished time.Time `json:"finished" yaml:"finished"`
+}
+
 // swagger:model request_node_volume_status
 type NodeVolumeStatusOptions struct {
 	// route status state
@@ -101,6 +101,10 @@ func (NodeRequest) NodePodStatusOptions() *NodePodStatusOptions {
 	return new(NodePodStatusOptions)
 }
 
+func (NodeRequest) NodeBuildStatusOptions() *NodeBuildStatusOptions {
+	return new(NodeBuildStatusOptions)
+}
+
 func (n *NodePodStatusOptions) Validate() *errors.Err {
 	return nil
 }
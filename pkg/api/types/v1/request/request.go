@@ -28,4 +28,6 @@ type RuntimeMeta struct {
 	Name        *string           `json:"name,omitempty" yaml:"name,omitempty"`
 	Description *string           `json:"description,omitempty",yaml:"description,omitempty"`
 	Labels      map[string]string `json:"labels,omitempty" yaml:"labels,omitempty"`
+	// Revision - entity storage revision, entity is updated only if it was not changed since this revision
+	Revision *int64 `json:"revision,omitempty" yaml:"revision,omitempty"`
 }
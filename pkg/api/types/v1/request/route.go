@@ -84,6 +84,10 @@ func (r *RouteManifest) SetRouteMeta(route *types.Route) {
 		route.Meta.Labels = r.Meta.Labels
 	}
 
+	if r.Meta.Revision != nil {
+		route.System.Revision = *r.Meta.Revision
+	}
+
 }
 
 func (r *RouteManifest) SetRouteSpec(route *types.Route, svc *types.ServiceList) {
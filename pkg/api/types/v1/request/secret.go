@@ -27,8 +27,9 @@ type SecretCreateOptions struct {
 
 // swagger:model request_secret_update
 type SecretUpdateOptions struct {
-	Kind string            `json:"kind"`
-	Data map[string][]byte `json:"data"`
+	Kind     string            `json:"kind"`
+	Data     map[string][]byte `json:"data"`
+	Revision *int64            `json:"revision,omitempty"`
 }
 
 // swagger:ignore
@@ -110,6 +110,7 @@ func (s *SecretUpdateOptions) DecodeAndValidate(reader io.Reader) (*types.Secret
 	opts := new(types.SecretUpdateOptions)
 	opts.Kind = s.Kind
 	opts.Data = s.Data
+	opts.Revision = s.Revision
 
 	return opts, nil
 }
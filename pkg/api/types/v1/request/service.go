@@ -22,6 +22,7 @@ import (
 	"encoding/json"
 	"github.com/lastbackend/lastbackend/pkg/distribution/types"
 	"gopkg.in/yaml.v2"
+	"reflect"
 	"strconv"
 	"strings"
 	"time"
@@ -37,11 +38,12 @@ type ServiceManifestMeta struct {
 }
 
 type ServiceManifestSpec struct {
-	Selector *ManifestSpecSelector `json:"selector,omitempty" yaml:"selector,omitempty"`
-	Replicas *int                  `json:"replicas,omitempty" yaml:"replicas,omitempty"`
-	Network  *ManifestSpecNetwork  `json:"network,omitempty" yaml:"network,omitempty"`
-	Strategy *ManifestSpecStrategy `json:"strategy,omitempty" yaml:"strategy,omitempty"`
-	Template *ManifestSpecTemplate `json:"template,omitempty" yaml:"template,omitempty"`
+	Selector   *ManifestSpecSelector   `json:"selector,omitempty" yaml:"selector,omitempty"`
+	Replicas   *int                    `json:"replicas,omitempty" yaml:"replicas,omitempty"`
+	Network    *ManifestSpecNetwork    `json:"network,omitempty" yaml:"network,omitempty"`
+	Strategy   *ManifestSpecStrategy   `json:"strategy,omitempty" yaml:"strategy,omitempty"`
+	Autoscaler *ManifestSpecAutoscaler `json:"autoscaler,omitempty" yaml:"autoscaler,omitempty"`
+	Template   *ManifestSpecTemplate   `json:"template,omitempty" yaml:"template,omitempty"`
 }
 
 func (s *ServiceManifest) FromJson(data []byte) error {
@@ -74,6 +76,10 @@ func (s *ServiceManifest) SetServiceMeta(svc *types.Service) {
 		svc.Meta.Labels = s.Meta.Labels
 	}
 
+	if s.Meta.Revision != nil {
+		svc.System.Revision = *s.Meta.Revision
+	}
+
 }
 
 func (s *ServiceManifest) SetServiceSpec(svc *types.Service) {
@@ -137,14 +143,57 @@ func (s *ServiceManifest) SetServiceSpec(svc *types.Service) {
 			svc.Spec.Selector.Labels = s.Spec.Selector.Labels
 		}
 
+		if s.Spec.Selector.Affinity != nil {
+			svc.Spec.Selector.Affinity = s.Spec.Selector.Affinity.GetSpec()
+		}
+
 	}
 
 	if s.Spec.Strategy != nil {
+
+		var strategy = svc.Spec.Strategy
+
 		if s.Spec.Strategy.Type != nil {
-			svc.Spec.Strategy.Type = *s.Spec.Strategy.Type
+			strategy.Type = *s.Spec.Strategy.Type
+		}
+
+		if s.Spec.Strategy.Deadline != nil {
+			strategy.Deadline = *s.Spec.Strategy.Deadline
+		}
+
+		if s.Spec.Strategy.RestartOnChange != nil {
+			strategy.RestartOnChange = *s.Spec.Strategy.RestartOnChange
+		}
+
+		if s.Spec.Strategy.Rolling != nil {
+
+			if s.Spec.Strategy.Rolling.Interval != nil {
+				strategy.RollingOptions.Interval = *s.Spec.Strategy.Rolling.Interval
+			}
+
+			if s.Spec.Strategy.Rolling.Timeout != nil {
+				strategy.RollingOptions.Timeout = *s.Spec.Strategy.Rolling.Timeout
+			}
+
+			if s.Spec.Strategy.Rolling.MaxUnavailable != nil {
+				strategy.RollingOptions.MaxUnavailable = *s.Spec.Strategy.Rolling.MaxUnavailable
+			}
+
+			if s.Spec.Strategy.Rolling.MaxSurge != nil {
+				strategy.RollingOptions.MaxSurge = *s.Spec.Strategy.Rolling.MaxSurge
+			}
+		}
+
+		if strategy != svc.Spec.Strategy {
+			strategy.Updated = time.Now()
+			svc.Spec.Strategy = strategy
 		}
 	}
 
+	if s.Spec.Autoscaler != nil {
+		svc.Spec.Autoscaler = s.Spec.Autoscaler.GetSpec()
+	}
+
 	if s.Spec.Template != nil {
 
 		for _, c := range s.Spec.Template.Containers {
@@ -301,6 +350,12 @@ func (s *ServiceManifest) SetServiceSpec(svc *types.Service) {
 
 			spec.Volumes = vlms
 
+			probes := c.Probes.GetSpec()
+			if !reflect.DeepEqual(spec.Probes, probes) {
+				spec.Probes = probes
+				svc.Spec.Template.Updated = time.Now()
+			}
+
 			if !f {
 				svc.Spec.Template.Containers = append(svc.Spec.Template.Containers, spec)
 			}
@@ -410,6 +465,12 @@ type ServiceRemoveOptions struct {
 	Force bool `json:"force"`
 }
 
+// swagger:model request_service_rollback
+type ServiceRollbackOptions struct {
+	// Deployment version to rollback, previous version is used if not set
+	Version *int `json:"version,omitempty"`
+}
+
 // swagger:ignore
 // swagger:model request_service_logs
 type ServiceLogsOptions struct {
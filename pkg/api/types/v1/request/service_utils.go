@@ -41,6 +41,34 @@ func (s *ServiceManifest) Validate() *errors.Err {
 		return errors.New("service").BadParameter("description")
 	}
 
+	if s.Spec.Selector != nil && !s.Spec.Selector.Valid() {
+		return errors.New("service").BadParameter("selector")
+	}
+
+	if s.Spec.Strategy != nil && !s.Spec.Strategy.Valid() {
+		return errors.New("service").BadParameter("strategy")
+	}
+
+	if s.Spec.Autoscaler != nil && !s.Spec.Autoscaler.Valid() {
+		return errors.New("service").BadParameter("autoscaler")
+	}
+
+	if s.Spec.Template != nil {
+		for _, c := range s.Spec.Template.Containers {
+			if !c.Resources.Valid() {
+				return errors.New("service").BadParameter("resources")
+			}
+
+			if c.Probes.LiveProbe != nil && !c.Probes.LiveProbe.Valid() {
+				return errors.New("service").BadParameter("live_probe")
+			}
+
+			if c.Probes.ReadProbe != nil && !c.Probes.ReadProbe.Valid() {
+				return errors.New("service").BadParameter("read_probe")
+			}
+		}
+	}
+
 	return nil
 }
 
@@ -68,6 +96,45 @@ func (s *ServiceManifest) DecodeAndValidate(reader io.Reader) *errors.Err {
 	return nil
 }
 
+func (ServiceRequest) RollbackOptions() *ServiceRollbackOptions {
+	return new(ServiceRollbackOptions)
+}
+
+func (s *ServiceRollbackOptions) Validate() *errors.Err {
+	if s.Version != nil && *s.Version < 1 {
+		return errors.New("service").BadParameter("version")
+	}
+	return nil
+}
+
+func (s *ServiceRollbackOptions) DecodeAndValidate(reader io.Reader) *errors.Err {
+
+	if reader == nil {
+		return nil
+	}
+
+	body, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return errors.New("service").Unknown(err)
+	}
+
+	// rollback to previous version if options are not provided
+	if len(body) == 0 {
+		return nil
+	}
+
+	err = json.Unmarshal(body, s)
+	if err != nil {
+		return errors.New("service").IncorrectJSON(err)
+	}
+
+	return s.Validate()
+}
+
+func (s *ServiceRollbackOptions) ToJson() ([]byte, error) {
+	return json.Marshal(s)
+}
+
 func (ServiceRequest) RemoveOptions() *ServiceRemoveOptions {
 	return new(ServiceRemoveOptions)
 }
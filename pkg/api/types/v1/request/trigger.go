@@ -18,9 +18,25 @@
 
 package request
 
-type TriggerCreateOptions struct{}
+// swagger:model request_trigger_create
+type TriggerCreateOptions struct {
+	Name      string `json:"name"`
+	Source    string `json:"source"`
+	Vendor    string `json:"vendor"`
+	Filter    string `json:"filter,omitempty"`
+	Secret    string `json:"secret"`
+	Container string `json:"container,omitempty"`
+}
 
-type TriggerUpdateOptions struct{}
+// swagger:model request_trigger_update
+type TriggerUpdateOptions struct {
+	Source    *string `json:"source,omitempty"`
+	Vendor    *string `json:"vendor,omitempty"`
+	Filter    *string `json:"filter,omitempty"`
+	Secret    *string `json:"secret,omitempty"`
+	Container *string `json:"container,omitempty"`
+	Revision  *int64  `json:"revision,omitempty"`
+}
 
 type TriggerRemoveOptions struct {
 	Force bool
@@ -22,8 +22,11 @@ import (
 	"encoding/json"
 	"io"
 	"io/ioutil"
+	"path"
 
 	"github.com/lastbackend/lastbackend/pkg/distribution/errors"
+	"github.com/lastbackend/lastbackend/pkg/distribution/types"
+	"github.com/lastbackend/lastbackend/pkg/util/validator"
 )
 
 type TriggerRequest struct{}
@@ -33,6 +36,18 @@ func (TriggerRequest) CreateOptions() *TriggerCreateOptions {
 }
 
 func (t *TriggerCreateOptions) Validate() *errors.Err {
+	switch true {
+	case !validator.IsServiceName(t.Name):
+		return errors.New("trigger").BadParameter("name")
+	case !triggerSourceValid(t.Source):
+		return errors.New("trigger").BadParameter("source")
+	case !triggerVendorValid(t.Source, t.Vendor):
+		return errors.New("trigger").BadParameter("vendor")
+	case !triggerFilterValid(t.Filter):
+		return errors.New("trigger").BadParameter("filter")
+	case t.Secret == types.EmptyString:
+		return errors.New("trigger").BadParameter("secret")
+	}
 	return nil
 }
 
@@ -60,11 +75,31 @@ func (t *TriggerCreateOptions) ToJson() ([]byte, error) {
 	return json.Marshal(t)
 }
 
+func (t *TriggerCreateOptions) SetTriggerMeta(trigger *types.Trigger) {
+	trigger.Meta.Name = t.Name
+}
+
+func (t *TriggerCreateOptions) SetTriggerSpec(trigger *types.Trigger) {
+	trigger.Spec.Source = t.Source
+	trigger.Spec.Vendor = t.Vendor
+	trigger.Spec.Filter = t.Filter
+	trigger.Spec.Secret = t.Secret
+	trigger.Spec.Container = t.Container
+}
+
 func (TriggerRequest) UpdateOptions() *TriggerUpdateOptions {
 	return new(TriggerUpdateOptions)
 }
 
 func (t *TriggerUpdateOptions) Validate() *errors.Err {
+	switch true {
+	case t.Source != nil && !triggerSourceValid(*t.Source):
+		return errors.New("trigger").BadParameter("source")
+	case t.Filter != nil && !triggerFilterValid(*t.Filter):
+		return errors.New("trigger").BadParameter("filter")
+	case t.Secret != nil && *t.Secret == types.EmptyString:
+		return errors.New("trigger").BadParameter("secret")
+	}
 	return nil
 }
 
@@ -92,6 +127,38 @@ func (t *TriggerUpdateOptions) ToJson() ([]byte, error) {
 	return json.Marshal(t)
 }
 
+func (t *TriggerUpdateOptions) SetTriggerSpec(trigger *types.Trigger) *errors.Err {
+
+	spec := trigger.Spec
+
+	if t.Source != nil {
+		spec.Source = *t.Source
+	}
+
+	if t.Vendor != nil {
+		spec.Vendor = *t.Vendor
+	}
+
+	if !triggerVendorValid(spec.Source, spec.Vendor) {
+		return errors.New("trigger").BadParameter("vendor")
+	}
+
+	if t.Filter != nil {
+		spec.Filter = *t.Filter
+	}
+
+	if t.Secret != nil {
+		spec.Secret = *t.Secret
+	}
+
+	if t.Container != nil {
+		spec.Container = *t.Container
+	}
+
+	trigger.Spec = spec
+	return nil
+}
+
 func (TriggerRequest) RemoveOptions() *TriggerRemoveOptions {
 	return new(TriggerRemoveOptions)
 }
@@ -99,3 +166,22 @@ func (TriggerRequest) RemoveOptions() *TriggerRemoveOptions {
 func (t *TriggerRemoveOptions) Validate() *errors.Err {
 	return nil
 }
+
+func triggerSourceValid(source string) bool {
+	return source == types.TriggerSourceVCS || source == types.TriggerSourceRegistry
+}
+
+func triggerVendorValid(source, vendor string) bool {
+	switch source {
+	case types.TriggerSourceVCS:
+		return vendor == types.TriggerVendorGitHub || vendor == types.TriggerVendorGitLab || vendor == types.TriggerVendorBitBucket
+	case types.TriggerSourceRegistry:
+		return vendor == types.TriggerVendorDocker
+	}
+	return false
+}
+
+func triggerFilterValid(filter string) bool {
+	_, err := path.Match(filter, types.EmptyString)
+	return err == nil
+}
@@ -20,6 +20,7 @@ package request
 
 const (
 	DEFAULT_MEMORY_MIN        = 128
+	DEFAULT_CPU_MIN           = 10
 	DEFAULT_REPLICAS_MIN      = 1
 	DEFAULT_DESCRIPTION_LIMIT = 512
 )
@@ -66,7 +67,10 @@ type IRequest interface {
 	Route() *RouteRequest
 	Service() *ServiceRequest
 	Secret() *SecretRequest
+	Config() *ConfigRequest
 	Trigger() *TriggerRequest
+	Build() *BuildRequest
+	Events() *EventsRequest
 	Volume() *VolumeRequest
 	Ingress() *IngressRequest
 	Discovery() *DiscoveryRequest
@@ -98,9 +102,18 @@ func (Request) Service() *ServiceRequest {
 func (Request) Secret() *SecretRequest {
 	return new(SecretRequest)
 }
+func (Request) Config() *ConfigRequest {
+	return new(ConfigRequest)
+}
 func (Request) Trigger() *TriggerRequest {
 	return new(TriggerRequest)
 }
+func (Request) Build() *BuildRequest {
+	return new(BuildRequest)
+}
+func (Request) Events() *EventsRequest {
+	return new(EventsRequest)
+}
 func (Request) Volume() *VolumeRequest {
 	return new(VolumeRequest)
 }
@@ -82,6 +82,10 @@ func (v *VolumeManifest) SetVolumeMeta(vol *types.Volume) {
 		vol.Meta.Labels = v.Meta.Labels
 	}
 
+	if v.Meta.Revision != nil {
+		vol.System.Revision = *v.Meta.Revision
+	}
+
 }
 
 func (v *VolumeManifest) SetVolumeSpec(vol *types.Volume) {
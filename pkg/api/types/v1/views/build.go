@@ -0,0 +1,70 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+
+package views
+
+import "time"
+
+// swagger:model views_build
+type Build struct {
+	Meta   BuildMeta   `json:"meta"`
+	Spec   BuildSpec   `json:"spec"`
+	Status BuildStatus `json:"status"`
+}
+
+// swagger:model views_build_meta
+type BuildMeta struct {
+	Name      string    `json:"name"`
+	Namespace string    `json:"namespace"`
+	SelfLink  string    `json:"self_link"`
+	Updated   time.Time `json:"updated"`
+	Created   time.Time `json:"created"`
+}
+
+// swagger:model views_build_spec
+type BuildSpec struct {
+	Source    BuildSource `json:"source"`
+	Image     BuildImage  `json:"image"`
+	Service   string      `json:"service"`
+	Container string      `json:"container"`
+}
+
+// swagger:model views_build_source
+type BuildSource struct {
+	Url        string `json:"url"`
+	Ref        string `json:"ref"`
+	Dockerfile string `json:"dockerfile"`
+}
+
+// swagger:model views_build_image
+type BuildImage struct {
+	Name   string `json:"name"`
+	Secret string `json:"secret"`
+}
+
+// swagger:model views_build_status
+type BuildStatus struct {
+	State    string    `json:"state"`
+	Message  string    `json:"message"`
+	Node     string    `json:"node"`
+	Started  time.Time `json:"started"`
+	Finished time.Time `json:"finished"`
+}
+
+// swagger:model views_build_list
+type BuildList map[string]*Build
@@ -0,0 +1,90 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+
+package views
+
+import (
+	"encoding/json"
+
+	"github.com/lastbackend/lastbackend/pkg/distribution/types"
+)
+
+type BuildView struct{}
+
+func (bv *BuildView) New(obj *types.Build) *Build {
+	b := Build{}
+	b.Meta = b.ToMeta(obj)
+	b.Spec = b.ToSpec(obj.Spec)
+	b.Status = b.ToStatus(obj.Status)
+	return &b
+}
+
+func (b *Build) ToJson() ([]byte, error) {
+	return json.Marshal(b)
+}
+
+func (b *Build) ToMeta(obj *types.Build) BuildMeta {
+	meta := BuildMeta{}
+	meta.Name = obj.Meta.Name
+	meta.Namespace = obj.Meta.Namespace
+	meta.SelfLink = obj.SelfLink()
+	meta.Updated = obj.Meta.Updated
+	meta.Created = obj.Meta.Created
+	return meta
+}
+
+func (b *Build) ToSpec(obj types.BuildSpec) BuildSpec {
+	spec := BuildSpec{}
+	spec.Source.Url = obj.Source.Url
+	spec.Source.Ref = obj.Source.Ref
+	spec.Source.Dockerfile = obj.Source.Dockerfile
+	spec.Image.Name = obj.Image.Name
+	spec.Image.Secret = obj.Image.Secret
+	spec.Service = obj.Service
+	spec.Container = obj.Container
+	return spec
+}
+
+func (b *Build) ToStatus(obj types.BuildStatus) BuildStatus {
+	status := BuildStatus{}
+	status.State = obj.State
+	status.Message = obj.Message
+	status.Node = obj.Node
+	status.Started = obj.Started
+	status.Finished = obj.Finished
+	return status
+}
+
+func (bv BuildView) NewList(obj *types.BuildList) *BuildList {
+	if obj == nil {
+		return nil
+	}
+
+	bl := make(BuildList, 0)
+	for _, v := range obj.Items {
+		bl[v.Meta.Name] = bv.New(v)
+	}
+	return &bl
+}
+
+func (bl *BuildList) ToJson() ([]byte, error) {
+	if bl == nil {
+		bl = &BuildList{}
+	}
+	return json.Marshal(bl)
+}
@@ -0,0 +1,43 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+
+package views
+
+import "time"
+
+// swagger:model views_config
+type Config struct {
+	Meta ConfigMeta        `json:"meta"`
+	Data map[string][]byte `json:"data"`
+}
+
+// swagger:model views_config_meta
+type ConfigMeta struct {
+	Name        string            `json:"name"`
+	Namespace   string            `json:"namespace"`
+	Kind        string            `json:"kind"`
+	Description string            `json:"description"`
+	SelfLink    string            `json:"self_link"`
+	Labels      map[string]string `json:"labels"`
+	Updated     time.Time         `json:"updated"`
+	Created     time.Time         `json:"created"`
+	Revision    int64             `json:"revision"`
+}
+
+// swagger:model views_config_list
+type ConfigList []*Config
@@ -0,0 +1,72 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+
+package views
+
+import (
+	"encoding/json"
+
+	"github.com/lastbackend/lastbackend/pkg/distribution/types"
+)
+
+type ConfigView struct{}
+
+func (cv *ConfigView) New(obj *types.Config) *Config {
+	c := Config{}
+	c.Meta = c.ToMeta(obj.Meta)
+	c.Meta.Revision = obj.System.Revision
+	c.Data = obj.Data
+	return &c
+}
+
+func (c *Config) ToJson() ([]byte, error) {
+	return json.Marshal(c)
+}
+
+func (c *Config) ToMeta(obj types.ConfigMeta) ConfigMeta {
+	meta := ConfigMeta{}
+	meta.Name = obj.Name
+	meta.Namespace = obj.Namespace
+	meta.Kind = obj.Kind
+	meta.Description = obj.Description
+	meta.SelfLink = obj.SelfLink
+	meta.Labels = obj.Labels
+	meta.Updated = obj.Updated
+	meta.Created = obj.Created
+
+	return meta
+}
+
+func (cv ConfigView) NewList(obj *types.ConfigList) *ConfigList {
+	if obj == nil {
+		return nil
+	}
+
+	cl := make(ConfigList, 0)
+	for _, v := range obj.Items {
+		cl = append(cl, cv.New(v))
+	}
+	return &cl
+}
+
+func (cl *ConfigList) ToJson() ([]byte, error) {
+	if cl == nil {
+		cl = &ConfigList{}
+	}
+	return json.Marshal(cl)
+}
@@ -74,6 +74,8 @@ type DeploymentMeta struct {
 	Created time.Time `json:"created"`
 	// Deployment creation time
 	Updated time.Time `json:"updated"`
+	// Deployment storage revision
+	Revision int64 `json:"revision"`
 }
 
 // DeploymentSources is a source of deployment model for api
@@ -30,6 +30,7 @@ func (dv *DeploymentView) New(obj *types.Deployment, pl *types.PodList) *Deploym
 	d := Deployment{}
 	d.ID = obj.Meta.Name
 	d.Meta = d.ToMeta(obj.Meta)
+	d.Meta.Revision = obj.System.Revision
 	d.Status = d.ToStatus(obj.Status)
 	d.Spec = d.ToSpec(obj.Spec)
 
@@ -18,14 +18,32 @@
 
 package views
 
-import (
-	"github.com/lastbackend/lastbackend/pkg/distribution/types"
-	"time"
-)
+import "time"
 
+// swagger:model views_event
 type Event struct {
-	Meta      types.NodeMeta   `json:"meta"`
-	Status    types.NodeStatus `json:"status"`
-	Pods      []types.Pod      `json:"pods"`
-	Timestamp time.Time        `json:"timestamp"`
+	Meta      EventMeta   `json:"meta"`
+	Object    EventObject `json:"object"`
+	Reason    string      `json:"reason"`
+	Message   string      `json:"message"`
+	Severity  string      `json:"severity"`
+	Count     int         `json:"count"`
+	FirstSeen time.Time   `json:"first_seen"`
+	LastSeen  time.Time   `json:"last_seen"`
 }
+
+// swagger:model views_event_meta
+type EventMeta struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	SelfLink  string `json:"self_link"`
+}
+
+// swagger:model views_event_object
+type EventObject struct {
+	Kind     string `json:"kind"`
+	SelfLink string `json:"self_link"`
+}
+
+// swagger:model views_event_list
+type EventList []*Event
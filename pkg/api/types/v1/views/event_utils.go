@@ -0,0 +1,66 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+
+package views
+
+import (
+	"encoding/json"
+
+	"github.com/lastbackend/lastbackend/pkg/distribution/types"
+)
+
+type EventView struct{}
+
+func (ev *EventView) New(obj *types.EventRecord) *Event {
+	e := Event{}
+	e.Meta.Name = obj.Meta.Name
+	e.Meta.Namespace = obj.Meta.Namespace
+	e.Meta.SelfLink = obj.SelfLink()
+	e.Object.Kind = obj.Object.Kind
+	e.Object.SelfLink = obj.Object.SelfLink
+	e.Reason = obj.Reason
+	e.Message = obj.Message
+	e.Severity = obj.Severity
+	e.Count = obj.Count
+	e.FirstSeen = obj.FirstSeen
+	e.LastSeen = obj.LastSeen
+	return &e
+}
+
+func (e *Event) ToJson() ([]byte, error) {
+	return json.Marshal(e)
+}
+
+func (ev EventView) NewList(obj *types.EventRecordList) *EventList {
+	if obj == nil {
+		return nil
+	}
+
+	el := make(EventList, 0)
+	for _, v := range obj.Items {
+		el = append(el, ev.New(v))
+	}
+	return &el
+}
+
+func (el *EventList) ToJson() ([]byte, error) {
+	if el == nil {
+		el = &EventList{}
+	}
+	return json.Marshal(el)
+}
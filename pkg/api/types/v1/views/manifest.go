@@ -19,7 +19,21 @@
 package views
 
 type ManifestSpecSelector struct {
-	Node   string            `json:"node,omitempty" yaml:"node,omitempty"`
+	Node     string                       `json:"node,omitempty" yaml:"node,omitempty"`
+	Labels   map[string]string            `json:"labels,omitempty" yaml:"labels,omitempty"`
+	Affinity ManifestSpecSelectorAffinity `json:"affinity,omitempty" yaml:"affinity,omitempty"`
+}
+
+type ManifestSpecSelectorAffinity struct {
+	Node     []ManifestSpecSelectorAffinityTerm `json:"node,omitempty" yaml:"node,omitempty"`
+	NodeAnti []ManifestSpecSelectorAffinityTerm `json:"node_anti,omitempty" yaml:"node_anti,omitempty"`
+	Pod      []ManifestSpecSelectorAffinityTerm `json:"pod,omitempty" yaml:"pod,omitempty"`
+	PodAnti  []ManifestSpecSelectorAffinityTerm `json:"pod_anti,omitempty" yaml:"pod_anti,omitempty"`
+}
+
+type ManifestSpecSelectorAffinityTerm struct {
+	Type   string            `json:"type,omitempty" yaml:"type,omitempty"`
+	Weight int               `json:"weight,omitempty" yaml:"weight,omitempty"`
 	Labels map[string]string `json:"labels,omitempty" yaml:"labels,omitempty"`
 }
 
@@ -28,8 +42,25 @@ type ManifestSpecNetwork struct {
 	Ports map[uint16]string `json:"ports,omitempty" yaml:"ports,omitempty"`
 }
 
+type ManifestSpecAutoscaler struct {
+	MinReplicas int `json:"min_replicas,omitempty" yaml:"min_replicas,omitempty"`
+	MaxReplicas int `json:"max_replicas,omitempty" yaml:"max_replicas,omitempty"`
+	TargetCPU   int `json:"target_cpu,omitempty" yaml:"target_cpu,omitempty"`
+	TargetRAM   int `json:"target_ram,omitempty" yaml:"target_ram,omitempty"`
+}
+
 type ManifestSpecStrategy struct {
-	Type string `json:"type,omitempty" yaml:"type,omitempty"`
+	Type            string                      `json:"type,omitempty" yaml:"type,omitempty"`
+	Rolling         ManifestSpecStrategyRolling `json:"rolling,omitempty" yaml:"rolling,omitempty"`
+	Deadline        int                         `json:"deadline,omitempty" yaml:"deadline,omitempty"`
+	RestartOnChange bool                        `json:"restart_on_change,omitempty" yaml:"restart_on_change,omitempty"`
+}
+
+type ManifestSpecStrategyRolling struct {
+	Interval       int `json:"interval,omitempty" yaml:"interval,omitempty"`
+	Timeout        int `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+	MaxUnavailable int `json:"max_unavailable,omitempty" yaml:"max_unavailable,omitempty"`
+	MaxSurge       int `json:"max_surge,omitempty" yaml:"max_surge,omitempty"`
 }
 
 type ManifestSpecTemplate struct {
@@ -38,6 +38,7 @@ type NamespaceMeta struct {
 	Labels      map[string]string `json:"labels"`
 	Created     time.Time         `json:"created"`
 	Updated     time.Time         `json:"updated"`
+	Revision    int64             `json:"revision"`
 }
 
 // swagger:model views_namespace_spec
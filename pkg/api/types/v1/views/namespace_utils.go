@@ -30,6 +30,7 @@ type NamespaceView struct{}
 func (nv *NamespaceView) New(obj *types.Namespace) *Namespace {
 	n := Namespace{}
 	n.Meta = n.ToMeta(obj.Meta)
+	n.Meta.Revision = obj.System.Revision
 	n.Spec = n.ToSpec(obj.Spec)
 	return &n
 }
@@ -81,14 +81,18 @@ type NodeStatusInterfaceState struct {
 type NodeStatus struct {
 	State     NodeStatusState `json:"state"`
 	Online    bool            `json:"online"`
+	Drained   bool            `json:"drained"`
 	Capacity  NodeResources   `json:"capacity"`
 	Allocated NodeResources   `json:"allocated"`
+	Builder   bool            `json:"builder"`
 }
 
 // swagger:ignore
 // swagger:model types_node_spec
 type NodeSpec struct {
-	Security NodeSecurity `json:"security"`
+	Security      NodeSecurity `json:"security"`
+	Unschedulable bool         `json:"unschedulable"`
+	Drain         bool         `json:"drain"`
 }
 
 type NodeSecurity struct {
@@ -115,6 +119,7 @@ type NodeManifest struct {
 	Volumes   map[string]*types.VolumeManifest   `json:"volumes,omitempty"`
 	Endpoints map[string]*types.EndpointManifest `json:"endpoints,omitempty"`
 	Routes    map[string]*types.RouteManifest    `json:"routes,omitempty"`
+	Builds    map[string]*types.BuildManifest    `json:"builds,omitempty"`
 }
 
 type NodeManifestMeta struct {
@@ -53,6 +53,8 @@ func (nv *NodeView) ToNodeStatus(status types.NodeStatus) NodeStatus {
 	ns := NodeStatus{}
 
 	ns.Online = status.Online
+	ns.Drained = status.Drained
+	ns.Builder = status.Role.Builder
 
 	ns.Capacity.Containers = status.Capacity.Containers
 	ns.Capacity.Pods = status.Capacity.Pods
@@ -92,6 +94,8 @@ func (nv *NodeView) ToNodeStatus(status types.NodeStatus) NodeStatus {
 func (nv *NodeView) ToNodeSpec(spec types.NodeSpec) NodeSpec {
 	ns := NodeSpec{}
 	ns.Security.TLS = spec.Security.TLS
+	ns.Unschedulable = spec.Unschedulable
+	ns.Drain = spec.Drain
 	return ns
 }
 
@@ -108,6 +112,7 @@ func (obj *NodeManifest) Decode() *types.NodeManifest {
 		Pods:      make(map[string]*types.PodManifest, 0),
 		Volumes:   make(map[string]*types.VolumeManifest, 0),
 		Endpoints: make(map[string]*types.EndpointManifest, 0),
+		Builds:    make(map[string]*types.BuildManifest, 0),
 	}
 
 	manifest.Meta.Initial = obj.Meta.Initial
@@ -136,6 +141,10 @@ func (obj *NodeManifest) Decode() *types.NodeManifest {
 		manifest.Secrets[i] = s
 	}
 
+	for i, s := range obj.Builds {
+		manifest.Builds[i] = s
+	}
+
 	return &manifest
 }
 
@@ -162,6 +171,7 @@ func (nv *NodeView) NewManifest(obj *types.NodeManifest) *NodeManifest {
 		Volumes:   make(map[string]*types.VolumeManifest, 0),
 		Endpoints: make(map[string]*types.EndpointManifest, 0),
 		Routes: make(map[string]*types.RouteManifest, 0),
+		Builds: make(map[string]*types.BuildManifest, 0),
 	}
 
 	if obj == nil {
@@ -176,6 +186,7 @@ func (nv *NodeView) NewManifest(obj *types.NodeManifest) *NodeManifest {
 	manifest.Pods = obj.Pods
 	manifest.Volumes = obj.Volumes
 	manifest.Endpoints = obj.Endpoints
+	manifest.Builds = obj.Builds
 
 	return &manifest
 }
@@ -104,8 +104,25 @@ type PodStatus struct {
 	Network PodNetwork `json:"network"`
 	// Pod containers
 	Containers PodContainers `json:"containers"`
+	// Pod resources usage
+	Usage PodUsage `json:"usage"`
+}
+
+// PodUsage is a resources usage of pod reported by node
+// swagger:model views_pod_usage
+type PodUsage struct {
+	// Pod total usage
+	Total types.PodContainerUsage `json:"total"`
+	// Pod containers usage
+	Containers map[string]types.PodContainerUsage `json:"containers"`
+	// Usage update time
+	Updated time.Time `json:"updated"`
 }
 
+// PodUsageList is a list of pod usage samples collected by node in stats window
+// swagger:model views_pod_usage_list
+type PodUsageList []PodUsage
+
 // PodContainers is a list of pod containers
 // swagger:model views_pod_container_list
 type PodContainers []PodContainer
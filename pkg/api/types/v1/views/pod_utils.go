@@ -19,6 +19,8 @@
 package views
 
 import (
+	"encoding/json"
+
 	"github.com/lastbackend/lastbackend/pkg/distribution/types"
 )
 
@@ -85,5 +87,38 @@ func (pv *Pod) toStatus(pod types.PodStatus) PodStatus {
 		cv := new(ContainerView)
 		status.Containers = append(status.Containers, cv.NewPodContainer(container))
 	}
+
+	status.Usage = pv.toUsage(pod.Usage)
+
 	return status
 }
+
+func (pv *Pod) toUsage(usage types.PodUsage) PodUsage {
+	var u = PodUsage{
+		Total:   usage.Total(),
+		Updated: usage.Updated,
+	}
+
+	u.Containers = make(map[string]types.PodContainerUsage, 0)
+	for name, c := range usage.Containers {
+		u.Containers[name] = c
+	}
+
+	return u
+}
+
+// NewUsageList returns pod usage samples view
+func (pv *Pod) NewUsageList(items []*types.PodUsage) PodUsageList {
+	var list = make(PodUsageList, 0)
+	for _, u := range items {
+		if u == nil {
+			continue
+		}
+		list = append(list, pv.toUsage(*u))
+	}
+	return list
+}
+
+func (pl PodUsageList) ToJson() ([]byte, error) {
+	return json.Marshal(pl)
+}
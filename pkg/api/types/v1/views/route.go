@@ -41,6 +41,7 @@ type RouteMeta struct {
 	Security  bool      `json:"security"`
 	Updated   time.Time `json:"updated"`
 	Created   time.Time `json:"created"`
+	Revision  int64     `json:"revision"`
 }
 
 // swagger:model views_route_spec
@@ -23,19 +23,24 @@ import (
 	"time"
 )
 
+// Secret view contains data keys only instead of data,
+// secret values are encrypted at rest and never exposed by api
 // swagger:model views_secret
 type Secret struct {
-	Meta SecretMeta        `json:"meta"`
-	Data map[string][]byte `json:"data"`
+	Meta SecretMeta `json:"meta"`
+	// Secret data keys sorted by name
+	Keys []string `json:"keys"`
 }
 
 // swagger:model views_secret_meta
 type SecretMeta struct {
-	Name     string    `json:"name"`
-	Kind     string    `json:"kind"`
-	SelfLink string    `json:"self_link"`
-	Updated  time.Time `json:"updated"`
-	Created  time.Time `json:"created"`
+	Name      string    `json:"name"`
+	Namespace string    `json:"namespace"`
+	Kind      string    `json:"kind"`
+	SelfLink  string    `json:"self_link"`
+	Updated   time.Time `json:"updated"`
+	Created   time.Time `json:"created"`
+	Revision  int64     `json:"revision"`
 }
 
 // swagger:ignore
@@ -48,12 +53,18 @@ func (s *Secret) Decode() *types.Secret {
 
 	o := new(types.Secret)
 	o.Meta.Name = s.Meta.Name
+	o.Meta.Namespace = s.Meta.Namespace
 	o.Meta.Kind = s.Meta.Kind
 	o.Meta.SelfLink = s.Meta.SelfLink
 	o.Meta.Updated = s.Meta.Updated
 	o.Meta.Created = s.Meta.Created
+	o.System.Revision = s.Meta.Revision
 
-	o.Data = s.Data
+	// values are not exposed, keys are kept to check secret data presence
+	o.Data = make(map[string][]byte, len(s.Keys))
+	for _, k := range s.Keys {
+		o.Data[k] = nil
+	}
 
 	return o
 }
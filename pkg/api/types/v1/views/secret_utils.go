@@ -20,6 +20,7 @@ package views
 
 import (
 	"encoding/json"
+	"sort"
 
 	"github.com/lastbackend/lastbackend/pkg/distribution/types"
 )
@@ -29,7 +30,12 @@ type SecretView struct{}
 func (sv *SecretView) New(obj *types.Secret) *Secret {
 	s := Secret{}
 	s.Meta = s.ToMeta(obj.Meta)
-	s.Data = obj.Data
+	s.Meta.Revision = obj.System.Revision
+	s.Keys = make([]string, 0)
+	for k := range obj.Data {
+		s.Keys = append(s.Keys, k)
+	}
+	sort.Strings(s.Keys)
 	return &s
 }
 
@@ -40,7 +46,9 @@ func (s *Secret) ToJson() ([]byte, error) {
 func (s *Secret) ToMeta(obj types.SecretMeta) SecretMeta {
 	meta := SecretMeta{}
 	meta.Name = obj.Name
+	meta.Namespace = obj.Namespace
 	meta.Kind = obj.Kind
+	meta.SelfLink = obj.SelfLink
 	meta.Updated = obj.Updated
 	meta.Created = obj.Created
 
@@ -47,6 +47,7 @@ type ServiceMeta struct {
 	Labels      map[string]string `json:"labels"`
 	Created     time.Time         `json:"created"`
 	Updated     time.Time         `json:"updated"`
+	Revision    int64             `json:"revision"`
 }
 
 // swagger:ignore
@@ -63,11 +64,17 @@ type ServiceImage struct {
 type ServiceSourcesRepo struct {
 }
 
+// ServiceStats is a total resources usage of service running pods
 // swagger:model views_service_stats
 type ServiceStats struct {
-	Memory  int64 `json:"memory"`
-	Cpu     int64 `json:"cpu"`
+	// RAM usage in MB
+	Memory int64 `json:"memory"`
+	// CPU usage in millicores
+	Cpu int64 `json:"cpu"`
+	// Network receive and transmit rate in bytes per second
 	Network int64 `json:"network"`
+	// Block devices read and write rate in bytes per second
+	Block int64 `json:"block"`
 }
 
 // swagger:model views_service_status
@@ -86,11 +93,12 @@ type ServiceDeployment struct {
 
 // swagger:model views_service_spec
 type ServiceSpec struct {
-	Selector ManifestSpecSelector `json:"selector,omitempty" yaml:"selector,omitempty"`
-	Replicas int                  `json:"replicas,omitempty" yaml:"replicas,omitempty"`
-	Network  ManifestSpecNetwork  `json:"network,omitempty" yaml:"network,omitempty"`
-	Strategy ManifestSpecStrategy `json:"strategy,omitempty" yaml:"strategy,omitempty"`
-	Template ManifestSpecTemplate `json:"template,omitempty" yaml:"template,omitempty"`
+	Selector   ManifestSpecSelector   `json:"selector,omitempty" yaml:"selector,omitempty"`
+	Replicas   int                    `json:"replicas,omitempty" yaml:"replicas,omitempty"`
+	Network    ManifestSpecNetwork    `json:"network,omitempty" yaml:"network,omitempty"`
+	Strategy   ManifestSpecStrategy   `json:"strategy,omitempty" yaml:"strategy,omitempty"`
+	Autoscaler ManifestSpecAutoscaler `json:"autoscaler,omitempty" yaml:"autoscaler,omitempty"`
+	Template   ManifestSpecTemplate   `json:"template,omitempty" yaml:"template,omitempty"`
 }
 
 type ServiceTemplateSpec struct {
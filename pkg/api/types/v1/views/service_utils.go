@@ -34,6 +34,7 @@ type ServiceView struct{}
 func (sv *ServiceView) New(srv *types.Service) *Service {
 	s := new(Service)
 	s.Meta = s.ToMeta(srv.Meta)
+	s.Meta.Revision = srv.System.Revision
 	s.Status = s.ToStatus(srv.Status)
 	s.Spec = s.ToSpec(srv.Spec)
 	return s
@@ -42,14 +43,42 @@ func (sv *ServiceView) New(srv *types.Service) *Service {
 func (sv *ServiceView) NewWithDeployment(srv *types.Service, d *types.DeploymentList, p *types.PodList) *Service {
 	s := new(Service)
 	s.Meta = s.ToMeta(srv.Meta)
+	s.Meta.Revision = srv.System.Revision
 	s.Status = s.ToStatus(srv.Status)
 	s.Spec = s.ToSpec(srv.Spec)
 	if d != nil {
 		s.Deployments = s.ToDeployments(d, p)
 	}
+	if p != nil {
+		s.Stats = s.ToStats(srv, p)
+	}
 	return s
 }
 
+// ToStats summarizes resources usage of service running pods
+func (sv *Service) ToStats(srv *types.Service, pl *types.PodList) ServiceStats {
+
+	var stats = ServiceStats{}
+
+	for _, p := range pl.Items {
+		if p.Meta.Namespace != srv.Meta.Namespace || p.Meta.Service != srv.Meta.Name {
+			continue
+		}
+
+		if p.Status.State != types.StateReady {
+			continue
+		}
+
+		t := p.Status.Usage.Total()
+		stats.Cpu += t.CPU
+		stats.Memory += t.RAM
+		stats.Network += t.NetworkRx + t.NetworkTx
+		stats.Block += t.BlockRead + t.BlockWrite
+	}
+
+	return stats
+}
+
 func (sv *Service) ToMeta(obj types.ServiceMeta) ServiceMeta {
 	return ServiceMeta{
 		Name:        obj.Name,
@@ -81,6 +110,12 @@ func (sv *Service) ToSpec(obj types.ServiceSpec) ServiceSpec {
 		Selector: ManifestSpecSelector{
 			Node:   obj.Selector.Node,
 			Labels: obj.Selector.Labels,
+			Affinity: ManifestSpecSelectorAffinity{
+				Node:     sv.ToAffinityTerms(obj.Selector.Affinity.Node),
+				NodeAnti: sv.ToAffinityTerms(obj.Selector.Affinity.NodeAnti),
+				Pod:      sv.ToAffinityTerms(obj.Selector.Affinity.Pod),
+				PodAnti:  sv.ToAffinityTerms(obj.Selector.Affinity.PodAnti),
+			},
 		},
 		Network: ManifestSpecNetwork{
 			IP:    obj.Network.IP,
@@ -88,6 +123,20 @@ func (sv *Service) ToSpec(obj types.ServiceSpec) ServiceSpec {
 		},
 		Strategy: ManifestSpecStrategy{
 			Type: obj.Strategy.Type,
+			Rolling: ManifestSpecStrategyRolling{
+				Interval:       obj.Strategy.RollingOptions.Interval,
+				Timeout:        obj.Strategy.RollingOptions.Timeout,
+				MaxUnavailable: obj.Strategy.RollingOptions.MaxUnavailable,
+				MaxSurge:       obj.Strategy.RollingOptions.MaxSurge,
+			},
+			Deadline:        obj.Strategy.Deadline,
+			RestartOnChange: obj.Strategy.RestartOnChange,
+		},
+		Autoscaler: ManifestSpecAutoscaler{
+			MinReplicas: obj.Autoscaler.MinReplicas,
+			MaxReplicas: obj.Autoscaler.MaxReplicas,
+			TargetCPU:   obj.Autoscaler.TargetCPU,
+			TargetRAM:   obj.Autoscaler.TargetRAM,
 		},
 	}
 
@@ -147,6 +196,18 @@ func (sv *Service) ToSpec(obj types.ServiceSpec) ServiceSpec {
 	return spec
 }
 
+func (sv *Service) ToAffinityTerms(obj []types.SpecSelectorAffinityTerm) []ManifestSpecSelectorAffinityTerm {
+	terms := make([]ManifestSpecSelectorAffinityTerm, 0)
+	for _, t := range obj {
+		terms = append(terms, ManifestSpecSelectorAffinityTerm{
+			Type:   t.Type,
+			Weight: t.Weight,
+			Labels: t.Labels,
+		})
+	}
+	return terms
+}
+
 func (sv *Service) ToDeployments(obj *types.DeploymentList, pods *types.PodList) DeploymentMap {
 	deployments := make(DeploymentMap, 0)
 	for _, d := range obj.Items {
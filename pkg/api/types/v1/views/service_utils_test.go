@@ -0,0 +1,117 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+
+package views
+
+import (
+	"testing"
+
+	"github.com/lastbackend/lastbackend/pkg/distribution/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func getStatsPodAsset(namespace, service, state string, usage ...types.PodContainerUsage) *types.Pod {
+	p := new(types.Pod)
+	p.Meta.Namespace = namespace
+	p.Meta.Service = service
+	p.Status.State = state
+	p.Status.Usage.Containers = make(map[string]types.PodContainerUsage, 0)
+	for i, u := range usage {
+		p.Status.Usage.Containers[string(rune('a'+i))] = u
+	}
+	return p
+}
+
+func TestServiceToStats(t *testing.T) {
+
+	svc := new(types.Service)
+	svc.Meta.Namespace = "demo"
+	svc.Meta.Name = "redis"
+
+	usage := types.PodContainerUsage{CPU: 100, RAM: 64, NetworkRx: 10, NetworkTx: 20, BlockRead: 30, BlockWrite: 40}
+
+	var tests = []struct {
+		name string
+		pods []*types.Pod
+		want ServiceStats
+	}{
+		{
+			name: "check empty pods list",
+			pods: []*types.Pod{},
+			want: ServiceStats{},
+		},
+		{
+			name: "check ready pod without usage",
+			pods: []*types.Pod{getStatsPodAsset("demo", "redis", types.StateReady)},
+			want: ServiceStats{},
+		},
+		{
+			name: "check ready pods containers usage summary",
+			pods: []*types.Pod{
+				getStatsPodAsset("demo", "redis", types.StateReady, usage, usage),
+				getStatsPodAsset("demo", "redis", types.StateReady, usage),
+			},
+			want: ServiceStats{Cpu: 300, Memory: 192, Network: 90, Block: 210},
+		},
+		{
+			name: "check not ready pods are skipped",
+			pods: []*types.Pod{
+				getStatsPodAsset("demo", "redis", types.StateReady, usage),
+				getStatsPodAsset("demo", "redis", types.StateError, usage),
+				getStatsPodAsset("demo", "redis", types.StateProvision, usage),
+			},
+			want: ServiceStats{Cpu: 100, Memory: 64, Network: 30, Block: 70},
+		},
+		{
+			name: "check pods of other services are skipped",
+			pods: []*types.Pod{
+				getStatsPodAsset("demo", "redis", types.StateReady, usage),
+				getStatsPodAsset("demo", "nginx", types.StateReady, usage),
+				getStatsPodAsset("test", "redis", types.StateReady, usage),
+			},
+			want: ServiceStats{Cpu: 100, Memory: 64, Network: 30, Block: 70},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+
+			pl := types.NewPodList()
+			pl.Items = tc.pods
+
+			assert.Equal(t, tc.want, new(Service).ToStats(svc, pl), "stats not equal")
+		})
+	}
+}
+
+func TestPodNewUsageList(t *testing.T) {
+
+	u := new(types.PodUsage)
+	u.Containers = map[string]types.PodContainerUsage{
+		"a": {CPU: 100, RAM: 64},
+		"b": {CPU: 50, RAM: 32},
+	}
+
+	list := new(Pod).NewUsageList([]*types.PodUsage{u, nil})
+	if !assert.Len(t, list, 1, "usage list length not equal") {
+		return
+	}
+
+	assert.Equal(t, types.PodContainerUsage{CPU: 150, RAM: 96}, list[0].Total, "total usage not equal")
+	assert.Equal(t, u.Containers, list[0].Containers, "containers usage not equal")
+}
@@ -20,22 +20,41 @@ package views
 
 import "time"
 
+// swagger:model views_trigger
 type Trigger struct {
 	Meta   TriggerMeta   `json:"meta"`
 	Spec   TriggerSpec   `json:"spec"`
 	Status TriggerStatus `json:"status"`
 }
 
+// swagger:model views_trigger_meta
 type TriggerMeta struct {
-	Name    string    `json:"name"`
-	Updated time.Time `json:"updated"`
-	Created time.Time `json:"created"`
+	Name      string    `json:"name"`
+	Namespace string    `json:"namespace"`
+	Service   string    `json:"service"`
+	SelfLink  string    `json:"self_link"`
+	Updated   time.Time `json:"updated"`
+	Created   time.Time `json:"created"`
+	Revision  int64     `json:"revision"`
 }
 
+// swagger:model views_trigger_spec
 type TriggerSpec struct {
+	Source    string `json:"source"`
+	Vendor    string `json:"vendor"`
+	Filter    string `json:"filter"`
+	Container string `json:"container"`
+	// Hook path to be set in vcs or registry webhook settings
+	Hook string `json:"hook"`
 }
 
+// swagger:model views_trigger_status
 type TriggerStatus struct {
+	State    string    `json:"state"`
+	Message  string    `json:"message"`
+	Ref      string    `json:"ref"`
+	Executed time.Time `json:"executed"`
 }
 
+// swagger:model views_trigger_list
 type TriggerList map[string]*Trigger
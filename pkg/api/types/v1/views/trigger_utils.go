@@ -0,0 +1,90 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+
+package views
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/lastbackend/lastbackend/pkg/distribution/types"
+)
+
+type TriggerView struct{}
+
+func (tv *TriggerView) New(obj *types.Trigger) *Trigger {
+	t := Trigger{}
+	t.Meta = t.ToMeta(obj)
+	t.Spec = t.ToSpec(obj)
+	t.Status = t.ToStatus(obj.Status)
+	return &t
+}
+
+func (t *Trigger) ToJson() ([]byte, error) {
+	return json.Marshal(t)
+}
+
+func (t *Trigger) ToMeta(obj *types.Trigger) TriggerMeta {
+	meta := TriggerMeta{}
+	meta.Name = obj.Meta.Name
+	meta.Namespace = obj.Meta.Namespace
+	meta.Service = obj.Meta.Service
+	meta.SelfLink = obj.SelfLink()
+	meta.Updated = obj.Meta.Updated
+	meta.Revision = obj.System.Revision
+	meta.Created = obj.Meta.Created
+	return meta
+}
+
+func (t *Trigger) ToSpec(obj *types.Trigger) TriggerSpec {
+	spec := TriggerSpec{}
+	spec.Source = obj.Spec.Source
+	spec.Vendor = obj.Spec.Vendor
+	spec.Filter = obj.Spec.Filter
+	spec.Container = obj.Spec.Container
+	spec.Hook = fmt.Sprintf("/hook/%s", obj.SelfLink())
+	return spec
+}
+
+func (t *Trigger) ToStatus(obj types.TriggerStatus) TriggerStatus {
+	status := TriggerStatus{}
+	status.State = obj.State
+	status.Message = obj.Message
+	status.Ref = obj.Ref
+	status.Executed = obj.Executed
+	return status
+}
+
+func (tv TriggerView) NewList(obj *types.TriggerList) *TriggerList {
+	if obj == nil {
+		return nil
+	}
+
+	tl := make(TriggerList, 0)
+	for _, v := range obj.Items {
+		tl[v.Meta.Name] = tv.New(v)
+	}
+	return &tl
+}
+
+func (tl *TriggerList) ToJson() ([]byte, error) {
+	if tl == nil {
+		tl = &TriggerList{}
+	}
+	return json.Marshal(tl)
+}
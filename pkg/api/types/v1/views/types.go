@@ -30,6 +30,10 @@ type IView interface {
 	Route() *RouteView
 	Service() *ServiceView
 	Secret() *SecretView
+	Config() *ConfigView
+	Trigger() *TriggerView
+	Build() *BuildView
+	Event() *EventView
 	Deployment() *DeploymentView
 	Endpoint() *EndpointView
 	Pod() *Pod
@@ -64,6 +68,18 @@ func (View) Service() *ServiceView {
 func (View) Secret() *SecretView {
 	return new(SecretView)
 }
+func (View) Config() *ConfigView {
+	return new(ConfigView)
+}
+func (View) Trigger() *TriggerView {
+	return new(TriggerView)
+}
+func (View) Build() *BuildView {
+	return new(BuildView)
+}
+func (View) Event() *EventView {
+	return new(EventView)
+}
 func (View) Deployment() *DeploymentView {
 	return new(DeploymentView)
 }
@@ -33,6 +33,7 @@ type VolumeMeta struct {
 	SelfLink    string    `json:"self_link"`
 	Updated     time.Time `json:"updated"`
 	Created     time.Time `json:"created"`
+	Revision    int64     `json:"revision"`
 }
 
 type VolumeSpec struct {
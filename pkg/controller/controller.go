@@ -23,13 +23,18 @@ import (
 	"syscall"
 
 	"github.com/lastbackend/lastbackend/pkg/controller/envs"
+	"github.com/lastbackend/lastbackend/pkg/controller/http"
+	"github.com/lastbackend/lastbackend/pkg/distribution"
 	"github.com/lastbackend/lastbackend/pkg/log"
+	"github.com/lastbackend/lastbackend/pkg/util/metrics"
 
 	"context"
 	"os"
+	"time"
 
 	"github.com/lastbackend/lastbackend/pkg/controller/ipam"
 	"github.com/lastbackend/lastbackend/pkg/controller/runtime"
+	"github.com/lastbackend/lastbackend/pkg/controller/scheduler"
 	"github.com/lastbackend/lastbackend/pkg/storage"
 	"github.com/spf13/viper"
 )
@@ -45,22 +50,52 @@ func Daemon() bool {
 
 	log.Info("Start State Controller")
 
-	stg, err := storage.Get(viper.GetString("etcd"))
+	stg, err := storage.Get(viper.GetString("storage"))
 	if err != nil {
 		log.Fatalf("Cannot initialize storage: %s", err.Error())
 	}
 	env.SetStorage(stg)
 
+	if err := distribution.NewMigrationModel(context.Background(), stg).CPUMillicores(); err != nil {
+		log.Fatalf("Cannot migrate cpu resources: %s", err.Error())
+	}
+
 	ipm, err := ipam.New(viper.GetString("service.cidr"))
 	if err != nil {
 		log.Fatalf("Cannot initialize ipam service: %s", err.Error())
 	}
 	env.SetIPAM(ipm)
 
+	metrics.NewGaugeFunc("lb_controller_ipam_addresses", "Count of service IP addresses by state",
+		[]string{"state"}, func() []metrics.Sample {
+			return []metrics.Sample{
+				{Labels: []string{"available"}, Value: float64(ipm.Available())},
+				{Labels: []string{"reserved"}, Value: float64(ipm.Reserved())},
+			}
+		})
+
+	sch, err := scheduler.New(viper.GetString("controller.scheduler.strategy"))
+	if err != nil {
+		log.Fatalf("Cannot initialize scheduler: %s", err.Error())
+	}
+	env.SetScheduler(sch)
+
+	env.SetRevisionHistoryLimit(viper.GetInt("controller.revision_history_limit"))
+	env.SetNodeHeartbeatGrace(time.Duration(viper.GetInt("controller.node.heartbeat_grace")) * time.Second)
+	env.SetNodeEvictionTimeout(time.Duration(viper.GetInt("controller.node.eviction_timeout")) * time.Second)
+
 	// Initialize Runtime
 	r := runtime.NewRuntime(context.Background())
 	r.Loop()
 
+	if port := viper.GetInt("controller.metrics.port"); port > 0 {
+		go func() {
+			if err := http.Listen(viper.GetString("controller.metrics.host"), port); err != nil {
+				log.Fatalf("Http server start error: %v", err)
+			}
+		}()
+	}
+
 	// Handle SIGINT and SIGTERM.
 	signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM)
 
@@ -19,15 +19,23 @@
 package envs
 
 import (
+	"time"
+
 	"github.com/lastbackend/lastbackend/pkg/controller/ipam/ipam"
+	"github.com/lastbackend/lastbackend/pkg/controller/scheduler"
 	"github.com/lastbackend/lastbackend/pkg/storage"
 )
 
 var e Env
 
 type Env struct {
-	storage storage.Storage
-	ipam    ipam.IPAM
+	storage   storage.Storage
+	ipam      ipam.IPAM
+	scheduler *scheduler.Scheduler
+
+	revisionHistoryLimit int
+	nodeHeartbeatGrace   time.Duration
+	nodeEvictionTimeout  time.Duration
 }
 
 func Get() *Env {
@@ -49,3 +57,35 @@ func (c *Env) SetIPAM(ipam ipam.IPAM) {
 func (c *Env) GetIPAM() ipam.IPAM {
 	return c.ipam
 }
+
+func (c *Env) SetRevisionHistoryLimit(limit int) {
+	c.revisionHistoryLimit = limit
+}
+
+func (c *Env) GetRevisionHistoryLimit() int {
+	return c.revisionHistoryLimit
+}
+
+func (c *Env) SetScheduler(s *scheduler.Scheduler) {
+	c.scheduler = s
+}
+
+func (c *Env) GetScheduler() *scheduler.Scheduler {
+	return c.scheduler
+}
+
+func (c *Env) SetNodeHeartbeatGrace(grace time.Duration) {
+	c.nodeHeartbeatGrace = grace
+}
+
+func (c *Env) GetNodeHeartbeatGrace() time.Duration {
+	return c.nodeHeartbeatGrace
+}
+
+func (c *Env) SetNodeEvictionTimeout(timeout time.Duration) {
+	c.nodeEvictionTimeout = timeout
+}
+
+func (c *Env) GetNodeEvictionTimeout() time.Duration {
+	return c.nodeEvictionTimeout
+}
@@ -0,0 +1,263 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+
+package scheduler
+
+import (
+	"github.com/lastbackend/lastbackend/pkg/distribution/types"
+)
+
+// OnlineFilter excludes offline nodes
+type OnlineFilter struct{}
+
+func (OnlineFilter) Name() string {
+	return "online"
+}
+
+func (OnlineFilter) Reason() string {
+	return "node offline"
+}
+
+func (OnlineFilter) Filter(n *types.Node, r *Request) bool {
+	return n.Status.Online
+}
+
+// UnschedulableFilter excludes cordoned nodes
+type UnschedulableFilter struct{}
+
+func (UnschedulableFilter) Name() string {
+	return "unschedulable"
+}
+
+func (UnschedulableFilter) Reason() string {
+	return "node unschedulable"
+}
+
+func (UnschedulableFilter) Filter(n *types.Node, r *Request) bool {
+	return !n.Spec.Unschedulable
+}
+
+// BuilderFilter excludes nodes without builder role for build requests
+type BuilderFilter struct{}
+
+func (BuilderFilter) Name() string {
+	return "builder"
+}
+
+func (BuilderFilter) Reason() string {
+	return "node builder disabled"
+}
+
+func (BuilderFilter) Filter(n *types.Node, r *Request) bool {
+	return !r.Builder || n.Status.Role.Builder
+}
+
+// SelectorFilter checks node name and node labels
+type SelectorFilter struct{}
+
+func (SelectorFilter) Name() string {
+	return "selector"
+}
+
+func (SelectorFilter) Reason() string {
+	return "node selector not match"
+}
+
+func (SelectorFilter) Filter(n *types.Node, r *Request) bool {
+
+	if r.Node != types.EmptyString {
+		if r.Node != n.SelfLink() && r.Node != n.Meta.Name && r.Node != n.Meta.Hostname {
+			return false
+		}
+	}
+
+	for k, v := range r.Selector {
+		if l, ok := n.Meta.Labels[k]; !ok || l != v {
+			return false
+		}
+	}
+
+	return true
+}
+
+// PodsFilter checks node pods capacity
+type PodsFilter struct{}
+
+func (PodsFilter) Name() string {
+	return "pods"
+}
+
+func (PodsFilter) Reason() string {
+	return "too many pods"
+}
+
+func (PodsFilter) Filter(n *types.Node, r *Request) bool {
+
+	// skip volume and build requests and nodes without reported capacity
+	if r.Storage > 0 || r.Builder || n.Status.Capacity.Pods == 0 {
+		return true
+	}
+
+	return n.Status.Allocated.Pods < n.Status.Capacity.Pods
+}
+
+// MemoryFilter checks node free memory
+type MemoryFilter struct{}
+
+func (MemoryFilter) Name() string {
+	return "memory"
+}
+
+func (MemoryFilter) Reason() string {
+	return "insufficient memory"
+}
+
+func (MemoryFilter) Filter(n *types.Node, r *Request) bool {
+
+	if r.Storage > 0 && r.Memory == 0 {
+		return true
+	}
+
+	return (n.Status.Capacity.Memory - n.Status.Allocated.Memory) > r.Memory
+}
+
+// CPUFilter checks node free cpu
+type CPUFilter struct{}
+
+func (CPUFilter) Name() string {
+	return "cpu"
+}
+
+func (CPUFilter) Reason() string {
+	return "insufficient cpu"
+}
+
+func (CPUFilter) Filter(n *types.Node, r *Request) bool {
+
+	if r.CPU == 0 {
+		return true
+	}
+
+	return int64(n.Status.Capacity.Cpu-n.Status.Allocated.Cpu) >= r.CPU
+}
+
+// VolumeFilter checks that node has volumes used by pod
+type VolumeFilter struct{}
+
+func (VolumeFilter) Name() string {
+	return "volume"
+}
+
+func (VolumeFilter) Reason() string {
+	return "volume node conflict"
+}
+
+func (VolumeFilter) Filter(n *types.Node, r *Request) bool {
+
+	for _, v := range r.Volumes {
+		if v.Meta.Node != types.EmptyString && v.Meta.Node != n.SelfLink() {
+			return false
+		}
+	}
+
+	return true
+}
+
+// NodeAffinityFilter checks required node affinity and anti-affinity terms
+type NodeAffinityFilter struct{}
+
+func (NodeAffinityFilter) Name() string {
+	return "node-affinity"
+}
+
+func (NodeAffinityFilter) Reason() string {
+	return "node affinity not match"
+}
+
+func (NodeAffinityFilter) Filter(n *types.Node, r *Request) bool {
+
+	for _, t := range r.Affinity.Node {
+		if t.IsRequired() && !t.Match(n.Meta.Labels) {
+			return false
+		}
+	}
+
+	for _, t := range r.Affinity.NodeAnti {
+		if t.IsRequired() && t.Match(n.Meta.Labels) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// PodAffinityFilter checks required pod affinity and anti-affinity terms
+type PodAffinityFilter struct{}
+
+func (PodAffinityFilter) Name() string {
+	return "pod-affinity"
+}
+
+func (PodAffinityFilter) Reason() string {
+	return "pod affinity not match"
+}
+
+func (PodAffinityFilter) Filter(n *types.Node, r *Request) bool {
+
+	for _, t := range r.Affinity.Pod {
+
+		if !t.IsRequired() || podAffinityMatch(t, r.Pods[n.SelfLink()]) {
+			continue
+		}
+
+		// allow first pod of the group to be placed anywhere if it matches own term
+		if t.Match(r.Labels) && !podAffinityMatchAny(t, r.Pods) {
+			continue
+		}
+
+		return false
+	}
+
+	for _, t := range r.Affinity.PodAnti {
+		if t.IsRequired() && podAffinityMatch(t, r.Pods[n.SelfLink()]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// podAffinityMatch checks if any pod matches affinity term
+func podAffinityMatch(t types.SpecSelectorAffinityTerm, pods []map[string]string) bool {
+	for _, labels := range pods {
+		if t.Match(labels) {
+			return true
+		}
+	}
+	return false
+}
+
+// podAffinityMatchAny checks if any pod in cluster matches affinity term
+func podAffinityMatchAny(t types.SpecSelectorAffinityTerm, nodes map[string][]map[string]string) bool {
+	for _, pods := range nodes {
+		if podAffinityMatch(t, pods) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,222 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+
+package scheduler
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/lastbackend/lastbackend/pkg/distribution/types"
+)
+
+const (
+	// StrategySpread - spread deployment replicas across nodes, prefer less allocated nodes
+	StrategySpread = "spread"
+	// StrategyLeastAllocated - prefer nodes with more free resources
+	StrategyLeastAllocated = "least-allocated"
+	// StrategyMostAllocated - pack pods on nodes with less free resources
+	StrategyMostAllocated = "most-allocated"
+)
+
+// Request describes pod, volume or build requirements for node selection
+type Request struct {
+	// Node name to pin request to
+	Node string
+	// Node labels selector
+	Selector map[string]string
+	// Requested memory
+	Memory int64
+	// Requested cpu
+	CPU int64
+	// Requested storage
+	Storage int64
+	// Volumes used by pod
+	Volumes []*types.Volume
+	// Deployment replicas count by node
+	Replicas map[string]int
+	// Pod labels
+	Labels map[string]string
+	// Pod affinity and anti-affinity rules
+	Affinity types.SpecSelectorAffinity
+	// Labels of pods running on node by node
+	Pods map[string][]map[string]string
+	// Request requires node with builder role
+	Builder bool
+}
+
+// Filter excludes nodes which can not satisfy request
+type Filter interface {
+	// Name returns filter name
+	Name() string
+	// Reason returns message describing why node is filtered
+	Reason() string
+	// Filter returns true if node can satisfy request
+	Filter(n *types.Node, r *Request) bool
+}
+
+// Scorer rates nodes which passed filters
+type Scorer interface {
+	// Name returns scorer name
+	Name() string
+	// Score returns node rate in range from 0 to 1
+	Score(n *types.Node, r *Request) float64
+}
+
+type scorer struct {
+	Scorer
+	weight float64
+}
+
+// Scheduler selects node for request by filters and scorers chain
+type Scheduler struct {
+	filters []Filter
+	scorers []scorer
+}
+
+// NoNodeErr is returned when no node fits request
+type NoNodeErr struct {
+	message string
+}
+
+func (e *NoNodeErr) Error() string {
+	return e.message
+}
+
+// IsNoNodeErr checks if error is returned because no node fits request
+func IsNoNodeErr(err error) bool {
+	_, ok := err.(*NoNodeErr)
+	return ok
+}
+
+// AddFilter adds filter to scheduler filters chain
+func (s *Scheduler) AddFilter(f Filter) {
+	s.filters = append(s.filters, f)
+}
+
+// AddScorer adds scorer with provided weight to scheduler scorers chain
+func (s *Scheduler) AddScorer(sc Scorer, weight float64) {
+	s.scorers = append(s.scorers, scorer{Scorer: sc, weight: weight})
+}
+
+// Schedule returns node with highest score from nodes passed all filters
+func (s *Scheduler) Schedule(nodes map[string]*types.Node, r *Request) (*types.Node, error) {
+
+	if len(nodes) == 0 {
+		return nil, &NoNodeErr{message: "no nodes available in cluster"}
+	}
+
+	var (
+		links    = make([]string, 0)
+		filtered = make(map[string]int)
+		node     *types.Node
+		score    float64
+	)
+
+	// sort nodes to get the same result for the same cluster state
+	for l := range nodes {
+		links = append(links, l)
+	}
+	sort.Strings(links)
+
+	for _, l := range links {
+
+		var (
+			n  = nodes[l]
+			ok = true
+		)
+
+		for _, f := range s.filters {
+			if !f.Filter(n, r) {
+				filtered[f.Reason()]++
+				ok = false
+				break
+			}
+		}
+
+		if !ok {
+			continue
+		}
+
+		var total float64
+		for _, sc := range s.scorers {
+			total += sc.weight * sc.Score(n, r)
+		}
+
+		if node == nil || total > score {
+			node = n
+			score = total
+		}
+	}
+
+	if node == nil {
+		return nil, &NoNodeErr{message: noNodeMessage(len(nodes), filtered)}
+	}
+
+	return node, nil
+}
+
+// noNodeMessage describes filters results
+// example: 0/3 nodes available: 1 selector labels not match, 2 insufficient memory
+func noNodeMessage(total int, filtered map[string]int) string {
+
+	var reasons = make([]string, 0)
+
+	for r, c := range filtered {
+		reasons = append(reasons, fmt.Sprintf("%d %s", c, r))
+	}
+
+	sort.Strings(reasons)
+	return fmt.Sprintf("0/%d nodes available: %s", total, strings.Join(reasons, ", "))
+}
+
+// New returns scheduler with default filters and scorers for provided strategy
+func New(strategy string) (*Scheduler, error) {
+
+	var s = new(Scheduler)
+
+	s.AddFilter(new(OnlineFilter))
+	s.AddFilter(new(UnschedulableFilter))
+	s.AddFilter(new(BuilderFilter))
+	s.AddFilter(new(SelectorFilter))
+	s.AddFilter(new(PodsFilter))
+	s.AddFilter(new(MemoryFilter))
+	s.AddFilter(new(CPUFilter))
+	s.AddFilter(new(VolumeFilter))
+	s.AddFilter(new(NodeAffinityFilter))
+	s.AddFilter(new(PodAffinityFilter))
+
+	// preferred affinity terms take precedence over strategy
+	s.AddScorer(new(AffinityScorer), 3)
+
+	switch strategy {
+	case types.EmptyString, StrategySpread:
+		s.AddScorer(new(SpreadScorer), 2)
+		s.AddScorer(new(LeastAllocatedScorer), 1)
+	case StrategyLeastAllocated:
+		s.AddScorer(new(LeastAllocatedScorer), 1)
+	case StrategyMostAllocated:
+		s.AddScorer(new(MostAllocatedScorer), 1)
+	default:
+		return nil, errors.New(fmt.Sprintf("unknown scheduler strategy: %s", strategy))
+	}
+
+	return s, nil
+}
@@ -0,0 +1,290 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+
+package scheduler
+
+import (
+	"testing"
+
+	"github.com/lastbackend/lastbackend/pkg/distribution/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func getNodeAsset(name string, memory int64, pods int, labels map[string]string) *types.Node {
+	n := new(types.Node)
+	n.Meta.Name = name
+	n.Meta.SelfLink = name
+	n.Meta.Labels = labels
+	n.Status.Online = true
+	n.Status.Capacity.Memory = memory
+	n.Status.Capacity.Pods = pods
+	n.Status.Capacity.Cpu = 1000
+	return n
+}
+
+func TestSchedulerSchedule(t *testing.T) {
+
+	type args struct {
+		strategy string
+		nodes    map[string]*types.Node
+		request  *Request
+	}
+
+	type want struct {
+		node string
+		err  string
+	}
+
+	var (
+		n1 = getNodeAsset("n1", 1000, 10, map[string]string{"zone": "a"})
+		n2 = getNodeAsset("n2", 2000, 10, map[string]string{"zone": "b"})
+		n3 = getNodeAsset("n3", 500, 10, nil)
+	)
+
+	nodes := map[string]*types.Node{"n1": n1, "n2": n2, "n3": n3}
+
+	vol := new(types.Volume)
+	vol.Meta.Node = "n3"
+
+	busy := getNodeAsset("n2", 2000, 10, nil)
+	busy.Status.Allocated.Cpu = 800
+
+	offline := getNodeAsset("n2", 2000, 10, nil)
+	offline.Status.Online = false
+	cordoned := getNodeAsset("n2", 2000, 10, nil)
+	cordoned.Spec.Unschedulable = true
+	builder := getNodeAsset("n3", 500, 10, nil)
+	builder.Status.Role.Builder = true
+
+	tests := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			"no nodes in cluster",
+			args{StrategySpread, map[string]*types.Node{}, &Request{Memory: 100}},
+			want{err: "no nodes available in cluster"},
+		},
+		{
+			"least allocated node",
+			args{StrategyLeastAllocated, nodes, &Request{Memory: 100}},
+			want{node: "n2"},
+		},
+		{
+			"most allocated node",
+			args{StrategyMostAllocated, nodes, &Request{Memory: 100}},
+			want{node: "n3"},
+		},
+		{
+			"allocated cpu",
+			args{StrategyLeastAllocated, map[string]*types.Node{"n1": n1, "n2": busy}, &Request{Memory: 100, CPU: 500}},
+			want{node: "n1"},
+		},
+		{
+			"offline node",
+			args{StrategyLeastAllocated, map[string]*types.Node{"n1": n1, "n2": offline}, &Request{Memory: 100}},
+			want{node: "n1"},
+		},
+		{
+			"unschedulable node",
+			args{StrategyLeastAllocated, map[string]*types.Node{"n1": n1, "n2": cordoned}, &Request{Memory: 100}},
+			want{node: "n1"},
+		},
+		{
+			"spread deployment replicas",
+			args{StrategySpread, nodes, &Request{Memory: 100, Replicas: map[string]int{"n2": 2, "n3": 1}}},
+			want{node: "n1"},
+		},
+		{
+			"selector labels",
+			args{StrategySpread, nodes, &Request{Memory: 100, Selector: map[string]string{"zone": "a"}}},
+			want{node: "n1"},
+		},
+		{
+			"node name",
+			args{StrategySpread, nodes, &Request{Memory: 100, Node: "n3"}},
+			want{node: "n3"},
+		},
+		{
+			"volume locality",
+			args{StrategySpread, nodes, &Request{Memory: 100, Volumes: []*types.Volume{vol}}},
+			want{node: "n3"},
+		},
+		{
+			"builder node",
+			args{StrategySpread, map[string]*types.Node{"n1": n1, "n2": n2, "n3": builder}, &Request{Builder: true}},
+			want{node: "n3"},
+		},
+		{
+			"no builder nodes",
+			args{StrategySpread, nodes, &Request{Builder: true}},
+			want{err: "0/3 nodes available: 3 node builder disabled"},
+		},
+		{
+			"insufficient resources",
+			args{StrategySpread, nodes, &Request{Memory: 1500, CPU: 2000}},
+			want{err: "0/3 nodes available: 1 insufficient cpu, 2 insufficient memory"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+
+			s, err := New(tc.args.strategy)
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			n, err := s.Schedule(tc.args.nodes, tc.args.request)
+			if tc.want.err != types.EmptyString {
+				if !assert.Error(t, err, "error should be presented") {
+					return
+				}
+				assert.True(t, IsNoNodeErr(err), "error type is different")
+				assert.Equal(t, tc.want.err, err.Error(), "err message different")
+				return
+			}
+
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			assert.Equal(t, tc.want.node, n.Meta.Name, "node is different")
+		})
+	}
+}
+
+func TestSchedulerNewUnknownStrategy(t *testing.T) {
+	_, err := New("unknown")
+	assert.Error(t, err, "error should be presented")
+}
+
+func TestSchedulerAffinity(t *testing.T) {
+
+	type want struct {
+		node string
+		err  string
+	}
+
+	var (
+		n1 = getNodeAsset("n1", 1000, 10, map[string]string{"zone": "a"})
+		n2 = getNodeAsset("n2", 1000, 10, map[string]string{"zone": "b"})
+		n3 = getNodeAsset("n3", 1000, 10, map[string]string{"zone": "b", "disk": "ssd"})
+	)
+
+	nodes := map[string]*types.Node{"n1": n1, "n2": n2, "n3": n3}
+
+	var (
+		db    = map[string]string{"app": "db"}
+		cache = map[string]string{"app": "cache"}
+		pods  = map[string][]map[string]string{"n1": {db}, "n2": {cache}}
+	)
+
+	term := func(tp string, labels map[string]string) types.SpecSelectorAffinityTerm {
+		return types.SpecSelectorAffinityTerm{Type: tp, Weight: 1, Labels: labels}
+	}
+
+	tests := []struct {
+		name    string
+		request *Request
+		want    want
+	}{
+		{
+			"required node affinity",
+			&Request{Affinity: types.SpecSelectorAffinity{
+				Node: []types.SpecSelectorAffinityTerm{term(types.SpecAffinityTypeRequired, map[string]string{"disk": "ssd"})},
+			}},
+			want{node: "n3"},
+		},
+		{
+			"required node anti-affinity",
+			&Request{Affinity: types.SpecSelectorAffinity{
+				NodeAnti: []types.SpecSelectorAffinityTerm{term(types.SpecAffinityTypeRequired, map[string]string{"zone": "b"})},
+			}},
+			want{node: "n1"},
+		},
+		{
+			"required pod affinity",
+			&Request{Pods: pods, Affinity: types.SpecSelectorAffinity{
+				Pod: []types.SpecSelectorAffinityTerm{term(types.SpecAffinityTypeRequired, cache)},
+			}},
+			want{node: "n2"},
+		},
+		{
+			"required pod affinity first pod of the group",
+			&Request{Labels: db, Affinity: types.SpecSelectorAffinity{
+				Pod: []types.SpecSelectorAffinityTerm{term(types.SpecAffinityTypeRequired, db)},
+			}},
+			want{node: "n1"},
+		},
+		{
+			"required pod anti-affinity",
+			&Request{Labels: db, Pods: map[string][]map[string]string{"n1": {db}, "n2": {db}}, Affinity: types.SpecSelectorAffinity{
+				PodAnti: []types.SpecSelectorAffinityTerm{term(types.SpecAffinityTypeRequired, db)},
+			}},
+			want{node: "n3"},
+		},
+		{
+			"required pod anti-affinity not satisfied",
+			&Request{Labels: db, Pods: map[string][]map[string]string{"n1": {db}, "n2": {db}, "n3": {db}}, Affinity: types.SpecSelectorAffinity{
+				PodAnti: []types.SpecSelectorAffinityTerm{term(types.SpecAffinityTypeRequired, db)},
+			}},
+			want{err: "0/3 nodes available: 3 pod affinity not match"},
+		},
+		{
+			"preferred pod affinity",
+			&Request{Pods: pods, Affinity: types.SpecSelectorAffinity{
+				Pod: []types.SpecSelectorAffinityTerm{term(types.SpecAffinityTypePreferred, cache)},
+			}},
+			want{node: "n2"},
+		},
+		{
+			"preferred node anti-affinity",
+			&Request{Affinity: types.SpecSelectorAffinity{
+				NodeAnti: []types.SpecSelectorAffinityTerm{term(types.SpecAffinityTypePreferred, map[string]string{"zone": "a"})},
+			}},
+			want{node: "n2"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+
+			s, err := New(StrategySpread)
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			n, err := s.Schedule(nodes, tc.request)
+			if tc.want.err != types.EmptyString {
+				if !assert.Error(t, err, "error should be presented") {
+					return
+				}
+				assert.Equal(t, tc.want.err, err.Error(), "err message different")
+				return
+			}
+
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			assert.Equal(t, tc.want.node, n.Meta.Name, "node is different")
+		})
+	}
+}
@@ -0,0 +1,149 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+
+package scheduler
+
+import (
+	"github.com/lastbackend/lastbackend/pkg/distribution/types"
+)
+
+// LeastAllocatedScorer prefers nodes with more free memory, cpu and pods slots
+type LeastAllocatedScorer struct{}
+
+func (LeastAllocatedScorer) Name() string {
+	return "least-allocated"
+}
+
+func (LeastAllocatedScorer) Score(n *types.Node, r *Request) float64 {
+	return nodeFreeRatio(n, r)
+}
+
+// MostAllocatedScorer prefers nodes with less free memory, cpu and pods slots
+type MostAllocatedScorer struct{}
+
+func (MostAllocatedScorer) Name() string {
+	return "most-allocated"
+}
+
+func (MostAllocatedScorer) Score(n *types.Node, r *Request) float64 {
+	return 1 - nodeFreeRatio(n, r)
+}
+
+// SpreadScorer prefers nodes with less replicas of the same deployment
+type SpreadScorer struct{}
+
+func (SpreadScorer) Name() string {
+	return "spread"
+}
+
+func (SpreadScorer) Score(n *types.Node, r *Request) float64 {
+	return 1 / float64(1+r.Replicas[n.SelfLink()])
+}
+
+// AffinityScorer prefers nodes which satisfy preferred affinity terms
+type AffinityScorer struct{}
+
+func (AffinityScorer) Name() string {
+	return "affinity"
+}
+
+func (AffinityScorer) Score(n *types.Node, r *Request) float64 {
+
+	var (
+		total     int
+		satisfied int
+		pods      = r.Pods[n.SelfLink()]
+	)
+
+	score := func(terms []types.SpecSelectorAffinityTerm, match func(t types.SpecSelectorAffinityTerm) bool) {
+		for _, t := range terms {
+
+			if t.IsRequired() {
+				continue
+			}
+
+			weight := t.Weight
+			if weight <= 0 {
+				weight = 1
+			}
+
+			total += weight
+			if match(t) {
+				satisfied += weight
+			}
+		}
+	}
+
+	score(r.Affinity.Node, func(t types.SpecSelectorAffinityTerm) bool {
+		return t.Match(n.Meta.Labels)
+	})
+	score(r.Affinity.NodeAnti, func(t types.SpecSelectorAffinityTerm) bool {
+		return !t.Match(n.Meta.Labels)
+	})
+	score(r.Affinity.Pod, func(t types.SpecSelectorAffinityTerm) bool {
+		return podAffinityMatch(t, pods)
+	})
+	score(r.Affinity.PodAnti, func(t types.SpecSelectorAffinityTerm) bool {
+		return !podAffinityMatch(t, pods)
+	})
+
+	if total == 0 {
+		return 0
+	}
+
+	return float64(satisfied) / float64(total)
+}
+
+// nodeFreeRatio returns average free resources ratio after request placement
+func nodeFreeRatio(n *types.Node, r *Request) float64 {
+
+	var (
+		ratio float64
+		count float64
+	)
+
+	if n.Status.Capacity.Memory > 0 {
+		free := n.Status.Capacity.Memory - n.Status.Allocated.Memory - r.Memory
+		ratio += float64(free) / float64(n.Status.Capacity.Memory)
+		count++
+	}
+
+	if n.Status.Capacity.Cpu > 0 {
+		free := int64(n.Status.Capacity.Cpu-n.Status.Allocated.Cpu) - r.CPU
+		ratio += float64(free) / float64(n.Status.Capacity.Cpu)
+		count++
+	}
+
+	if n.Status.Capacity.Pods > 0 {
+		free := n.Status.Capacity.Pods - n.Status.Allocated.Pods - 1
+		ratio += float64(free) / float64(n.Status.Capacity.Pods)
+		count++
+	}
+
+	if count == 0 {
+		return 0
+	}
+
+	ratio = ratio / count
+
+	if ratio < 0 {
+		return 0
+	}
+
+	return ratio
+}
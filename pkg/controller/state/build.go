@@ -0,0 +1,112 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+
+package state
+
+import (
+	"context"
+	"time"
+
+	"github.com/lastbackend/lastbackend/pkg/controller/envs"
+	"github.com/lastbackend/lastbackend/pkg/controller/state/cluster"
+	"github.com/lastbackend/lastbackend/pkg/distribution"
+	"github.com/lastbackend/lastbackend/pkg/distribution/types"
+	"github.com/lastbackend/lastbackend/pkg/log"
+)
+
+const logBuildPrefix = "state:build"
+
+// buildProvision leases builder node for created build
+func buildProvision(cs *cluster.ClusterState, b *types.Build) error {
+
+	if b.Status.State != types.StateCreated {
+		return nil
+	}
+
+	var (
+		bm     = distribution.NewBuildModel(context.Background(), envs.Get().GetStorage())
+		status = b.Status
+	)
+
+	node, err := cs.BuildLease(b)
+	if err != nil {
+
+		// no online nodes with builder role
+		if !cluster.IsNodeLeaseErr(err) {
+			log.Errorf("%s:provision:> build %s node lease err: %s", logBuildPrefix, b.SelfLink(), err.Error())
+			return err
+		}
+
+		status.State = types.StateError
+		status.Message = err.Error()
+		status.Finished = time.Now().UTC()
+		return bm.SetStatus(b, &status)
+	}
+
+	log.V(logLevel).Debugf("%s:provision:> build %s scheduled to node %s", logBuildPrefix, b.SelfLink(), node.SelfLink())
+
+	status.State = types.StateProvision
+	status.Node = node.SelfLink()
+	return bm.SetStatus(b, &status)
+}
+
+// buildServiceUpdate sets built image to service containers and starts service rolling update
+func buildServiceUpdate(b *types.Build) error {
+
+	if b.Status.State != types.StateReady || b.Spec.Service == types.EmptyString {
+		return nil
+	}
+
+	sm := distribution.NewServiceModel(context.Background(), envs.Get().GetStorage())
+
+	svc, err := sm.Get(b.Meta.Namespace, b.Spec.Service)
+	if err != nil {
+		log.Errorf("%s:service:> get service %s err: %s", logBuildPrefix, b.Spec.Service, err.Error())
+		return err
+	}
+	if svc == nil || svc.Spec.State.Destroy {
+		log.V(logLevel).Warnf("%s:service:> service %s for build %s not found", logBuildPrefix, b.Spec.Service, b.SelfLink())
+		return nil
+	}
+
+	var updated bool
+	for _, c := range svc.Spec.Template.Containers {
+
+		if b.Spec.Container != types.EmptyString && c.Name != b.Spec.Container {
+			continue
+		}
+
+		c.Image.Name = b.Spec.Image.Name
+		if b.Spec.Image.Secret != types.EmptyString {
+			c.Image.Secret = b.Spec.Image.Secret
+		}
+		updated = true
+	}
+
+	if !updated {
+		log.V(logLevel).Warnf("%s:service:> container %s not found in service %s", logBuildPrefix, b.Spec.Container, svc.SelfLink())
+		return nil
+	}
+
+	log.V(logLevel).Debugf("%s:service:> update service %s image to %s", logBuildPrefix, svc.SelfLink(), b.Spec.Image.Name)
+
+	svc.Spec.Template.Updated = time.Now()
+	svc.Status.State = types.StateProvision
+
+	return sm.Set(svc)
+}
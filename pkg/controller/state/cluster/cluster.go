@@ -18,7 +18,63 @@
 
 package cluster
 
+import (
+	"context"
+
+	"github.com/lastbackend/lastbackend/pkg/controller/envs"
+	"github.com/lastbackend/lastbackend/pkg/distribution"
+	"github.com/lastbackend/lastbackend/pkg/distribution/types"
+	"github.com/lastbackend/lastbackend/pkg/log"
+)
+
+// clusterStatusState summarizes nodes capacity, nodes allocation and volumes into cluster status
 func clusterStatusState(cs *ClusterState) error {
 
+	if cs.cluster == nil {
+		return nil
+	}
+
+	var status = types.ClusterStatus{
+		Deleted: cs.cluster.Status.Deleted,
+	}
+
+	for _, n := range cs.node.list {
+
+		status.Nodes.Total++
+
+		if n.Status.Online {
+			status.Nodes.Online++
+		} else {
+			status.Nodes.Offline++
+		}
+
+		status.Capacity.Containers += n.Status.Capacity.Containers
+		status.Capacity.Pods += n.Status.Capacity.Pods
+		status.Capacity.Memory += n.Status.Capacity.Memory
+		status.Capacity.Cpu += n.Status.Capacity.Cpu
+		status.Capacity.Storage += int64(n.Status.Capacity.Storage)
+
+		status.Allocated.Containers += n.Status.Allocated.Containers
+		status.Allocated.Pods += n.Status.Allocated.Pods
+		status.Allocated.Memory += n.Status.Allocated.Memory
+		status.Allocated.Cpu += n.Status.Allocated.Cpu
+	}
+
+	for _, v := range cs.volume.list {
+		status.Allocated.Storage += v.Spec.Capacity.Storage
+	}
+
+	if status == cs.cluster.Status {
+		return nil
+	}
+
+	cs.cluster.Status = status
+
+	cm := distribution.NewClusterModel(context.Background(), envs.Get().GetStorage())
+	if err := cm.Set(cs.cluster); err != nil {
+		log.Errorf("%s:> cluster status update err: %s", logPrefix, err.Error())
+		return err
+	}
+
 	return nil
 }
@@ -20,11 +20,21 @@ package cluster
 
 import (
 	"context"
+	"fmt"
+	"strings"
+	"time"
+
 	"github.com/lastbackend/lastbackend/pkg/controller/envs"
+	"github.com/lastbackend/lastbackend/pkg/controller/scheduler"
 	"github.com/lastbackend/lastbackend/pkg/distribution"
+	"github.com/lastbackend/lastbackend/pkg/distribution/errors"
 	"github.com/lastbackend/lastbackend/pkg/distribution/types"
+	"github.com/lastbackend/lastbackend/pkg/log"
 )
 
+// nodeUpdateRetries - attempts to update node status if node was changed concurrently
+const nodeUpdateRetries = 3
+
 type NodeLease struct {
 	done     chan bool
 	Request  NodeLeaseOptions
@@ -35,10 +45,38 @@ type NodeLease struct {
 }
 
 type NodeLeaseOptions struct {
-	Node    *string
-	Memory  *int64
-	Storage *int64
+	Node     *string
+	Memory   *int64
+	CPU      *int64
+	Storage  *int64
 	Selector map[string]string
+	Volumes  []string
+	Replicas map[string]int
+	Labels   map[string]string
+	Affinity *types.SpecSelectorAffinity
+	Pods     map[string][]map[string]string
+	Builder  bool
+	// Cancel releases resources reserved by lease in cluster state only
+	Cancel bool
+}
+
+// NodeLeaseErr describes why no node can be leased for request
+type NodeLeaseErr struct {
+	message string
+}
+
+func (e *NodeLeaseErr) Error() string {
+	return e.message
+}
+
+func newNodeLeaseErr(message string) *NodeLeaseErr {
+	return &NodeLeaseErr{message: message}
+}
+
+// IsNodeLeaseErr checks if error is returned because no node fits lease request
+func IsNodeLeaseErr(err error) bool {
+	_, ok := err.(*NodeLeaseErr)
+	return ok
 }
 
 func (nl *NodeLease) Wait() {
@@ -51,22 +89,60 @@ func handleNodeLease(cs *ClusterState, nl *NodeLease) error {
 		nl.done <- true
 	}()
 
-	for _, n := range cs.node.list {
+	var r = new(scheduler.Request)
+
+	if nl.Request.Node != nil {
+		r.Node = *nl.Request.Node
+	}
 
-		if (n.Status.Capacity.Memory - n.Status.Allocated.Memory) > *nl.Request.Memory {
+	if nl.Request.Memory != nil {
+		r.Memory = *nl.Request.Memory
+	}
 
-			n.Status.Allocated.Pods++
-			n.Status.Allocated.Memory += *nl.Request.Memory
+	if nl.Request.CPU != nil {
+		r.CPU = *nl.Request.CPU
+	}
 
-			nm := distribution.NewNodeModel(context.Background(), envs.Get().GetStorage())
-			nm.Set(n)
+	if nl.Request.Storage != nil {
+		r.Storage = *nl.Request.Storage
+	}
 
-			nl.Response.Node = n
-			return nil
+	r.Selector = nl.Request.Selector
+	// resolve volumes used by pod to get volumes locality
+	for _, link := range nl.Request.Volumes {
+		if v, ok := cs.volume.list[link]; ok {
+			r.Volumes = append(r.Volumes, v)
 		}
+	}
+
+	r.Replicas = nl.Request.Replicas
+	r.Labels = nl.Request.Labels
+	r.Pods = nl.Request.Pods
+	r.Builder = nl.Request.Builder
+
+	if nl.Request.Affinity != nil {
+		r.Affinity = *nl.Request.Affinity
+	}
+
+	n, err := cs.scheduler.Schedule(cs.node.list, r)
+	if err != nil {
+		nl.Response.Err = newNodeLeaseErr(err.Error())
+		return nil
+	}
+
+	// builds are not counted in node allocated resources,
+	// pod resources are reserved in cluster state and stored in node with pod provision
+	if r.Storage == 0 && !r.Builder {
+		n = nodeStatusReserve(cs, n, func(status *types.NodeStatus) {
+			status.Allocated.Pods++
+			status.Allocated.Memory += r.Memory
+			status.Allocated.Cpu += int(r.CPU)
+		})
 
+		clusterStatusState(cs)
 	}
 
+	nl.Response.Node = n
 	return nil
 }
 
@@ -81,11 +157,259 @@ func handleNodeRelease(cs *ClusterState, nl *NodeLease) error {
 	}
 
 	n := cs.node.list[*nl.Request.Node]
-	n.Status.Allocated.Pods--
-	n.Status.Allocated.Memory -= *nl.Request.Memory
 
-	nm := distribution.NewNodeModel(context.Background(), envs.Get().GetStorage())
-	nm.Set(n)
+	if nl.Request.Storage != nil && *nl.Request.Storage != 0 {
+		return nil
+	}
+
+	release := func(status *types.NodeStatus) {
+		status.Allocated.Pods--
+		if nl.Request.Memory != nil {
+			status.Allocated.Memory -= *nl.Request.Memory
+		}
+		if nl.Request.CPU != nil {
+			status.Allocated.Cpu -= int(*nl.Request.CPU)
+		}
+	}
+
+	if nl.Request.Cancel {
+		nl.Response.Node = nodeStatusReserve(cs, n, release)
+		clusterStatusState(cs)
+		return nil
+	}
+
+	err := nodeStatusUpdate(n, release)
+	if err != nil {
+		log.Errorf("%s:> node %s release err: %s", logPrefix, n.SelfLink(), err.Error())
+		nl.Response.Err = err
+		return err
+	}
+
+	clusterStatusState(cs)
+
+	return nil
+}
+
+// handleNodeHeartbeat marks nodes with expired heartbeat as offline
+// and moves pods from nodes which are offline longer than eviction timeout
+func handleNodeHeartbeat(cs *ClusterState) error {
+
+	var (
+		grace    = envs.Get().GetNodeHeartbeatGrace()
+		eviction = envs.Get().GetNodeEvictionTimeout()
+	)
+
+	if grace == 0 {
+		return nil
+	}
+
+	for _, n := range cs.node.list {
+
+		// node never reported status
+		if n.Status.Heartbeat.IsZero() {
+			continue
+		}
+
+		if n.Status.Online {
+			delete(cs.node.evicted, n.SelfLink())
+		}
+
+		since := time.Since(n.Status.Heartbeat)
+
+		if n.Status.Online && since > grace {
+
+			log.V(logLevel).Debugf("%s:> node %s heartbeat expired: mark as offline", logPrefix, n.SelfLink())
+
+			err := nodeStatusUpdate(n, func(status *types.NodeStatus) {
+				status.Online = false
+			})
+			if err != nil {
+				log.Errorf("%s:> node %s set offline err: %s", logPrefix, n.SelfLink(), err.Error())
+				continue
+			}
+
+			clusterStatusState(cs)
+		}
+
+		if n.Status.Online || eviction == 0 || since < eviction || cs.node.evicted[n.SelfLink()] {
+			continue
+		}
+
+		if err := nodeEvict(cs, n); err != nil {
+			log.Errorf("%s:> node %s evict pods err: %s", logPrefix, n.SelfLink(), err.Error())
+			continue
+		}
+
+		cs.node.evicted[n.SelfLink()] = true
+	}
+
+	return nil
+}
+
+// nodeEvict moves pods from offline node to provision state without node
+// service state observer leases new node for them through pod provision
+func nodeEvict(cs *ClusterState, n *types.Node) error {
+
+	log.V(logLevel).Debugf("%s:> evict pods from node %s", logPrefix, n.SelfLink())
+
+	var (
+		pm = distribution.NewPodModel(context.Background(), envs.Get().GetStorage())
+
+		// resources released by evicted pods
+		pods     int
+		RAM, CPU int64
+	)
+
+	mf, err := pm.ManifestMap(n.SelfLink())
+	if err != nil {
+		return err
+	}
+
+	if mf == nil {
+		return nil
+	}
+
+	for link := range mf.Items {
+
+		keys := strings.Split(link, ":")
+		if len(keys) != 4 {
+			continue
+		}
+
+		p, err := pm.Get(keys[0], keys[1], keys[2], keys[3])
+		if err != nil {
+			return err
+		}
+
+		if p != nil && p.Meta.Node == n.SelfLink() {
+
+			ram, cpu := podResources(p)
+
+			pods++
+			RAM += ram
+			CPU += cpu
+
+			p.Meta.Node = types.EmptyString
+			p.Meta.Updated = time.Now()
+
+			if p.Spec.State.Destroy {
+				p.Status.SetDestroyed()
+			} else {
+				p.Status.SetProvision()
+				p.Status.Message = fmt.Sprintf("node %s is offline: pod is moved to another node", n.SelfLink())
+			}
+
+			if err := pm.Update(p); err != nil {
+				return err
+			}
+		}
+
+		if err := pm.ManifestDel(n.SelfLink(), link); err != nil {
+			return err
+		}
+	}
+
+	err = nodeStatusUpdate(n, func(status *types.NodeStatus) {
+		status.Allocated.Pods -= pods
+		status.Allocated.Memory -= RAM
+		status.Allocated.Cpu -= int(CPU)
+	})
+	if err != nil {
+		return err
+	}
+
+	return clusterStatusState(cs)
+}
+
+// handleNodeDrain marks draining nodes without pods as drained
+// and asks services to move pods from draining nodes
+func handleNodeDrain(cs *ClusterState) error {
+
+	cs.node.drain.lock.RLock()
+	handler := cs.node.drain.handler
+	cs.node.drain.lock.RUnlock()
+
+	pm := distribution.NewPodModel(context.Background(), envs.Get().GetStorage())
+
+	for _, n := range cs.node.list {
+
+		if !n.Spec.Drain || n.Status.Drained {
+			continue
+		}
+
+		mf, err := pm.ManifestMap(n.SelfLink())
+		if err != nil {
+			log.Errorf("%s:> node %s get pods err: %s", logPrefix, n.SelfLink(), err.Error())
+			continue
+		}
+
+		if mf == nil || len(mf.Items) == 0 {
+
+			log.V(logLevel).Debugf("%s:> node %s is drained", logPrefix, n.SelfLink())
+
+			err := nodeStatusUpdate(n, func(status *types.NodeStatus) {
+				status.Drained = true
+			})
+			if err != nil {
+				log.Errorf("%s:> node %s set drained err: %s", logPrefix, n.SelfLink(), err.Error())
+			}
+			continue
+		}
+
+		// service observers lease nodes through cluster state loop: do not block it,
+		// node is not drained again until previous drain is finished
+		if handler != nil && cs.drainStart(n.SelfLink()) {
+			go func(node string) {
+				defer cs.drainDone(node)
+				handler(node)
+			}(n.SelfLink())
+		}
+	}
 
 	return nil
 }
+
+// nodeStatusReserve updates node status in cluster state only, node in storage is not changed,
+// node is copied as leased node can be used outside of cluster state loop
+func nodeStatusReserve(cs *ClusterState, n *types.Node, update func(status *types.NodeStatus)) *types.Node {
+
+	node := *n
+	update(&node.Status)
+	cs.node.list[node.SelfLink()] = &node
+
+	return &node
+}
+
+// nodeStatusUpdate applies status changes to the node read from storage and saves it with revision check,
+// so node changes made through api (cordon, drain) are not overwritten by cached node copy.
+// Cached node is replaced by saved node on success
+func nodeStatusUpdate(n *types.Node, update func(status *types.NodeStatus)) error {
+
+	nm := distribution.NewNodeModel(context.Background(), envs.Get().GetStorage())
+
+	for i := 0; ; i++ {
+
+		node, err := nm.Get(n.Meta.Name)
+		if err != nil {
+			return err
+		}
+
+		if node == nil {
+			return errors.New(errors.ErrEntityNotFound)
+		}
+
+		update(&node.Status)
+
+		err = nm.Update(node)
+		if err == nil {
+			*n = *node
+			return nil
+		}
+
+		if !errors.Storage().IsErrEntityRevision(err) || i >= nodeUpdateRetries {
+			return err
+		}
+
+		log.V(logLevel).Debugf("%s:> node %s was changed: retry update", logPrefix, n.SelfLink())
+	}
+}
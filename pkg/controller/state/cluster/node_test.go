@@ -0,0 +1,106 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+
+package cluster
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/lastbackend/lastbackend/pkg/controller/envs"
+	"github.com/lastbackend/lastbackend/pkg/distribution"
+	"github.com/lastbackend/lastbackend/pkg/distribution/types"
+	"github.com/lastbackend/lastbackend/pkg/storage"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandleNodeDrain(t *testing.T) {
+
+	stg, _ := storage.Get("mock")
+	envs.Get().SetStorage(stg)
+
+	n := new(types.Node)
+	n.Meta.Name = "node"
+	n.Spec.Drain = true
+	n.SelfLink()
+
+	pm := distribution.NewPodModel(context.Background(), stg)
+	if !assert.NoError(t, pm.ManifestAdd(n.SelfLink(), "ns:svc:dp:pod", new(types.PodManifest))) {
+		return
+	}
+
+	// cluster state is created without observe loop, ticks are called directly
+	cs := new(ClusterState)
+	cs.node.list = map[string]*types.Node{n.SelfLink(): n}
+	cs.node.drain.list = make(map[string]bool)
+	cs.node.drain.active = make(map[string]bool)
+
+	var (
+		calls   = make(chan string, 10)
+		release = make(chan bool)
+	)
+
+	cs.OnNodeDrain(func(node string) {
+		calls <- node
+		<-release
+	})
+
+	for i := 0; i < 3; i++ {
+		if !assert.NoError(t, handleNodeDrain(cs)) {
+			return
+		}
+	}
+
+	select {
+	case node := <-calls:
+		assert.Equal(t, n.SelfLink(), node, "drained node mismatch")
+	case <-time.After(time.Second):
+		t.Error("drain handler is not called")
+		return
+	}
+
+	select {
+	case <-calls:
+		t.Error("drain handler should not run while previous drain is not finished")
+		return
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	release <- true
+
+	// node with pods is drained again on next tick after previous drain is finished
+	for i := 0; ; i++ {
+
+		if !assert.NoError(t, handleNodeDrain(cs)) {
+			return
+		}
+
+		select {
+		case <-calls:
+			close(release)
+			return
+		case <-time.After(10 * time.Millisecond):
+		}
+
+		if i > 100 {
+			t.Error("drain handler should be called after previous drain is finished")
+			return
+		}
+	}
+}
@@ -20,9 +20,12 @@ package cluster
 
 import (
 	"context"
+	"sync"
+	"time"
 
 	"github.com/lastbackend/lastbackend/pkg/controller/envs"
 	"github.com/lastbackend/lastbackend/pkg/controller/ipam/ipam"
+	"github.com/lastbackend/lastbackend/pkg/controller/scheduler"
 	"github.com/lastbackend/lastbackend/pkg/distribution"
 	"github.com/lastbackend/lastbackend/pkg/distribution/types"
 	"github.com/lastbackend/lastbackend/pkg/log"
@@ -31,13 +34,17 @@ import (
 const (
 	logLevel  = 3
 	logPrefix = "observer:cluster"
+
+	// nodeHeartbeatInterval - interval to check nodes heartbeat
+	nodeHeartbeatInterval = 5 * time.Second
 )
 
 // ClusterState is cluster current state struct
 type ClusterState struct {
 
-	cluster *types.Cluster
-	ingress struct {
+	cluster   *types.Cluster
+	scheduler *scheduler.Scheduler
+	ingress   struct {
 		list map[string]*types.Ingress
 	}
 	volume struct {
@@ -49,14 +56,30 @@ type ClusterState struct {
 		lease    chan *NodeLease
 		release  chan *NodeLease
 		list     map[string]*types.Node
+		evicted  map[string]bool
+		drain    struct {
+			lock    sync.RWMutex
+			list    map[string]bool
+			handler func(node string)
+			// active - nodes with running drain handler
+			active map[string]bool
+		}
 	}
 }
 
 // Runtime cluster describes main cluster state loop
 func (cs *ClusterState) Observe() {
+
+	ticker := time.NewTicker(nodeHeartbeatInterval)
+	defer ticker.Stop()
+
 	// Watch node changes
 	for {
 		select {
+		case <-ticker.C:
+			handleNodeHeartbeat(cs)
+			handleNodeDrain(cs)
+			break
 		case l := <-cs.node.lease:
 			handleNodeLease(cs, l)
 			break
@@ -69,6 +92,8 @@ func (cs *ClusterState) Observe() {
 				log.Errorf("%s", err.Error())
 			}
 			cs.node.list[n.SelfLink()] = n
+			cs.setNodeDrain(n.SelfLink(), n.Spec.Drain)
+			clusterStatusState(cs)
 			break
 			case v := <- cs.volume.observer:
 			log.V(7).Debugf("volume: %s", v.SelfLink())
@@ -93,6 +118,10 @@ func (cs *ClusterState) Loop() error {
 		return err
 	}
 
+	if cs.cluster == nil {
+		cs.cluster = new(types.Cluster)
+	}
+
 	// Get all nodes in cluster
 	nm := distribution.NewNodeModel(context.Background(), envs.Get().GetStorage())
 	nl, err := nm.List()
@@ -177,18 +206,96 @@ func (cs *ClusterState) SetNode(n *types.Node) {
 
 func (cs *ClusterState) DelNode(n *types.Node) {
 	delete(cs.node.list, n.Meta.SelfLink)
+	cs.setNodeDrain(n.Meta.SelfLink, false)
 }
 
-func (cs *ClusterState) PodLease(p *types.Pod) (*types.Node, error) {
+// IsNodeDraining checks if node pods should be moved to other nodes
+func (cs *ClusterState) IsNodeDraining(node string) bool {
+	cs.node.drain.lock.RLock()
+	defer cs.node.drain.lock.RUnlock()
+	return cs.node.drain.list[node]
+}
 
-	var RAM int64
+// OnNodeDrain sets handler called periodically for each draining node with pods
+func (cs *ClusterState) OnNodeDrain(handler func(node string)) {
+	cs.node.drain.lock.Lock()
+	defer cs.node.drain.lock.Unlock()
+	cs.node.drain.handler = handler
+}
 
-	for _, s := range p.Spec.Template.Containers {
-		RAM += s.Resources.Request.RAM
+// drainStart marks node drain as running, returns false if node drain is already running
+func (cs *ClusterState) drainStart(node string) bool {
+	cs.node.drain.lock.Lock()
+	defer cs.node.drain.lock.Unlock()
+
+	if cs.node.drain.active[node] {
+		return false
+	}
+
+	cs.node.drain.active[node] = true
+	return true
+}
+
+// drainDone marks node drain as finished, so node is drained again on next tick if it still has pods
+func (cs *ClusterState) drainDone(node string) {
+	cs.node.drain.lock.Lock()
+	defer cs.node.drain.lock.Unlock()
+	delete(cs.node.drain.active, node)
+}
+
+func (cs *ClusterState) setNodeDrain(node string, drain bool) {
+	cs.node.drain.lock.Lock()
+	defer cs.node.drain.lock.Unlock()
+
+	if drain {
+		cs.node.drain.list[node] = true
+		return
+	}
+
+	delete(cs.node.drain.list, node)
+}
+
+// PodLease leases node for pod, replicas contains deployment pods count by node
+func (cs *ClusterState) PodLease(p *types.Pod, replicas map[string]int) (*types.Node, error) {
+
+	var (
+		RAM, CPU = podResources(p)
+		volumes  = make([]string, 0)
+	)
+
+	for _, v := range p.Spec.Template.Volumes {
+		if v.Secret.Name != types.EmptyString || v.Config.Name != types.EmptyString {
+			continue
+		}
+
+		volumes = append(volumes, new(types.Volume).CreateSelfLink(p.Meta.Namespace, v.Name))
 	}
 
 	opts := NodeLeaseOptions{
-		Memory: &RAM,
+		Node:     &p.Spec.Selector.Node,
+		Memory:   &RAM,
+		CPU:      &CPU,
+		Selector: p.Spec.Selector.Labels,
+		Volumes:  volumes,
+		Replicas: replicas,
+		Labels:   p.Meta.Labels,
+		Affinity: &p.Spec.Selector.Affinity,
+	}
+
+	if err := podQuotaCheck(p); err != nil {
+		log.Errorf("%s:> pod lease err: %s", logPrefix, err)
+		return nil, err
+	}
+
+	if len(p.Spec.Selector.Affinity.Pod) > 0 || len(p.Spec.Selector.Affinity.PodAnti) > 0 {
+
+		pods, err := podLabelsByNode(p)
+		if err != nil {
+			log.Errorf("%s:> pod lease err: %s", logPrefix, err)
+			return nil, err
+		}
+
+		opts.Pods = pods
 	}
 
 	node, err := cs.lease(opts)
@@ -200,16 +307,117 @@ func (cs *ClusterState) PodLease(p *types.Pod) (*types.Node, error) {
 	return node, err
 }
 
-func (cs *ClusterState) PodRelease(p *types.Pod) (*types.Node, error) {
-	var RAM int64
+// podResources returns memory and cpu requested by pod containers
+func podResources(p *types.Pod) (RAM int64, CPU int64) {
 
 	for _, s := range p.Spec.Template.Containers {
 		RAM += s.Resources.Request.RAM
+		CPU += s.Resources.Request.CPU
 	}
 
+	return RAM, CPU
+}
+
+// podQuotaCheck checks that namespace ram quota allows to schedule pod,
+// pods of other service deployments are not counted as they are replaced by pod deployment
+func podQuotaCheck(p *types.Pod) error {
+
+	var (
+		stg = envs.Get().GetStorage()
+		nm  = distribution.NewNamespaceModel(context.Background(), stg)
+		pm  = distribution.NewPodModel(context.Background(), stg)
+	)
+
+	ns, err := nm.Get(p.Meta.Namespace)
+	if err != nil {
+		return err
+	}
+
+	if ns == nil || ns.Spec.Quotas.Disabled || ns.Spec.Quotas.RAM == 0 {
+		return nil
+	}
+
+	pl, err := pm.ListByNamespace(p.Meta.Namespace)
+	if err != nil {
+		return err
+	}
+
+	var usage types.NamespaceResources
+	usage.RAM, _ = podResources(p)
+
+	for _, item := range pl.Items {
+
+		if item.SelfLink() == p.SelfLink() || item.Meta.Node == types.EmptyString || item.Spec.State.Destroy {
+			continue
+		}
+
+		if item.Meta.Service == p.Meta.Service && item.Meta.Deployment != p.Meta.Deployment {
+			continue
+		}
+
+		RAM, _ := podResources(item)
+		usage.RAM += RAM
+	}
+
+	if err := ns.CheckQuotas(usage); err != nil {
+		return newNodeLeaseErr(err.Error())
+	}
+
+	return nil
+}
+
+// podLabelsByNode returns labels of namespace pods grouped by node to match pod affinity rules
+func podLabelsByNode(p *types.Pod) (map[string][]map[string]string, error) {
+
+	var pods = make(map[string][]map[string]string)
+
+	pm := distribution.NewPodModel(context.Background(), envs.Get().GetStorage())
+	pl, err := pm.ListByNamespace(p.Meta.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, item := range pl.Items {
+
+		if item.SelfLink() == p.SelfLink() || item.Meta.Node == types.EmptyString || item.Spec.State.Destroy {
+			continue
+		}
+
+		pods[item.Meta.Node] = append(pods[item.Meta.Node], item.Meta.Labels)
+	}
+
+	return pods, nil
+}
+
+// PodLeaseCancel releases node resources reserved by pod lease if pod was not provisioned
+func (cs *ClusterState) PodLeaseCancel(p *types.Pod) (*types.Node, error) {
+
+	RAM, CPU := podResources(p)
+
 	opts := NodeLeaseOptions{
 		Node:   &p.Meta.Node,
 		Memory: &RAM,
+		CPU:    &CPU,
+		Cancel: true,
+	}
+
+	node, err := cs.release(opts)
+	if err != nil {
+		log.Errorf("%s:> pod lease cancel err: %s", logPrefix, err)
+		return nil, err
+	}
+
+	return node, err
+}
+
+func (cs *ClusterState) PodRelease(p *types.Pod) (*types.Node, error) {
+
+	RAM, CPU := podResources(p)
+
+	opts := NodeLeaseOptions{
+		Node:   &p.Meta.Node,
+		Memory: &RAM,
+		CPU:    &CPU,
 	}
 
 	node, err := cs.release(opts)
@@ -254,11 +462,34 @@ func (cs *ClusterState) VolumeRelease(v *types.Volume) (*types.Node, error) {
 	return node, err
 }
 
+// BuildLease leases node with builder role for image build
+func (cs *ClusterState) BuildLease(b *types.Build) (*types.Node, error) {
+
+	opts := NodeLeaseOptions{
+		Builder: true,
+	}
+
+	node, err := cs.lease(opts)
+	if err != nil {
+		log.Errorf("%s:> build lease err: %s", logPrefix, err)
+		return nil, err
+	}
+
+	return node, err
+}
+
 // NewClusterState returns new cluster state instance
 func NewClusterState() *ClusterState {
 
 	var cs = new(ClusterState)
 
+	cs.cluster = new(types.Cluster)
+
+	cs.scheduler = envs.Get().GetScheduler()
+	if cs.scheduler == nil {
+		cs.scheduler, _ = scheduler.New(scheduler.StrategySpread)
+	}
+
 	cs.ingress.list = make(map[string]*types.Ingress)
 
 	cs.volume.list  = make(map[string]*types.Volume)
@@ -266,6 +497,9 @@ func NewClusterState() *ClusterState {
 
 	cs.node.observer = make(chan *types.Node)
 	cs.node.list = make(map[string]*types.Node)
+	cs.node.evicted = make(map[string]bool)
+	cs.node.drain.list = make(map[string]bool)
+	cs.node.drain.active = make(map[string]bool)
 
 	cs.node.lease = make(chan *NodeLease)
 	cs.node.release = make(chan *NodeLease)
@@ -152,7 +152,7 @@ func volumeUpdate(v *types.Volume, timestamp time.Time) error {
 
 	if timestamp.Before(v.Meta.Updated) {
 		vm := distribution.NewVolumeModel(context.Background(), envs.Get().GetStorage())
-		if err := vm.Update(v); err != nil {
+		if err := vm.Set(v); err != nil {
 			log.Errorf("%s", err.Error())
 			return err
 		}
@@ -195,6 +195,15 @@ func volumeProvision(cs *ClusterState, volume *types.Volume) (err error) {
 	if volume.Meta.Node == types.EmptyString {
 		node, err := cs.VolumeLease(volume)
 		if err != nil {
+
+			// no node fits volume selector
+			if IsNodeLeaseErr(err) {
+				volume.Status.State = types.StateError
+				volume.Status.Message = err.Error()
+				volume.Meta.Updated = time.Now()
+				return nil
+			}
+
 			log.Errorf("%s:> volume manifest lease err: %s", logPrefixVolume, err.Error())
 			return err
 		}
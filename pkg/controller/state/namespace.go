@@ -0,0 +1,195 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+
+package state
+
+import (
+	"context"
+	"sync"
+
+	"github.com/lastbackend/lastbackend/pkg/controller/envs"
+	"github.com/lastbackend/lastbackend/pkg/distribution"
+	"github.com/lastbackend/lastbackend/pkg/distribution/types"
+	"github.com/lastbackend/lastbackend/pkg/log"
+)
+
+const logNamespacePrefix = "state:namespace"
+
+// namespaceResources keeps resources used by deployments and routes grouped by namespace,
+// so namespace usage is recalculated on events without listing storage
+type namespaceResources struct {
+	lock sync.Mutex
+	// ram requested by deployments: namespace -> deployment -> ram
+	deployments map[string]map[string]int64
+	// routes: namespace -> route -> exists
+	routes map[string]map[string]bool
+}
+
+// restore loads namespace deployments and routes and stores namespace resources
+func (nr *namespaceResources) restore(namespace string) error {
+
+	var (
+		stg = envs.Get().GetStorage()
+		dm  = distribution.NewDeploymentModel(context.Background(), stg)
+		rm  = distribution.NewRouteModel(context.Background(), stg)
+	)
+
+	dl, err := dm.ListByNamespace(namespace)
+	if err != nil {
+		log.Errorf("%s:restore:> get deployments in namespace %s err: %v", logNamespacePrefix, namespace, err)
+		return err
+	}
+
+	rl, err := rm.ListByNamespace(namespace)
+	if err != nil {
+		log.Errorf("%s:restore:> get routes in namespace %s err: %v", logNamespacePrefix, namespace, err)
+		return err
+	}
+
+	nr.lock.Lock()
+	defer nr.lock.Unlock()
+
+	nr.deployments[namespace] = make(map[string]int64)
+	for _, d := range dl.Items {
+		if ram := deploymentRAM(d); ram > 0 {
+			nr.deployments[namespace][d.SelfLink()] = ram
+		}
+	}
+
+	nr.routes[namespace] = make(map[string]bool)
+	for _, r := range rl.Items {
+		nr.routes[namespace][r.SelfLink()] = true
+	}
+
+	return namespaceResourcesUpdate(namespace, nr.usage(namespace))
+}
+
+// setDeployment updates namespace resources if ram requested by deployment is changed
+func (nr *namespaceResources) setDeployment(d *types.Deployment, remove bool) error {
+
+	nr.lock.Lock()
+	defer nr.lock.Unlock()
+
+	var (
+		namespace = d.Meta.Namespace
+		ram       int64
+	)
+
+	if !remove {
+		ram = deploymentRAM(d)
+	}
+
+	if nr.deployments[namespace][d.SelfLink()] == ram {
+		return nil
+	}
+
+	if _, ok := nr.deployments[namespace]; !ok {
+		nr.deployments[namespace] = make(map[string]int64)
+	}
+
+	if ram == 0 {
+		delete(nr.deployments[namespace], d.SelfLink())
+	} else {
+		nr.deployments[namespace][d.SelfLink()] = ram
+	}
+
+	return namespaceResourcesUpdate(namespace, nr.usage(namespace))
+}
+
+// setRoute updates namespace resources if route is added or removed
+func (nr *namespaceResources) setRoute(r *types.Route, remove bool) error {
+
+	nr.lock.Lock()
+	defer nr.lock.Unlock()
+
+	namespace := r.Meta.Namespace
+
+	if nr.routes[namespace][r.SelfLink()] == !remove {
+		return nil
+	}
+
+	if _, ok := nr.routes[namespace]; !ok {
+		nr.routes[namespace] = make(map[string]bool)
+	}
+
+	if remove {
+		delete(nr.routes[namespace], r.SelfLink())
+	} else {
+		nr.routes[namespace][r.SelfLink()] = true
+	}
+
+	return namespaceResourcesUpdate(namespace, nr.usage(namespace))
+}
+
+func (nr *namespaceResources) usage(namespace string) types.NamespaceResources {
+
+	var resources types.NamespaceResources
+
+	for _, ram := range nr.deployments[namespace] {
+		resources.RAM += ram
+	}
+
+	resources.Routes = len(nr.routes[namespace])
+
+	return resources
+}
+
+// deploymentRAM returns ram requested by all deployment replicas, destroyed deployments are not counted
+func deploymentRAM(d *types.Deployment) int64 {
+	if d.Status.State == types.StateDestroy || d.Status.State == types.StateDestroyed {
+		return 0
+	}
+	return int64(d.Spec.Replicas) * d.Spec.Template.GetResourceRequest().RAM
+}
+
+// namespaceResourcesUpdate stores resources used by namespace in namespace spec if they were changed
+func namespaceResourcesUpdate(namespace string, resources types.NamespaceResources) error {
+
+	nm := distribution.NewNamespaceModel(context.Background(), envs.Get().GetStorage())
+
+	ns, err := nm.Get(namespace)
+	if err != nil {
+		log.Errorf("%s:resources:> get namespace %s err: %v", logNamespacePrefix, namespace, err)
+		return err
+	}
+	if ns == nil {
+		return nil
+	}
+
+	if ns.Spec.Resources == resources {
+		return nil
+	}
+
+	log.V(logLevel).Debugf("%s:resources:> namespace %s resources: ram %d, routes %d",
+		logNamespacePrefix, namespace, resources.RAM, resources.Routes)
+
+	ns.Spec.Resources = resources
+	if err := nm.Set(ns); err != nil {
+		log.Errorf("%s:resources:> update namespace %s err: %v", logNamespacePrefix, namespace, err)
+		return err
+	}
+
+	return nil
+}
+
+func newNamespaceResources() *namespaceResources {
+	nr := new(namespaceResources)
+	nr.deployments = make(map[string]map[string]int64)
+	nr.routes = make(map[string]map[string]bool)
+	return nr
+}
@@ -0,0 +1,213 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+
+package service
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/lastbackend/lastbackend/pkg/controller/envs"
+	"github.com/lastbackend/lastbackend/pkg/distribution"
+	"github.com/lastbackend/lastbackend/pkg/distribution/types"
+	"github.com/lastbackend/lastbackend/pkg/log"
+)
+
+const (
+	logAutoscalerPrefix = "state:observer:autoscaler"
+
+	// autoscalerTolerance - utilisation deviation from target which does not change replicas
+	autoscalerTolerance = 0.1
+	// autoscalerDownscaleDelay - minimal interval after previous scaling before replicas are decreased
+	autoscalerDownscaleDelay = 5 * time.Minute
+	// autoscalerUsageTimeout - pod usage reported earlier is not used for scaling
+	autoscalerUsageTimeout = 2 * time.Minute
+)
+
+// serviceAutoscale adjusts service replicas to keep average pods utilisation near autoscaler target
+func serviceAutoscale(ss *ServiceState) error {
+
+	var (
+		svc = ss.service
+		d   = ss.deployment.active
+	)
+
+	if svc == nil || svc.Spec.State.Destroy || !svc.Spec.Autoscaler.Enabled() {
+		return nil
+	}
+
+	// scale only stable deployment, rollout replicas are managed by deployment strategy
+	if d == nil || ss.deployment.provision != nil || d.Status.State != types.StateReady {
+		return nil
+	}
+
+	var usage = make([]types.PodContainerUsage, 0)
+
+	for _, p := range ss.pod.list[d.SelfLink()] {
+		if p.Status.State != types.StateReady || p.Spec.State.Destroy {
+			continue
+		}
+
+		if time.Since(p.Status.Usage.Updated) > autoscalerUsageTimeout {
+			continue
+		}
+
+		usage = append(usage, p.Status.Usage.Total())
+	}
+
+	request := d.Spec.Template.GetResourceRequest()
+	replicas := autoscaleReplicas(svc.Spec.Autoscaler, d.Spec.Replicas, request, usage)
+
+	if replicas > d.Spec.Replicas {
+		max, err := autoscaleQuotaReplicas(svc, request)
+		if err != nil {
+			return err
+		}
+
+		// keep current replicas if namespace quota is already exhausted
+		if replicas > max {
+			replicas = max
+			if replicas < d.Spec.Replicas {
+				replicas = d.Spec.Replicas
+			}
+		}
+	}
+
+	if replicas == d.Spec.Replicas {
+		return nil
+	}
+
+	if replicas < d.Spec.Replicas && time.Since(ss.autoscaler.scaled) < autoscalerDownscaleDelay {
+		return nil
+	}
+
+	log.V(logLevel).Debugf("%s:> scale service %s: %d -> %d", logAutoscalerPrefix, svc.SelfLink(), d.Spec.Replicas, replicas)
+
+	ss.autoscaler.scaled = time.Now()
+
+	ok, err := serviceSpecUpdate(svc, func(s *types.Service) bool {
+		// replicas or template are changed through api: scale on next check
+		if s.Spec.Replicas != svc.Spec.Replicas || !s.Spec.Template.Updated.Equal(svc.Spec.Template.Updated) {
+			return false
+		}
+		s.Spec.Replicas = replicas
+		return true
+	})
+	if err != nil || !ok {
+		return err
+	}
+
+	svc.Spec.Replicas = replicas
+	return deploymentScale(d, replicas)
+}
+
+// autoscaleReplicas calculates replicas count required to reach autoscaler utilisation target
+// by pods usage, result is limited by autoscaler replicas range
+func autoscaleReplicas(as types.SpecAutoscaler, current int, request types.SpecTemplateContainerResource, usage []types.PodContainerUsage) int {
+
+	var (
+		replicas = 0
+		total    types.PodContainerUsage
+	)
+
+	for _, u := range usage {
+		total.CPU += u.CPU
+		total.RAM += u.RAM
+	}
+
+	scale := func(used, requested int64, target int) int {
+		utilisation := float64(used) / float64(requested*int64(len(usage))) * 100
+		ratio := utilisation / float64(target)
+		if math.Abs(ratio-1) <= autoscalerTolerance {
+			return current
+		}
+		return int(math.Ceil(float64(current) * ratio))
+	}
+
+	if len(usage) > 0 {
+
+		if as.TargetCPU > 0 && request.CPU > 0 {
+			if r := scale(total.CPU, request.CPU, as.TargetCPU); r > replicas {
+				replicas = r
+			}
+		}
+
+		if as.TargetRAM > 0 && request.RAM > 0 {
+			if r := scale(total.RAM, request.RAM, as.TargetRAM); r > replicas {
+				replicas = r
+			}
+		}
+	}
+
+	if replicas == 0 {
+		replicas = current
+	}
+
+	if replicas < as.MinReplicas {
+		replicas = as.MinReplicas
+	}
+
+	if replicas < 1 {
+		replicas = 1
+	}
+
+	if replicas > as.MaxReplicas {
+		replicas = as.MaxReplicas
+	}
+
+	return replicas
+}
+
+// autoscaleQuotaReplicas returns max service replicas count allowed by namespace ram quota
+func autoscaleQuotaReplicas(svc *types.Service, request types.SpecTemplateContainerResource) (int, error) {
+
+	var (
+		stg = envs.Get().GetStorage()
+		nm  = distribution.NewNamespaceModel(context.Background(), stg)
+		sm  = distribution.NewServiceModel(context.Background(), stg)
+	)
+
+	ns, err := nm.Get(svc.Meta.Namespace)
+	if err != nil {
+		return 0, err
+	}
+
+	if ns == nil || ns.Spec.Quotas.Disabled || ns.Spec.Quotas.RAM == 0 || request.RAM == 0 {
+		return math.MaxInt32, nil
+	}
+
+	sl, err := sm.List(svc.Meta.Namespace)
+	if err != nil {
+		return 0, err
+	}
+
+	var used int64
+	for _, s := range sl.Items {
+		if s.Meta.Name == svc.Meta.Name || s.Spec.State.Destroy {
+			continue
+		}
+		used += s.Spec.GetResourceRequest().RAM
+	}
+
+	if used >= ns.Spec.Quotas.RAM {
+		return 0, nil
+	}
+
+	return int((ns.Spec.Quotas.RAM - used) / request.RAM), nil
+}
@@ -0,0 +1,194 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/lastbackend/lastbackend/pkg/controller/envs"
+	"github.com/lastbackend/lastbackend/pkg/distribution"
+	"github.com/lastbackend/lastbackend/pkg/distribution/types"
+	"github.com/lastbackend/lastbackend/pkg/storage"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAutoscaleReplicas(t *testing.T) {
+
+	type args struct {
+		as      types.SpecAutoscaler
+		current int
+		request types.SpecTemplateContainerResource
+		usage   []types.PodContainerUsage
+	}
+
+	var (
+		as      = types.SpecAutoscaler{MinReplicas: 1, MaxReplicas: 10, TargetCPU: 50}
+		request = types.SpecTemplateContainerResource{CPU: 1000, RAM: 512}
+	)
+
+	getUsage := func(cpu, ram int64, count int) []types.PodContainerUsage {
+		usage := make([]types.PodContainerUsage, 0)
+		for i := 0; i < count; i++ {
+			usage = append(usage, types.PodContainerUsage{CPU: cpu, RAM: ram})
+		}
+		return usage
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want int
+	}{
+		{
+			"scale up on high cpu utilisation",
+			args{as, 2, request, getUsage(1000, 128, 2)},
+			4,
+		},
+		{
+			"scale down on low cpu utilisation",
+			args{as, 4, request, getUsage(250, 128, 4)},
+			2,
+		},
+		{
+			"keep replicas within tolerance",
+			args{as, 3, request, getUsage(520, 128, 3)},
+			3,
+		},
+		{
+			"keep replicas without usage",
+			args{as, 3, request, getUsage(0, 0, 0)},
+			3,
+		},
+		{
+			"limit replicas by max replicas",
+			args{as, 8, request, getUsage(1000, 128, 8)},
+			10,
+		},
+		{
+			"limit replicas by min replicas",
+			args{types.SpecAutoscaler{MinReplicas: 2, MaxReplicas: 10, TargetCPU: 50}, 3, request, getUsage(10, 128, 3)},
+			2,
+		},
+		{
+			"use highest replicas count from cpu and ram targets",
+			args{types.SpecAutoscaler{MinReplicas: 1, MaxReplicas: 10, TargetCPU: 50, TargetRAM: 50}, 2, request, getUsage(500, 512, 2)},
+			4,
+		},
+		{
+			"skip cpu target without cpu request",
+			args{as, 2, types.SpecTemplateContainerResource{RAM: 512}, getUsage(1000, 128, 2)},
+			2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := autoscaleReplicas(tt.args.as, tt.args.current, tt.args.request, tt.args.usage)
+			assert.Equal(t, tt.want, got, "replicas count not match")
+		})
+	}
+}
+
+func TestServiceAutoscale(t *testing.T) {
+
+	var (
+		ctx = context.Background()
+		stg = envs.Get().GetStorage()
+		sm  = distribution.NewServiceModel(ctx, stg)
+	)
+
+	getState := func() *ServiceState {
+
+		c := new(types.SpecTemplateContainer)
+		c.Resources.Request.CPU = 1000
+
+		svc := getServiceAsset(types.StateReady, types.EmptyString)
+		svc.Spec.Replicas = 2
+		svc.Spec.Autoscaler = types.SpecAutoscaler{MinReplicas: 1, MaxReplicas: 10, TargetCPU: 50}
+		svc.Spec.Template.Containers = append(svc.Spec.Template.Containers, c)
+
+		opts := storage.GetOpts()
+		opts.Force = true
+		stg.Set(ctx, stg.Collection().Service(), stg.Key().Service(svc.Meta.Namespace, svc.Meta.Name), svc, opts)
+
+		d := getDeploymentAsset(svc, types.StateReady, types.EmptyString)
+
+		ss := getServiceStateAsset(svc)
+		ss.deployment.active = d
+		ss.deployment.list[d.SelfLink()] = d
+		ss.pod.list[d.SelfLink()] = make(map[string]*types.Pod)
+
+		for i := 0; i < 2; i++ {
+			p := getPodAsset(d, types.StateReady, types.EmptyString)
+			p.Status.Usage.Updated = time.Now()
+			p.Status.Usage.Containers = map[string]types.PodContainerUsage{"c": {CPU: 1000}}
+			ss.pod.list[d.SelfLink()][p.SelfLink()] = p
+		}
+
+		return ss
+	}
+
+	t.Run("scale service replicas", func(t *testing.T) {
+
+		ss := getState()
+
+		if !assert.NoError(t, serviceAutoscale(ss)) {
+			return
+		}
+
+		svc, err := sm.Get(ss.service.Meta.Namespace, ss.service.Meta.Name)
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		assert.Equal(t, 4, svc.Spec.Replicas, "service replicas not match")
+		assert.Equal(t, 4, ss.deployment.active.Spec.Replicas, "deployment replicas not match")
+	})
+
+	t.Run("skip scaling if service replicas are changed through api", func(t *testing.T) {
+
+		ss := getState()
+
+		svc, err := sm.Get(ss.service.Meta.Namespace, ss.service.Meta.Name)
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		svc.Spec.Replicas = 3
+		svc.Spec.Autoscaler.MaxReplicas = 3
+		if _, err := sm.Update(svc); !assert.NoError(t, err) {
+			return
+		}
+
+		if !assert.NoError(t, serviceAutoscale(ss)) {
+			return
+		}
+
+		svc, err = sm.Get(ss.service.Meta.Namespace, ss.service.Meta.Name)
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		assert.Equal(t, 3, svc.Spec.Replicas, "service replicas not match")
+		assert.Equal(t, 3, svc.Spec.Autoscaler.MaxReplicas, "service autoscaler not match")
+		assert.Equal(t, 2, ss.deployment.active.Spec.Replicas, "deployment replicas not match")
+	})
+}
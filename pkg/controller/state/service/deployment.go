@@ -20,6 +20,8 @@ package service
 
 import (
 	"context"
+	"fmt"
+	"sort"
 
 	"github.com/lastbackend/lastbackend/pkg/controller/envs"
 	"github.com/lastbackend/lastbackend/pkg/distribution"
@@ -112,6 +114,14 @@ func handleDeploymentStateCreated(ss *ServiceState, d *types.Deployment) error {
 
 	log.V(logLevel).Debugf("%s:> handleDeploymentStateCreated: %s > %s", logDeploymentPrefix, d.SelfLink(), d.Status.State)
 
+	if deploymentRolling(ss, d) {
+		if err := deploymentRollingUpdate(ss, d); err != nil {
+			log.Errorf("%s", err.Error())
+			return err
+		}
+		return nil
+	}
+
 	if err := deploymentPodProvision(ss, d); err != nil {
 		log.Errorf("%s", err.Error())
 		return err
@@ -124,6 +134,14 @@ func handleDeploymentStateProvision(ss *ServiceState, d *types.Deployment) error
 
 	log.V(logLevel).Debugf("%s:> handleDeploymentStateProvision: %s > %s", logDeploymentPrefix, d.SelfLink(), d.Status.State)
 
+	if deploymentRolling(ss, d) {
+		if err := deploymentRollingUpdate(ss, d); err != nil {
+			log.Errorf("%s", err.Error())
+			return err
+		}
+		return nil
+	}
+
 	if err := deploymentPodProvision(ss, d); err != nil {
 		log.Errorf("%s", err.Error())
 		return err
@@ -136,6 +154,11 @@ func handleDeploymentStateReady(ss *ServiceState, d *types.Deployment) error {
 
 	log.V(logLevel).Debugf("%s:> handleDeploymentStateReady: %s > %s", logDeploymentPrefix, d.SelfLink(), d.Status.State)
 
+	if ss.deployment.active == nil || ss.deployment.active.SelfLink() != d.SelfLink() {
+		eventRecord(d.Meta.Namespace, types.KindDeployment, d.SelfLink(), types.EventSeverityNormal,
+			types.EventReasonRolledOut, fmt.Sprintf("deployment %s rolled out with %d replicas", d.Meta.Name, d.Spec.Replicas))
+	}
+
 	if ss.deployment.active != nil {
 		if ss.deployment.active.SelfLink() != d.SelfLink() {
 			if err := deploymentDestroy(ss, ss.deployment.active); err != nil {
@@ -153,6 +176,10 @@ func handleDeploymentStateReady(ss *ServiceState, d *types.Deployment) error {
 		}
 	}
 
+	if ss.deployment.rollout.deployment == d.SelfLink() {
+		deploymentRollingReset(ss)
+	}
+
 	if ss.deployment.active.SelfLink() != d.SelfLink() {
 		return deploymentDestroy(ss, d)
 	}
@@ -253,7 +280,19 @@ func handleDeploymentStateDestroyed(ss *ServiceState, d *types.Deployment) error
 
 		d.Status.State = types.StateDestroy
 		dm := distribution.NewDeploymentModel(context.Background(), envs.Get().GetStorage())
-		return dm.Update(d)
+		return dm.Set(d)
+	}
+
+	// keep destroyed deployment in storage as revision history for rollback
+	if deploymentHistoryRetain(ss) {
+
+		if err := deploymentHistoryCleanup(ss); err != nil {
+			log.Errorf("%s", err.Error())
+			return err
+		}
+
+		ss.DelDeployment(d)
+		return nil
 	}
 
 	if err := deploymentRemove(d); err != nil {
@@ -277,8 +316,17 @@ func deploymentPodProvision(ss *ServiceState, d *types.Deployment) (err error) {
 
 	var (
 		provision = false
+		replicas  = d.Spec.Replicas
 	)
 
+	// limit pods count by max surge while active deployment pods are not drained
+	if deploymentRolling(ss, d) {
+		replicas = deploymentRollingReplicas(ss, d)
+	} else {
+		// keep replacement pods for pods on draining nodes
+		replicas += deploymentDrainSurge(ss, d)
+	}
+
 	defer func() {
 		if err == nil {
 			err = deploymentUpdate(d, t)
@@ -338,12 +386,12 @@ func deploymentPodProvision(ss *ServiceState, d *types.Deployment) (err error) {
 			state[p.Status.State] = append(state[p.Status.State], p)
 		}
 
-		if d.Spec.Replicas == total {
+		if replicas == total {
 			break
 		}
 
-		if d.Spec.Replicas > total {
-			log.V(logLevel).Debugf("create additional replica: %d -> %d", total, d.Spec.Replicas)
+		if replicas > total {
+			log.V(logLevel).Debugf("create additional replica: %d -> %d", total, replicas)
 			p, err := podCreate(d)
 			if err != nil {
 				log.Errorf("%s", err.Error())
@@ -354,8 +402,8 @@ func deploymentPodProvision(ss *ServiceState, d *types.Deployment) (err error) {
 			continue
 		}
 
-		if d.Spec.Replicas < total {
-			log.V(logLevel).Debugf("remove unneeded replica: %d -> %d", total, d.Spec.Replicas)
+		if replicas < total {
+			log.V(logLevel).Debugf("remove unneeded replica: %d -> %d", total, replicas)
 			for _, s := range st {
 
 				if len(state[s]) > 0 {
@@ -385,6 +433,405 @@ func deploymentPodProvision(ss *ServiceState, d *types.Deployment) (err error) {
 	return nil
 }
 
+// deploymentRolling checks if provided deployment should replace
+// active deployment pods in batches according to service strategy
+func deploymentRolling(ss *ServiceState, d *types.Deployment) bool {
+
+	if ss.service == nil {
+		return false
+	}
+
+	if ss.deployment.provision == nil || ss.deployment.provision.SelfLink() != d.SelfLink() {
+		return false
+	}
+
+	if ss.deployment.active == nil || ss.deployment.active.SelfLink() == d.SelfLink() {
+		return false
+	}
+
+	switch ss.deployment.active.Status.State {
+	case types.StateDestroy, types.StateDestroyed:
+		return false
+	}
+
+	switch ss.service.Spec.Strategy.Type {
+	case types.EmptyString, types.SpecStrategyTypeRolling:
+		return true
+	}
+
+	return false
+}
+
+// deploymentDrainSurge returns additional replicas count allowed
+// to replace deployment pods placed on draining nodes
+func deploymentDrainSurge(ss *ServiceState, d *types.Deployment) int {
+
+	if ss.service == nil {
+		return 0
+	}
+
+	var (
+		surge, _ = deploymentRollingOptions(ss)
+		draining int
+	)
+
+	for _, p := range ss.pod.list[d.SelfLink()] {
+
+		if p.Spec.State.Destroy || p.Status.State == types.StateDestroy || p.Status.State == types.StateDestroyed {
+			continue
+		}
+
+		if ss.cluster.IsNodeDraining(p.Meta.Node) {
+			draining++
+		}
+	}
+
+	if draining < surge {
+		return draining
+	}
+
+	return surge
+}
+
+// deploymentDrain moves active deployment pods from draining node
+// in batches limited by service rolling options max surge and max unavailable
+func deploymentDrain(ss *ServiceState, node string) error {
+
+	log.V(logLevel).Debugf("%s:> deploymentDrain: %s > %s", logDeploymentPrefix, ss.service.SelfLink(), node)
+
+	d := ss.deployment.active
+	if d == nil {
+		return nil
+	}
+
+	// wait for rollout finish: new deployment pods are not placed on draining nodes
+	if ss.deployment.provision != nil && ss.deployment.provision.SelfLink() != d.SelfLink() {
+		return nil
+	}
+
+	var (
+		_, unavailable = deploymentRollingOptions(ss)
+		ready          int
+		draining       = make([]*types.Pod, 0)
+	)
+
+	for _, p := range ss.pod.list[d.SelfLink()] {
+
+		if p.Spec.State.Destroy || p.Status.State == types.StateDestroy || p.Status.State == types.StateDestroyed {
+			continue
+		}
+
+		if podReady(p) {
+			ready++
+		}
+
+		if ss.cluster.IsNodeDraining(p.Meta.Node) {
+			draining = append(draining, p)
+		}
+	}
+
+	if len(draining) == 0 {
+		return nil
+	}
+
+	// destroy not ready pods first
+	sort.Slice(draining, func(i, j int) bool {
+		return !podReady(draining[i]) && podReady(draining[j])
+	})
+
+	for _, p := range draining {
+
+		// keep at least replicas - max unavailable ready pods
+		if podReady(p) {
+			if ready-1 < d.Spec.Replicas-unavailable {
+				break
+			}
+			ready--
+		}
+
+		if err := podDestroy(ss, p); err != nil {
+			log.Errorf("%s:> pod destroy err: %s", logDeploymentPrefix, err.Error())
+			return err
+		}
+	}
+
+	// create replacement pods on other nodes
+	return deploymentPodProvision(ss, d)
+}
+
+// deploymentRollingOptions returns max surge and max unavailable pods count
+// if both options are not set, one additional pod is allowed
+func deploymentRollingOptions(ss *ServiceState) (surge int, unavailable int) {
+
+	opts := ss.service.Spec.Strategy.RollingOptions
+
+	if opts.MaxSurge > 0 {
+		surge = opts.MaxSurge
+	}
+
+	if opts.MaxUnavailable > 0 {
+		unavailable = opts.MaxUnavailable
+	}
+
+	if surge == 0 && unavailable == 0 {
+		surge = 1
+	}
+
+	return surge, unavailable
+}
+
+// deploymentRollingCount returns alive and ready pods count of provided deployment
+func deploymentRollingCount(ss *ServiceState, d *types.Deployment) (alive int, ready int) {
+
+	pl, ok := ss.pod.list[d.SelfLink()]
+	if !ok {
+		return 0, 0
+	}
+
+	for _, p := range pl {
+
+		if p.Status.State == types.StateDestroy || p.Status.State == types.StateDestroyed {
+			continue
+		}
+
+		alive++
+
+		if podReady(p) {
+			ready++
+		}
+	}
+
+	return alive, ready
+}
+
+// deploymentRollingReplicas returns pods count allowed for provided deployment
+// while active deployment pods are still alive
+func deploymentRollingReplicas(ss *ServiceState, d *types.Deployment) int {
+
+	var (
+		surge, _ = deploymentRollingOptions(ss)
+		old, _   = deploymentRollingCount(ss, ss.deployment.active)
+		alive, _ = deploymentRollingCount(ss, d)
+		replicas = d.Spec.Replicas + surge - old
+	)
+
+	// never remove already created pods during rollout
+	if replicas < alive {
+		replicas = alive
+	}
+
+	if replicas > d.Spec.Replicas {
+		replicas = d.Spec.Replicas
+	}
+
+	return replicas
+}
+
+// deploymentRollingUpdate - replaces active deployment pods with provided deployment pods:
+// new pods are created in batches limited by max surge,
+// active deployment pods are drained in batches limited by max unavailable
+func deploymentRollingUpdate(ss *ServiceState, d *types.Deployment) error {
+
+	log.V(logLevel).Debugf("%s:> deploymentRollingUpdate: %s > %s", logDeploymentPrefix, d.SelfLink(), d.Status.State)
+
+	if ss.deployment.rollout.deployment != d.SelfLink() {
+		deploymentRollingReset(ss)
+		ss.deployment.rollout.deployment = d.SelfLink()
+		ss.deployment.rollout.progress = time.Now()
+	}
+
+	var (
+		active   = ss.deployment.active
+		strategy = ss.service.Spec.Strategy
+		replicas = d.Spec.Replicas
+		rollout  = &ss.deployment.rollout
+	)
+
+	surge, unavailable := deploymentRollingOptions(ss)
+	old, oldReady := deploymentRollingCount(ss, active)
+	alive, ready := deploymentRollingCount(ss, d)
+
+	log.V(logLevel).Debugf("%s:> rollout %s: surge %d, unavailable %d, active %d/%d, new %d/%d",
+		logDeploymentPrefix, d.SelfLink(), surge, unavailable, oldReady, old, ready, alive)
+
+	if rollout.ready != ready || rollout.alive != old {
+		rollout.ready = ready
+		rollout.alive = old
+		rollout.progress = time.Now()
+	}
+
+	if strategy.Deadline > 0 && time.Since(d.Meta.Created) > time.Duration(strategy.Deadline)*time.Second {
+		return deploymentRollingAbort(ss, d, "rollout deadline exceeded")
+	}
+
+	if strategy.RollingOptions.Timeout > 0 &&
+		time.Since(rollout.progress) > time.Duration(strategy.RollingOptions.Timeout)*time.Second {
+		return deploymentRollingAbort(ss, d, "rollout timeout exceeded")
+	}
+
+	// drain active deployment pods keeping enough ready pods available
+	keep := replicas - unavailable - ready
+	if keep < 0 {
+		keep = 0
+	}
+
+	if keep > oldReady {
+		keep = oldReady
+	}
+
+	if active.Spec.Replicas > keep {
+
+		log.V(logLevel).Debugf("%s:> rollout drain: %s: %d -> %d", logDeploymentPrefix, active.SelfLink(), active.Spec.Replicas, keep)
+
+		if err := deploymentScale(active, keep); err != nil {
+			log.Errorf("%s:> rollout drain err: %s", logDeploymentPrefix, err.Error())
+			return err
+		}
+
+		if err := deploymentPodProvision(ss, active); err != nil {
+			log.Errorf("%s:> rollout drain err: %s", logDeploymentPrefix, err.Error())
+			return err
+		}
+	}
+
+	// wait interval between new pods batches
+	interval := time.Duration(strategy.RollingOptions.Interval) * time.Second
+	if alive < replicas && interval > 0 && time.Since(rollout.batch) < interval {
+		deploymentRollingSchedule(ss, d)
+		return nil
+	}
+
+	if err := deploymentPodProvision(ss, d); err != nil {
+		log.Errorf("%s:> rollout provision err: %s", logDeploymentPrefix, err.Error())
+		return err
+	}
+
+	if n, _ := deploymentRollingCount(ss, d); n > alive {
+		rollout.batch = time.Now()
+	}
+
+	deploymentRollingSchedule(ss, d)
+	return nil
+}
+
+// deploymentRollingAbort - stops rollout, marks deployment as failed
+// and restores active deployment replicas
+func deploymentRollingAbort(ss *ServiceState, d *types.Deployment, message string) error {
+
+	log.V(logLevel).Debugf("%s:> rollout abort: %s > %s", logDeploymentPrefix, d.SelfLink(), message)
+
+	deploymentRollingReset(ss)
+
+	t := d.Meta.Updated
+
+	d.Status.State = types.StateError
+	d.Status.Message = message
+	d.Meta.Updated = time.Now()
+
+	if err := deploymentUpdate(d, t); err != nil {
+		return err
+	}
+
+	if ss.deployment.active.Spec.Replicas != ss.service.Spec.Replicas {
+		if err := deploymentScale(ss.deployment.active, ss.service.Spec.Replicas); err != nil {
+			log.Errorf("%s:> rollout restore err: %s", logDeploymentPrefix, err.Error())
+			return err
+		}
+
+		if err := deploymentPodProvision(ss, ss.deployment.active); err != nil {
+			log.Errorf("%s:> rollout restore err: %s", logDeploymentPrefix, err.Error())
+			return err
+		}
+	}
+
+	ss.service.Status.State = types.StateError
+	ss.service.Status.Message = message
+	ss.service.Meta.Updated = time.Now()
+
+	sm := distribution.NewServiceModel(context.Background(), envs.Get().GetStorage())
+	if err := sm.Set(ss.service); err != nil {
+		log.Errorf("%s", err.Error())
+		return err
+	}
+
+	return handleDeploymentStateError(ss, d)
+}
+
+// deploymentRollingSchedule - plans rollout check for deadline, timeout and interval
+// because they can expire without any pod or deployment changes
+func deploymentRollingSchedule(ss *ServiceState, d *types.Deployment) {
+
+	var (
+		wait     time.Duration
+		strategy = ss.service.Spec.Strategy
+		timers   = make([]time.Duration, 0)
+	)
+
+	if strategy.Deadline > 0 {
+		timers = append(timers, time.Until(d.Meta.Created.Add(time.Duration(strategy.Deadline)*time.Second)))
+	}
+
+	if strategy.RollingOptions.Timeout > 0 {
+		timers = append(timers, time.Until(ss.deployment.rollout.progress.Add(time.Duration(strategy.RollingOptions.Timeout)*time.Second)))
+	}
+
+	if strategy.RollingOptions.Interval > 0 {
+		timers = append(timers, time.Until(ss.deployment.rollout.batch.Add(time.Duration(strategy.RollingOptions.Interval)*time.Second)))
+	}
+
+	for _, t := range timers {
+		if t > 0 && (wait == 0 || t < wait) {
+			wait = t
+		}
+	}
+
+	if ss.deployment.rollout.timer != nil {
+		ss.deployment.rollout.timer.Stop()
+		ss.deployment.rollout.timer = nil
+	}
+
+	if wait == 0 {
+		return
+	}
+
+	// deployment is looked up by self link on check, it can be replaced before timer fires
+	link := d.SelfLink()
+	ss.deployment.rollout.timer = time.AfterFunc(wait, func() {
+		ss.CheckRollout(link)
+	})
+}
+
+// deploymentRollingCheck - handles planned rollout check with current deployment state,
+// check is skipped if deployment is removed or newer rollout is started
+func deploymentRollingCheck(ss *ServiceState, link string) error {
+
+	if ss.deployment.rollout.deployment != link {
+		return nil
+	}
+
+	d, ok := ss.deployment.list[link]
+	if !ok {
+		return nil
+	}
+
+	return deploymentObserve(ss, d)
+}
+
+// deploymentRollingReset - clears rollout progress and stops planned checks
+func deploymentRollingReset(ss *ServiceState) {
+
+	if ss.deployment.rollout.timer != nil {
+		ss.deployment.rollout.timer.Stop()
+	}
+
+	ss.deployment.rollout.deployment = types.EmptyString
+	ss.deployment.rollout.progress = time.Time{}
+	ss.deployment.rollout.batch = time.Time{}
+	ss.deployment.rollout.ready = 0
+	ss.deployment.rollout.alive = 0
+	ss.deployment.rollout.timer = nil
+}
+
 func deploymentCreate(svc *types.Service) (*types.Deployment, error) {
 
 	dm := distribution.NewDeploymentModel(context.Background(), envs.Get().GetStorage())
@@ -400,7 +847,7 @@ func deploymentCreate(svc *types.Service) (*types.Deployment, error) {
 func deploymentUpdate(d *types.Deployment, timestamp time.Time) error {
 	if timestamp.Before(d.Meta.Updated) {
 		dm := distribution.NewDeploymentModel(context.Background(), envs.Get().GetStorage())
-		if err := dm.Update(d); err != nil {
+		if err := dm.Set(d); err != nil {
 			log.Errorf("%s", err.Error())
 			return err
 		}
@@ -464,11 +911,92 @@ func deploymentRemove(d *types.Deployment) error {
 	return nil
 }
 
+// deploymentHistoryRetain checks if destroyed deployments should be kept in storage
+func deploymentHistoryRetain(ss *ServiceState) bool {
+
+	if envs.Get().GetRevisionHistoryLimit() <= 0 || ss.service == nil {
+		return false
+	}
+
+	switch ss.service.Status.State {
+	case types.StateDestroy, types.StateDestroyed:
+		return false
+	}
+
+	return true
+}
+
+// deploymentHistoryCleanup removes destroyed deployments over revision history limit
+func deploymentHistoryCleanup(ss *ServiceState) error {
+
+	var (
+		limit   = envs.Get().GetRevisionHistoryLimit()
+		history = make([]*types.Deployment, 0)
+		dm      = distribution.NewDeploymentModel(context.Background(), envs.Get().GetStorage())
+	)
+
+	dl, err := dm.ListByService(ss.service.Meta.Namespace, ss.service.Meta.Name)
+	if err != nil {
+		return err
+	}
+
+	for _, d := range dl.Items {
+		if d.Status.State == types.StateDestroyed {
+			history = append(history, d)
+		}
+	}
+
+	if len(history) <= limit {
+		return nil
+	}
+
+	sort.Slice(history, func(i, j int) bool {
+		return history[i].Meta.Version > history[j].Meta.Version
+	})
+
+	for _, d := range history[limit:] {
+		log.V(logLevel).Debugf("%s:> remove deployment from history: %s", logDeploymentPrefix, d.SelfLink())
+		if err := dm.Remove(d); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// deploymentHistoryRemove removes all destroyed deployments kept in storage
+func deploymentHistoryRemove(ss *ServiceState, svc *types.Service) error {
+
+	dm := distribution.NewDeploymentModel(context.Background(), envs.Get().GetStorage())
+
+	dl, err := dm.ListByService(svc.Meta.Namespace, svc.Meta.Name)
+	if err != nil {
+		return err
+	}
+
+	for _, d := range dl.Items {
+
+		if d.Status.State != types.StateDestroyed {
+			continue
+		}
+
+		if _, ok := ss.deployment.list[d.SelfLink()]; ok {
+			continue
+		}
+
+		if err := dm.Remove(d); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func deploymentScale(d *types.Deployment, replicas int) error {
 	d.Status.State = types.StateProvision
 	d.Spec.Replicas = replicas
 	dm := distribution.NewDeploymentModel(context.Background(), envs.Get().GetStorage())
-	return dm.Update(d)
+	return dm.Set(d)
 }
 
 func deploymentStatusState(d *types.Deployment, pl map[string]*types.Pod) (err error) {
@@ -504,7 +1032,13 @@ func deploymentStatusState(d *types.Deployment, pl map[string]*types.Pod) (err e
 		break
 	case types.StateProvision:
 
-		if _, ok := state[types.StateReady]; ok && running == len(pl) {
+		if n, ok := state[types.StateReady]; ok && running == len(pl) {
+
+			// rolling update creates pods in batches: wait for all replicas
+			if n < d.Spec.Replicas {
+				break
+			}
+
 			d.Status.State = types.StateReady
 			d.Status.Message = types.EmptyString
 			d.Meta.Updated = time.Now()
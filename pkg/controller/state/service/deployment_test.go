@@ -25,6 +25,7 @@ import (
 	"github.com/lastbackend/lastbackend/pkg/distribution/types"
 	"github.com/stretchr/testify/assert"
 	"testing"
+	"time"
 )
 
 func testDeploymentObserver(t *testing.T, name, werr string, wst *ServiceState, state *ServiceState, d *types.Deployment) {
@@ -1040,3 +1041,181 @@ func TestHandleDeploymentStateDestroyed(t *testing.T) {
 		testDeploymentObserver(t, tt.name, tt.want.err, tt.want.state, tt.args.state, tt.args.d)
 	}
 }
+
+func TestDeploymentRollingReplicas(t *testing.T) {
+
+	type suit struct {
+		name string
+		args struct {
+			state *ServiceState
+			d     *types.Deployment
+		}
+		want int
+	}
+
+	var tests []suit
+
+	getRollingState := func(surge, unavailable, old, alive int) (*ServiceState, *types.Deployment) {
+
+		svc := getServiceAsset(types.StateProvision, types.EmptyString)
+		svc.Spec.Replicas = 3
+		svc.Spec.Strategy.RollingOptions.MaxSurge = surge
+		svc.Spec.Strategy.RollingOptions.MaxUnavailable = unavailable
+
+		dp1 := getDeploymentAsset(svc, types.StateReady, types.EmptyString)
+		dp2 := getDeploymentAsset(svc, types.StateProvision, types.EmptyString)
+
+		state := getServiceStateAsset(svc)
+		state.deployment.active = dp1
+		state.deployment.provision = dp2
+		state.deployment.list[dp1.SelfLink()] = dp1
+		state.deployment.list[dp2.SelfLink()] = dp2
+		state.pod.list[dp1.SelfLink()] = make(map[string]*types.Pod)
+		state.pod.list[dp2.SelfLink()] = make(map[string]*types.Pod)
+
+		for i := 0; i < old; i++ {
+			p := getPodAsset(dp1, types.StateReady, types.EmptyString)
+			state.pod.list[dp1.SelfLink()][p.SelfLink()] = p
+		}
+
+		for i := 0; i < alive; i++ {
+			p := getPodAsset(dp2, types.StateProvision, types.EmptyString)
+			state.pod.list[dp2.SelfLink()][p.SelfLink()] = p
+		}
+
+		return state, dp2
+	}
+
+	tests = append(tests, func() suit {
+		s := suit{name: "default strategy should allow one additional pod"}
+		s.args.state, s.args.d = getRollingState(0, 0, 3, 0)
+		s.want = 1
+		return s
+	}())
+
+	tests = append(tests, func() suit {
+		s := suit{name: "max surge should limit new pods count"}
+		s.args.state, s.args.d = getRollingState(2, 0, 3, 0)
+		s.want = 2
+		return s
+	}())
+
+	tests = append(tests, func() suit {
+		s := suit{name: "drained active pods should allow new pods"}
+		s.args.state, s.args.d = getRollingState(1, 1, 1, 1)
+		s.want = 3
+		return s
+	}())
+
+	tests = append(tests, func() suit {
+		s := suit{name: "created pods should not be removed"}
+		s.args.state, s.args.d = getRollingState(0, 1, 3, 2)
+		s.want = 2
+		return s
+	}())
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, deploymentRollingReplicas(tt.args.state, tt.args.d), "replicas count not match")
+		})
+	}
+}
+
+func TestDeploymentRollingCheck(t *testing.T) {
+
+	type suit struct {
+		name string
+		args struct {
+			state *ServiceState
+			link  string
+		}
+		want struct {
+			rollout string
+			state   string
+			message string
+		}
+	}
+
+	var tests []suit
+
+	getRollingState := func(timeout int, progress time.Duration) (*ServiceState, *types.Deployment) {
+
+		svc := getServiceAsset(types.StateProvision, types.EmptyString)
+		svc.Spec.Strategy.RollingOptions.Timeout = timeout
+
+		dp1 := getDeploymentAsset(svc, types.StateReady, types.EmptyString)
+		dp2 := getDeploymentAsset(svc, types.StateProvision, types.EmptyString)
+
+		// active deployment is created from previous service spec
+		dp1.Spec.Template.Updated = svc.Spec.Template.Updated.Add(-time.Minute)
+
+		p1 := getPodAsset(dp1, types.StateReady, types.EmptyString)
+
+		state := getServiceStateAsset(svc)
+		state.deployment.active = dp1
+		state.deployment.provision = dp2
+		state.deployment.list[dp1.SelfLink()] = dp1
+		state.deployment.list[dp2.SelfLink()] = dp2
+		state.pod.list[dp1.SelfLink()] = make(map[string]*types.Pod)
+		state.pod.list[dp1.SelfLink()][p1.SelfLink()] = p1
+		state.pod.list[dp2.SelfLink()] = make(map[string]*types.Pod)
+
+		state.deployment.rollout.deployment = dp2.SelfLink()
+		state.deployment.rollout.progress = time.Now().Add(-progress)
+		state.deployment.rollout.alive = 1
+
+		return state, dp2
+	}
+
+	tests = append(tests, func() suit {
+		s := suit{name: "check should be skipped if newer rollout started"}
+		state, d := getRollingState(60, 2*time.Minute)
+		state.deployment.rollout.deployment = "newer"
+		s.args.state, s.args.link = state, d.SelfLink()
+		s.want.rollout = "newer"
+		s.want.state = types.StateProvision
+		return s
+	}())
+
+	tests = append(tests, func() suit {
+		s := suit{name: "check should be skipped if deployment removed"}
+		state, d := getRollingState(60, 2*time.Minute)
+		delete(state.deployment.list, d.SelfLink())
+		s.args.state, s.args.link = state, d.SelfLink()
+		s.want.rollout = d.SelfLink()
+		s.want.state = types.StateProvision
+		return s
+	}())
+
+	tests = append(tests, func() suit {
+		s := suit{name: "rollout should be aborted if timeout exceeded"}
+		state, d := getRollingState(60, 2*time.Minute)
+		s.args.state, s.args.link = state, d.SelfLink()
+		s.want.rollout = types.EmptyString
+		s.want.state = types.StateError
+		s.want.message = "rollout timeout exceeded"
+		return s
+	}())
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			d := tt.args.state.deployment.provision
+
+			err := deploymentRollingCheck(tt.args.state, tt.args.link)
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			assert.Equal(t, tt.want.rollout, tt.args.state.deployment.rollout.deployment, "rollout deployment not match")
+			assert.Equal(t, tt.want.message, d.Status.Message, "deployment status message not match")
+
+			if tt.want.state != types.StateError {
+				assert.Equal(t, tt.want.state, d.Status.State, "deployment status state not match")
+				return
+			}
+
+			assert.Equal(t, tt.want.message, tt.args.state.service.Status.Message, "service status message not match")
+		})
+	}
+}
@@ -284,13 +284,7 @@ func endpointManifestProvision(ss *ServiceState) error {
 
 	if ss.endpoint.manifest != nil {
 
-		var pl = make(map[string]*types.Pod)
-
-		if ss.deployment.active != nil {
-			if _, ok := ss.pod.list[ss.deployment.active.SelfLink()]; ok {
-				pl = ss.pod.list[ss.deployment.active.SelfLink()]
-			}
-		}
+		var pl = endpointPodList(ss)
 
 		if !endpointManifestSpecEqual(ss.endpoint.endpoint, ss.endpoint.manifest) || !endpointManifestUpstreamsEqual(ss.endpoint.manifest, pl) {
 			if err := endpointManifestSet(ss); err != nil {
@@ -314,7 +308,7 @@ func endpointManifestAdd(ss *ServiceState) error {
 	var (
 		err error
 		em  = distribution.NewEndpointModel(context.Background(), envs.Get().GetStorage())
-		pl  = make(map[string]*types.Pod)
+		pl  map[string]*types.Pod
 	)
 
 	if ss.endpoint.endpoint == nil {
@@ -322,11 +316,7 @@ func endpointManifestAdd(ss *ServiceState) error {
 		return nil
 	}
 
-	if ss.deployment.active != nil {
-		if _, ok := ss.pod.list[ss.deployment.active.SelfLink()]; ok {
-			pl = ss.pod.list[ss.deployment.active.SelfLink()]
-		}
-	}
+	pl = endpointPodList(ss)
 
 	epm, err := em.ManifestGet(ss.endpoint.endpoint.SelfLink())
 	if err != nil {
@@ -367,7 +357,7 @@ func endpointManifestSet(ss *ServiceState) error {
 	var (
 		err error
 		em  = distribution.NewEndpointModel(context.Background(), envs.Get().GetStorage())
-		pl  = make(map[string]*types.Pod)
+		pl  map[string]*types.Pod
 	)
 
 	if ss.endpoint.endpoint == nil {
@@ -375,11 +365,7 @@ func endpointManifestSet(ss *ServiceState) error {
 		return nil
 	}
 
-	if ss.deployment.active != nil {
-		if _, ok := ss.pod.list[ss.deployment.active.SelfLink()]; ok {
-			pl = ss.pod.list[ss.deployment.active.SelfLink()]
-		}
-	}
+	pl = endpointPodList(ss)
 
 	ss.endpoint.manifest.EndpointSpec = ss.endpoint.endpoint.Spec
 	ss.endpoint.manifest.Upstreams = endpointManifestGetUpstreams(pl)
@@ -408,14 +394,41 @@ func endpointManifestDel(ss *ServiceState) error {
 	return nil
 }
 
+// endpointPodList returns active deployment pods
+// and pods of deployment which is rolled out over active deployment
+func endpointPodList(ss *ServiceState) map[string]*types.Pod {
+
+	var pl = make(map[string]*types.Pod)
+
+	if ss.deployment.active == nil {
+		return pl
+	}
+
+	for k, p := range ss.pod.list[ss.deployment.active.SelfLink()] {
+		pl[k] = p
+	}
+
+	if ss.deployment.provision != nil && deploymentRolling(ss, ss.deployment.provision) {
+		for k, p := range ss.pod.list[ss.deployment.provision.SelfLink()] {
+			pl[k] = p
+		}
+	}
+
+	return pl
+}
+
 func endpointManifestGetUpstreams(pl map[string]*types.Pod) []string {
 
 	ips := make([]string, 0)
 
 	for _, p := range pl {
-		if p.Status.State == types.StateReady && p.Status.Network.PodIP != types.EmptyString {
-			ips = append(ips, p.Status.Network.PodIP)
+
+		// skip pods with containers not passed readiness probe
+		if !podReady(p) || p.Status.Network.PodIP == types.EmptyString {
+			continue
 		}
+
+		ips = append(ips, p.Status.Network.PodIP)
 	}
 
 	return ips
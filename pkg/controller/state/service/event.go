@@ -0,0 +1,70 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lastbackend/lastbackend/pkg/controller/envs"
+	"github.com/lastbackend/lastbackend/pkg/distribution"
+	"github.com/lastbackend/lastbackend/pkg/distribution/types"
+	"github.com/lastbackend/lastbackend/pkg/log"
+)
+
+const logEventPrefix = "state:observer:event"
+
+// eventRecord writes event record for object state transition
+// failed record is logged only and does not break state transition
+func eventRecord(namespace, kind, selflink, severity, reason, message string) {
+
+	em := distribution.NewEventModel(context.Background(), envs.Get().GetStorage())
+
+	object := types.EventObject{Kind: kind, SelfLink: selflink}
+	if _, err := em.Record(namespace, object, severity, reason, message); err != nil {
+		log.Errorf("%s:> record event %s for %s err: %s", logEventPrefix, reason, selflink, err.Error())
+	}
+}
+
+// podEvents records pod image pull failures and containers killed by out of memory killer
+func podEvents(prev, p *types.Pod) {
+
+	if p.Status.State == types.StateError && p.Status.Status == types.StatusPull {
+		if prev == nil || prev.Status.State != types.StateError || prev.Status.Status != types.StatusPull {
+			eventRecord(p.Meta.Namespace, types.KindPod, p.SelfLink(), types.EventSeverityError,
+				types.EventReasonPullFailed, p.Status.Message)
+		}
+	}
+
+	for id, c := range p.Status.Containers {
+
+		if !c.State.Stopped.Exit.OOMKilled && !c.State.Error.Exit.OOMKilled {
+			continue
+		}
+
+		if prev != nil {
+			if pc, ok := prev.Status.Containers[id]; ok && (pc.State.Stopped.Exit.OOMKilled || pc.State.Error.Exit.OOMKilled) {
+				continue
+			}
+		}
+
+		eventRecord(p.Meta.Namespace, types.KindPod, p.SelfLink(), types.EventSeverityWarning,
+			types.EventReasonOOMKilled, fmt.Sprintf("container %s killed by out of memory killer", c.Name))
+	}
+}
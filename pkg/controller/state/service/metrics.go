@@ -0,0 +1,48 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+
+package service
+
+import (
+	"github.com/lastbackend/lastbackend/pkg/util/metrics"
+)
+
+const (
+	observerService    = "service"
+	observerDeployment = "deployment"
+	observerPod        = "pod"
+	observerNode       = "node"
+	observerAutoscale  = "autoscale"
+	observerRestart    = "restart"
+	observerRollout    = "rollout"
+)
+
+var (
+	observerQueue = metrics.NewGauge("lb_controller_observer_queue_depth",
+		"Count of objects waiting to be handled by service observers", "observer")
+	stateTransitions = metrics.NewCounter("lb_controller_state_transitions_total",
+		"Count of objects state transitions handled by controller", "kind", "state")
+)
+
+// stateTransition counts object state change, object seen first time is counted as transition too
+func stateTransition(kind, prev, state string) {
+	if prev == state {
+		return
+	}
+	stateTransitions.Inc(kind, state)
+}
@@ -21,6 +21,7 @@ package service
 import (
 	"context"
 	"sync"
+	"time"
 
 	"github.com/lastbackend/lastbackend/pkg/controller/envs"
 	"github.com/lastbackend/lastbackend/pkg/controller/state/cluster"
@@ -48,15 +49,35 @@ type ServiceState struct {
 		active    *types.Deployment
 		provision *types.Deployment
 		list      map[string]*types.Deployment
+		rollout   struct {
+			// deployment - self link of rolled out deployment
+			deployment string
+			// progress - last time rollout made progress
+			progress time.Time
+			// batch - last time new pods batch was created
+			batch time.Time
+			ready int
+			alive int
+			timer *time.Timer
+		}
 	}
 	pod struct {
 		list map[string]map[string]*types.Pod
 	}
 
+	autoscaler struct {
+		// scaled - last time replicas were changed by autoscaler
+		scaled time.Time
+	}
+
 	observers struct {
 		service    chan *types.Service
 		deployment chan *types.Deployment
 		pod        chan *types.Pod
+		node       chan string
+		autoscale  chan bool
+		restart    chan restartSource
+		rollout    chan string
 	}
 }
 
@@ -98,6 +119,13 @@ func (ss *ServiceState) Restore() error {
 	}
 
 	for _, d := range dl.Items {
+
+		// skip destroyed deployments kept as revision history
+		if d.Status.State == types.StateDestroyed && len(ss.pod.list[d.SelfLink()]) == 0 &&
+			envs.Get().GetRevisionHistoryLimit() > 0 {
+			continue
+		}
+
 		log.Infof("%s: restore deployment: %s", logPrefix, d.SelfLink())
 		ss.deployment.list[d.SelfLink()] = d
 	}
@@ -150,15 +178,18 @@ func (ss *ServiceState) Restore() error {
 	// Range over pods to sync pod status
 	for _, pl := range ss.pod.list {
 		for _, p := range pl {
+			observerQueue.Inc(observerPod)
 			ss.observers.pod <- p
 		}
 	}
 
 	// Provision deployment only in provision state
 	for _, d := range ss.deployment.list {
+		observerQueue.Inc(observerDeployment)
 		ss.observers.deployment <- d
 	}
 
+	observerQueue.Inc(observerService)
 	ss.observers.service <- ss.service
 
 	return nil
@@ -170,6 +201,12 @@ func (ss *ServiceState) Observe() {
 
 		case p := <-ss.observers.pod:
 			log.V(logLevel).Debugf("%s:observe:pod:> %v", logPrefix, p)
+			observerQueue.Dec(observerPod)
+			if prev, ok := ss.pod.list[p.DeploymentLink()][p.SelfLink()]; ok {
+				stateTransition(types.KindPod, prev.Status.State, p.Status.State)
+			} else {
+				stateTransition(types.KindPod, types.EmptyString, p.Status.State)
+			}
 			if err := PodObserve(ss, p); err != nil {
 				log.Errorf("%s:observe:pod err:> %s", logPrefix, err.Error())
 			}
@@ -177,13 +214,53 @@ func (ss *ServiceState) Observe() {
 
 		case d := <-ss.observers.deployment:
 			log.V(logLevel).Debugf("%s:observe:deployment:> %v", logPrefix, d)
+			observerQueue.Dec(observerDeployment)
+			if prev, ok := ss.deployment.list[d.SelfLink()]; ok {
+				stateTransition(types.KindDeployment, prev.Status.State, d.Status.State)
+			} else {
+				stateTransition(types.KindDeployment, types.EmptyString, d.Status.State)
+			}
 			if err := deploymentObserve(ss, d); err != nil {
 				log.Errorf("%s:observe:deployment err:> %s", logPrefix, err.Error())
 			}
 			break
 
+		case n := <-ss.observers.node:
+			log.V(logLevel).Debugf("%s:observe:node:> drain %s", logPrefix, n)
+			observerQueue.Dec(observerNode)
+			if err := deploymentDrain(ss, n); err != nil {
+				log.Errorf("%s:observe:node err:> %s", logPrefix, err.Error())
+			}
+			break
+
+		case <-ss.observers.autoscale:
+			log.V(logLevel).Debugf("%s:observe:autoscale:> %s", logPrefix, ss.service.SelfLink())
+			observerQueue.Dec(observerAutoscale)
+			if err := serviceAutoscale(ss); err != nil {
+				log.Errorf("%s:observe:autoscale err:> %s", logPrefix, err.Error())
+			}
+			break
+
+		case r := <-ss.observers.restart:
+			log.V(logLevel).Debugf("%s:observe:restart:> %s %s", logPrefix, r.kind, r.name)
+			observerQueue.Dec(observerRestart)
+			if err := serviceRestart(ss, r); err != nil {
+				log.Errorf("%s:observe:restart err:> %s", logPrefix, err.Error())
+			}
+			break
+
+		case link := <-ss.observers.rollout:
+			log.V(logLevel).Debugf("%s:observe:rollout:> %s", logPrefix, link)
+			observerQueue.Dec(observerRollout)
+			if err := deploymentRollingCheck(ss, link); err != nil {
+				log.Errorf("%s:observe:rollout err:> %s", logPrefix, err.Error())
+			}
+			break
+
 		case s := <-ss.observers.service:
 			log.V(logLevel).Debugf("%s:observe:service:> %v", logPrefix, s)
+			observerQueue.Dec(observerService)
+			stateTransition(types.KindService, ss.service.Status.State, s.Status.State)
 			if err := serviceObserve(ss, s); err != nil {
 				log.Errorf("%s:observe:service err:> %s", logPrefix, err.Error())
 			}
@@ -194,10 +271,12 @@ func (ss *ServiceState) Observe() {
 }
 
 func (ss *ServiceState) SetService(s *types.Service) {
+	observerQueue.Inc(observerService)
 	ss.observers.service <- s
 }
 
 func (ss *ServiceState) SetDeployment(d *types.Deployment) {
+	observerQueue.Inc(observerDeployment)
 	ss.observers.deployment <- d
 }
 
@@ -214,6 +293,10 @@ func (ss *ServiceState) DelDeployment(d *types.Deployment) {
 
 	delete(ss.deployment.list, d.SelfLink())
 
+	if ss.deployment.rollout.deployment == d.SelfLink() {
+		deploymentRollingReset(ss)
+	}
+
 	if ss.deployment.active != nil {
 		if ss.deployment.active.SelfLink() == d.SelfLink() {
 			ss.deployment.active = nil
@@ -228,7 +311,32 @@ func (ss *ServiceState) DelDeployment(d *types.Deployment) {
 
 }
 
+// DrainNode moves service pods from draining node
+func (ss *ServiceState) DrainNode(node string) {
+	observerQueue.Inc(observerNode)
+	ss.observers.node <- node
+}
+
+// Autoscale adjusts service replicas by pods resources usage
+func (ss *ServiceState) Autoscale() {
+	observerQueue.Inc(observerAutoscale)
+	ss.observers.autoscale <- true
+}
+
+// Restart updates service pods if changed secret or config is used in env vars
+func (ss *ServiceState) Restart(namespace, kind, name string) {
+	observerQueue.Inc(observerRestart)
+	ss.observers.restart <- restartSource{namespace: namespace, kind: kind, name: name}
+}
+
+// CheckRollout rechecks rollout of deployment by self link when planned check time comes
+func (ss *ServiceState) CheckRollout(link string) {
+	observerQueue.Inc(observerRollout)
+	ss.observers.rollout <- link
+}
+
 func (ss *ServiceState) SetPod(p *types.Pod) {
+	observerQueue.Inc(observerPod)
 	ss.observers.pod <- p
 }
 
@@ -250,6 +358,10 @@ func NewServiceState(cs *cluster.ClusterState, s *types.Service) *ServiceState {
 	ss.observers.service = make(chan *types.Service)
 	ss.observers.deployment = make(chan *types.Deployment)
 	ss.observers.pod = make(chan *types.Pod)
+	ss.observers.node = make(chan string)
+	ss.observers.autoscale = make(chan bool)
+	ss.observers.restart = make(chan restartSource)
+	ss.observers.rollout = make(chan string)
 
 	ss.deployment.list = make(map[string]*types.Deployment)
 	ss.pod.list = make(map[string]map[string]*types.Pod)
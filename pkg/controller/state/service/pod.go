@@ -20,8 +20,10 @@ package service
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/lastbackend/lastbackend/pkg/controller/envs"
+	"github.com/lastbackend/lastbackend/pkg/controller/state/cluster"
 	"github.com/lastbackend/lastbackend/pkg/distribution"
 	"github.com/lastbackend/lastbackend/pkg/distribution/errors"
 	"github.com/lastbackend/lastbackend/pkg/distribution/types"
@@ -35,6 +37,13 @@ const logPodPrefix = "state:observer:pod"
 func PodObserve(ss *ServiceState, p *types.Pod) error {
 
 	log.V(logLevel).Debugf("%s:> observe start: %s > state %s", logPodPrefix, p.SelfLink(), p.Status.State)
+
+	if pl, ok := ss.pod.list[p.DeploymentLink()]; ok {
+		podEvents(pl[p.SelfLink()], p)
+	} else {
+		podEvents(nil, p)
+	}
+
 	// Call pod state manager methods
 	switch p.Status.State {
 	case types.StateCreated:
@@ -86,6 +95,19 @@ func PodObserve(ss *ServiceState, p *types.Pod) error {
 		pl[p.SelfLink()] = p
 	}
 
+	var (
+		active    = ss.deployment.active != nil && ss.deployment.active.SelfLink() == p.DeploymentLink()
+		provision = ss.deployment.provision != nil && ss.deployment.provision.SelfLink() == p.DeploymentLink()
+	)
+
+	// pod readiness can be changed without deployment state change
+	if active || provision {
+		if err := endpointManifestProvision(ss); err != nil {
+			log.Errorf("%s:> endpoint manifest provision err: %s", logPodPrefix, err.Error())
+			return err
+		}
+	}
+
 	d, ok := ss.deployment.list[p.DeploymentLink()]
 	if !ok {
 		log.V(logLevel).Debugf("%s:> deployment node found: %s", logPodPrefix, p.DeploymentLink())
@@ -93,7 +115,19 @@ func PodObserve(ss *ServiceState, p *types.Pod) error {
 	}
 
 	log.V(logLevel).Debugf("%s:> observe finish: %s > %s", logPodPrefix, p.SelfLink(), p.Status.State)
-	return deploymentStatusState(d, pl)
+	if err := deploymentStatusState(d, pl); err != nil {
+		return err
+	}
+
+	// continue rolling update on active or provision deployment pods changes
+	if (active || provision) && ss.deployment.provision != nil && deploymentRolling(ss, ss.deployment.provision) {
+		switch ss.deployment.provision.Status.State {
+		case types.StateCreated, types.StateProvision:
+			return deploymentRollingUpdate(ss, ss.deployment.provision)
+		}
+	}
+
+	return nil
 }
 
 func handlePodStateCreated(ss *ServiceState, p *types.Pod) error {
@@ -162,6 +196,49 @@ func handlePodStateDestroyed(ss *ServiceState, p *types.Pod) error {
 	return nil
 }
 
+// podReady checks that pod is ready and all containers passed readiness probe
+func podReady(p *types.Pod) bool {
+
+	if p.Status.State != types.StateReady {
+		return false
+	}
+
+	for _, c := range p.Status.Containers {
+		if !c.Ready {
+			return false
+		}
+	}
+
+	return true
+}
+
+// podReplicasByNode returns deployment pods count on each node
+// it is used by scheduler to spread deployment replicas across nodes
+func podReplicasByNode(ss *ServiceState, p *types.Pod) map[string]int {
+
+	var replicas = make(map[string]int)
+
+	pl, ok := ss.pod.list[p.DeploymentLink()]
+	if !ok {
+		return replicas
+	}
+
+	for _, pod := range pl {
+
+		if pod.SelfLink() == p.SelfLink() || pod.Meta.Node == types.EmptyString {
+			continue
+		}
+
+		if pod.Spec.State.Destroy {
+			continue
+		}
+
+		replicas[pod.Meta.Node]++
+	}
+
+	return replicas
+}
+
 // podCreate function creates new pod based on deployment spec
 func podCreate(d *types.Deployment) (*types.Pod, error) {
 	dm := distribution.NewPodModel(context.Background(), envs.Get().GetStorage())
@@ -181,12 +258,23 @@ func podProvision(ss *ServiceState, p *types.Pod) (err error) {
 
 	}()
 
-	if p.Meta.Node == types.EmptyString {
+	var node *types.Node
 
-		var node *types.Node
+	if p.Meta.Node == types.EmptyString {
 
-		node, err = ss.cluster.PodLease(p)
+		node, err = ss.cluster.PodLease(p, podReplicasByNode(ss, p))
 		if err != nil {
+
+			// no node fits pod selector or resources
+			if cluster.IsNodeLeaseErr(err) {
+				p.Status.State = types.StateError
+				p.Status.Message = err.Error()
+				p.Meta.Updated = time.Now()
+				eventRecord(p.Meta.Namespace, types.KindPod, p.SelfLink(), types.EventSeverityWarning,
+					types.EventReasonFailedScheduling, err.Error())
+				return nil
+			}
+
 			log.Errorf("%s:> pod node lease err: %s", logPrefix, err.Error())
 			return err
 		}
@@ -202,9 +290,15 @@ func podProvision(ss *ServiceState, p *types.Pod) (err error) {
 		p.Meta.Updated = time.Now()
 	}
 
-	if err = podManifestPut(p); err != nil {
-		log.Errorf("%s:> pod manifest create err: %s", logPrefix, err.Error())
-		return err
+	pm := distribution.NewPodModel(context.Background(), envs.Get().GetStorage())
+	m, err := pm.ManifestGet(p.Meta.Node, p.Meta.SelfLink)
+	if err != nil {
+		if !errors.Storage().IsErrEntityNotFound(err) {
+			log.Errorf("%s:> pod manifest get err: %s", logPrefix, err.Error())
+			podLeaseRollback(ss, p, node)
+			return err
+		}
+		err = nil
 	}
 
 	if p.Status.State != types.StateProvision {
@@ -212,9 +306,45 @@ func podProvision(ss *ServiceState, p *types.Pod) (err error) {
 		p.Meta.Updated = time.Now()
 	}
 
+	// manifest is already on node, pod is updated after provision
+	if m != nil && node == nil {
+		return nil
+	}
+
+	// leased node allocated resources are stored together with pod and manifest
+	mf := types.PodManifest(p.Spec)
+	if err = pm.Provision(p, &mf, node != nil); err != nil {
+		log.Errorf("%s:> pod provision err: %s", logPrefix, err.Error())
+		podLeaseRollback(ss, p, node)
+		return err
+	}
+
+	// pod is already stored together with manifest
+	t = p.Meta.Updated
+
+	if node != nil {
+		eventRecord(p.Meta.Namespace, types.KindPod, p.SelfLink(), types.EventSeverityNormal,
+			types.EventReasonScheduled, fmt.Sprintf("pod scheduled to node %s", node.SelfLink()))
+	}
+
 	return nil
 }
 
+// podLeaseRollback cancels node lease for pod if pod provision is failed,
+// so pod is scheduled again on next observe
+func podLeaseRollback(ss *ServiceState, p *types.Pod, node *types.Node) {
+
+	if node == nil {
+		return
+	}
+
+	if _, err := ss.cluster.PodLeaseCancel(p); err != nil {
+		log.Errorf("%s:> pod node release err: %s", logPrefix, err.Error())
+	}
+
+	p.Meta.Node = types.EmptyString
+}
+
 // podDestroy function marks pod spec as destroy
 func podDestroy(ss *ServiceState, p *types.Pod) (err error) {
 
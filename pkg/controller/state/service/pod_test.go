@@ -22,7 +22,9 @@ import (
 	"context"
 	"github.com/lastbackend/lastbackend/pkg/controller/envs"
 	"github.com/lastbackend/lastbackend/pkg/controller/ipam"
+	"github.com/lastbackend/lastbackend/pkg/distribution"
 	"github.com/lastbackend/lastbackend/pkg/distribution/types"
+	"github.com/lastbackend/lastbackend/pkg/storage"
 	"github.com/stretchr/testify/assert"
 	"testing"
 )
@@ -49,6 +51,17 @@ func testPodObserver(t *testing.T, name, werr string, wst *ServiceState, state *
 		return
 	}
 
+	// pods are created in storage before they are observed
+	for _, pl := range state.pod.list {
+		for _, sp := range pl {
+			err = stg.Put(ctx, stg.Collection().Pod(),
+				stg.Key().Pod(sp.Meta.Namespace, sp.Meta.Service, sp.Meta.Deployment, sp.Meta.Name), sp, nil)
+			if !assert.NoError(t, err) {
+				return
+			}
+		}
+	}
+
 	t.Run(name, func(t *testing.T) {
 
 		err := PodObserve(state, p)
@@ -282,6 +295,106 @@ func TestHandlePodStateCreated(t *testing.T) {
 		return s
 	}())
 
+	tests = append(tests, func() suit {
+
+		s := suit{name: "successful state handle with not matched node selector"}
+
+		svc := getServiceAsset(types.StateProvision, types.EmptyString)
+		svc.Spec.Selector.Labels = map[string]string{"type": "db"}
+
+		dp := getDeploymentAsset(svc, types.StateCreated, types.EmptyString)
+		pod := getPodAsset(dp, types.StateCreated, types.EmptyString)
+		pod.Spec.Selector = svc.Spec.Selector
+
+		s.args.pod = pod
+
+		s.args.state = getServiceStateAsset(svc)
+		s.args.state.deployment.provision = dp
+		s.args.state.deployment.list[dp.SelfLink()] = dp
+		s.args.state.pod.list[pod.DeploymentLink()] = make(map[string]*types.Pod)
+		s.args.state.pod.list[pod.DeploymentLink()][pod.SelfLink()] = pod
+
+		s.want.err = types.EmptyString
+		s.want.state = getServiceStateCopy(s.args.state)
+		s.want.state.pod.list[pod.DeploymentLink()][pod.SelfLink()].Status.State = types.StateError
+
+		return s
+	}())
+
+	tests = append(tests, func() suit {
+
+		s := suit{name: "successful state handle with matched node selector"}
+
+		svc := getServiceAsset(types.StateProvision, types.EmptyString)
+		svc.Spec.Selector.Node = "node"
+
+		dp := getDeploymentAsset(svc, types.StateCreated, types.EmptyString)
+		pod := getPodAsset(dp, types.StateCreated, types.EmptyString)
+		pod.Spec.Selector = svc.Spec.Selector
+
+		s.args.pod = pod
+
+		s.args.state = getServiceStateAsset(svc)
+		s.args.state.deployment.provision = dp
+		s.args.state.deployment.list[dp.SelfLink()] = dp
+		s.args.state.pod.list[pod.DeploymentLink()] = make(map[string]*types.Pod)
+		s.args.state.pod.list[pod.DeploymentLink()][pod.SelfLink()] = pod
+
+		s.want.err = types.EmptyString
+		s.want.state = getServiceStateCopy(s.args.state)
+		s.want.state.pod.list[pod.DeploymentLink()][pod.SelfLink()].Status.State = types.StateProvision
+
+		return s
+	}())
+
+	tests = append(tests, func() suit {
+
+		s := suit{name: "pod state handle with exceeded namespace ram quota"}
+
+		stg := envs.Get().GetStorage()
+
+		ns := new(types.Namespace)
+		ns.Meta.Name = "quota"
+		ns.Spec.Quotas.RAM = 128
+		ns.SelfLink()
+
+		opts := storage.GetOpts()
+		opts.Force = true
+
+		err := stg.Set(context.Background(), stg.Collection().Namespace(), stg.Key().Namespace(ns.Meta.Name), ns, opts)
+		if !assert.NoError(t, err) {
+			return s
+		}
+
+		c := new(types.SpecTemplateContainer)
+		c.Resources.Request.RAM = 256
+
+		svc := getServiceAsset(types.StateProvision, types.EmptyString)
+		svc.Meta.Namespace = ns.Meta.Name
+		svc.Spec.Template.Containers = append(svc.Spec.Template.Containers, c)
+
+		dp := getDeploymentAsset(svc, types.StateCreated, types.EmptyString)
+		pod := getPodAsset(dp, types.StateCreated, types.EmptyString)
+
+		s.args.pod = pod
+
+		s.args.state = getServiceStateAsset(svc)
+		s.args.state.deployment.provision = dp
+		s.args.state.deployment.list[dp.SelfLink()] = dp
+		s.args.state.pod.list[pod.DeploymentLink()] = make(map[string]*types.Pod)
+		s.args.state.pod.list[pod.DeploymentLink()][pod.SelfLink()] = pod
+
+		s.want.err = types.EmptyString
+		s.want.state = getServiceStateCopy(s.args.state)
+
+		// pods are shared with args state: copy wanted pod to keep created state for observer
+		wp := *pod
+		wp.Status.State = types.StateError
+		s.want.state.pod.list[pod.DeploymentLink()][pod.SelfLink()] = &wp
+
+		return s
+	}())
+
 	for _, tt := range tests {
 		testPodObserver(t, tt.name, tt.want.err, tt.want.state, tt.args.state, tt.args.pod)
 	}
@@ -875,3 +988,138 @@ func TestHandlePodStateDestroyed(t *testing.T) {
 		testPodObserver(t, tt.name, tt.want.err, tt.want.state, tt.args.state, tt.args.pod)
 	}
 }
+
+func TestPodEvents(t *testing.T) {
+
+	var (
+		ctx = context.Background()
+		stg = envs.Get().GetStorage()
+	)
+
+	svc := getServiceAsset(types.StateProvision, types.EmptyString)
+	dp := getDeploymentAsset(svc, types.StateProvision, types.EmptyString)
+
+	oom := func(p *types.Pod) *types.Pod {
+		c := new(types.PodContainer)
+		c.Name = "demo"
+		c.State.Stopped.Stopped = true
+		c.State.Stopped.Exit.OOMKilled = true
+		p.Status.Containers = map[string]*types.PodContainer{"demo": c}
+		return p
+	}
+
+	pull := getPodAsset(dp, types.StateError, "image not found")
+	pull.Status.Status = types.StatusPull
+
+	tests := []struct {
+		name   string
+		prev   *types.Pod
+		pod    *types.Pod
+		reason string
+		count  int
+	}{
+		{"pull failed", nil, pull, types.EventReasonPullFailed, 1},
+		{"pull failed already recorded", pull, pull, types.EventReasonPullFailed, 1},
+		{"container oom killed", nil, oom(getPodAsset(dp, types.StateReady, types.EmptyString)), types.EventReasonOOMKilled, 1},
+		{"no events", nil, getPodAsset(dp, types.StateReady, types.EmptyString), types.EmptyString, 0},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+
+			err := stg.Del(ctx, stg.Collection().Event(), types.EmptyString)
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			if tc.prev != nil {
+				podEvents(nil, tc.prev)
+			}
+
+			podEvents(tc.prev, tc.pod)
+
+			e := new(types.EventRecord)
+			e.Object = types.EventObject{Kind: types.KindPod, SelfLink: tc.pod.SelfLink()}
+			e.Reason = tc.reason
+
+			item, err := distribution.NewEventModel(ctx, stg).Get(tc.pod.Meta.Namespace, e.CreateName())
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			if tc.count == 0 {
+				assert.Nil(t, item, "event should not be recorded")
+				return
+			}
+
+			if assert.NotNil(t, item, "event should be recorded") {
+				assert.Equal(t, tc.count, item.Count, "event count mismatch")
+			}
+		})
+	}
+}
+
+func TestPodProvisionNodeAllocation(t *testing.T) {
+
+	var (
+		ctx = context.Background()
+		stg = envs.Get().GetStorage()
+	)
+
+	c := new(types.SpecTemplateContainer)
+	c.Resources.Request.RAM = 64
+
+	svc := getServiceAsset(types.StateProvision, types.EmptyString)
+	svc.Spec.Template.Containers = append(svc.Spec.Template.Containers, c)
+	dp := getDeploymentAsset(svc, types.StateProvision, types.EmptyString)
+
+	tests := []struct {
+		name      string
+		stored    bool
+		wantErr   bool
+		allocated int
+	}{
+		{"pod provision stores node allocated resources", true, false, 1},
+		{"pod provision failure cancels node lease", false, true, 0},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+
+			ss := getServiceStateAsset(svc)
+			p := getPodAsset(dp, types.StateCreated, types.EmptyString)
+
+			if tc.stored {
+				err := stg.Put(ctx, stg.Collection().Pod(),
+					stg.Key().Pod(p.Meta.Namespace, p.Meta.Service, p.Meta.Deployment, p.Meta.Name), p, nil)
+				if !assert.NoError(t, err) {
+					return
+				}
+			}
+
+			err := podProvision(ss, p)
+			if tc.wantErr {
+				assert.Error(t, err, "expected err")
+				assert.Equal(t, types.EmptyString, p.Meta.Node, "pod node should be empty")
+			} else if !assert.NoError(t, err) {
+				return
+			}
+
+			node, err := distribution.NewNodeModel(ctx, stg).Get("node")
+			if !assert.NoError(t, err) || !assert.NotNil(t, node, "node should exist") {
+				return
+			}
+
+			assert.Equal(t, tc.allocated, node.Status.Allocated.Pods, "node allocated pods mismatch")
+			assert.Equal(t, int64(tc.allocated)*c.Resources.Request.RAM, node.Status.Allocated.Memory,
+				"node allocated memory mismatch")
+
+			// cluster state reservation is kept until node change is received from storage
+			n, err := ss.cluster.PodLease(p, nil)
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.Equal(t, tc.allocated+1, n.Status.Allocated.Pods, "cluster state node allocated pods mismatch")
+		})
+	}
+}
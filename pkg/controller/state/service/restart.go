@@ -0,0 +1,88 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+
+package service
+
+import (
+	"time"
+
+	"github.com/lastbackend/lastbackend/pkg/distribution/types"
+	"github.com/lastbackend/lastbackend/pkg/log"
+)
+
+const logRestartPrefix = "state:observer:restart"
+
+// restartSource - changed secret or config which may be used in service env vars
+type restartSource struct {
+	namespace string
+	kind      string
+	name      string
+}
+
+// serviceRestart starts service rolling update if changed secret or config is used in container env vars
+func serviceRestart(ss *ServiceState, src restartSource) error {
+
+	svc := ss.service
+
+	if svc == nil || svc.Spec.State.Destroy || !svc.Spec.Strategy.RestartOnChange {
+		return nil
+	}
+
+	if src.namespace != types.EmptyString && src.namespace != svc.Meta.Namespace {
+		return nil
+	}
+
+	if !serviceEnvUses(svc.Spec.Template, src.kind, src.name) {
+		return nil
+	}
+
+	log.V(logLevel).Debugf("%s:> restart service %s: %s %s changed", logRestartPrefix, svc.SelfLink(), src.kind, src.name)
+
+	_, err := serviceSpecUpdate(svc, func(s *types.Service) bool {
+		if s.Spec.State.Destroy || !serviceEnvUses(s.Spec.Template, src.kind, src.name) {
+			return false
+		}
+		s.Spec.Template.Updated = time.Now()
+		s.Status.State = types.StateProvision
+		return true
+	})
+
+	return err
+}
+
+// serviceEnvUses checks if any template container env var is taken from secret or config
+func serviceEnvUses(t types.SpecTemplate, kind, name string) bool {
+
+	for _, c := range t.Containers {
+		for _, e := range c.EnvVars {
+
+			switch kind {
+			case types.KindSecret:
+				if e.Secret.Name == name {
+					return true
+				}
+			case types.KindConfig:
+				if e.Config.Name == name {
+					return true
+				}
+			}
+		}
+	}
+
+	return false
+}
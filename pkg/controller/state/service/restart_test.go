@@ -0,0 +1,188 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/lastbackend/lastbackend/pkg/controller/envs"
+	"github.com/lastbackend/lastbackend/pkg/distribution"
+	"github.com/lastbackend/lastbackend/pkg/distribution/types"
+	"github.com/lastbackend/lastbackend/pkg/storage"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServiceEnvUses(t *testing.T) {
+
+	type args struct {
+		kind string
+		name string
+	}
+
+	var template = types.SpecTemplate{
+		Containers: types.SpecTemplateContainers{
+			&types.SpecTemplateContainer{
+				EnvVars: types.SpecTemplateContainerEnvs{
+					&types.SpecTemplateContainerEnv{Name: "PLAIN", Value: "value"},
+					&types.SpecTemplateContainerEnv{Name: "TOKEN", Secret: types.SpecTemplateContainerEnvSecret{Name: "auth", Key: "token"}},
+				},
+			},
+			&types.SpecTemplateContainer{
+				EnvVars: types.SpecTemplateContainerEnvs{
+					&types.SpecTemplateContainerEnv{Name: "MODE", Config: types.SpecTemplateContainerEnvConfig{Name: "settings", Key: "mode"}},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want bool
+	}{
+		{
+			"secret used in env",
+			args{types.KindSecret, "auth"},
+			true,
+		},
+		{
+			"config used in env",
+			args{types.KindConfig, "settings"},
+			true,
+		},
+		{
+			"secret not used in env",
+			args{types.KindSecret, "other"},
+			false,
+		},
+		{
+			"config name used as secret name",
+			args{types.KindSecret, "settings"},
+			false,
+		},
+		{
+			"unknown kind",
+			args{types.KindPod, "auth"},
+			false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := serviceEnvUses(template, tt.args.kind, tt.args.name)
+			assert.Equal(t, tt.want, got, "env usage not match")
+		})
+	}
+}
+
+func TestServiceRestart(t *testing.T) {
+
+	var (
+		ctx = context.Background()
+		stg = envs.Get().GetStorage()
+		sm  = distribution.NewServiceModel(ctx, stg)
+	)
+
+	type args struct {
+		src restartSource
+		// service template changed through api after service state was cached
+		changed bool
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		restart bool
+	}{
+		{
+			"restart service on used secret change",
+			args{restartSource{"test", types.KindSecret, "auth"}, false},
+			true,
+		},
+		{
+			"skip service on not used secret change",
+			args{restartSource{"test", types.KindSecret, "other"}, false},
+			false,
+		},
+		{
+			"skip service in other namespace",
+			args{restartSource{"other", types.KindSecret, "auth"}, false},
+			false,
+		},
+		{
+			"skip service if secret is not used by stored template",
+			args{restartSource{"test", types.KindSecret, "auth"}, true},
+			false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			c := new(types.SpecTemplateContainer)
+			c.EnvVars = types.SpecTemplateContainerEnvs{
+				&types.SpecTemplateContainerEnv{Name: "TOKEN", Secret: types.SpecTemplateContainerEnvSecret{Name: "auth", Key: "token"}},
+			}
+
+			svc := getServiceAsset(types.StateReady, types.EmptyString)
+			svc.Spec.Strategy.RestartOnChange = true
+			svc.Spec.Template.Containers = append(svc.Spec.Template.Containers, c)
+
+			opts := storage.GetOpts()
+			opts.Force = true
+			err := stg.Set(ctx, stg.Collection().Service(), stg.Key().Service(svc.Meta.Namespace, svc.Meta.Name), svc, opts)
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			ss := getServiceStateAsset(svc)
+			updated := svc.Spec.Template.Updated
+
+			if tt.args.changed {
+				s, err := sm.Get(svc.Meta.Namespace, svc.Meta.Name)
+				if !assert.NoError(t, err) {
+					return
+				}
+				s.Spec.Template.Containers = types.SpecTemplateContainers{new(types.SpecTemplateContainer)}
+				if _, err := sm.Update(s); !assert.NoError(t, err) {
+					return
+				}
+			}
+
+			if !assert.NoError(t, serviceRestart(ss, tt.args.src)) {
+				return
+			}
+
+			s, err := sm.Get(svc.Meta.Namespace, svc.Meta.Name)
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			if tt.restart {
+				assert.True(t, s.Spec.Template.Updated.After(updated), "service template should be updated")
+				assert.Equal(t, types.StateProvision, s.Status.State, "service state not match")
+				return
+			}
+
+			assert.True(t, s.Spec.Template.Updated.Equal(updated), "service template should not be updated")
+			assert.Equal(t, types.StateReady, s.Status.State, "service state not match")
+		})
+	}
+}
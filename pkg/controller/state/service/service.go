@@ -20,18 +20,20 @@ package service
 
 import (
 	"context"
-	"errors"
 
 	"time"
 
 	"github.com/lastbackend/lastbackend/pkg/controller/envs"
 	"github.com/lastbackend/lastbackend/pkg/distribution"
+	"github.com/lastbackend/lastbackend/pkg/distribution/errors"
 	"github.com/lastbackend/lastbackend/pkg/distribution/types"
 	"github.com/lastbackend/lastbackend/pkg/log"
 )
 
 const (
 	logServicePrefix = "state:observer:service"
+	// serviceUpdateRetries - attempts to update service if it was changed concurrently
+	serviceUpdateRetries = 3
 )
 
 // serviceObserve manage handlers based on service state
@@ -190,6 +192,12 @@ func handleServiceStateDestroy(ss *ServiceState, svc *types.Service) (err error)
 
 	dm := distribution.NewDeploymentModel(context.Background(), envs.Get().GetStorage())
 	if len(ss.deployment.list) == 0 {
+
+		if err = deploymentHistoryRemove(ss, svc); err != nil {
+			log.Errorf("%s:> deployment history remove err: %s", logServicePrefix, err.Error())
+			return err
+		}
+
 		sm := distribution.NewServiceModel(context.Background(), envs.Get().GetStorage())
 		if err = sm.Remove(svc); err != nil {
 			log.Errorf("%s:> service remove err: %s", logServicePrefix, err.Error())
@@ -258,6 +266,11 @@ func handleServiceStateDestroyed(ss *ServiceState, svc *types.Service) (err erro
 		return nil
 	}
 
+	if err = deploymentHistoryRemove(ss, svc); err != nil {
+		log.Errorf("%s:> deployment history remove err: %s", logServicePrefix, err.Error())
+		return err
+	}
+
 	sm := distribution.NewServiceModel(context.Background(), envs.Get().GetStorage())
 	if err = sm.Remove(svc); err != nil {
 		log.Errorf("%s:> service remove err: %s", logServicePrefix, err.Error())
@@ -335,11 +348,17 @@ func serviceDeploymentProvision(ss *ServiceState, svc *types.Service) error {
 			return err
 		}
 
+		// previous rollout is replaced by new deployment
+		deploymentRollingReset(ss)
+
 		for _, od := range ss.deployment.list {
 
 			if ss.deployment.active != nil {
-				if ss.deployment.active.SelfLink() == od.SelfLink() && od.Status.State == types.StateReady {
-					continue
+				if ss.deployment.active.SelfLink() == od.SelfLink() {
+					// active deployment can be partially drained by rolling update
+					if od.Status.State == types.StateReady || od.Status.State == types.StateProvision {
+						continue
+					}
 				}
 			}
 
@@ -419,6 +438,12 @@ func serviceStatusState(ss *ServiceState) (err error) {
 
 	if ss.deployment.active != nil {
 
+		// keep aborted rollout error until service spec is changed
+		if ss.service.Status.State == types.StateError && ss.deployment.provision == nil &&
+			!deploymentSpecValidate(ss.deployment.active, ss.service.Spec.Template) {
+			return nil
+		}
+
 		ss.service.Status.State = ss.deployment.active.Status.State
 		ss.service.Status.Message = ss.deployment.active.Status.Message
 
@@ -435,3 +460,35 @@ func serviceStatusState(ss *ServiceState) (err error) {
 
 	return nil
 }
+
+// serviceSpecUpdate applies changes to the service read from storage and saves it with revision check,
+// so service changes made through api are not overwritten by cached service.
+// Service is read again if it was changed concurrently, update is skipped if update func returns false.
+// Returns true if service is saved
+func serviceSpecUpdate(svc *types.Service, update func(svc *types.Service) bool) (bool, error) {
+
+	sm := distribution.NewServiceModel(context.Background(), envs.Get().GetStorage())
+
+	for i := 0; ; i++ {
+
+		s, err := sm.Get(svc.Meta.Namespace, svc.Meta.Name)
+		if err != nil {
+			return false, err
+		}
+
+		if s == nil || !update(s) {
+			return false, nil
+		}
+
+		_, err = sm.Update(s)
+		if err == nil {
+			return true, nil
+		}
+
+		if !errors.Storage().IsErrEntityRevision(err) || i >= serviceUpdateRetries {
+			return false, err
+		}
+
+		log.V(logLevel).Debugf("%s:> service %s was changed: retry update", logServicePrefix, svc.SelfLink())
+	}
+}
@@ -1267,6 +1267,7 @@ func getServiceStateAsset(svc *types.Service) *ServiceState {
 
 	n.Meta.Name = "node"
 	n.Meta.Hostname = "node.local"
+	n.Status.Online = true
 	n.Status.Capacity = types.NodeResources{
 		Containers: 10,
 		Pods:       10,
@@ -1276,6 +1277,12 @@ func getServiceStateAsset(svc *types.Service) *ServiceState {
 	}
 	n.SelfLink()
 
+	// pod provision updates node allocated resources in storage
+	stg := envs.Get().GetStorage()
+	opts := storage.GetOpts()
+	opts.Force = true
+	stg.Set(context.Background(), stg.Collection().Node().Info(), stg.Key().Node(n.Meta.Name), n, opts)
+
 	cs := cluster.NewClusterState()
 	cs.SetNode(n)
 	s := NewServiceState(cs, svc)
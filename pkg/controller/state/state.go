@@ -20,6 +20,8 @@ package state
 
 import (
 	"context"
+	"sync"
+	"time"
 
 	"github.com/lastbackend/lastbackend/pkg/controller/envs"
 	"github.com/lastbackend/lastbackend/pkg/controller/state/cluster"
@@ -29,11 +31,19 @@ import (
 	"github.com/lastbackend/lastbackend/pkg/log"
 )
 
-const logLevel = 3
+const (
+	logLevel = 3
+	// autoscaleInterval - interval between services autoscaling checks
+	autoscaleInterval = 30 * time.Second
+)
 
 type State struct {
 	Cluster *cluster.ClusterState
 	Service map[string]*service.ServiceState
+	// lock guards Service map: it is changed by services watcher and read from other goroutines
+	lock sync.RWMutex
+	// resources used by namespaces deployments and routes
+	resources *namespaceResources
 }
 
 func (s *State) Loop() {
@@ -74,6 +84,10 @@ func (s *State) Loop() {
 
 	for _, n := range ns.Items {
 		log.V(logLevel).Debugf("\n\nrestore service in namespace: %s", n.SelfLink())
+		if err := s.resources.restore(n.SelfLink()); err != nil {
+			log.Errorf("%s", err.Error())
+		}
+
 		ss, err := sm.List(n.SelfLink())
 		if err != nil {
 			log.Errorf("%s", err.Error())
@@ -83,11 +97,7 @@ func (s *State) Loop() {
 		for _, svc := range ss.Items {
 
 			log.V(logLevel).Debugf("restore service state: %s \n", svc.SelfLink())
-			if _, ok := s.Service[svc.SelfLink()]; !ok {
-				s.Service[svc.SelfLink()] = service.NewServiceState(s.Cluster, svc)
-			}
-
-			s.Service[svc.SelfLink()].Restore()
+			s.setService(svc).Restore()
 		}
 
 	}
@@ -95,6 +105,11 @@ func (s *State) Loop() {
 	go s.watchPods(context.Background(), &pr.System.Revision)
 	go s.watchDeployments(context.Background(), &dr.System.Revision)
 	go s.watchServices(context.Background(), &sr.System.Revision)
+	go s.watchRoutes(context.Background())
+	go s.watchSecrets(context.Background())
+	go s.watchConfigs(context.Background())
+	go s.watchBuilds(context.Background())
+	go s.autoscale(context.Background())
 
 	log.Info("finish services restore\n\n")
 }
@@ -119,19 +134,11 @@ func (s *State) watchServices(ctx context.Context, rev *int64) {
 				}
 
 				if w.IsActionRemove() {
-					_, ok := s.Service[w.Data.SelfLink()]
-					if ok {
-						delete(s.Service, w.Data.SelfLink())
-					}
+					s.delService(w.Data.SelfLink())
 					continue
 				}
 
-				_, ok := s.Service[w.Data.SelfLink()]
-				if !ok {
-					s.Service[w.Data.SelfLink()] = service.NewServiceState(s.Cluster, w.Data)
-				}
-
-				s.Service[w.Data.SelfLink()].SetService(w.Data)
+				s.setService(w.Data).SetService(w.Data)
 			}
 		}
 	}()
@@ -159,20 +166,21 @@ func (s *State) watchDeployments(ctx context.Context, rev *int64) {
 					continue
 				}
 
-				if w.IsActionRemove() {
-					_, ok := s.Service[w.Data.ServiceLink()]
-					if ok {
-						s.Service[w.Data.ServiceLink()].DelDeployment(w.Data)
-					}
+				if err := s.resources.setDeployment(w.Data, w.IsActionRemove()); err != nil {
+					log.Errorf("%s", err.Error())
+				}
+
+				ss := s.getService(w.Data.ServiceLink())
+				if ss == nil {
 					continue
 				}
 
-				_, ok := s.Service[w.Data.ServiceLink()]
-				if !ok {
-					break
+				if w.IsActionRemove() {
+					ss.DelDeployment(w.Data)
+					continue
 				}
 
-				s.Service[w.Data.ServiceLink()].SetDeployment(w.Data)
+				ss.SetDeployment(w.Data)
 			}
 		}
 	}()
@@ -200,20 +208,17 @@ func (s *State) watchPods(ctx context.Context, rev *int64) {
 					continue
 				}
 
-				if w.IsActionRemove() {
-					_, ok := s.Service[w.Data.ServiceLink()]
-					if ok {
-						s.Service[w.Data.ServiceLink()].DelPod(w.Data)
-					}
+				ss := s.getService(w.Data.ServiceLink())
+				if ss == nil {
 					continue
 				}
 
-				_, ok := s.Service[w.Data.ServiceLink()]
-				if !ok {
-					break
+				if w.IsActionRemove() {
+					ss.DelPod(w.Data)
+					continue
 				}
 
-				s.Service[w.Data.ServiceLink()].SetPod(w.Data)
+				ss.SetPod(w.Data)
 			}
 		}
 	}()
@@ -221,9 +226,217 @@ func (s *State) watchPods(ctx context.Context, rev *int64) {
 	pm.Watch(p, rev)
 }
 
+func (s *State) watchRoutes(ctx context.Context) {
+
+	// Watch routes change to keep namespace resources up to date
+	var (
+		r = make(chan types.RouteEvent)
+	)
+
+	rm := distribution.NewRouteModel(ctx, envs.Get().GetStorage())
+
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case w := <-r:
+
+				if w.Data == nil {
+					continue
+				}
+
+				if err := s.resources.setRoute(w.Data, w.IsActionRemove()); err != nil {
+					log.Errorf("%s", err.Error())
+				}
+			}
+		}
+	}()
+
+	rm.Watch(r, nil)
+}
+
+func (s *State) watchSecrets(ctx context.Context) {
+
+	// Watch secrets change to restart services used them in env vars
+	var (
+		sc = make(chan types.SecretEvent)
+	)
+
+	sm := distribution.NewSecretModel(ctx, envs.Get().GetStorage())
+
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case w := <-sc:
+
+				if w.Data == nil || !w.IsActionUpdate() {
+					continue
+				}
+
+				for _, ss := range s.services() {
+					ss.Restart(w.Data.Meta.Namespace, types.KindSecret, w.Data.Meta.Name)
+				}
+			}
+		}
+	}()
+
+	sm.Watch(sc, nil)
+}
+
+func (s *State) watchConfigs(ctx context.Context) {
+
+	// Watch configs change to restart services used them in env vars
+	var (
+		c = make(chan types.ConfigEvent)
+	)
+
+	cm := distribution.NewConfigModel(ctx, envs.Get().GetStorage())
+
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case w := <-c:
+
+				if w.Data == nil || !w.IsActionUpdate() {
+					continue
+				}
+
+				for _, ss := range s.services() {
+					ss.Restart(w.Data.Meta.Namespace, types.KindConfig, w.Data.Meta.Name)
+				}
+			}
+		}
+	}()
+
+	cm.Watch(c, nil)
+}
+
+func (s *State) watchBuilds(ctx context.Context) {
+
+	// Watch builds change to schedule new builds and update services with built images
+	var (
+		b = make(chan types.BuildEvent)
+	)
+
+	bm := distribution.NewBuildModel(ctx, envs.Get().GetStorage())
+
+	bl, err := bm.List()
+	if err != nil {
+		log.Errorf("%s", err.Error())
+		return
+	}
+
+	for _, item := range bl.Items {
+		if err := buildProvision(s.Cluster, item); err != nil {
+			log.Errorf("%s", err.Error())
+		}
+	}
+
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case w := <-b:
+
+				if w.Data == nil || w.IsActionRemove() {
+					continue
+				}
+
+				if err := buildProvision(s.Cluster, w.Data); err != nil {
+					log.Errorf("%s", err.Error())
+				}
+
+				if !w.IsActionUpdate() {
+					continue
+				}
+
+				if err := buildServiceUpdate(w.Data); err != nil {
+					log.Errorf("%s", err.Error())
+				}
+			}
+		}
+	}()
+
+	bm.Watch(b, &bl.System.Revision)
+}
+
 func NewState() *State {
 	var state = new(State)
 	state.Cluster = cluster.NewClusterState()
 	state.Service = make(map[string]*service.ServiceState)
+	state.resources = newNamespaceResources()
+	state.Cluster.OnNodeDrain(state.drainNode)
 	return state
 }
+
+// autoscale periodically asks services to adjust replicas by pods resources usage
+func (s *State) autoscale(ctx context.Context) {
+
+	ticker := time.NewTicker(autoscaleInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			for _, ss := range s.services() {
+				ss.Autoscale()
+			}
+		}
+	}
+}
+
+// drainNode asks all services to move pods from draining node
+func (s *State) drainNode(node string) {
+	for _, ss := range s.services() {
+		ss.DrainNode(node)
+	}
+}
+
+// getService returns service state by service self link
+func (s *State) getService(link string) *service.ServiceState {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.Service[link]
+}
+
+// setService returns service state, new service state is created if it not exists
+func (s *State) setService(svc *types.Service) *service.ServiceState {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	ss, ok := s.Service[svc.SelfLink()]
+	if !ok {
+		ss = service.NewServiceState(s.Cluster, svc)
+		s.Service[svc.SelfLink()] = ss
+	}
+
+	return ss
+}
+
+func (s *State) delService(link string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	delete(s.Service, link)
+}
+
+// services returns snapshot of service states, service state observers are blocking:
+// lock should not be held while requests are sent to them
+func (s *State) services() []*service.ServiceState {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	list := make([]*service.ServiceState, 0, len(s.Service))
+	for _, ss := range s.Service {
+		list = append(list, ss)
+	}
+
+	return list
+}
@@ -0,0 +1,196 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+
+package distribution
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/lastbackend/lastbackend/pkg/distribution/errors"
+	"github.com/lastbackend/lastbackend/pkg/distribution/types"
+	"github.com/lastbackend/lastbackend/pkg/log"
+	"github.com/lastbackend/lastbackend/pkg/storage"
+	"github.com/lastbackend/lastbackend/pkg/util/generator"
+)
+
+const (
+	logBuildPrefix = "distribution:build"
+)
+
+type Build struct {
+	context context.Context
+	storage storage.Storage
+}
+
+func (b *Build) Get(namespace, name string) (*types.Build, error) {
+
+	log.V(logLevel).Debugf("%s:get:> get build by name %s:%s", logBuildPrefix, namespace, name)
+
+	build := new(types.Build)
+
+	err := b.storage.Get(b.context, b.storage.Collection().Build(), b.storage.Key().Build(namespace, name), &build, nil)
+	if err != nil {
+		if errors.Storage().IsErrEntityNotFound(err) {
+			log.V(logLevel).Warnf("%s:get:> build %s:%s not found", logBuildPrefix, namespace, name)
+			return nil, nil
+		}
+
+		log.V(logLevel).Errorf("%s:get:> get build err: %v", logBuildPrefix, err)
+		return nil, err
+	}
+
+	return build, nil
+}
+
+func (b *Build) List() (*types.BuildList, error) {
+
+	log.V(logLevel).Debugf("%s:list:> get builds list", logBuildPrefix)
+
+	list := types.NewBuildList()
+
+	err := b.storage.List(b.context, b.storage.Collection().Build(), types.EmptyString, list, nil)
+	if err != nil {
+		log.V(logLevel).Errorf("%s:list:> get builds list err: %v", logBuildPrefix, err)
+		return list, err
+	}
+
+	return list, nil
+}
+
+func (b *Build) ListByNamespace(namespace string) (*types.BuildList, error) {
+
+	log.V(logLevel).Debugf("%s:listbynamespace:> get builds list by namespace %s", logBuildPrefix, namespace)
+
+	list := types.NewBuildList()
+
+	err := b.storage.List(b.context, b.storage.Collection().Build(), b.storage.Filter().Build().ByNamespace(namespace), list, nil)
+	if err != nil {
+		log.V(logLevel).Errorf("%s:listbynamespace:> get builds list by namespace err: %v", logBuildPrefix, err)
+		return list, err
+	}
+
+	return list, nil
+}
+
+func (b *Build) Create(build *types.Build) (*types.Build, error) {
+
+	build.Meta.SetDefault()
+	if build.Meta.Name == types.EmptyString {
+		build.Meta.Name = strings.Split(generator.GetUUIDV4(), "-")[4][5:]
+	}
+
+	log.V(logLevel).Debugf("%s:create:> create build %s", logBuildPrefix, build.Meta.Name)
+
+	build.Meta.SelfLink = build.CreateSelfLink(build.Meta.Namespace, build.Meta.Name)
+	build.Status.State = types.StateCreated
+
+	if err := b.storage.Put(b.context, b.storage.Collection().Build(),
+		b.storage.Key().Build(build.Meta.Namespace, build.Meta.Name), build, nil); err != nil {
+		log.V(logLevel).Errorf("%s:create:> insert build err: %v", logBuildPrefix, err)
+		return nil, err
+	}
+
+	return build, nil
+}
+
+func (b *Build) SetStatus(build *types.Build, status *types.BuildStatus) error {
+
+	if build == nil {
+		log.V(logLevel).Warnf("%s:setstatus:> invalid argument %v", logBuildPrefix, build)
+		return nil
+	}
+
+	log.V(logLevel).Debugf("%s:setstatus:> set status build %s -> %#v", logBuildPrefix, build.Meta.Name, status)
+
+	build.Status = *status
+	build.Meta.Updated = time.Now().UTC()
+
+	if err := b.storage.Set(b.context, b.storage.Collection().Build(),
+		b.storage.Key().Build(build.Meta.Namespace, build.Meta.Name), build, nil); err != nil {
+		log.V(logLevel).Errorf("%s:setstatus:> build set status err: %v", logBuildPrefix, err)
+		return err
+	}
+
+	return nil
+}
+
+func (b *Build) Remove(build *types.Build) error {
+
+	log.V(logLevel).Debugf("%s:remove:> remove build %s", logBuildPrefix, build.Meta.Name)
+
+	if err := b.storage.Del(b.context, b.storage.Collection().Build(),
+		b.storage.Key().Build(build.Meta.Namespace, build.Meta.Name)); err != nil {
+		log.V(logLevel).Errorf("%s:remove:> remove build err: %v", logBuildPrefix, err)
+		return err
+	}
+
+	return nil
+}
+
+// Watch build changes
+func (b *Build) Watch(ch chan types.BuildEvent, rev *int64) error {
+
+	log.V(logLevel).Debugf("%s:watch:> watch build", logBuildPrefix)
+
+	done := make(chan bool)
+	watcher := storage.NewWatcher()
+
+	go func() {
+		for {
+			select {
+			case <-b.context.Done():
+				done <- true
+				return
+			case e := <-watcher:
+				if e.Data == nil {
+					continue
+				}
+
+				res := types.BuildEvent{}
+				res.Action = e.Action
+				res.Name = e.Name
+
+				build := new(types.Build)
+
+				if err := json.Unmarshal(e.Data.([]byte), build); err != nil {
+					log.Errorf("%s:> parse data err: %v", logBuildPrefix, err)
+					continue
+				}
+
+				res.Data = build
+
+				ch <- res
+			}
+		}
+	}()
+
+	opts := storage.GetOpts()
+	opts.Rev = rev
+	if err := b.storage.Watch(b.context, b.storage.Collection().Build(), watcher, opts); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func NewBuildModel(ctx context.Context, stg storage.Storage) *Build {
+	return &Build{ctx, stg}
+}
@@ -61,6 +61,22 @@ func (c *Cluster) Get() (*types.Cluster, error) {
 	return cluster, nil
 }
 
+// Set - update cluster info and status
+func (c *Cluster) Set(cluster *types.Cluster) error {
+
+	log.V(logLevel).Debugf("%s:set:> set cluster info", logClusterPrefix)
+
+	opts := storage.GetOpts()
+	opts.Force = true
+
+	if err := c.storage.Set(c.context, c.storage.Collection().Cluster(), "", cluster, opts); err != nil {
+		log.V(logLevel).Errorf("%s:set:> set cluster err: %v", logClusterPrefix, err)
+		return err
+	}
+
+	return nil
+}
+
 // Watch cluster changes
 func (c *Cluster) Watch(ch chan types.ClusterEvent) {
 
@@ -0,0 +1,183 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+
+package distribution
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/lastbackend/lastbackend/pkg/distribution/errors"
+	"github.com/lastbackend/lastbackend/pkg/distribution/types"
+	"github.com/lastbackend/lastbackend/pkg/log"
+	"github.com/lastbackend/lastbackend/pkg/storage"
+)
+
+const (
+	logConfigPrefix = "distribution:config"
+)
+
+type Config struct {
+	context context.Context
+	storage storage.Storage
+}
+
+func (c *Config) Get(namespace, name string) (*types.Config, error) {
+	log.V(logLevel).Debugf("%s:get:> get config by id %s/%s", logConfigPrefix, namespace, name)
+
+	item := new(types.Config)
+
+	err := c.storage.Get(c.context, c.storage.Collection().Config(), c.storage.Key().Config(namespace, name), &item, nil)
+	if err != nil {
+		if errors.Storage().IsErrEntityNotFound(err) {
+			log.V(logLevel).Warnf("%s:get:> in namespace %s by name %s not found", logConfigPrefix, namespace, name)
+			return nil, nil
+		}
+
+		log.V(logLevel).Errorf("%s:get:> in namespace %s by name %s error: %v", logConfigPrefix, namespace, name, err)
+		return nil, err
+	}
+
+	return item, nil
+}
+
+func (c *Config) List() (*types.ConfigList, error) {
+	log.V(logLevel).Debugf("%s:list:> get configs list", logConfigPrefix)
+
+	list := types.NewConfigList()
+	err := c.storage.List(c.context, c.storage.Collection().Config(), types.EmptyString, list, nil)
+	if err != nil {
+		log.V(logLevel).Errorf("%s:list:> get configs list err: %v", logConfigPrefix, err)
+		return list, err
+	}
+
+	log.V(logLevel).Debugf("%s:list:> get configs list result: %d", logConfigPrefix, len(list.Items))
+
+	return list, nil
+}
+
+func (c *Config) ListByNamespace(namespace string) (*types.ConfigList, error) {
+	log.V(logLevel).Debugf("%s:listbynamespace:> get configs list by namespace %s", logConfigPrefix, namespace)
+
+	list := types.NewConfigList()
+	filter := c.storage.Filter().Config().ByNamespace(namespace)
+	err := c.storage.List(c.context, c.storage.Collection().Config(), filter, list, nil)
+	if err != nil {
+		log.V(logLevel).Errorf("%s:listbynamespace:> get configs list by namespace err: %v", logConfigPrefix, err)
+		return list, err
+	}
+
+	log.V(logLevel).Debugf("%s:listbynamespace:> get configs list by namespace result: %d", logConfigPrefix, len(list.Items))
+
+	return list, nil
+}
+
+func (c *Config) Create(namespace *types.Namespace, cfg *types.Config) (*types.Config, error) {
+	log.V(logLevel).Debugf("%s:create:> create config %s", logConfigPrefix, cfg.Meta.Name)
+
+	cfg.Meta.Namespace = namespace.Meta.Name
+	cfg.SelfLink()
+
+	if err := c.storage.Put(c.context, c.storage.Collection().Config(),
+		c.storage.Key().Config(cfg.Meta.Namespace, cfg.Meta.Name), cfg, nil); err != nil {
+		log.V(logLevel).Errorf("%s:create:> insert config err: %v", logConfigPrefix, err)
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+func (c *Config) Update(cfg *types.Config) error {
+	log.V(logLevel).Debugf("%s:update:> update config %s", logConfigPrefix, cfg.Meta.Name)
+
+	cfg.Meta.Updated = time.Now().UTC()
+
+	opts := storage.GetOpts()
+	opts.Rev = revision(cfg.Runtime)
+
+	if err := c.storage.Set(c.context, c.storage.Collection().Config(),
+		c.storage.Key().Config(cfg.Meta.Namespace, cfg.Meta.Name), cfg, opts); err != nil {
+		log.V(logLevel).Errorf("%s:update:> update config err: %v", logConfigPrefix, err)
+		return err
+	}
+
+	return nil
+}
+
+func (c *Config) Remove(cfg *types.Config) error {
+	log.V(logLevel).Debugf("%s:remove:> remove config %s", logConfigPrefix, cfg.Meta.Name)
+
+	if err := c.storage.Del(c.context, c.storage.Collection().Config(),
+		c.storage.Key().Config(cfg.Meta.Namespace, cfg.Meta.Name)); err != nil {
+		log.V(logLevel).Errorf("%s:remove:> remove config err: %v", logConfigPrefix, err)
+		return err
+	}
+
+	return nil
+}
+
+// Watch config changes
+func (c *Config) Watch(ch chan types.ConfigEvent, rev *int64) error {
+
+	log.V(logLevel).Debugf("%s:watch:> watch config", logConfigPrefix)
+
+	done := make(chan bool)
+	watcher := storage.NewWatcher()
+
+	go func() {
+		for {
+			select {
+			case <-c.context.Done():
+				done <- true
+				return
+			case e := <-watcher:
+				if e.Data == nil {
+					continue
+				}
+
+				res := types.ConfigEvent{}
+				res.Action = e.Action
+				res.Name = e.Name
+
+				cfg := new(types.Config)
+
+				if err := json.Unmarshal(e.Data.([]byte), cfg); err != nil {
+					log.Errorf("%s:> parse data err: %v", logConfigPrefix, err)
+					continue
+				}
+
+				res.Data = cfg
+
+				ch <- res
+			}
+		}
+	}()
+
+	opts := storage.GetOpts()
+	opts.Rev = rev
+	if err := c.storage.Watch(c.context, c.storage.Collection().Config(), watcher, opts); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func NewConfigModel(ctx context.Context, stg storage.Storage) *Config {
+	return &Config{ctx, stg}
+}
@@ -80,10 +80,25 @@ func (d *Deployment) Create(service *types.Service) (*types.Deployment, error) {
 
 	log.V(logLevel).Debugf("%s:create:> distribution create in service: %s", logDeploymentPrefix, service.Meta.Name)
 
+	dl, err := d.ListByService(service.Meta.Namespace, service.Meta.Name)
+	if err != nil {
+		log.Errorf("%s:create:> distribution create in service: %s err: %v", logDeploymentPrefix, service.Meta.Name, err)
+		return nil, err
+	}
+
 	deployment := new(types.Deployment)
 
+	// deployment version is next after latest service deployment version
+	for _, dp := range dl.Items {
+		if dp.Meta.Version > deployment.Meta.Version {
+			deployment.Meta.Version = dp.Meta.Version
+		}
+	}
+	deployment.Meta.Version++
+
 	deployment.Meta.Namespace = service.Meta.Namespace
 	deployment.Meta.Service = service.Meta.Name
+	deployment.Meta.Labels = make(map[string]string)
 	deployment.Meta.Status = types.StateCreated
 	deployment.Meta.Name = strings.Split(generator.GetUUIDV4(), "-")[4][5:]
 	deployment.Meta.Created = time.Now()
@@ -91,6 +106,11 @@ func (d *Deployment) Create(service *types.Service) (*types.Deployment, error) {
 
 	deployment.SelfLink()
 
+	// deployment pods inherit service labels to be matched by affinity rules
+	for k, v := range service.Meta.Labels {
+		deployment.Meta.Labels[k] = v
+	}
+
 	deployment.Spec = types.DeploymentSpec{
 		Replicas: service.Spec.Replicas,
 		Template: service.Spec.Template,
@@ -147,8 +167,11 @@ func (d *Deployment) Update(dt *types.Deployment) error {
 
 	log.V(logLevel).Debugf("%s:update:> update deployment %s", logDeploymentPrefix, dt.Meta.Name)
 
+	opts := storage.GetOpts()
+	opts.Rev = revision(dt.Runtime)
+
 	if err := d.storage.Set(d.context, d.storage.Collection().Deployment(),
-		d.storage.Key().Deployment(dt.Meta.Namespace, dt.Meta.Service, dt.Meta.Name), dt, nil); err != nil {
+		d.storage.Key().Deployment(dt.Meta.Namespace, dt.Meta.Service, dt.Meta.Name), dt, opts); err != nil {
 		log.Errorf("%s:update:> update for deployment %s err: %v", logDeploymentPrefix, dt.Meta.Name, err)
 		return err
 	}
@@ -156,6 +179,20 @@ func (d *Deployment) Update(dt *types.Deployment) error {
 	return nil
 }
 
+// Set deployment without revision check
+func (d *Deployment) Set(dt *types.Deployment) error {
+
+	log.V(logLevel).Debugf("%s:set:> set deployment %s", logDeploymentPrefix, dt.Meta.Name)
+
+	if err := d.storage.Set(d.context, d.storage.Collection().Deployment(),
+		d.storage.Key().Deployment(dt.Meta.Namespace, dt.Meta.Service, dt.Meta.Name), dt, nil); err != nil {
+		log.Errorf("%s:set:> set deployment %s err: %v", logDeploymentPrefix, dt.Meta.Name, err)
+		return err
+	}
+
+	return nil
+}
+
 // Cancel deployment
 func (d *Deployment) Cancel(dt *types.Deployment) error {
 
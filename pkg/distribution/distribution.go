@@ -18,4 +18,16 @@
 
 package distribution
 
+import "github.com/lastbackend/lastbackend/pkg/distribution/types"
+
 const logLevel = 4
+
+// revision returns entity revision received from storage,
+// entity is updated only if it was not changed since this revision
+func revision(rt types.Runtime) *int64 {
+	if rt.System.Revision == 0 {
+		return nil
+	}
+	rev := rt.System.Revision
+	return &rev
+}
@@ -128,6 +128,14 @@ func (e *err) NotFound(err ...error) *Err {
 	}
 }
 
+func (e *err) Conflict(err ...error) *Err {
+	return &Err{
+		Code:   http.StatusText(http.StatusConflict),
+		origin: getError(joinNameAndMessage(e.s, "revision conflict"), err...),
+		http:   HTTP.getConflict(toUpperFirstChar(e.s) + " revision conflict"),
+	}
+}
+
 func (e *err) NotUnique(attr string, err ...error) *Err {
 	return &Err{
 		Code:   StatusNotUnique,
@@ -52,6 +52,10 @@ func (Http) NotFound(w http.ResponseWriter, args ...string) {
 	HTTP.getNotFound(args...).send(w)
 }
 
+func (Http) Conflict(w http.ResponseWriter, msg ...string) {
+	HTTP.getConflict(msg...).send(w)
+}
+
 func (Http) InternalServerError(w http.ResponseWriter, msg ...string) {
 	HTTP.getInternalServerError(msg...).send(w)
 }
@@ -107,6 +111,10 @@ func (Http) getPaymentRequired(msg ...string) *Http {
 	return getHttpError(http.StatusPaymentRequired, msg...)
 }
 
+func (Http) getConflict(msg ...string) *Http {
+	return getHttpError(http.StatusConflict, msg...)
+}
+
 func (Http) getUnknown(msg ...string) *Http {
 	return getHttpError(http.StatusInternalServerError, msg...)
 }
@@ -26,6 +26,7 @@ const (
 	ErrEntityExists          = "entity exists"
 	ErrOperationFailure      = "operation failure"
 	ErrEntityNotFound        = "entity not found"
+	ErrEntityRevision        = "entity revision conflict"
 	ErrStructArgIsNil        = "input structure is nil"
 	ErrStructOutIsNil        = "output structure is nil"
 	ErrStructArgIsInvalid    = "input structure is invalid"
@@ -59,6 +60,14 @@ func (storage) NewErrEntityNotFound() error {
 	return errors.New(ErrEntityNotFound)
 }
 
+func (storage) IsErrEntityRevision(err error) bool {
+	return err.Error() == ErrEntityRevision
+}
+
+func (storage) NewErrEntityRevision() error {
+	return errors.New(ErrEntityRevision)
+}
+
 func (storage) IsErrStructArgIsNil(err error) bool {
 	return err.Error() == ErrStructArgIsNil
 }
@@ -0,0 +1,141 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+
+package distribution
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/lastbackend/lastbackend/pkg/distribution/errors"
+	"github.com/lastbackend/lastbackend/pkg/distribution/types"
+	"github.com/lastbackend/lastbackend/pkg/log"
+	"github.com/lastbackend/lastbackend/pkg/storage"
+)
+
+const (
+	logEventPrefix = "distribution:event"
+	// eventTTL - seconds event record is kept in storage after last occurrence
+	eventTTL = 60 * 60
+)
+
+type Event struct {
+	context context.Context
+	storage storage.Storage
+}
+
+func (e *Event) Get(namespace, name string) (*types.EventRecord, error) {
+
+	log.V(logLevel).Debugf("%s:get:> get event by name %s:%s", logEventPrefix, namespace, name)
+
+	event := new(types.EventRecord)
+
+	err := e.storage.Get(e.context, e.storage.Collection().Event(), e.storage.Key().Event(namespace, name), &event, nil)
+	if err != nil {
+		if errors.Storage().IsErrEntityNotFound(err) {
+			log.V(logLevel).Warnf("%s:get:> event %s:%s not found", logEventPrefix, namespace, name)
+			return nil, nil
+		}
+
+		log.V(logLevel).Errorf("%s:get:> get event err: %v", logEventPrefix, err)
+		return nil, err
+	}
+
+	return event, nil
+}
+
+// ListByNamespace returns namespace events sorted by last occurrence
+func (e *Event) ListByNamespace(namespace string) (*types.EventRecordList, error) {
+
+	log.V(logLevel).Debugf("%s:listbynamespace:> get events list by namespace %s", logEventPrefix, namespace)
+
+	list := types.NewEventRecordList()
+
+	err := e.storage.List(e.context, e.storage.Collection().Event(), e.storage.Filter().Event().ByNamespace(namespace), list, nil)
+	if err != nil {
+		log.V(logLevel).Errorf("%s:listbynamespace:> get events list by namespace err: %v", logEventPrefix, err)
+		return list, err
+	}
+
+	sort.Slice(list.Items, func(i, j int) bool {
+		return list.Items[i].LastSeen.Before(list.Items[j].LastSeen)
+	})
+
+	return list, nil
+}
+
+// Record creates event record or increments count of existing record with same object and reason
+func (e *Event) Record(namespace string, object types.EventObject, severity, reason, message string) (*types.EventRecord, error) {
+
+	event := new(types.EventRecord)
+	event.Meta.Namespace = namespace
+	event.Object = object
+	event.Reason = reason
+	event.Meta.Name = event.CreateName()
+
+	log.V(logLevel).Debugf("%s:record:> record event %s for %s: %s", logEventPrefix, reason, object.SelfLink, message)
+
+	prev, err := e.Get(namespace, event.Meta.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		now  = time.Now().UTC()
+		opts = storage.GetOpts()
+	)
+
+	opts.Ttl = eventTTL
+
+	if prev != nil {
+		prev.Message = message
+		prev.Severity = severity
+		prev.Count++
+		prev.LastSeen = now
+		prev.Meta.Updated = now
+
+		if err := e.storage.Set(e.context, e.storage.Collection().Event(),
+			e.storage.Key().Event(namespace, prev.Meta.Name), prev, opts); err != nil {
+			log.V(logLevel).Errorf("%s:record:> update event err: %v", logEventPrefix, err)
+			return nil, err
+		}
+
+		return prev, nil
+	}
+
+	event.Meta.SetDefault()
+	event.Meta.SelfLink = event.CreateSelfLink(namespace, event.Meta.Name)
+	event.Message = message
+	event.Severity = severity
+	event.Count = 1
+	event.FirstSeen = now
+	event.LastSeen = now
+
+	if err := e.storage.Put(e.context, e.storage.Collection().Event(),
+		e.storage.Key().Event(namespace, event.Meta.Name), event, opts); err != nil {
+		log.V(logLevel).Errorf("%s:record:> insert event err: %v", logEventPrefix, err)
+		return nil, err
+	}
+
+	return event, nil
+}
+
+func NewEventModel(ctx context.Context, stg storage.Storage) *Event {
+	return &Event{ctx, stg}
+}
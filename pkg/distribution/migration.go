@@ -0,0 +1,292 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+
+package distribution
+
+import (
+	"context"
+
+	"github.com/lastbackend/lastbackend/pkg/distribution/errors"
+	"github.com/lastbackend/lastbackend/pkg/distribution/types"
+	"github.com/lastbackend/lastbackend/pkg/log"
+	"github.com/lastbackend/lastbackend/pkg/storage"
+	"github.com/lastbackend/lastbackend/pkg/util/crypto"
+)
+
+const (
+	logMigrationPrefix = "distribution:migration"
+	// migrationCPUMillicores - system key marking container cpu resources converted into millicores
+	migrationCPUMillicores = "migration-cpu-millicores"
+	// migrationSecretsEncrypt - system key marking cluster level secrets moved into namespaces and encrypted
+	migrationSecretsEncrypt = "migration-secrets-encrypt"
+
+	migrationStateStarted = "started"
+	migrationStateDone    = "done"
+
+	// cpu values were stored as docker cpu shares before, where 1024 is one core
+	cpuSharesPerCore = 1024
+	cpuMillicores    = 1000
+)
+
+type Migration struct {
+	context context.Context
+	storage storage.Storage
+}
+
+type migrationState struct {
+	State string `json:"state"`
+}
+
+// CPUMillicores converts container cpu resources of stored services, deployments,
+// pods and pod manifests from docker cpu shares into millicores.
+// Migration is executed once per cluster, state is kept in system collection
+func (m *Migration) CPUMillicores() error {
+
+	log.V(logLevel).Debugf("%s:cpu:> convert cpu shares to millicores", logMigrationPrefix)
+
+	return m.run(migrationCPUMillicores, m.cpuMillicores)
+}
+
+// SecretsEncrypt moves secrets created before namespaced secrets were introduced
+// into every namespace, encrypts their plaintext data with provided key and removes them.
+// Migration is executed once per cluster, state is kept in system collection
+func (m *Migration) SecretsEncrypt(kp crypto.KeyProvider) error {
+
+	log.V(logLevel).Debugf("%s:secrets:> move cluster secrets into namespaces and encrypt them", logMigrationPrefix)
+
+	return m.run(migrationSecretsEncrypt, func() error {
+		return m.secretsEncrypt(kp)
+	})
+}
+
+// run executes migration if it was not started before.
+// Migration state put is used as a lock: only one process is allowed to run migration
+func (m *Migration) run(key string, migrate func() error) error {
+
+	state := new(migrationState)
+	err := m.storage.Get(m.context, m.storage.Collection().System(), key, state, nil)
+	if err == nil {
+		if state.State != migrationStateDone {
+			log.Warnf("%s:> migration %s is in state %s, skip it", logMigrationPrefix, key, state.State)
+		}
+		return nil
+	}
+
+	if !errors.Storage().IsErrEntityNotFound(err) {
+		log.Errorf("%s:> get migration %s state err: %v", logMigrationPrefix, key, err)
+		return err
+	}
+
+	state.State = migrationStateStarted
+	if err := m.storage.Put(m.context, m.storage.Collection().System(), key, state, nil); err != nil {
+		if errors.Storage().IsErrEntityExists(err) {
+			return nil
+		}
+		log.Errorf("%s:> put migration %s state err: %v", logMigrationPrefix, key, err)
+		return err
+	}
+
+	if err := migrate(); err != nil {
+		return err
+	}
+
+	state.State = migrationStateDone
+	if err := m.storage.Set(m.context, m.storage.Collection().System(), key, state, nil); err != nil {
+		log.Errorf("%s:> set migration %s state err: %v", logMigrationPrefix, key, err)
+		return err
+	}
+
+	return nil
+}
+
+func (m *Migration) cpuMillicores() error {
+
+	nl, err := NewNamespaceModel(m.context, m.storage).List()
+	if err != nil {
+		return err
+	}
+
+	for _, ns := range nl.Items {
+
+		sl := types.NewServiceList()
+		if err := m.storage.List(m.context, m.storage.Collection().Service(),
+			m.storage.Filter().Service().ByNamespace(ns.Meta.Name), sl, nil); err != nil {
+			log.Errorf("%s:cpu:> list services err: %v", logMigrationPrefix, err)
+			return err
+		}
+
+		for _, s := range sl.Items {
+			if !migrateCPUMillicores(&s.Spec.Template) {
+				continue
+			}
+			if err := m.storage.Set(m.context, m.storage.Collection().Service(),
+				m.storage.Key().Service(s.Meta.Namespace, s.Meta.Name), s, nil); err != nil {
+				log.Errorf("%s:cpu:> set service err: %v", logMigrationPrefix, err)
+				return err
+			}
+		}
+
+		dl := types.NewDeploymentList()
+		if err := m.storage.List(m.context, m.storage.Collection().Deployment(),
+			m.storage.Filter().Deployment().ByNamespace(ns.Meta.Name), dl, nil); err != nil {
+			log.Errorf("%s:cpu:> list deployments err: %v", logMigrationPrefix, err)
+			return err
+		}
+
+		for _, d := range dl.Items {
+			if !migrateCPUMillicores(&d.Spec.Template) {
+				continue
+			}
+			if err := m.storage.Set(m.context, m.storage.Collection().Deployment(),
+				m.storage.Key().Deployment(d.Meta.Namespace, d.Meta.Service, d.Meta.Name), d, nil); err != nil {
+				log.Errorf("%s:cpu:> set deployment err: %v", logMigrationPrefix, err)
+				return err
+			}
+		}
+
+		pl := types.NewPodList()
+		if err := m.storage.List(m.context, m.storage.Collection().Pod(),
+			m.storage.Filter().Pod().ByNamespace(ns.Meta.Name), pl, nil); err != nil {
+			log.Errorf("%s:cpu:> list pods err: %v", logMigrationPrefix, err)
+			return err
+		}
+
+		for _, p := range pl.Items {
+			if !migrateCPUMillicores(&p.Spec.Template) {
+				continue
+			}
+			if err := m.storage.Set(m.context, m.storage.Collection().Pod(),
+				m.storage.Key().Pod(p.Meta.Namespace, p.Meta.Service, p.Meta.Deployment, p.Meta.Name), p, nil); err != nil {
+				log.Errorf("%s:cpu:> set pod err: %v", logMigrationPrefix, err)
+				return err
+			}
+		}
+	}
+
+	nodes, err := NewNodeModel(m.context, m.storage).List()
+	if err != nil {
+		return err
+	}
+
+	pm := NewPodModel(m.context, m.storage)
+	for _, n := range nodes.Items {
+
+		mf, err := pm.ManifestMap(n.Meta.Name)
+		if err != nil {
+			return err
+		}
+		if mf == nil {
+			continue
+		}
+
+		for name, p := range mf.Items {
+			if !migrateCPUMillicores(&p.Template) {
+				continue
+			}
+			if err := pm.ManifestSet(n.Meta.Name, name, p); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m *Migration) secretsEncrypt(kp crypto.KeyProvider) error {
+
+	sl := types.NewSecretList()
+	if err := m.storage.List(m.context, m.storage.Collection().Secret(), types.EmptyString, sl, nil); err != nil {
+		log.Errorf("%s:secrets:> list secrets err: %v", logMigrationPrefix, err)
+		return err
+	}
+
+	nl, err := NewNamespaceModel(m.context, m.storage).List()
+	if err != nil {
+		return err
+	}
+
+	for _, s := range sl.Items {
+
+		// cluster level secrets were stored without namespace under secret name key
+		if s.Meta.Namespace != types.EmptyString {
+			continue
+		}
+
+		// secret was available for services of all namespaces, keep it available for them
+		for _, ns := range nl.Items {
+
+			secret := *s
+			secret.Meta.Namespace = ns.Meta.Name
+			secret.Meta.SelfLink = types.EmptyString
+
+			data, err := crypto.EncryptMap(kp, secret.SelfLink(), s.Data)
+			if err != nil {
+				log.Errorf("%s:secrets:> encrypt secret %s data err: %v", logMigrationPrefix, secret.SelfLink(), err)
+				return err
+			}
+			secret.Data = data
+
+			if err := m.storage.Put(m.context, m.storage.Collection().Secret(),
+				m.storage.Key().Secret(secret.Meta.Namespace, secret.Meta.Name), &secret, nil); err != nil {
+				if errors.Storage().IsErrEntityExists(err) {
+					log.Warnf("%s:secrets:> secret %s already exists, skip it", logMigrationPrefix, secret.SelfLink())
+					continue
+				}
+				log.Errorf("%s:secrets:> put secret %s err: %v", logMigrationPrefix, secret.SelfLink(), err)
+				return err
+			}
+		}
+
+		if err := m.storage.Del(m.context, m.storage.Collection().Secret(), s.Meta.Name); err != nil {
+			log.Errorf("%s:secrets:> remove secret %s err: %v", logMigrationPrefix, s.Meta.Name, err)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// migrateCPUMillicores converts template containers cpu from shares into millicores
+// and returns true if template was changed
+func migrateCPUMillicores(t *types.SpecTemplate) bool {
+
+	var changed bool
+
+	convert := func(cpu int64) int64 {
+		if cpu <= 0 {
+			return cpu
+		}
+		changed = true
+		cpu = cpu * cpuMillicores / cpuSharesPerCore
+		if cpu < types.DEFAULT_CPU_MIN {
+			cpu = types.DEFAULT_CPU_MIN
+		}
+		return cpu
+	}
+
+	for _, c := range t.Containers {
+		c.Resources.Request.CPU = convert(c.Resources.Request.CPU)
+		c.Resources.Limits.CPU = convert(c.Resources.Limits.CPU)
+	}
+
+	return changed
+}
+
+func NewMigrationModel(ctx context.Context, stg storage.Storage) *Migration {
+	return &Migration{ctx, stg}
+}
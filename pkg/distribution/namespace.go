@@ -144,6 +144,10 @@ func (n *Namespace) Update(namespace *types.Namespace, opts *types.NamespaceUpda
 		namespace.Spec.Quotas.Disabled = opts.Quotas.Disabled
 	}
 
+	if opts.Revision != nil {
+		namespace.System.Revision = *opts.Revision
+	}
+
 	if opts.Domain != nil {
 		if len(*opts.Domain) == 0 {
 			namespace.Spec.Domain.External = viper.GetString("domain.external")
@@ -152,8 +156,11 @@ func (n *Namespace) Update(namespace *types.Namespace, opts *types.NamespaceUpda
 		}
 	}
 
+	so := storage.GetOpts()
+	so.Rev = revision(namespace.Runtime)
+
 	if err := n.storage.Set(n.context, n.storage.Collection().Namespace(),
-		n.storage.Key().Namespace(namespace.Meta.Name), namespace, nil); err != nil {
+		n.storage.Key().Namespace(namespace.Meta.Name), namespace, so); err != nil {
 		log.V(logLevel).Errorf("%s:update:> namespace update err: %v", logNamespacePrefix, err)
 		return err
 	}
@@ -161,6 +168,20 @@ func (n *Namespace) Update(namespace *types.Namespace, opts *types.NamespaceUpda
 	return nil
 }
 
+// Set namespace without revision check
+func (n *Namespace) Set(namespace *types.Namespace) error {
+
+	log.V(logLevel).Debugf("%s:set:> set namespace %s", logNamespacePrefix, namespace.Meta.Name)
+
+	if err := n.storage.Set(n.context, n.storage.Collection().Namespace(),
+		n.storage.Key().Namespace(namespace.Meta.Name), namespace, nil); err != nil {
+		log.V(logLevel).Errorf("%s:set:> namespace set err: %v", logNamespacePrefix, err)
+		return err
+	}
+
+	return nil
+}
+
 func (n *Namespace) Remove(namespace *types.Namespace) error {
 
 	log.V(logLevel).Debugf("%s:remove:> remove namespace %s", logNamespacePrefix, namespace.Meta.Name)
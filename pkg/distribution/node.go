@@ -122,6 +122,22 @@ func (n *Node) Set(node *types.Node) error {
 	return nil
 }
 
+// Update node with revision check: node should be read from storage before update
+func (n *Node) Update(node *types.Node) error {
+
+	log.V(logLevel).Debugf("%s:update:> update node %s", logNodePrefix, node.Meta.Name)
+
+	opts := storage.GetOpts()
+	opts.Rev = revision(node.Runtime)
+
+	if err := n.storage.Set(n.context, n.storage.Collection().Node().Info(), n.storage.Key().Node(node.Meta.Name), node, opts); err != nil {
+		log.V(logLevel).Errorf("%s:update:> update node err: %v", logNodePrefix, err)
+		return err
+	}
+
+	return nil
+}
+
 func (n *Node) Remove(node *types.Node) error {
 
 	log.V(logLevel).Debugf("%s:remove:> remove node %s", logNodePrefix, node.Meta.Name)
@@ -37,6 +37,9 @@ import (
 
 const (
 	logPodPrefix = "distribution:pod"
+
+	// podProvisionRetries - attempts to provision pod if pod node was changed concurrently
+	podProvisionRetries = 3
 )
 
 type Pod struct {
@@ -87,6 +90,10 @@ func (p *Pod) Create(deployment *types.Deployment) (*types.Pod, error) {
 	pod.Meta.Service = deployment.Meta.Service
 	pod.Meta.Namespace = deployment.Meta.Namespace
 
+	for k, v := range deployment.Meta.Labels {
+		pod.Meta.Labels[k] = v
+	}
+
 	pod.Status.SetCreated()
 	pod.Status.Steps = make(map[string]types.PodStep)
 	pod.Status.Steps[types.StepInitialized] = types.PodStep{
@@ -201,6 +208,75 @@ func (p *Pod) Update(pod *types.Pod) error {
 	return nil
 }
 
+// Provision updates pod and puts pod manifest to pod node in one transaction,
+// so node never receives manifest of pod which is not scheduled in storage.
+// If allocate is set, pod resources are added to node allocated resources in the same transaction,
+// transaction is repeated if node was changed concurrently
+func (p *Pod) Provision(pod *types.Pod, manifest *types.PodManifest, allocate bool) error {
+
+	log.V(logLevel).Debugf("%s:provision:> provision pod: %s on node %s", logPodPrefix, pod.Meta.Name, pod.Meta.Node)
+
+	for i := 0; ; i++ {
+
+		err := p.provision(pod, manifest, allocate)
+		if err == nil {
+			return nil
+		}
+
+		if !allocate || !errors.Storage().IsErrEntityRevision(err) || i >= podProvisionRetries {
+			log.Errorf("%s:provision:> commit pod provision err: %v", logPodPrefix, err)
+			return err
+		}
+
+		log.V(logLevel).Debugf("%s:provision:> node %s was changed: retry provision", logPodPrefix, pod.Meta.Node)
+	}
+}
+
+func (p *Pod) provision(pod *types.Pod, manifest *types.PodManifest, allocate bool) error {
+
+	tx := p.storage.Begin(p.context)
+
+	if err := tx.Put(p.storage.Collection().Manifest().Pod(pod.Meta.Node), pod.SelfLink(), manifest, nil); err != nil {
+		log.Errorf("%s:provision:> pod manifest put err: %v", logPodPrefix, err)
+		return err
+	}
+
+	if err := tx.Set(p.storage.Collection().Pod(),
+		p.storage.Key().Pod(pod.Meta.Namespace, pod.Meta.Service, pod.Meta.Deployment, pod.Meta.Name),
+		pod, nil); err != nil {
+		log.Errorf("%s:provision:> pod update err: %v", logPodPrefix, err)
+		return err
+	}
+
+	if allocate {
+
+		node, err := NewNodeModel(p.context, p.storage).Get(pod.Meta.Node)
+		if err != nil {
+			return err
+		}
+
+		if node == nil {
+			return errors.New(errors.ErrEntityNotFound)
+		}
+
+		r := pod.Spec.Template.GetResourceRequest()
+		node.Status.Allocated.Pods++
+		node.Status.Allocated.Memory += r.RAM
+		node.Status.Allocated.Cpu += int(r.CPU)
+
+		// node is updated only if it was not changed since read
+		opts := storage.GetOpts()
+		opts.Rev = revision(node.Runtime)
+
+		if err := tx.Set(p.storage.Collection().Node().Info(), p.storage.Key().Node(node.Meta.Name), node, opts); err != nil {
+			log.Errorf("%s:provision:> node update err: %v", logPodPrefix, err)
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
 // Destroy pod
 func (p *Pod) Destroy(pod *types.Pod) error {
 
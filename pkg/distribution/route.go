@@ -115,8 +115,11 @@ func (n *Route) Update(route *types.Route) (*types.Route, error) {
 	log.V(logLevel).Debugf("%s:update:> update route %s", logRoutePrefix, route.Meta.Name)
 	route.Status.State = types.StateProvision
 
+	opts := storage.GetOpts()
+	opts.Rev = revision(route.Runtime)
+
 	if err := n.storage.Set(n.context, n.storage.Collection().Route(),
-		n.storage.Key().Route(route.Meta.Namespace, route.Meta.Name), route, nil); err != nil {
+		n.storage.Key().Route(route.Meta.Namespace, route.Meta.Name), route, opts); err != nil {
 		log.V(logLevel).Errorf("%s:update:> update route err: %v", logRoutePrefix, err)
 		return nil, err
 	}
@@ -20,8 +20,9 @@ package distribution
 
 import (
 	"context"
-
 	"encoding/json"
+	"time"
+
 	"github.com/lastbackend/lastbackend/pkg/distribution/errors"
 	"github.com/lastbackend/lastbackend/pkg/distribution/types"
 	"github.com/lastbackend/lastbackend/pkg/log"
@@ -37,21 +38,21 @@ type Secret struct {
 	storage storage.Storage
 }
 
-func (n *Secret) Get(name string) (*types.Secret, error) {
+func (n *Secret) Get(namespace, name string) (*types.Secret, error) {
 
-	log.V(logLevel).Debugf("%s:get:> get secret by id %s/%s", logSecretPrefix, name)
+	log.V(logLevel).Debugf("%s:get:> get secret by id %s/%s", logSecretPrefix, namespace, name)
 
 	item := new(types.Secret)
 
-	err := n.storage.Get(n.context, n.storage.Collection().Secret(), n.storage.Key().Secret(name), &item, nil)
+	err := n.storage.Get(n.context, n.storage.Collection().Secret(), n.storage.Key().Secret(namespace, name), &item, nil)
 	if err != nil {
 
 		if errors.Storage().IsErrEntityNotFound(err) {
-			log.V(logLevel).Warnf("%s:get:> in namespace %s by name %s not found", logSecretPrefix, name)
+			log.V(logLevel).Warnf("%s:get:> in namespace %s by name %s not found", logSecretPrefix, namespace, name)
 			return nil, nil
 		}
 
-		log.V(logLevel).Errorf("%s:get:> in namespace %s by name %s error: %s", logSecretPrefix, name, err)
+		log.V(logLevel).Errorf("%s:get:> in namespace %s by name %s error: %s", logSecretPrefix, namespace, name, err)
 		return nil, err
 	}
 
@@ -80,19 +81,20 @@ func (n *Secret) List(filter string) (*types.SecretList, error) {
 	return list, nil
 }
 
-func (n *Secret) Create(opts *types.SecretCreateOptions) (*types.Secret, error) {
+func (n *Secret) Create(namespace string, opts *types.SecretCreateOptions) (*types.Secret, error) {
 
-	log.V(logLevel).Debugf("%s:crete:> create secret %#v", logSecretPrefix, opts)
+	log.V(logLevel).Debugf("%s:crete:> create secret %s in namespace %s", logSecretPrefix, opts.Name, namespace)
 
 	secret := new(types.Secret)
 	secret.Meta.SetDefault()
+	secret.Meta.Namespace = namespace
 	secret.Meta.Name = opts.Name
 	secret.Meta.Kind = opts.Kind
 	secret.Data = opts.Data
 	secret.SelfLink()
 
 	if err := n.storage.Put(n.context, n.storage.Collection().Secret(),
-		n.storage.Key().Secret(secret.Meta.Name), secret, nil); err != nil {
+		n.storage.Key().Secret(secret.Meta.Namespace, secret.Meta.Name), secret, nil); err != nil {
 		log.V(logLevel).Errorf("%s:crete:> insert secret err: %s", logSecretPrefix, err)
 		return nil, err
 	}
@@ -106,9 +108,17 @@ func (n *Secret) Update(secret *types.Secret, opts *types.SecretUpdateOptions) (
 
 	secret.Meta.Kind = opts.Kind
 	secret.Data = opts.Data
+	secret.Meta.Updated = time.Now().UTC()
+
+	if opts.Revision != nil {
+		secret.System.Revision = *opts.Revision
+	}
+
+	so := storage.GetOpts()
+	so.Rev = revision(secret.Runtime)
 
 	if err := n.storage.Set(n.context, n.storage.Collection().Secret(),
-		n.storage.Key().Secret(secret.Meta.Name), secret, nil); err != nil {
+		n.storage.Key().Secret(secret.Meta.Namespace, secret.Meta.Name), secret, so); err != nil {
 		log.V(logLevel).Errorf("%s:update:> update secret err: %s", logSecretPrefix, err)
 		return nil, err
 	}
@@ -118,10 +128,10 @@ func (n *Secret) Update(secret *types.Secret, opts *types.SecretUpdateOptions) (
 
 func (n *Secret) Remove(secret *types.Secret) error {
 
-	log.V(logLevel).Debugf("%s:remove:> remove secret %#v", logSecretPrefix, secret)
+	log.V(logLevel).Debugf("%s:remove:> remove secret %s", logSecretPrefix, secret.SelfLink())
 
 	if err := n.storage.Del(n.context, n.storage.Collection().Secret(),
-		n.storage.Key().Secret(secret.Meta.Name)); err != nil {
+		n.storage.Key().Secret(secret.Meta.Namespace, secret.Meta.Name)); err != nil {
 		log.V(logLevel).Errorf("%s:remove:> remove secret  err: %s", logSecretPrefix, err)
 		return err
 	}
@@ -121,8 +121,11 @@ func (s *Service) Update(service *types.Service) (*types.Service, error) {
 
 	log.V(logLevel).Debugf("%s:update:> %#v -> %#v", logServicePrefix, service)
 
+	opts := storage.GetOpts()
+	opts.Rev = revision(service.Runtime)
+
 	if err := s.storage.Set(s.context, s.storage.Collection().Service(),
-		s.storage.Key().Service(service.Meta.Namespace, service.Meta.Name), service, nil); err != nil {
+		s.storage.Key().Service(service.Meta.Namespace, service.Meta.Name), service, opts); err != nil {
 		log.V(logLevel).Errorf("%s:update:> update service spec err: %v", logServicePrefix, err)
 		return nil, err
 	}
@@ -20,7 +20,9 @@ package distribution
 
 import (
 	"context"
+	"time"
 
+	"github.com/lastbackend/lastbackend/pkg/distribution/errors"
 	"github.com/lastbackend/lastbackend/pkg/distribution/types"
 	"github.com/lastbackend/lastbackend/pkg/log"
 	"github.com/lastbackend/lastbackend/pkg/storage"
@@ -37,19 +39,105 @@ type Trigger struct {
 
 func (t *Trigger) Get(namespace, service, name string) (*types.Trigger, error) {
 
-	log.V(logLevel).Debugf("%s:get:> get trigger by name %s: %s", logTriggerPrefix, namespace, name)
+	log.V(logLevel).Debugf("%s:get:> get trigger by name %s:%s:%s", logTriggerPrefix, namespace, service, name)
 
 	trigger := new(types.Trigger)
 
 	err := t.storage.Get(t.context, t.storage.Collection().Trigger(), t.storage.Key().Trigger(namespace, service, name), &trigger, nil)
 	if err != nil {
-		log.V(logLevel).Errorf("%s:get:> create trigger err: %v", logTriggerPrefix, err)
+		if errors.Storage().IsErrEntityNotFound(err) {
+			log.V(logLevel).Warnf("%s:get:> trigger %s:%s:%s not found", logTriggerPrefix, namespace, service, name)
+			return nil, nil
+		}
+
+		log.V(logLevel).Errorf("%s:get:> get trigger err: %v", logTriggerPrefix, err)
+		return nil, err
+	}
+
+	return trigger, nil
+}
+
+func (t *Trigger) ListByService(namespace, service string) (*types.TriggerList, error) {
+
+	log.V(logLevel).Debugf("%s:listbyservice:> list triggers for service %s:%s", logTriggerPrefix, namespace, service)
+
+	list := types.NewTriggerList()
+
+	err := t.storage.List(t.context, t.storage.Collection().Trigger(), t.storage.Filter().Trigger().ByService(namespace, service), list, nil)
+	if err != nil {
+		log.V(logLevel).Errorf("%s:listbyservice:> list triggers err: %v", logTriggerPrefix, err)
+		return list, err
+	}
+
+	return list, nil
+}
+
+func (t *Trigger) Create(trigger *types.Trigger) (*types.Trigger, error) {
+
+	log.V(logLevel).Debugf("%s:create:> create trigger %s", logTriggerPrefix, trigger.Meta.Name)
+
+	trigger.Meta.SelfLink = trigger.CreateSelfLink(trigger.Meta.Namespace, trigger.Meta.Service, trigger.Meta.Name)
+	trigger.Status.State = types.StateReady
+
+	if err := t.storage.Put(t.context, t.storage.Collection().Trigger(),
+		t.storage.Key().Trigger(trigger.Meta.Namespace, trigger.Meta.Service, trigger.Meta.Name), trigger, nil); err != nil {
+		log.V(logLevel).Errorf("%s:create:> insert trigger err: %v", logTriggerPrefix, err)
+		return nil, err
+	}
+
+	return trigger, nil
+}
+
+func (t *Trigger) Update(trigger *types.Trigger) (*types.Trigger, error) {
+
+	log.V(logLevel).Debugf("%s:update:> update trigger %s", logTriggerPrefix, trigger.Meta.Name)
+
+	trigger.Meta.Updated = time.Now().UTC()
+
+	opts := storage.GetOpts()
+	opts.Rev = revision(trigger.Runtime)
+
+	if err := t.storage.Set(t.context, t.storage.Collection().Trigger(),
+		t.storage.Key().Trigger(trigger.Meta.Namespace, trigger.Meta.Service, trigger.Meta.Name), trigger, opts); err != nil {
+		log.V(logLevel).Errorf("%s:update:> update trigger err: %v", logTriggerPrefix, err)
 		return nil, err
 	}
 
 	return trigger, nil
 }
 
+func (t *Trigger) SetStatus(trigger *types.Trigger, status *types.TriggerStatus) error {
+
+	if trigger == nil {
+		log.V(logLevel).Warnf("%s:setstatus:> invalid argument %v", logTriggerPrefix, trigger)
+		return nil
+	}
+
+	log.V(logLevel).Debugf("%s:setstatus:> set status trigger %s -> %#v", logTriggerPrefix, trigger.Meta.Name, status)
+
+	trigger.Status = *status
+	if err := t.storage.Set(t.context, t.storage.Collection().Trigger(),
+		t.storage.Key().Trigger(trigger.Meta.Namespace, trigger.Meta.Service, trigger.Meta.Name), trigger, nil); err != nil {
+		log.Errorf("%s:setstatus:> trigger set status err: %v", logTriggerPrefix, err)
+		return err
+	}
+
+	return nil
+}
+
+func (t *Trigger) Remove(trigger *types.Trigger) error {
+
+	log.V(logLevel).Debugf("%s:remove:> remove trigger %s", logTriggerPrefix, trigger.Meta.Name)
+
+	if err := t.storage.Del(t.context, t.storage.Collection().Trigger(),
+		t.storage.Key().Trigger(trigger.Meta.Namespace, trigger.Meta.Service, trigger.Meta.Name)); err != nil {
+		log.V(logLevel).Errorf("%s:remove:> remove trigger err: %v", logTriggerPrefix, err)
+		return err
+	}
+
+	return nil
+}
+
 func NewTriggerModel(ctx context.Context, stg storage.Storage) *Trigger {
 	return &Trigger{ctx, stg}
 }
@@ -0,0 +1,134 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+
+package types
+
+import (
+	"fmt"
+	"time"
+)
+
+type BuildMap struct {
+	Runtime
+	Items map[string]*Build
+}
+
+type BuildList struct {
+	Runtime
+	Items []*Build
+}
+
+type Build struct {
+	Runtime
+	Meta   BuildMeta   `json:"meta"`
+	Spec   BuildSpec   `json:"spec"`
+	Status BuildStatus `json:"status"`
+}
+
+type BuildMeta struct {
+	Meta
+	Namespace string `json:"namespace"`
+}
+
+type BuildSpec struct {
+	// Sources to build image from
+	Source BuildSource `json:"source"`
+	// Image to build and push
+	Image BuildImage `json:"image"`
+	// Service to update with built image, no service is updated if empty
+	Service string `json:"service"`
+	// Service container to update, all service containers are updated if empty
+	Container string `json:"container"`
+}
+
+type BuildSource struct {
+	// Git repository url
+	Url string `json:"url"`
+	// Branch, tag or commit to build
+	Ref string `json:"ref"`
+	// Dockerfile path in repository
+	Dockerfile string `json:"dockerfile"`
+}
+
+type BuildImage struct {
+	// Image name with tag
+	Name string `json:"name"`
+	// Registry auth secret name
+	Secret string `json:"secret"`
+}
+
+type BuildStatus struct {
+	State   string `json:"state"`
+	Message string `json:"message"`
+	// Node where build is executed
+	Node     string    `json:"node"`
+	Started  time.Time `json:"started"`
+	Finished time.Time `json:"finished"`
+}
+
+type BuildManifest struct {
+	Runtime
+	State  string      `json:"state"`
+	Source BuildSource `json:"source"`
+	Image  BuildImage  `json:"image"`
+}
+
+type BuildManifestMap struct {
+	Runtime
+	Items map[string]*BuildManifest
+}
+
+func (b *Build) SelfLink() string {
+	if b.Meta.SelfLink == "" {
+		b.Meta.SelfLink = b.CreateSelfLink(b.Meta.Namespace, b.Meta.Name)
+	}
+	return b.Meta.SelfLink
+}
+
+func (b *Build) CreateSelfLink(namespace, name string) string {
+	return fmt.Sprintf("%s:%s", namespace, name)
+}
+
+// Done returns true if build is finished with success or error
+func (b *Build) Done() bool {
+	return b.Status.State == StateReady || b.Status.State == StateError
+}
+
+func (m *BuildManifest) Set(build *Build) {
+	m.Source = build.Spec.Source
+	m.Image = build.Spec.Image
+	m.State = build.Status.State
+}
+
+func NewBuildList() *BuildList {
+	dm := new(BuildList)
+	dm.Items = make([]*Build, 0)
+	return dm
+}
+
+func NewBuildMap() *BuildMap {
+	dm := new(BuildMap)
+	dm.Items = make(map[string]*Build)
+	return dm
+}
+
+func NewBuildManifestMap() *BuildManifestMap {
+	dm := new(BuildManifestMap)
+	dm.Items = make(map[string]*BuildManifest)
+	return dm
+}
@@ -54,8 +54,9 @@ type ConfigMap struct {
 // swagger:ignore
 // swagger:model types_config_meta
 type ConfigMeta struct {
-	Kind string `json:"kind"`
-	Meta `yaml:",inline"`
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace"`
+	Meta      `yaml:",inline"`
 }
 
 type ConfigManifest struct {
@@ -77,6 +78,34 @@ type ConfigManifestMap struct {
 	Items map[string]*ConfigManifest
 }
 
+func (c *ConfigManifest) Set(cfg *Config) {
+	c.Kind = cfg.Meta.Kind
+	c.Data = cfg.Data
+	c.Created = cfg.Meta.Created
+	c.Updated = cfg.Meta.Updated
+	c.State = StateUpdated
+}
+
+// GetValue returns decoded config value by key
+func (c *ConfigManifest) GetValue(key string) (string, error) {
+
+	d, ok := c.Data[key]
+	if !ok {
+		return EmptyString, errors.New("config key not found")
+	}
+
+	if c.Kind != KindConfigText {
+		return string(d), nil
+	}
+
+	v, err := base64.StdEncoding.DecodeString(string(d))
+	if err != nil {
+		return EmptyString, err
+	}
+
+	return string(v), nil
+}
+
 func NewConfigManifestList() *ConfigManifestList {
 	dm := new(ConfigManifestList)
 	dm.Items = make([]*ConfigManifest, 0)
@@ -124,13 +153,13 @@ func (s *Config) GetHash() string {
 
 func (s *Config) SelfLink() string {
 	if s.Meta.SelfLink == "" {
-		s.Meta.SelfLink = s.CreateSelfLink(s.Meta.Name)
+		s.Meta.SelfLink = s.CreateSelfLink(s.Meta.Namespace, s.Meta.Name)
 	}
 	return s.Meta.SelfLink
 }
 
-func (s *Config) CreateSelfLink(name string) string {
-	return fmt.Sprintf("%s", name)
+func (s *Config) CreateSelfLink(namespace, name string) string {
+	return fmt.Sprintf("%s:%s", namespace, name)
 }
 
 func (s *Config) DecodeRegistry() {
@@ -53,6 +53,8 @@ type Container struct {
 	State string `json:"state"`
 	// ExitCode of the container
 	ExitCode int `json:"exit_code"`
+	// Container was killed by out of memory killer
+	OOMKilled bool `json:"oom_killed"`
 	// Container current state
 	Status string `json:"status,omitempty"`
 	// Container network settings
@@ -250,6 +252,24 @@ type ContainerStatusInfoPort struct {
 	HostPort string `json:"host_port"`
 }
 
+// ContainerStats is a container resources usage sample
+type ContainerStats struct {
+	// Total CPU time consumed by container in nanoseconds
+	CPUTotal uint64 `json:"cpu_total"`
+	// Memory used by container in bytes
+	Memory uint64 `json:"memory"`
+	// Total bytes received by container network interfaces
+	NetworkRx uint64 `json:"network_rx"`
+	// Total bytes transmitted by container network interfaces
+	NetworkTx uint64 `json:"network_tx"`
+	// Total bytes read from block devices
+	BlockRead uint64 `json:"block_read"`
+	// Total bytes written to block devices
+	BlockWrite uint64 `json:"block_write"`
+	// Sample read time
+	Timestamp time.Time `json:"timestamp"`
+}
+
 func (cs *ContainerSpec) CommandToString() string {
 	res, err := convertSliceToString(cs.Command)
 	if err != nil {
@@ -424,3 +444,34 @@ func NewContainerManifest(spec *SpecTemplateContainer) *ContainerManifest {
 	mf.Envs = envs
 	return mf
 }
+
+const (
+	// Exec stream channels, first byte of every exec websocket message
+	ContainerExecStreamStdin byte = iota
+	ContainerExecStreamStdout
+	ContainerExecStreamStderr
+	ContainerExecStreamStatus
+	ContainerExecStreamResize
+)
+
+// ContainerExec is an interactive command executed in running container
+type ContainerExec struct {
+	// Command with arguments
+	Command []string `json:"command"`
+	// Allocate pseudo-TTY
+	Tty bool `json:"tty"`
+	// Attach command stdin
+	Stdin bool `json:"stdin"`
+}
+
+// ContainerExecResize is a terminal size change of exec with pseudo-TTY
+type ContainerExecResize struct {
+	Width  uint `json:"width"`
+	Height uint `json:"height"`
+}
+
+// ContainerExecStatus is sent to client when exec command is finished
+type ContainerExecStatus struct {
+	ExitCode int    `json:"exit_code"`
+	Error    string `json:"error,omitempty"`
+}
@@ -71,6 +71,11 @@ type SecretEvent struct {
 	Data *Secret
 }
 
+type ConfigEvent struct {
+	event
+	Data *Config
+}
+
 type RouteEvent struct {
 	event
 	Data *Route
@@ -111,6 +116,17 @@ type PodEvent struct {
 	Data *Pod
 }
 
+type BuildEvent struct {
+	event
+	Data *Build
+}
+
+type BuildManifestEvent struct {
+	event
+	Node string
+	Data *BuildManifest
+}
+
 type PodManifestEvent struct {
 	event
 	Node string
@@ -18,6 +18,8 @@
 
 package types
 
+import "strings"
+
 type NodeManifest struct {
 	Meta      NodeManifestMeta             `json:"meta"`
 	Secrets   map[string]*SecretManifest   `json:"secrets"`
@@ -26,6 +28,7 @@ type NodeManifest struct {
 	Network   map[string]*SubnetManifest   `json:"network"`
 	Pods      map[string]*PodManifest      `json:"pods"`
 	Volumes   map[string]*VolumeManifest   `json:"volumes"`
+	Builds    map[string]*BuildManifest    `json:"builds"`
 }
 
 type NodeManifestMeta struct {
@@ -33,6 +36,26 @@ type NodeManifestMeta struct {
 	Discovery []string `json:"discovery"`
 }
 
+// GetSecrets returns self links of secrets used by node pods and builds
+func (m *NodeManifest) GetSecrets() map[string]bool {
+
+	secrets := make(map[string]bool, 0)
+
+	for pod, p := range m.Pods {
+		for _, s := range p.GetSecrets(pod) {
+			secrets[s] = true
+		}
+	}
+
+	for build, b := range m.Builds {
+		if b.Image.Secret != EmptyString {
+			secrets[new(Secret).CreateSelfLink(strings.Split(build, ":")[0], b.Image.Secret)] = true
+		}
+	}
+
+	return secrets
+}
+
 type IngressManifest struct {
 	Meta      NodeManifestMeta             `json:"meta"`
 	Routes    map[string]*RouteManifest    `json:"routes"`
@@ -47,6 +70,42 @@ type IngressManifestMeta struct {
 
 type PodManifest PodSpec
 
+// GetSecrets returns self links of secrets used by pod volumes, envs and images.
+// Secrets are taken from the pod namespace
+func (m *PodManifest) GetSecrets(pod string) []string {
+
+	var (
+		namespace = strings.Split(pod, ":")[0]
+		names     = make(map[string]bool, 0)
+		secrets   = make([]string, 0)
+	)
+
+	for _, v := range m.Template.Volumes {
+		if v.Secret.Name != EmptyString {
+			names[v.Secret.Name] = true
+		}
+	}
+
+	for _, c := range m.Template.Containers {
+
+		if c.Image.Secret != EmptyString {
+			names[c.Image.Secret] = true
+		}
+
+		for _, e := range c.EnvVars {
+			if e.Secret.Name != EmptyString {
+				names[e.Secret.Name] = true
+			}
+		}
+	}
+
+	for name := range names {
+		secrets = append(secrets, new(Secret).CreateSelfLink(namespace, name))
+	}
+
+	return secrets
+}
+
 type PodManifestList struct {
 	Runtime
 	Items []*PodManifest
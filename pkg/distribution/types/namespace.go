@@ -20,6 +20,7 @@ package types
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 )
 
@@ -37,6 +38,7 @@ type NamespaceList struct {
 
 // swagger:ignore
 type Namespace struct {
+	Runtime
 	Meta NamespaceMeta `json:"meta"`
 	Spec NamespaceSpec `json:"spec"`
 }
@@ -91,6 +93,25 @@ func (n *Namespace) SelfLink() string {
 	return n.Meta.SelfLink
 }
 
+// CheckQuotas returns an error if namespace quotas are enabled
+// and provided resources usage exceeds them, zero quota is not limited
+func (n *Namespace) CheckQuotas(usage NamespaceResources) error {
+
+	if n.Spec.Quotas.Disabled {
+		return nil
+	}
+
+	if n.Spec.Quotas.RAM > 0 && usage.RAM > n.Spec.Quotas.RAM {
+		return errors.New(fmt.Sprintf("namespace ram quota exceeded: requested %d MB of %d MB", usage.RAM, n.Spec.Quotas.RAM))
+	}
+
+	if n.Spec.Quotas.Routes > 0 && usage.Routes > n.Spec.Quotas.Routes {
+		return errors.New(fmt.Sprintf("namespace routes quota exceeded: requested %d of %d routes", usage.Routes, n.Spec.Quotas.Routes))
+	}
+
+	return nil
+}
+
 func (n *Namespace) ToJson() ([]byte, error) {
 	buf, err := json.Marshal(n)
 	if err != nil {
@@ -123,6 +144,7 @@ type NamespaceUpdateOptions struct {
 	Description *string                 `json:"description"`
 	Domain      *string                 `json:"domain"`
 	Quotas      *NamespaceQuotasOptions `json:"quotas"`
+	Revision    *int64                  `json:"revision"`
 }
 
 // swagger:ignore
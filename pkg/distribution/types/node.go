@@ -21,6 +21,7 @@ package types
 import (
 	"context"
 	"fmt"
+	"time"
 )
 
 // swagger:ignore
@@ -110,10 +111,16 @@ type NodeStatus struct {
 	State NodeStatusState `json:"state"`
 	// node status online
 	Online bool `json:"online"`
+	// Node last heartbeat time
+	Heartbeat time.Time `json:"heartbeat"`
+	// Node pods are moved to other nodes
+	Drained bool `json:"drained"`
 	// Node Capacity
 	Capacity NodeResources `json:"capacity"`
 	// Node Allocated
 	Allocated NodeResources `json:"allocated"`
+	// Node roles
+	Role NodeRole `json:"role"`
 }
 
 type NodeStatusState struct {
@@ -135,6 +142,10 @@ type NodeStatusInterfaceState struct {
 // swagger:model types_node_spec
 type NodeSpec struct {
 	Security  NodeSecurity            `json:"security"`
+	// Node is excluded from pods scheduling
+	Unschedulable bool `json:"unschedulable"`
+	// Node pods should be moved to other nodes
+	Drain bool `json:"drain"`
 }
 
 type NodeSecurity struct {
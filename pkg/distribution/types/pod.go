@@ -97,6 +97,33 @@ type PodStatus struct {
 	Containers map[string]*PodContainer `json:"containers" yaml:"containers"`
 	// Pod volumes
 	Volumes map[string]*PodVolume `json:"volumes" yaml:"volumes"`
+	// Pod resources usage
+	Usage PodUsage `json:"usage" yaml:"usage"`
+}
+
+// PodUsage is a resources usage of pod containers reported by node
+// swagger:model types_pod_usage
+type PodUsage struct {
+	// Pod containers usage
+	Containers map[string]PodContainerUsage `json:"containers" yaml:"containers"`
+	// Usage update time
+	Updated time.Time `json:"updated" yaml:"updated"`
+}
+
+// swagger:model types_pod_container_usage
+type PodContainerUsage struct {
+	// CPU usage in millicores
+	CPU int64 `json:"cpu" yaml:"cpu"`
+	// RAM usage in MB
+	RAM int64 `json:"ram" yaml:"ram"`
+	// Network receive rate in bytes per second
+	NetworkRx int64 `json:"network_rx" yaml:"network_rx"`
+	// Network transmit rate in bytes per second
+	NetworkTx int64 `json:"network_tx" yaml:"network_tx"`
+	// Block devices read rate in bytes per second
+	BlockRead int64 `json:"block_read" yaml:"block_read"`
+	// Block devices write rate in bytes per second
+	BlockWrite int64 `json:"block_write" yaml:"block_write"`
 }
 
 // PodSteps is a map of pod steps
@@ -162,6 +189,10 @@ type PodVolume struct {
 	Type string `json:"type" yaml:"type"`
 	// Pod volume Path
 	Path string `json:"path" yaml:"path"`
+	// Pod volume data source secret
+	Secret string `json:"secret,omitempty" yaml:"secret,omitempty"`
+	// Pod volume data source config
+	Config string `json:"config,omitempty" yaml:"config,omitempty"`
 }
 
 // swagger:model types_pod_container_image
@@ -223,6 +254,8 @@ type PodContainerStateError struct {
 type PodContainerStateExit struct {
 	Code      int       `json:"code" yaml:"code"`
 	Timestamp time.Time `json:"timestamp" yaml:"timestamp"`
+	// Container was killed by out of memory killer
+	OOMKilled bool `json:"oom_killed" yaml:"oom_killed"`
 }
 
 func (s *PodStatus) SetInitialized() {
@@ -312,6 +345,23 @@ func NewPodStatus() *PodStatus {
 	return &status
 }
 
+// Total returns resources usage of all pod containers
+func (u *PodUsage) Total() PodContainerUsage {
+
+	var t PodContainerUsage
+
+	for _, c := range u.Containers {
+		t.CPU += c.CPU
+		t.RAM += c.RAM
+		t.NetworkRx += c.NetworkRx
+		t.NetworkTx += c.NetworkTx
+		t.BlockRead += c.BlockRead
+		t.BlockWrite += c.BlockWrite
+	}
+
+	return t
+}
+
 func (p *Pod) SelfLink() string {
 	if p.Meta.SelfLink == "" {
 		p.Meta.SelfLink = p.CreateSelfLink(p.Meta.Namespace, p.Meta.Service, p.Meta.Deployment, p.Meta.Name)
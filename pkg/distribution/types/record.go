@@ -0,0 +1,89 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+
+package types
+
+import (
+	"crypto/sha1"
+	"fmt"
+	"time"
+)
+
+const (
+	EventSeverityNormal  = "normal"
+	EventSeverityWarning = "warning"
+	EventSeverityError   = "error"
+
+	EventReasonScheduled        = "Scheduled"
+	EventReasonFailedScheduling = "FailedScheduling"
+	EventReasonPullFailed       = "PullFailed"
+	EventReasonOOMKilled        = "OOMKilled"
+	EventReasonRolledOut        = "RolledOut"
+)
+
+type EventRecordList struct {
+	Runtime
+	Items []*EventRecord
+}
+
+// EventRecord describes what happened with cluster object
+// same reason events of an object are aggregated into single record
+type EventRecord struct {
+	Runtime
+	Meta      EventRecordMeta `json:"meta"`
+	Object    EventObject     `json:"object"`
+	Reason    string          `json:"reason"`
+	Message   string          `json:"message"`
+	Severity  string          `json:"severity"`
+	Count     int             `json:"count"`
+	FirstSeen time.Time       `json:"first_seen"`
+	LastSeen  time.Time       `json:"last_seen"`
+}
+
+type EventRecordMeta struct {
+	Meta
+	Namespace string `json:"namespace"`
+}
+
+// EventObject describes object involved into event
+type EventObject struct {
+	Kind     string `json:"kind"`
+	SelfLink string `json:"self_link"`
+}
+
+func (e *EventRecord) SelfLink() string {
+	if e.Meta.SelfLink == "" {
+		e.Meta.SelfLink = e.CreateSelfLink(e.Meta.Namespace, e.Meta.Name)
+	}
+	return e.Meta.SelfLink
+}
+
+func (e *EventRecord) CreateSelfLink(namespace, name string) string {
+	return fmt.Sprintf("%s:%s", namespace, name)
+}
+
+// CreateName returns record name based on involved object and reason
+func (e *EventRecord) CreateName() string {
+	return fmt.Sprintf("%x", sha1.Sum([]byte(fmt.Sprintf("%s:%s:%s", e.Object.Kind, e.Object.SelfLink, e.Reason))))[:16]
+}
+
+func NewEventRecordList() *EventRecordList {
+	dm := new(EventRecordList)
+	dm.Items = make([]*EventRecord, 0)
+	return dm
+}
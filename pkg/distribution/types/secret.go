@@ -55,16 +55,18 @@ type SecretMap struct {
 // swagger:ignore
 // swagger:model types_secret_meta
 type SecretMeta struct {
-	Kind string `json:"kind"`
-	Meta `yaml:",inline"`
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace"`
+	Meta      `yaml:",inline"`
 }
 
 type SecretManifest struct {
 	Runtime
-	State   string    `json:"state"`
-	Kind    string    `json:"kind"`
-	Created time.Time `json:"created"`
-	Updated time.Time `json:"updated"`
+	State   string            `json:"state"`
+	Kind    string            `json:"kind"`
+	Data    map[string][]byte `json:"data"`
+	Created time.Time         `json:"created"`
+	Updated time.Time         `json:"updated"`
 }
 
 type SecretManifestList struct {
@@ -77,6 +79,15 @@ type SecretManifestMap struct {
 	Items map[string]*SecretManifest
 }
 
+// Set fills manifest from secret, data should be passed already decrypted
+func (s *SecretManifest) Set(secret *Secret, data map[string][]byte) {
+	s.Kind = secret.Meta.Kind
+	s.Data = data
+	s.Created = secret.Meta.Created
+	s.Updated = secret.Meta.Updated
+	s.State = StateUpdated
+}
+
 func NewSecretManifestList() *SecretManifestList {
 	dm := new(SecretManifestList)
 	dm.Items = make([]*SecretManifest, 0)
@@ -158,13 +169,13 @@ func (s *Secret) GetHash() string {
 
 func (s *Secret) SelfLink() string {
 	if s.Meta.SelfLink == "" {
-		s.Meta.SelfLink = s.CreateSelfLink(s.Meta.Name)
+		s.Meta.SelfLink = s.CreateSelfLink(s.Meta.Namespace, s.Meta.Name)
 	}
 	return s.Meta.SelfLink
 }
 
-func (s *Secret) CreateSelfLink(name string) string {
-	return fmt.Sprintf("%s", name)
+func (s *Secret) CreateSelfLink(namespace, name string) string {
+	return fmt.Sprintf("%s:%s", namespace, name)
 }
 
 func (s *Secret) DecodeRegistry() {
@@ -180,8 +191,9 @@ type SecretCreateOptions struct {
 
 // swagger:ignore
 type SecretUpdateOptions struct {
-	Kind string
-	Data map[string][]byte
+	Kind     string
+	Data     map[string][]byte
+	Revision *int64
 }
 
 // swagger:ignore
@@ -25,6 +25,8 @@ import (
 const (
 	DEFAULT_SERVICE_MEMORY   int64 = 128
 	DEFAULT_SERVICE_REPLICAS int   = 1
+	// DEFAULT_CPU_MIN - minimal container cpu in millicores, docker rejects smaller cpu quota
+	DEFAULT_CPU_MIN int64 = 10
 )
 
 type Service struct {
@@ -70,6 +72,8 @@ type ServiceSpec struct {
 	Strategy SpecStrategy `json:"strategy" yaml:"strategy"`
 	Selector SpecSelector `json:"selector" yaml:"selector"`
 	Template SpecTemplate `json:"template" yaml:"template"`
+	// Autoscaler adjusts replicas by pods resources usage
+	Autoscaler SpecAutoscaler `json:"autoscaler" yaml:"autoscaler"`
 }
 
 type ServiceStatusNetwork struct {
@@ -108,6 +112,14 @@ func (s *ServiceSpec) SetDefault() {
 	s.Template.Containers = make(SpecTemplateContainers, 0)
 }
 
+// GetResourceRequest returns resources requested by all service replicas
+func (s *ServiceSpec) GetResourceRequest() SpecTemplateContainerResource {
+	r := s.Template.GetResourceRequest()
+	r.RAM *= int64(s.Replicas)
+	r.CPU *= int64(s.Replicas)
+	return r
+}
+
 func (s *Service) SelfLink() string {
 	if s.Meta.SelfLink == "" {
 		s.Meta.SelfLink = s.CreateSelfLink(s.Meta.Namespace, s.Meta.Name)
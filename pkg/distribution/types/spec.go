@@ -167,6 +167,7 @@ type SpecBuildImage struct {
 	SuppressOutput bool
 	AuthConfigs    map[string]AuthConfig
 	Context        io.Reader
+	RemoteContext  string   // Remote url to fetch build context from
 	ExtraHosts     []string // List of extra hosts
 }
 
@@ -262,7 +263,7 @@ type SpecTemplateContainerExec struct {
 
 // swagger:model types_spec_template_container_resource
 type SpecTemplateContainerResource struct {
-	// CPU resource option
+	// CPU resource option in millicores, 1000 is one core
 	CPU int64 `json:"cpu"`
 	// RAM resource option
 	RAM int64 `json:"ram"`
@@ -300,6 +301,19 @@ type SpecTemplateContainerProbe struct {
 		Port     int    `json:"port"`
 	} `json:"socket"`
 
+	// HTTP GET request to check container liveness
+	HTTP struct {
+		Path    string            `json:"path"`
+		Port    int               `json:"port"`
+		Scheme  string            `json:"scheme"`
+		Headers map[string]string `json:"headers"`
+		// Range of response status codes treated as success
+		Status struct {
+			Min int `json:"min"`
+			Max int `json:"max"`
+		} `json:"status"`
+	} `json:"http"`
+
 	InitialDelaySeconds int `json:"initial_delay"`
 	TimeoutSeconds      int `json:"timeout_seconds"`
 	PeriodSeconds       int `json:"period_seconds"`
@@ -307,6 +321,13 @@ type SpecTemplateContainerProbe struct {
 	ThresholdFailure    int `json:"threshold_failure"`
 }
 
+const (
+	// SpecProbeHTTPStatusMin - lowest success response status of http probe if range is not set
+	SpecProbeHTTPStatusMin = 200
+	// SpecProbeHTTPStatusMax - highest success response status of http probe if range is not set
+	SpecProbeHTTPStatusMax = 399
+)
+
 // swagger:model types_spec_template_container_security
 type SpecTemplateContainerSecurity struct {
 	// Start container in priveleged mode
@@ -360,12 +381,31 @@ type SpecTemplateRestartPolicy struct {
 	Attempt int `json:"attempt" yaml:"attempt"`
 }
 
+const (
+	// SpecStrategyTypeRolling - replace deployment pods in batches
+	SpecStrategyTypeRolling = "rolling"
+)
+
+// swagger:model types_spec_autoscaler
+type SpecAutoscaler struct {
+	// Minimal replicas count
+	MinReplicas int `json:"min_replicas"`
+	// Maximal replicas count
+	MaxReplicas int `json:"max_replicas"`
+	// Target average CPU utilisation in percents of requested CPU
+	TargetCPU int `json:"target_cpu"`
+	// Target average RAM utilisation in percents of requested RAM
+	TargetRAM int `json:"target_ram"`
+}
+
 // swagger:model types_spec_strategy
 type SpecStrategy struct {
 	Type           string                     `json:"type"` // Rolling
 	RollingOptions SpecStrategyRollingOptions `json:"rollingOptions"`
 	Resources      SpecStrategyResources      `json:"resources"`
 	Deadline       int                        `json:"deadline"`
+	// Restart pods when secrets or configs used in env vars are changed
+	RestartOnChange bool `json:"restart_on_change"`
 	// Spec updated time
 	Updated time.Time `json:"updated"`
 }
@@ -396,10 +436,80 @@ type SpecSelector struct {
 	Labels map[string]string `json:"labels"`
 
 	Node string `json:"node"`
+	// Affinity and anti-affinity rules
+	Affinity SpecSelectorAffinity `json:"affinity"`
 	// Spec updated time
 	Updated time.Time `json:"updated"`
 }
 
+const (
+	// SpecAffinityTypeRequired - node should satisfy term to run pod
+	SpecAffinityTypeRequired = "required"
+	// SpecAffinityTypePreferred - nodes which satisfy term are preferred
+	SpecAffinityTypePreferred = "preferred"
+)
+
+// swagger:model types_spec_selector_affinity
+type SpecSelectorAffinity struct {
+	// Node affinity terms matched against node labels
+	Node []SpecSelectorAffinityTerm `json:"node,omitempty"`
+	// Node anti-affinity terms matched against node labels
+	NodeAnti []SpecSelectorAffinityTerm `json:"node_anti,omitempty"`
+	// Pod affinity terms matched against labels of pods running on node
+	Pod []SpecSelectorAffinityTerm `json:"pod,omitempty"`
+	// Pod anti-affinity terms matched against labels of pods running on node
+	PodAnti []SpecSelectorAffinityTerm `json:"pod_anti,omitempty"`
+}
+
+// swagger:model types_spec_selector_affinity_term
+type SpecSelectorAffinityTerm struct {
+	// Term type: required or preferred
+	Type string `json:"type"`
+	// Preferred term weight
+	Weight int `json:"weight"`
+	// Labels to match
+	Labels map[string]string `json:"labels"`
+}
+
+// IsRequired checks if term should be satisfied to place pod on node
+func (t SpecSelectorAffinityTerm) IsRequired() bool {
+	return t.Type != SpecAffinityTypePreferred
+}
+
+// Match checks that all term labels are presented in provided labels
+func (t SpecSelectorAffinityTerm) Match(labels map[string]string) bool {
+
+	if len(t.Labels) == 0 {
+		return false
+	}
+
+	for k, v := range t.Labels {
+		if l, ok := labels[k]; !ok || l != v {
+			return false
+		}
+	}
+
+	return true
+}
+
+// GetResourceRequest returns resources requested by all template containers
+func (s *SpecTemplate) GetResourceRequest() SpecTemplateContainerResource {
+
+	var r SpecTemplateContainerResource
+
+	for _, c := range s.Containers {
+		r.RAM += c.Resources.Request.RAM
+		r.CPU += c.Resources.Request.CPU
+	}
+
+	return r
+}
+
+// Enabled checks if service replicas should be scaled automatically
+func (a SpecAutoscaler) Enabled() bool {
+	return a.MaxReplicas > 0 && (a.TargetCPU > 0 || a.TargetRAM > 0)
+}
+
 func (s *SpecTemplateContainerEnvs) ToLinuxFormat() []string {
 	env := make([]string, 0)
 
@@ -433,6 +543,11 @@ func (s *SpecTemplateContainer) SetDefault() {
 	}
 }
 
+// Enabled returns true if any check is defined for the probe
+func (p *SpecTemplateContainerProbe) Enabled() bool {
+	return len(p.Exec.Command) > 0 || p.Socket.Port > 0 || p.HTTP.Port > 0
+}
+
 func (s *SpecTemplateContainerPort) Parse(p string) {
 
 	var (
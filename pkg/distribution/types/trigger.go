@@ -18,7 +18,21 @@
 
 package types
 
-import "fmt"
+import (
+	"fmt"
+	"path"
+	"time"
+)
+
+const (
+	TriggerSourceVCS      = "vcs"
+	TriggerSourceRegistry = "registry"
+
+	TriggerVendorGitHub    = "github"
+	TriggerVendorGitLab    = "gitlab"
+	TriggerVendorBitBucket = "bitbucket"
+	TriggerVendorDocker    = "docker"
+)
 
 type TriggerMap struct {
 	Runtime
@@ -46,9 +60,38 @@ type TriggerMeta struct {
 type TriggerStatus struct {
 	State   string `json:"state"`
 	Message string `json:"message"`
+	// Branch or tag of last executed hook
+	Ref string `json:"ref"`
+	// Last time service was updated by trigger
+	Executed time.Time `json:"executed"`
 }
 
 type TriggerSpec struct {
+	// Hook source: vcs or registry
+	Source string `json:"source"`
+	// Hook vendor: github, gitlab, bitbucket or docker
+	Vendor string `json:"vendor"`
+	// Branch or tag pattern, hooks for any branch or tag are accepted if empty
+	Filter string `json:"filter"`
+	// Secret to verify incoming hooks
+	Secret string `json:"secret"`
+	// Service container to update, all service containers are updated if empty
+	Container string `json:"container"`
+}
+
+// Match checks if branch or tag is accepted by trigger filter
+func (s TriggerSpec) Match(ref string) bool {
+
+	if s.Filter == EmptyString {
+		return true
+	}
+
+	ok, err := path.Match(s.Filter, ref)
+	if err != nil {
+		return false
+	}
+
+	return ok
 }
 
 func (t *Trigger) SelfLink() string {
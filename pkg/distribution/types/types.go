@@ -25,12 +25,14 @@ const (
 	DEFAULT_REPLICAS_MIN      = 1
 	DEFAULT_DESCRIPTION_LIMIT = 512
 
-	KindSecret    = "secret"
-	KindRoute     = "route"
-	KindNamespace = "namespace"
-	KindService   = "service"
-	KindPod       = "pod"
-	KindEndpoint  = "endpoint"
-	KindConfig    = "config"
-	KindVolume    = "volume"
+	KindSecret     = "secret"
+	KindRoute      = "route"
+	KindNamespace  = "namespace"
+	KindService    = "service"
+	KindPod        = "pod"
+	KindEndpoint   = "endpoint"
+	KindConfig     = "config"
+	KindVolume     = "volume"
+	KindDeployment = "deployment"
+	KindCluster    = "cluster"
 )
@@ -93,8 +93,11 @@ func (v *Volume) Create(namespace *types.Namespace, vol *types.Volume) (*types.V
 func (v *Volume) Update(volume *types.Volume) error {
 	log.V(logLevel).Debugf("%s:update:> update volume %s", logVolumePrefix, volume.Meta.Name)
 
+	opts := storage.GetOpts()
+	opts.Rev = revision(volume.Runtime)
+
 	if err := v.storage.Set(v.context, v.storage.Collection().Volume(),
-		v.storage.Key().Volume(volume.Meta.Namespace, volume.Meta.Name), volume, nil); err != nil {
+		v.storage.Key().Volume(volume.Meta.Namespace, volume.Meta.Name), volume, opts); err != nil {
 		log.V(logLevel).Errorf("%s:update:> update volume err: %v", logVolumePrefix, err)
 		return err
 	}
@@ -102,6 +105,19 @@ func (v *Volume) Update(volume *types.Volume) error {
 	return nil
 }
 
+// Set volume without revision check
+func (v *Volume) Set(volume *types.Volume) error {
+	log.V(logLevel).Debugf("%s:set:> set volume %s", logVolumePrefix, volume.Meta.Name)
+
+	if err := v.storage.Set(v.context, v.storage.Collection().Volume(),
+		v.storage.Key().Volume(volume.Meta.Namespace, volume.Meta.Name), volume, nil); err != nil {
+		log.V(logLevel).Errorf("%s:set:> set volume err: %v", logVolumePrefix, err)
+		return err
+	}
+
+	return nil
+}
+
 func (v *Volume) Destroy(volume *types.Volume) error {
 
 	if volume == nil {
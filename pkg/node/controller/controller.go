@@ -26,6 +26,7 @@ import (
 	"github.com/lastbackend/lastbackend/pkg/log"
 	"github.com/lastbackend/lastbackend/pkg/node/envs"
 	"github.com/lastbackend/lastbackend/pkg/node/runtime"
+	"github.com/lastbackend/lastbackend/pkg/util/metrics"
 	"github.com/spf13/viper"
 	"io/ioutil"
 	"sync"
@@ -37,6 +38,9 @@ const (
 	logLevel  = 3
 )
 
+var syncDuration = metrics.NewHistogram("lb_node_sync_duration_seconds",
+	"Node status sync with API latency by result", nil, "result")
+
 type Controller struct {
 	runtime *runtime.Runtime
 	cache   struct {
@@ -117,6 +121,12 @@ func (c *Controller) Sync(ctx context.Context) error {
 		opts.Pods = make(map[string]*request.NodePodStatusOptions)
 		opts.Resources.Capacity = envs.Get().GetState().Node().Status.Capacity
 		opts.Resources.Allocated = envs.Get().GetState().Node().Status.Allocated
+		opts.Usage = envs.Get().GetState().Stats().FlushPodUsage()
+		opts.Builds = make(map[string]*request.NodeBuildStatusOptions)
+
+		for b, status := range envs.Get().GetState().Builds().GetBuilds() {
+			opts.Builds[b] = getBuildOptions(status)
+		}
 
 		c.cache.lock.Lock()
 		var i = 0
@@ -141,9 +151,13 @@ func (c *Controller) Sync(ctx context.Context) error {
 			log.Debugf("send pod status: %s > %s", p, i.State)
 		}
 
+		started := time.Now()
 		spec, err := envs.Get().GetNodeClient().SetStatus(ctx, opts)
 		if err != nil {
 			log.Errorf("node:exporter:dispatch err: %s", err.Error())
+			syncDuration.Observe(time.Since(started).Seconds(), "error")
+		} else {
+			syncDuration.Observe(time.Since(started).Seconds(), "success")
 		}
 
 		if spec != nil {
@@ -186,4 +200,13 @@ func getPodOptions(p *types.PodStatus) *request.NodePodStatusOptions {
 	opts.Network = p.Network
 	opts.Steps = p.Steps
 	return opts
-}
\ No newline at end of file
+}
+
+func getBuildOptions(b *types.BuildStatus) *request.NodeBuildStatusOptions {
+	opts := v1.Request().Node().NodeBuildStatusOptions()
+	opts.State = b.State
+	opts.Message = b.Message
+	opts.Started = b.Started
+	opts.Finished = b.Finished
+	return opts
+}
@@ -0,0 +1,65 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+
+package build
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/lastbackend/lastbackend/pkg/distribution/errors"
+	"github.com/lastbackend/lastbackend/pkg/log"
+	"github.com/lastbackend/lastbackend/pkg/node/envs"
+	"github.com/lastbackend/lastbackend/pkg/node/runtime"
+)
+
+const logLevel = 2
+
+// BuildLogsH handler streams build logs into response writer until build is finished
+func BuildLogsH(w http.ResponseWriter, r *http.Request) {
+
+	log.V(logLevel).Debug("node:http:build:logs:> get build logs")
+
+	var (
+		b      = mux.Vars(r)["build"]
+		notify = w.(http.CloseNotifier).CloseNotify()
+	)
+
+	if envs.Get().GetState().Builds().GetLog(b) == nil {
+		log.Errorf("node:http:build:logs:> build not found")
+		errors.New("build").NotFound().Http(w)
+		return
+	}
+
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+
+	go func() {
+		select {
+		case <-notify:
+			log.V(logLevel).Debug("HTTP connection just closed.")
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	if err := runtime.BuildLogs(ctx, b, w); err != nil && err != context.Canceled {
+		log.Errorf("node:http:build:logs:> get build logs err: %s", err.Error())
+	}
+}
@@ -21,10 +21,12 @@ package http
 import (
 	"github.com/gorilla/mux"
 	"github.com/lastbackend/lastbackend/pkg/log"
+	"github.com/lastbackend/lastbackend/pkg/node/http/build"
 	"github.com/lastbackend/lastbackend/pkg/node/http/node"
 	"github.com/lastbackend/lastbackend/pkg/node/http/pod"
 	"github.com/lastbackend/lastbackend/pkg/util/http"
 	"github.com/lastbackend/lastbackend/pkg/util/http/cors"
+	"github.com/lastbackend/lastbackend/pkg/util/metrics"
 )
 
 const (
@@ -52,6 +54,8 @@ func AddRoutes(r ...[]http.Route) {
 func init() {
 	AddRoutes(node.Routes)
 	AddRoutes(pod.Routes)
+	AddRoutes(build.Routes)
+	AddRoutes([]http.Route{{Path: "/metrics", Method: http.MethodGet, Handler: metrics.Handler}})
 }
 
 func Listen(host string, port int, opts *HttpOpts) error {
@@ -19,8 +19,12 @@
 package node
 
 import (
-	"github.com/lastbackend/lastbackend/pkg/log"
+	"encoding/json"
 	"net/http"
+
+	"github.com/lastbackend/lastbackend/pkg/distribution/errors"
+	"github.com/lastbackend/lastbackend/pkg/log"
+	"github.com/lastbackend/lastbackend/pkg/node/envs"
 )
 
 const logLevel = 2
@@ -29,3 +33,22 @@ func NodeGetH(w http.ResponseWriter, r *http.Request) {
 
 	log.V(logLevel).Debug("Handler: Node: list node")
 }
+
+// NodeStatsH handler returns usage samples of all node pods collected in stats window
+func NodeStatsH(w http.ResponseWriter, _ *http.Request) {
+
+	log.V(logLevel).Debug("node:http:node:stats:> get node pods stats")
+
+	response, err := json.Marshal(envs.Get().GetState().Stats().GetUsageHistory())
+	if err != nil {
+		log.Errorf("node:http:node:stats:> convert struct to json err: %s", err.Error())
+		errors.HTTP.InternalServerError(w)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(response); err != nil {
+		log.Errorf("node:http:node:stats:> write response err: %s", err.Error())
+		return
+	}
+}
@@ -19,21 +19,65 @@
 package pod
 
 import (
+	"context"
+	"encoding/json"
 	"github.com/gorilla/mux"
+	"github.com/gorilla/websocket"
 	"github.com/lastbackend/lastbackend/pkg/distribution/errors"
+	"github.com/lastbackend/lastbackend/pkg/distribution/types"
 	"github.com/lastbackend/lastbackend/pkg/log"
 	"github.com/lastbackend/lastbackend/pkg/node/envs"
 	"github.com/lastbackend/lastbackend/pkg/node/runtime"
+	"io"
 	"net/http"
+	"sync"
 )
 
 const logLevel = 2
 
+// upgrader accepts exec sessions proxied by api, those come without Origin header
+var upgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 1024,
+}
+
 func PodGetH(w http.ResponseWriter, _ *http.Request) {
 
 	log.V(logLevel).Debug("node:http:pod:get:> get pod info")
 }
 
+// PodStatsH handler returns pod usage samples collected in stats window
+func PodStatsH(w http.ResponseWriter, r *http.Request) {
+
+	log.V(logLevel).Debug("node:http:pod:stats:> get pod stats")
+
+	var p = mux.Vars(r)["pod"]
+
+	if envs.Get().GetState().Pods().GetPod(p) == nil {
+		log.Errorf("node:http:pod:stats:> pod not found")
+		errors.New("pod").NotFound().Http(w)
+		return
+	}
+
+	stats := envs.Get().GetState().Stats().GetPodUsageHistory(p)
+	if stats == nil {
+		stats = make([]*types.PodUsage, 0)
+	}
+
+	response, err := json.Marshal(stats)
+	if err != nil {
+		log.Errorf("node:http:pod:stats:> convert struct to json err: %s", err.Error())
+		errors.HTTP.InternalServerError(w)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(response); err != nil {
+		log.Errorf("node:http:pod:stats:> write response err: %s", err.Error())
+		return
+	}
+}
+
 // PodLogsH handler streams pod logs into response writer
 func PodLogsH(w http.ResponseWriter, r *http.Request) {
 
@@ -70,3 +114,170 @@ func PodLogsH(w http.ResponseWriter, r *http.Request) {
 
 	return
 }
+
+// PodExecH handler attaches websocket connection to interactive command in pod container
+func PodExecH(w http.ResponseWriter, r *http.Request) {
+
+	log.V(logLevel).Debug("node:http:pod:exec:> exec in pod container")
+
+	var (
+		c = mux.Vars(r)["container"]
+		p = envs.Get().GetState().Pods().GetPod(mux.Vars(r)["pod"])
+		q = r.URL.Query()
+	)
+
+	if p == nil {
+		log.Errorf("node:http:pod:exec:> pod not found")
+		errors.New("pod").NotFound().Http(w)
+		return
+	}
+
+	if _, ok := p.Containers[c]; !ok {
+		log.Errorf("node:http:pod:exec:> container not found")
+		errors.New("pod").NotFound().Http(w)
+		return
+	}
+
+	exec := new(types.ContainerExec)
+	exec.Command = q["command"]
+	exec.Tty = q.Get("tty") == "true"
+	exec.Stdin = q.Get("stdin") == "true"
+
+	if len(exec.Command) == 0 {
+		log.Errorf("node:http:pod:exec:> command is empty")
+		errors.New("exec").BadParameter("command").Http(w)
+		return
+	}
+
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		log.Errorf("node:http:pod:exec:> set websocket upgrade err: %s", err.Error())
+		return
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+
+	var (
+		stream      = &execStream{conn: conn}
+		stdin, pipe = io.Pipe()
+		resize      = make(chan *types.ContainerExecResize)
+	)
+	defer stdin.Close()
+
+	// stdin messages are dropped when exec is not attached to stdin
+	if !exec.Stdin {
+		stdin.Close()
+	}
+
+	go stream.read(ctx, cancel, pipe, resize)
+
+	status := new(types.ContainerExecStatus)
+	status.ExitCode, err = runtime.PodExec(ctx, c, exec, stdin,
+		stream.writer(types.ContainerExecStreamStdout), stream.writer(types.ContainerExecStreamStderr), resize)
+	if err != nil {
+		log.Errorf("node:http:pod:exec:> exec err: %s", err.Error())
+		status.Error = err.Error()
+	}
+
+	if err := stream.status(status); err != nil {
+		log.Errorf("node:http:pod:exec:> write exec status err: %s", err.Error())
+	}
+}
+
+// execStream multiplexes exec streams over websocket connection:
+// the first byte of every message is a stream channel, the rest is a payload
+type execStream struct {
+	sync.Mutex
+	conn *websocket.Conn
+}
+
+type execWriter struct {
+	stream  *execStream
+	channel byte
+}
+
+func (w *execWriter) Write(p []byte) (int, error) {
+	if err := w.stream.write(w.channel, p); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+func (s *execStream) writer(channel byte) io.Writer {
+	return &execWriter{stream: s, channel: channel}
+}
+
+func (s *execStream) write(channel byte, p []byte) error {
+	s.Lock()
+	defer s.Unlock()
+	return s.conn.WriteMessage(websocket.BinaryMessage, append([]byte{channel}, p...))
+}
+
+func (s *execStream) status(status *types.ContainerExecStatus) error {
+
+	buf, err := json.Marshal(status)
+	if err != nil {
+		return err
+	}
+
+	if err := s.write(types.ContainerExecStreamStatus, buf); err != nil {
+		return err
+	}
+
+	s.Lock()
+	defer s.Unlock()
+	return s.conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+}
+
+// read passes client stdin and terminal resize messages to exec,
+// empty stdin message closes exec stdin, closed connection cancels exec
+func (s *execStream) read(ctx context.Context, cancel context.CancelFunc, stdin *io.PipeWriter, resize chan *types.ContainerExecResize) {
+
+	defer func() {
+		stdin.Close()
+		close(resize)
+		cancel()
+	}()
+
+	for {
+
+		_, msg, err := s.conn.ReadMessage()
+		if err != nil {
+			log.V(logLevel).Debugf("node:http:pod:exec:> websocket connection closed: %s", err.Error())
+			return
+		}
+
+		if len(msg) == 0 {
+			continue
+		}
+
+		switch msg[0] {
+		case types.ContainerExecStreamStdin:
+
+			if len(msg) == 1 {
+				stdin.Close()
+				continue
+			}
+
+			if _, err := stdin.Write(msg[1:]); err != nil {
+				log.V(logLevel).Debugf("node:http:pod:exec:> write stdin err: %s", err.Error())
+			}
+
+		case types.ContainerExecStreamResize:
+
+			size := new(types.ContainerExecResize)
+			if err := json.Unmarshal(msg[1:], size); err != nil {
+				log.Errorf("node:http:pod:exec:> parse resize message err: %s", err.Error())
+				continue
+			}
+
+			select {
+			case resize <- size:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
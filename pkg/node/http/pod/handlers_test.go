@@ -0,0 +1,213 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+
+package pod
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/gorilla/websocket"
+	"github.com/lastbackend/lastbackend/pkg/distribution/types"
+	"github.com/lastbackend/lastbackend/pkg/node/envs"
+	"github.com/lastbackend/lastbackend/pkg/node/state"
+	"github.com/lastbackend/lastbackend/pkg/runtime/cri"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeCRI echoes exec stdin into stdout and writes command into stderr
+type fakeCRI struct {
+	cri.CRI
+	command []string
+	resize  chan *types.ContainerExecResize
+}
+
+func (c *fakeCRI) ExecCreate(ctx context.Context, ID string, exec *types.ContainerExec) (string, error) {
+	c.command = exec.Command
+	return "exec", nil
+}
+
+func (c *fakeCRI) ExecAttach(ctx context.Context, ID string, tty bool, stdin io.Reader, stdout, stderr io.Writer) error {
+
+	if _, err := stderr.Write([]byte(strings.Join(c.command, " "))); err != nil {
+		return err
+	}
+
+	if stdin == nil {
+		return nil
+	}
+
+	_, err := io.Copy(stdout, stdin)
+	return err
+}
+
+func (c *fakeCRI) ExecResize(ctx context.Context, ID string, resize *types.ContainerExecResize) error {
+	c.resize <- resize
+	return nil
+}
+
+func (c *fakeCRI) ExecInspect(ctx context.Context, ID string) (int, error) {
+	return 3, nil
+}
+
+func getExecServer(t *testing.T) (*httptest.Server, *fakeCRI) {
+
+	fc := &fakeCRI{resize: make(chan *types.ContainerExecResize, 1)}
+
+	envs.Get().SetState(state.New())
+	envs.Get().SetCRI(fc)
+	envs.Get().GetState().Pods().SetPod("pod", &types.PodStatus{
+		Containers: map[string]*types.PodContainer{"container": {ID: "container", Pod: "pod", Name: "c"}},
+	})
+
+	r := mux.NewRouter()
+	r.HandleFunc("/pod/{pod}/{container}/exec", PodExecH).Methods(http.MethodGet)
+
+	return httptest.NewServer(r), fc
+}
+
+func TestPodExecH(t *testing.T) {
+
+	srv, fc := getExecServer(t)
+	defer srv.Close()
+
+	q := url.Values{}
+	q.Add("command", "cat")
+	q.Add("command", "-")
+	q.Set("stdin", "true")
+
+	endpoint := fmt.Sprintf("ws%s/pod/pod/container/exec?%s", strings.TrimPrefix(srv.URL, "http"), q.Encode())
+
+	conn, _, err := websocket.DefaultDialer.Dial(endpoint, nil)
+	if !assert.NoError(t, err, "websocket dial") {
+		return
+	}
+	defer conn.Close()
+
+	resize, _ := json.Marshal(&types.ContainerExecResize{Width: 80, Height: 24})
+	assert.NoError(t, conn.WriteMessage(websocket.BinaryMessage, append([]byte{types.ContainerExecStreamResize}, resize...)))
+	assert.Equal(t, &types.ContainerExecResize{Width: 80, Height: 24}, <-fc.resize, "resize mismatch")
+
+	assert.NoError(t, conn.WriteMessage(websocket.BinaryMessage, append([]byte{types.ContainerExecStreamStdin}, "hello"...)))
+	assert.NoError(t, conn.WriteMessage(websocket.BinaryMessage, []byte{types.ContainerExecStreamStdin}))
+
+	var (
+		stdout string
+		stderr string
+		status *types.ContainerExecStatus
+	)
+
+	for {
+
+		_, msg, err := conn.ReadMessage()
+		if err != nil {
+			assert.True(t, websocket.IsCloseError(err, websocket.CloseNormalClosure), "unexpected close: %v", err)
+			break
+		}
+
+		if !assert.NotEmpty(t, msg, "empty message") {
+			return
+		}
+
+		switch msg[0] {
+		case types.ContainerExecStreamStdout:
+			stdout += string(msg[1:])
+		case types.ContainerExecStreamStderr:
+			stderr += string(msg[1:])
+		case types.ContainerExecStreamStatus:
+			status = new(types.ContainerExecStatus)
+			assert.NoError(t, json.Unmarshal(msg[1:], status))
+		default:
+			t.Errorf("unexpected stream channel: %d", msg[0])
+		}
+	}
+
+	assert.Equal(t, []string{"cat", "-"}, fc.command, "command mismatch")
+	assert.Equal(t, "hello", stdout, "stdout mismatch")
+	assert.Equal(t, "cat -", stderr, "stderr mismatch")
+	assert.Equal(t, &types.ContainerExecStatus{ExitCode: 3}, status, "status mismatch")
+}
+
+func TestPodExecHErrors(t *testing.T) {
+
+	srv, _ := getExecServer(t)
+	defer srv.Close()
+
+	tests := []struct {
+		name    string
+		url     string
+		headers http.Header
+		code    int
+		err     string
+	}{
+		{
+			name: "pod not found",
+			url:  "/pod/unknown/container/exec?command=ls",
+			code: http.StatusNotFound,
+			err:  "{\"code\":404,\"status\":\"Not Found\",\"message\":\"Pod not found\"}",
+		},
+		{
+			name: "container not found",
+			url:  "/pod/pod/unknown/exec?command=ls",
+			code: http.StatusNotFound,
+			err:  "{\"code\":404,\"status\":\"Not Found\",\"message\":\"Pod not found\"}",
+		},
+		{
+			name: "command is empty",
+			url:  "/pod/pod/container/exec",
+			code: http.StatusBadRequest,
+			err:  "{\"code\":400,\"status\":\"Bad Parameter\",\"message\":\"Bad command parameter\"}",
+		},
+		{
+			name:    "cross origin request",
+			url:     "/pod/pod/container/exec?command=ls",
+			headers: http.Header{"Origin": []string{"http://example.com"}},
+			code:    http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+
+			endpoint := fmt.Sprintf("ws%s%s", strings.TrimPrefix(srv.URL, "http"), tc.url)
+
+			_, res, err := websocket.DefaultDialer.Dial(endpoint, tc.headers)
+			if !assert.Error(t, err, "websocket dial should fail") || !assert.NotNil(t, res, "response is nil") {
+				return
+			}
+			defer res.Body.Close()
+
+			assert.Equal(t, tc.code, res.StatusCode, "status code not equal")
+
+			if tc.err != "" {
+				body, err := ioutil.ReadAll(res.Body)
+				assert.NoError(t, err)
+				assert.Equal(t, tc.err, string(body), "error message mismatch")
+			}
+		})
+	}
+}
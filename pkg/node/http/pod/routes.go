@@ -25,5 +25,7 @@ import (
 
 var Routes = []http.Route{
 	{Path: "/pod/{pod}", Method: http.MethodGet, Middleware: []http.Middleware{middleware.Authenticate}, Handler: PodGetH},
+	{Path: "/pod/{pod}/stats", Method: http.MethodGet, Middleware: []http.Middleware{middleware.Authenticate}, Handler: PodStatsH},
 	{Path: "/pod/{pod}/{container}/logs", Method: http.MethodGet, Middleware: []http.Middleware{middleware.Authenticate}, Handler: PodLogsH},
+	{Path: "/pod/{pod}/{container}/exec", Method: http.MethodGet, Middleware: []http.Middleware{middleware.Authenticate}, Handler: PodExecH},
 }
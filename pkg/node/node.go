@@ -110,6 +110,7 @@ func Daemon() {
 	r.Restore(ctx)
 	r.Subscribe(ctx)
 	r.Loop(ctx)
+	go r.StatsLoop(ctx)
 
 	if viper.IsSet("node.manifest.dir") ||  viper.IsSet("dir") {
 
@@ -0,0 +1,165 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/lastbackend/lastbackend/pkg/distribution/errors"
+	"github.com/lastbackend/lastbackend/pkg/distribution/types"
+	"github.com/lastbackend/lastbackend/pkg/log"
+	"github.com/lastbackend/lastbackend/pkg/node/envs"
+	"github.com/lastbackend/lastbackend/pkg/node/state"
+)
+
+const logBuildPrefix = "node:runtime:build"
+
+// BuildManage starts new builds and cancels removed ones
+func BuildManage(ctx context.Context, key string, manifest *types.BuildManifest) error {
+
+	log.V(logLevel).Debugf("%s:manage:> manage build %s: %s", logBuildPrefix, key, manifest.State)
+
+	bs := envs.Get().GetState().Builds()
+
+	switch manifest.State {
+	case types.StateDestroyed:
+		bs.DelBuild(key)
+		return nil
+	case types.StateReady, types.StateError:
+		// build status is delivered to api, build log is kept until build is removed
+		bs.DelStatus(key)
+		return nil
+	}
+
+	if bs.GetLog(key) != nil {
+		return nil
+	}
+
+	status := new(types.BuildStatus)
+	status.State = types.StateProvision
+	status.Started = time.Now().UTC()
+
+	bctx, cancel := context.WithCancel(context.Background())
+
+	out := state.NewBuildLog()
+	bs.SetLog(key, out)
+	bs.SetCancel(key, cancel)
+	bs.SetBuild(key, status)
+
+	go func() {
+		defer out.Close()
+
+		status := *status
+
+		if err := BuildRun(bctx, key, manifest, out); err != nil {
+			log.Errorf("%s:manage:> build %s err: %s", logBuildPrefix, key, err.Error())
+			fmt.Fprintf(out, "%s\n", err.Error())
+			status.State = types.StateError
+			status.Message = err.Error()
+		} else {
+			status.State = types.StateReady
+		}
+
+		// canceled build is removed from state
+		if bctx.Err() != nil {
+			return
+		}
+
+		status.Finished = time.Now().UTC()
+		bs.SetBuild(key, &status)
+	}()
+
+	return nil
+}
+
+// BuildRun builds image from git repository and pushes it to registry
+func BuildRun(ctx context.Context, key string, manifest *types.BuildManifest, out io.Writer) error {
+
+	var (
+		spec = new(types.SpecBuildImage)
+		mf   = new(types.ImageManifest)
+	)
+
+	spec.Tags = []string{manifest.Image.Name}
+	spec.Dockerfile = manifest.Source.Dockerfile
+	spec.RemoteContext = buildRemoteContext(manifest.Source)
+
+	mf.Name = manifest.Image.Name
+
+	if manifest.Image.Secret != types.EmptyString {
+		secret, err := SecretGet(ctx, key, manifest.Image.Secret)
+		if err != nil {
+			return err
+		}
+
+		data, err := secret.DecodeSecretAuthData()
+		if err != nil {
+			log.Errorf("%s:run:> can not parse secret auth data. err: %s", logBuildPrefix, err.Error())
+			return err
+		}
+
+		auth, err := envs.Get().GetIRI().Auth(ctx, data)
+		if err != nil {
+			log.Errorf("%s:run:> can not create secret string. err: %s", logBuildPrefix, err.Error())
+			return err
+		}
+
+		mf.Auth = auth
+	}
+
+	log.V(logLevel).Debugf("%s:run:> build image %s from %s", logBuildPrefix, manifest.Image.Name, spec.RemoteContext)
+
+	if _, err := envs.Get().GetIRI().Build(ctx, nil, spec, out); err != nil {
+		return err
+	}
+
+	if ctx.Err() != nil {
+		return errors.New("build canceled")
+	}
+
+	log.V(logLevel).Debugf("%s:run:> push image %s", logBuildPrefix, manifest.Image.Name)
+
+	if _, err := envs.Get().GetIRI().Push(ctx, mf, out); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// BuildLogs streams build output into writer until build is finished
+func BuildLogs(ctx context.Context, key string, w io.Writer) error {
+
+	l := envs.Get().GetState().Builds().GetLog(key)
+	if l == nil {
+		return errors.New("build not found")
+	}
+
+	return l.Stream(ctx, w)
+}
+
+// buildRemoteContext returns git remote context in docker format: url#ref
+func buildRemoteContext(source types.BuildSource) string {
+	if source.Ref == types.EmptyString {
+		return source.Url
+	}
+	return fmt.Sprintf("%s#%s", source.Url, source.Ref)
+}
@@ -20,6 +20,9 @@ package runtime
 
 import (
 	"context"
+	"fmt"
+	"strings"
+
 	"github.com/lastbackend/lastbackend/pkg/distribution/types"
 	"github.com/lastbackend/lastbackend/pkg/node/envs"
 )
@@ -53,11 +56,35 @@ func ConfigCreate(ctx context.Context, name string, cfg *types.ConfigManifest) e
 
 func ConfigUpdate(ctx context.Context, name string, cfg *types.ConfigManifest) error {
 
+	prev := envs.Get().GetState().Configs().GetConfig(name)
 	envs.Get().GetState().Configs().SetConfig(name, cfg)
-	return nil
 
+	if prev != nil && !prev.Updated.Equal(cfg.Updated) {
+		VolumesRefresh(ctx, types.KindConfig, name)
+	}
+
+	return nil
 }
 
 func ConfigRemove(ctx context.Context, name string) {
 	envs.Get().GetState().Configs().DelConfig(name)
 }
+
+// ConfigGet returns config manifest available for pod:
+// namespaced cluster config has priority over node local config
+func ConfigGet(ctx context.Context, pod, name string) *types.ConfigManifest {
+	return envs.Get().GetState().Configs().GetConfig(configKeyResolve(pod, name))
+}
+
+func configKeyResolve(pod, name string) string {
+
+	parts := strings.Split(pod, ":")
+	if len(parts) > 0 && parts[0] != types.EmptyString {
+		key := fmt.Sprintf("%s:%s", parts[0], name)
+		if envs.Get().GetState().Configs().GetConfig(key) != nil {
+			return key
+		}
+	}
+
+	return name
+}
@@ -48,6 +48,7 @@ func containerInspect(ctx context.Context, status *types.PodStatus, container *t
 				Exit: types.PodContainerStateExit{
 					Code:      info.ExitCode,
 					Timestamp: time.Now().UTC(),
+					OOMKilled: info.OOMKilled,
 				},
 			}
 		}
@@ -111,6 +112,7 @@ func containerSubscribe(ctx context.Context) error {
 			container.State.Stopped.Exit = types.PodContainerStateExit{
 				Code:      c.ExitCode,
 				Timestamp: time.Now().UTC(),
+				OOMKilled: c.OOMKilled,
 			}
 			container.State.Started.Started = false
 		case types.StateError:
@@ -122,12 +124,14 @@ func containerSubscribe(ctx context.Context) error {
 			container.State.Error.Exit = types.PodContainerStateExit{
 				Code:      c.ExitCode,
 				Timestamp: time.Now().UTC(),
+				OOMKilled: c.OOMKilled,
 			}
 			container.State.Started.Started = false
 			container.State.Stopped.Stopped = false
 			container.State.Stopped.Exit = types.PodContainerStateExit{
 				Code:      c.ExitCode,
 				Timestamp: time.Now().UTC(),
+				OOMKilled: c.OOMKilled,
 			}
 			container.State.Started.Started = false
 		}
@@ -144,9 +148,7 @@ func containerSubscribe(ctx context.Context) error {
 func containerManifestCreate(ctx context.Context, pod string, spec *types.SpecTemplateContainer) (*types.ContainerManifest, error) {
 
 	mf := types.NewContainerManifest(spec)
-
-	name := strings.Split(pod, ":")
-	mf.Name = fmt.Sprintf("%s-%s", name[len(name)-1], spec.Name)
+	mf.Name = containerNameCreate(pod, spec.Name)
 
 	mf.Labels = make(map[string]string, 0)
 	for n, v := range spec.Labels {
@@ -161,7 +163,7 @@ func containerManifestCreate(ctx context.Context, pod string, spec *types.SpecTe
 			continue
 		}
 
-		secret, err := SecretGet(ctx, s.Secret.Name)
+		secret, err := SecretGet(ctx, pod, s.Secret.Name)
 		if err != nil {
 			log.Errorf("Can not get secret for container: %s", err.Error())
 			return nil, err
@@ -185,6 +187,28 @@ func containerManifestCreate(ctx context.Context, pod string, spec *types.SpecTe
 
 	}
 
+	for _, s := range spec.EnvVars {
+
+		if s.Config.Name == types.EmptyString || s.Config.Key == types.EmptyString {
+			continue
+		}
+
+		cfg := ConfigGet(ctx, pod, s.Config.Name)
+		if cfg == nil {
+			log.Errorf("Can not get config for container: %s", s.Config.Name)
+			continue
+		}
+
+		val, err := cfg.GetValue(s.Config.Key)
+		if err != nil {
+			continue
+		}
+
+		env := fmt.Sprintf("%s=%s", s.Name, val)
+		mf.Envs = append(mf.Envs, env)
+
+	}
+
 	for _, v := range spec.Volumes {
 
 		log.Debugf("try to attach volume: %s", v.Name)
@@ -221,3 +245,8 @@ func containerManifestCreate(ctx context.Context, pod string, spec *types.SpecTe
 
 	return mf, nil
 }
+
+func containerNameCreate(pod, name string) string {
+	parts := strings.Split(pod, ":")
+	return fmt.Sprintf("%s-%s", parts[len(parts)-1], name)
+}
@@ -25,7 +25,7 @@ import (
 	"golang.org/x/net/context"
 )
 
-func ImagePull(ctx context.Context, image *types.SpecTemplateContainerImage) error {
+func ImagePull(ctx context.Context, pod string, image *types.SpecTemplateContainerImage) error {
 
 	var (
 		mf = new(types.ImageManifest)
@@ -33,7 +33,7 @@ func ImagePull(ctx context.Context, image *types.SpecTemplateContainerImage) err
 
 	mf.Name = image.Name
 	if image.Secret != types.EmptyString {
-		secret, err := SecretGet(ctx, image.Secret)
+		secret, err := SecretGet(ctx, pod, image.Secret)
 		if err != nil {
 			log.Errorf("can not get secret for image. err: %s", err.Error())
 			return err
@@ -56,9 +56,12 @@ func ImagePull(ctx context.Context, image *types.SpecTemplateContainerImage) err
 	img, err := envs.Get().GetIRI().Pull(ctx, mf, nil)
 	if err != nil {
 		log.Errorf("can not pull image: %s", err.Error())
+		imagePulls.Inc("error")
 		return err
 	}
 
+	imagePulls.Inc("success")
+
 	if img != nil {
 		envs.Get().GetState().Images().AddImage(img.SelfLink(), img)
 	}
@@ -0,0 +1,65 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+
+package runtime
+
+import (
+	"github.com/lastbackend/lastbackend/pkg/distribution/types"
+	"github.com/lastbackend/lastbackend/pkg/node/envs"
+	"github.com/lastbackend/lastbackend/pkg/util/metrics"
+)
+
+var (
+	imagePulls = metrics.NewCounter("lb_node_image_pulls_total",
+		"Count of images pulls by result", "result")
+
+	_ = metrics.NewGaugeFunc("lb_node_pods", "Count of node pods by state",
+		[]string{"state"}, func() []metrics.Sample {
+			var samples = make([]metrics.Sample, 0)
+			for state, count := range envs.Get().GetState().Pods().GetStatesCount() {
+				samples = append(samples, metrics.Sample{Labels: []string{state}, Value: float64(count)})
+			}
+			return samples
+		})
+
+	_ = metrics.NewGaugeFunc("lb_node_container_cpu_millicores", "Container CPU usage in millicores",
+		[]string{"pod", "container"}, usageSamples(func(u types.PodContainerUsage) int64 { return u.CPU }))
+	_ = metrics.NewGaugeFunc("lb_node_container_memory_megabytes", "Container RAM usage in MB",
+		[]string{"pod", "container"}, usageSamples(func(u types.PodContainerUsage) int64 { return u.RAM }))
+	_ = metrics.NewGaugeFunc("lb_node_container_network_receive_bytes_per_second", "Container network receive rate",
+		[]string{"pod", "container"}, usageSamples(func(u types.PodContainerUsage) int64 { return u.NetworkRx }))
+	_ = metrics.NewGaugeFunc("lb_node_container_network_transmit_bytes_per_second", "Container network transmit rate",
+		[]string{"pod", "container"}, usageSamples(func(u types.PodContainerUsage) int64 { return u.NetworkTx }))
+	_ = metrics.NewGaugeFunc("lb_node_container_block_read_bytes_per_second", "Container block devices read rate",
+		[]string{"pod", "container"}, usageSamples(func(u types.PodContainerUsage) int64 { return u.BlockRead }))
+	_ = metrics.NewGaugeFunc("lb_node_container_block_write_bytes_per_second", "Container block devices write rate",
+		[]string{"pod", "container"}, usageSamples(func(u types.PodContainerUsage) int64 { return u.BlockWrite }))
+)
+
+// usageSamples returns collector of containers usage value from last collected pods stats
+func usageSamples(value func(u types.PodContainerUsage) int64) func() []metrics.Sample {
+	return func() []metrics.Sample {
+		var samples = make([]metrics.Sample, 0)
+		for pod, usage := range envs.Get().GetState().Stats().GetPodsUsage() {
+			for name, c := range usage.Containers {
+				samples = append(samples, metrics.Sample{Labels: []string{pod, name}, Value: float64(value(c))})
+			}
+		}
+		return samples
+	}
+}
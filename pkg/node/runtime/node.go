@@ -23,12 +23,17 @@ import (
 	"github.com/lastbackend/lastbackend/pkg/node/envs"
 	"github.com/lastbackend/lastbackend/pkg/util/system"
 	"github.com/shirou/gopsutil/mem"
+	"github.com/spf13/viper"
 
 	"fmt"
 	"os"
 )
 
-const MinContainerMemory = 32
+const (
+	MinContainerMemory = 32
+	// CPU resources are measured in millicores, 1000 is one core
+	CPUCoreMillicores = 1000
+)
 
 func NodeInfo() types.NodeInfo {
 
@@ -65,7 +70,7 @@ func NodeStatus() types.NodeStatus {
 
 	//state.Services.Router.Enabled = viper.GetBool("node.services.router.enabled")
 	//state.Services.Router.ExternalIP = viper.GetString("node.services.router.external_ip")
-	//state.Services.Builder = viper.GetBool("node.services.builder")
+	state.Role.Builder = viper.GetBool("node.services.builder")
 
 	return state
 }
@@ -81,6 +86,7 @@ func NodeCapacity() types.NodeResources {
 
 	return types.NodeResources{
 		Memory:     int64(m),
+		Cpu:        system.GetCPUCount() * CPUCoreMillicores,
 		Pods:       int(m / MinContainerMemory),
 		Containers: int(m / MinContainerMemory),
 	}
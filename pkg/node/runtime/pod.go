@@ -96,7 +96,9 @@ func PodManage(ctx context.Context, key string, manifest *types.PodManifest) err
 
 			}
 			return PodRestart(ctx, key)
-		default: return nil
+		default:
+			PodProbesStart(key, manifest)
+			return nil
 		}
 	}
 
@@ -112,6 +114,10 @@ func PodManage(ctx context.Context, key string, manifest *types.PodManifest) err
 	}
 
 	envs.Get().GetState().Pods().SetPod(key, status)
+	if err == nil {
+		PodProbesStart(key, manifest)
+	}
+
 	return nil
 }
 
@@ -172,7 +178,7 @@ func PodCreate(ctx context.Context, key string, manifest *types.PodManifest) (*t
 	log.V(logLevel).Debugf("Have %d containers", len(manifest.Template.Containers))
 	for _, c := range manifest.Template.Containers {
 		log.V(logLevel).Debug("Pull images for pod if needed")
-		if err := ImagePull(ctx, &c.Image); err != nil {
+		if err := ImagePull(ctx, key, &c.Image); err != nil {
 			log.Errorf("can not pull image: %s", err.Error())
 			return setError(err)
 		}
@@ -190,17 +196,6 @@ func PodCreate(ctx context.Context, key string, manifest *types.PodManifest) (*t
 
 	envs.Get().GetState().Pods().SetPod(key, status)
 
-	for _, s := range manifest.Template.Containers {
-		for _, e := range s.EnvVars {
-			if e.Secret.Name != types.EmptyString {
-				log.V(logLevel).Debug("Get secret info from api")
-				if err := SecretCreate(ctx, e.Secret.Name); err != nil {
-					log.Errorf("can not fetch secret from api")
-				}
-			}
-		}
-	}
-
 	for _, s := range manifest.Template.Containers {
 
 		//==========================================================================
@@ -217,6 +212,7 @@ func PodCreate(ctx context.Context, key string, manifest *types.PodManifest) (*t
 			return setError(err)
 		}
 
+		c.Name = m.Name
 		c.ID, err = envs.Get().GetCRI().Create(ctx, m)
 		if err != nil {
 			switch err {
@@ -284,7 +280,8 @@ func PodCreate(ctx context.Context, key string, manifest *types.PodManifest) (*t
 			return status, err
 		}
 
-		c.Ready = true
+		// container with readiness probe becomes ready after first successful check
+		c.Ready = !s.Probes.ReadProbe.Enabled()
 		c.State.Started = types.PodContainerStateStarted{
 			Started:   true,
 			Timestamp: time.Now().UTC(),
@@ -324,6 +321,7 @@ func PodClean(ctx context.Context, status *types.PodStatus) {
 
 func PodDestroy(ctx context.Context, pod string, status *types.PodStatus) {
 	log.V(logLevel).Debugf("Try to remove pod: %s", pod)
+	PodProbesStop(pod)
 	PodClean(ctx, status)
 	envs.Get().GetState().Pods().DelPod(pod)
 	for _, v := range status.Volumes {
@@ -391,6 +389,7 @@ func PodRestore(ctx context.Context) error {
 			cs.State.Stopped.Exit = types.PodContainerStateExit{
 				Code:      c.ExitCode,
 				Timestamp: time.Now().UTC(),
+				OOMKilled: c.OOMKilled,
 			}
 			cs.State.Started.Started = false
 		case types.StateError:
@@ -400,12 +399,14 @@ func PodRestore(ctx context.Context) error {
 			cs.State.Error.Exit = types.PodContainerStateExit{
 				Code:      c.ExitCode,
 				Timestamp: time.Now().UTC(),
+				OOMKilled: c.OOMKilled,
 			}
 			cs.State.Started.Started = false
 			cs.State.Stopped.Stopped = false
 			cs.State.Stopped.Exit = types.PodContainerStateExit{
 				Code:      c.ExitCode,
 				Timestamp: time.Now().UTC(),
+				OOMKilled: c.OOMKilled,
 			}
 			cs.State.Started.Started = false
 		}
@@ -416,6 +417,7 @@ func PodRestore(ctx context.Context) error {
 				Exit: types.PodContainerStateExit{
 					Code:      c.ExitCode,
 					Timestamp: time.Now().UTC(),
+					OOMKilled: c.OOMKilled,
 				},
 			}
 		}
@@ -508,6 +510,47 @@ func PodLogs(ctx context.Context, id string, follow bool, s io.Writer, doneChan
 	return nil
 }
 
+// PodExec runs interactive command in pod container and returns command exit code
+func PodExec(ctx context.Context, id string, exec *types.ContainerExec, stdin io.Reader, stdout, stderr io.Writer, resize <-chan *types.ContainerExecResize) (int, error) {
+
+	log.V(logLevel).Debugf("Exec in container [%s]: %v", id, exec.Command)
+
+	var cri = envs.Get().GetCRI()
+
+	eid, err := cri.ExecCreate(ctx, id, exec)
+	if err != nil {
+		log.Errorf("Error create exec %s", err)
+		return 0, err
+	}
+
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case size, ok := <-resize:
+				if !ok {
+					return
+				}
+				if err := cri.ExecResize(ctx, eid, size); err != nil {
+					log.Errorf("Error resize exec tty %s", err)
+				}
+			}
+		}
+	}()
+
+	if !exec.Stdin {
+		stdin = nil
+	}
+
+	if err := cri.ExecAttach(ctx, eid, exec.Tty, stdin, stdout, stderr); err != nil {
+		log.Errorf("Error attach exec %s", err)
+		return 0, err
+	}
+
+	return cri.ExecInspect(context.Background(), eid)
+}
+
 func PodSpecCheck(ctx context.Context, key string, manifest *types.PodManifest) bool {
 
 	log.V(logLevel).Infof("Pod check spec pod: %s", key)
@@ -763,19 +806,17 @@ func PodVolumesCheck(ctx context.Context, pod string, spec []*types.SpecTemplate
 		name := podVolumeKeyCreate(pod, v.Name)
 
 		if v.Config.Name != types.EmptyString && len(v.Config.Files) > 0 {
-			equal, err := VolumeCheckConfigData(ctx, name, v.Config.Name)
-			if err != nil {
+			equal, err := VolumeCheckConfigData(ctx, name, configKeyResolve(pod, v.Config.Name))
+			if err != nil || !equal {
 				return false
 			}
-			return equal
 		}
 
 		if v.Secret.Name != types.EmptyString && len(v.Secret.Files) > 0 {
-			equal, err := VolumeCheckSecretData(ctx, name, v.Config.Name)
-			if err != nil {
+			equal, err := VolumeCheckSecretData(ctx, name, secretKeyCreate(pod, v.Secret.Name))
+			if err != nil || !equal {
 				return false
 			}
-			return equal
 		}
 	}
 
@@ -802,20 +843,16 @@ func PodVolumeUpdate(ctx context.Context, pod string, spec *types.SpecTemplateVo
 
 	pv := &types.PodVolume{
 		Pod:   pod,
+		Name:  spec.Name,
 		Type:  types.VOLUMETYPELOCAL,
 		Ready: vol.Ready,
 		Path:  vol.Path,
 	}
 
-
-	if spec.Config.Name != types.EmptyString && len(spec.Config.Files) > 0 {
-		if err := VolumeSetConfigData(ctx, name, spec.Config.Name); err != nil {
-			log.Errorf("can not set config data to volume: %s", err.Error())
-			return pv, err
-		}
+	if err := podVolumeDataSet(ctx, pod, name, spec, pv); err != nil {
+		return pv, err
 	}
 
-
 	return pv, nil
 }
 
@@ -842,19 +879,40 @@ func PodVolumeCreate(ctx context.Context, pod string, spec *types.SpecTemplateVo
 
 	pv := &types.PodVolume{
 		Pod:   pod,
+		Name:  spec.Name,
 		Type:  types.VOLUMETYPELOCAL,
 		Ready: st.Ready,
 		Path:  st.Path,
 	}
 
+	if err := podVolumeDataSet(ctx, pod, name, spec, pv); err != nil {
+		return pv, err
+	}
+
+	return pv, nil
+}
+
+// podVolumeDataSet writes secret or config files into pod volume
+// and keeps data source in volume status to refresh files on changes
+func podVolumeDataSet(ctx context.Context, pod, name string, spec *types.SpecTemplateVolume, pv *types.PodVolume) error {
+
 	if spec.Config.Name != types.EmptyString && len(spec.Config.Files) > 0 {
-		if err := VolumeSetConfigData(ctx, name, spec.Config.Name); err != nil {
+		pv.Config = configKeyResolve(pod, spec.Config.Name)
+		if err := VolumeSetConfigData(ctx, name, pv.Config); err != nil {
 			log.Errorf("can not set config data to volume: %s", err.Error())
-			return pv, err
+			return err
 		}
 	}
 
-	return pv, nil
+	if spec.Secret.Name != types.EmptyString && len(spec.Secret.Files) > 0 {
+		pv.Secret = secretKeyCreate(pod, spec.Secret.Name)
+		if err := VolumeSetSecretData(ctx, name, pv.Secret); err != nil {
+			log.Errorf("can not set secret data to volume: %s", err.Error())
+			return err
+		}
+	}
+
+	return nil
 }
 
 func PodVolumeDestroy(ctx context.Context, pod, volume string) error {
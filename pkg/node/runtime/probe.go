@@ -0,0 +1,361 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+
+package runtime
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/lastbackend/lastbackend/pkg/distribution/errors"
+	"github.com/lastbackend/lastbackend/pkg/distribution/types"
+	"github.com/lastbackend/lastbackend/pkg/log"
+	"github.com/lastbackend/lastbackend/pkg/node/envs"
+)
+
+const (
+	probeLive = "live"
+	probeRead = "read"
+
+	probeDefaultPeriod           = 10
+	probeDefaultTimeout          = 1
+	probeDefaultThresholdSuccess = 1
+	probeDefaultThresholdFailure = 3
+)
+
+var probeHTTPClient = &http.Client{
+	Transport: &http.Transport{
+		// probes check the application health, not the certificate chain
+		TLSClientConfig:   &tls.Config{InsecureSkipVerify: true},
+		DisableKeepAlives: true,
+	},
+}
+
+// PodProbesStart starts liveness and readiness checks for pod containers.
+// Checks are started only once per pod and restarted if containers probes are changed,
+// so it is safe to call it on every sync.
+func PodProbesStart(key string, manifest *types.PodManifest) {
+
+	spec := probesSpec(manifest)
+
+	if envs.Get().GetState().Probes().GetProbe(key) != nil {
+
+		if envs.Get().GetState().Probes().GetProbeSpec(key) == spec {
+			return
+		}
+
+		log.V(logLevel).Debugf("Pod probes changed: %s", key)
+		PodProbesStop(key)
+	}
+
+	var ctx, cancel = context.WithCancel(context.Background())
+	var probes = 0
+
+	for _, c := range manifest.Template.Containers {
+
+		name := containerNameCreate(key, c.Name)
+
+		if c.Probes.LiveProbe.Enabled() {
+			log.V(logLevel).Debugf("Start liveness probe: %s: %s", key, name)
+			go probeRun(ctx, key, name, probeLive, c.Probes.LiveProbe)
+			probes++
+		}
+
+		if c.Probes.ReadProbe.Enabled() {
+			log.V(logLevel).Debugf("Start readiness probe: %s: %s", key, name)
+			go probeRun(ctx, key, name, probeRead, c.Probes.ReadProbe)
+			probes++
+		}
+	}
+
+	if probes == 0 {
+		cancel()
+		return
+	}
+
+	envs.Get().GetState().Probes().SetProbe(key, &types.NodeTask{Cancel: cancel}, spec)
+}
+
+// probesSpec returns pod containers probes by container name encoded to compare with running probes
+func probesSpec(manifest *types.PodManifest) string {
+
+	spec := make(map[string]types.SpecTemplateContainerProbes)
+	for _, c := range manifest.Template.Containers {
+		spec[c.Name] = c.Probes
+	}
+
+	b, _ := json.Marshal(spec)
+	return string(b)
+}
+
+// PodProbesStop stops all pod containers checks
+func PodProbesStop(key string) {
+
+	task := envs.Get().GetState().Probes().GetProbe(key)
+	if task == nil {
+		return
+	}
+
+	log.V(logLevel).Debugf("Stop pod probes: %s", key)
+	task.Cancel()
+	envs.Get().GetState().Probes().DelProbe(key)
+}
+
+func probeRun(ctx context.Context, pod, container, kind string, probe types.SpecTemplateContainerProbe) {
+
+	var (
+		period  = probeDefaultPeriod
+		timeout = probeDefaultTimeout
+		success = probeDefaultThresholdSuccess
+		failure = probeDefaultThresholdFailure
+
+		successes, failures int
+	)
+
+	if probe.PeriodSeconds > 0 {
+		period = probe.PeriodSeconds
+	}
+
+	if probe.TimeoutSeconds > 0 {
+		timeout = probe.TimeoutSeconds
+	}
+
+	if probe.ThresholdSuccess > 0 {
+		success = probe.ThresholdSuccess
+	}
+
+	if probe.ThresholdFailure > 0 {
+		failure = probe.ThresholdFailure
+	}
+
+	if !probeWait(ctx, time.Duration(probe.InitialDelaySeconds)*time.Second) {
+		return
+	}
+
+	ticker := time.NewTicker(time.Duration(period) * time.Second)
+	defer ticker.Stop()
+
+	for {
+
+		if err := probeCheck(ctx, pod, container, probe, time.Duration(timeout)*time.Second); err != nil {
+
+			if ctx.Err() != nil {
+				return
+			}
+
+			log.V(logLevel).Debugf("Probe %s failed: %s: %s: %s", kind, pod, container, err.Error())
+			successes = 0
+			failures++
+		} else {
+			failures = 0
+			successes++
+		}
+
+		switch kind {
+		case probeLive:
+			if failures >= failure {
+				log.Warnf("Liveness probe failed %d times, restart pod: %s", failures, pod)
+				failures = 0
+
+				if err := PodRestart(ctx, pod); err != nil {
+					log.Errorf("Can not restart pod %s: %s", pod, err.Error())
+				}
+
+				if !probeWait(ctx, time.Duration(probe.InitialDelaySeconds)*time.Second) {
+					return
+				}
+			}
+		case probeRead:
+			if successes >= success {
+				probeSetReady(pod, container, true)
+			}
+
+			if failures >= failure {
+				probeSetReady(pod, container, false)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func probeWait(ctx context.Context, delay time.Duration) bool {
+
+	if delay <= 0 {
+		return ctx.Err() == nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+func probeCheck(ctx context.Context, pod, container string, probe types.SpecTemplateContainerProbe, timeout time.Duration) error {
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	status := envs.Get().GetState().Pods().GetPod(pod)
+	if status == nil {
+		return errors.New("pod not found")
+	}
+
+	switch true {
+	case len(probe.Exec.Command) > 0:
+		return probeExec(ctx, pod, container, probe.Exec.Command)
+	case probe.Socket.Port > 0:
+		return probeSocket(status, probe.Socket.Protocol, probe.Socket.Port, timeout)
+	case probe.HTTP.Port > 0:
+		return probeHTTP(ctx, status, probe)
+	}
+
+	return nil
+}
+
+func probeExec(ctx context.Context, pod, container string, cmd []string) error {
+
+	c := envs.Get().GetState().Pods().GetPodContainer(pod, container)
+	if c == nil {
+		return errors.New("container not found")
+	}
+
+	code, err := envs.Get().GetCRI().Exec(ctx, c.ID, cmd)
+	if err != nil {
+		return err
+	}
+
+	if code != 0 {
+		return fmt.Errorf("command exited with code %d", code)
+	}
+
+	return nil
+}
+
+func probeSocket(status *types.PodStatus, protocol string, port int, timeout time.Duration) error {
+
+	if status.Network.PodIP == types.EmptyString {
+		return errors.New("pod ip is not set")
+	}
+
+	if protocol == types.EmptyString {
+		protocol = "tcp"
+	}
+
+	conn, err := net.DialTimeout(protocol, net.JoinHostPort(status.Network.PodIP, strconv.Itoa(port)), timeout)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if protocol != "udp" {
+		return nil
+	}
+
+	// udp is connectionless: the port is treated as closed
+	// only if the host responds with port unreachable error
+	conn.SetDeadline(time.Now().Add(timeout))
+	if _, err := conn.Write([]byte{}); err != nil {
+		return err
+	}
+
+	if _, err := conn.Read(make([]byte, 1)); err != nil {
+		if e, ok := err.(net.Error); ok && e.Timeout() {
+			return nil
+		}
+		return err
+	}
+
+	return nil
+}
+
+func probeHTTP(ctx context.Context, status *types.PodStatus, probe types.SpecTemplateContainerProbe) error {
+
+	if status.Network.PodIP == types.EmptyString {
+		return errors.New("pod ip is not set")
+	}
+
+	var (
+		scheme = "http"
+		path   = "/"
+		min    = types.SpecProbeHTTPStatusMin
+		max    = types.SpecProbeHTTPStatusMax
+	)
+
+	if probe.HTTP.Scheme != types.EmptyString {
+		scheme = probe.HTTP.Scheme
+	}
+
+	if probe.HTTP.Path != types.EmptyString {
+		path = probe.HTTP.Path
+	}
+
+	if probe.HTTP.Status.Min > 0 {
+		min = probe.HTTP.Status.Min
+	}
+
+	if probe.HTTP.Status.Max > 0 {
+		max = probe.HTTP.Status.Max
+	}
+
+	url := fmt.Sprintf("%s://%s%s", scheme, net.JoinHostPort(status.Network.PodIP, strconv.Itoa(probe.HTTP.Port)), path)
+
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+
+	for k, v := range probe.HTTP.Headers {
+		if http.CanonicalHeaderKey(k) == "Host" {
+			req.Host = v
+			continue
+		}
+		req.Header.Set(k, v)
+	}
+
+	res, err := probeHTTPClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < min || res.StatusCode > max {
+		return fmt.Errorf("unexpected response status %d", res.StatusCode)
+	}
+
+	return nil
+}
+
+func probeSetReady(pod, container string, ready bool) {
+	envs.Get().GetState().Pods().SetContainerReady(pod, container, ready)
+}
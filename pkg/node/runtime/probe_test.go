@@ -0,0 +1,287 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+
+package runtime
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lastbackend/lastbackend/pkg/distribution/types"
+	"github.com/lastbackend/lastbackend/pkg/node/envs"
+	"github.com/lastbackend/lastbackend/pkg/node/state"
+	"github.com/lastbackend/lastbackend/pkg/runtime/cri"
+	"github.com/stretchr/testify/assert"
+)
+
+const probeTestTimeout = 5 * time.Second
+
+// fakeProbeCRI fails exec in unhealthy containers and records restarted containers
+type fakeProbeCRI struct {
+	cri.CRI
+	lock      sync.Mutex
+	unhealthy map[string]bool
+	restarted chan string
+}
+
+func (c *fakeProbeCRI) Exec(ctx context.Context, ID string, cmd []string) (int, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.unhealthy[ID] || cmd[0] != "ok" {
+		return 1, nil
+	}
+
+	return 0, nil
+}
+
+func (c *fakeProbeCRI) Restart(ctx context.Context, ID string, timeout *time.Duration) error {
+	select {
+	case c.restarted <- ID:
+	default:
+	}
+	return nil
+}
+
+// probeTarget is service checked by probe, unhealthy target fails probe
+type probeTarget struct {
+	probe     types.SpecTemplateContainerProbe
+	unhealthy func()
+	close     func()
+}
+
+func probeExecTarget(t *testing.T, fake *fakeProbeCRI, container string) *probeTarget {
+
+	pt := new(probeTarget)
+	pt.probe.Exec.Command = []string{"ok"}
+	pt.unhealthy = func() {
+		fake.lock.Lock()
+		defer fake.lock.Unlock()
+		fake.unhealthy[container] = true
+	}
+	pt.close = func() {}
+
+	return pt
+}
+
+func probeSocketTarget(t *testing.T, fake *fakeProbeCRI, container string) *probeTarget {
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen err: %v", err)
+	}
+
+	pt := new(probeTarget)
+	pt.probe.Socket.Port = l.Addr().(*net.TCPAddr).Port
+	pt.unhealthy = func() { l.Close() }
+	pt.close = func() { l.Close() }
+
+	return pt
+}
+
+func probeHTTPTarget(t *testing.T, fake *fakeProbeCRI, container string) *probeTarget {
+
+	var (
+		lock   sync.Mutex
+		status = http.StatusOK
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		defer lock.Unlock()
+		w.WriteHeader(status)
+	}))
+
+	u, _ := url.Parse(srv.URL)
+	port, _ := strconv.Atoi(u.Port())
+
+	pt := new(probeTarget)
+	pt.probe.HTTP.Port = port
+	pt.unhealthy = func() {
+		lock.Lock()
+		defer lock.Unlock()
+		status = http.StatusInternalServerError
+	}
+	pt.close = srv.Close
+
+	return pt
+}
+
+func probePodSet(pod, container string) {
+
+	status := &types.PodStatus{
+		Containers: map[string]*types.PodContainer{
+			container: {ID: container, Pod: pod, Name: container},
+		},
+	}
+	status.Network.PodIP = "127.0.0.1"
+	status.Containers[container].State.Started.Started = true
+
+	envs.Get().GetState().Pods().SetPod(pod, status)
+}
+
+func probeWaitReady(t *testing.T, pod, container string, ready bool) bool {
+
+	timeout := time.After(probeTestTimeout)
+
+	for {
+		c := envs.Get().GetState().Pods().GetPodContainer(pod, container)
+		if c != nil && c.Ready == ready {
+			return true
+		}
+
+		select {
+		case <-timeout:
+			t.Errorf("container ready state is not changed to %t", ready)
+			return false
+		case <-time.After(50 * time.Millisecond):
+		}
+	}
+}
+
+func TestProbeRun(t *testing.T) {
+
+	fake := &fakeProbeCRI{unhealthy: make(map[string]bool), restarted: make(chan string, 10)}
+
+	envs.Get().SetState(state.New())
+	envs.Get().SetCRI(fake)
+
+	var tests = []struct {
+		name   string
+		target func(t *testing.T, fake *fakeProbeCRI, container string) *probeTarget
+	}{
+		{"exec", probeExecTarget},
+		{"socket", probeSocketTarget},
+		{"http", probeHTTPTarget},
+	}
+
+	for _, tc := range tests {
+
+		t.Run("check readiness changed by "+tc.name+" probe", func(t *testing.T) {
+
+			var (
+				pod       = "demo:svc:dp:" + tc.name
+				container = tc.name + "-read"
+				target    = tc.target(t, fake, container)
+			)
+			defer target.close()
+
+			probePodSet(pod, container)
+
+			target.probe.PeriodSeconds = 1
+			target.probe.ThresholdFailure = 1
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			go probeRun(ctx, pod, container, probeRead, target.probe)
+
+			if !probeWaitReady(t, pod, container, true) {
+				return
+			}
+
+			target.unhealthy()
+			probeWaitReady(t, pod, container, false)
+		})
+
+		t.Run("check liveness restarts pod by "+tc.name+" probe", func(t *testing.T) {
+
+			var (
+				pod       = "demo:svc:dp:" + tc.name
+				container = tc.name + "-live"
+				target    = tc.target(t, fake, container)
+			)
+			defer target.close()
+
+			probePodSet(pod, container)
+
+			target.unhealthy()
+			target.probe.PeriodSeconds = 1
+			target.probe.ThresholdFailure = 1
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			go probeRun(ctx, pod, container, probeLive, target.probe)
+
+			timeout := time.After(probeTestTimeout)
+			for {
+				select {
+				case id := <-fake.restarted:
+					if id == container {
+						return
+					}
+				case <-timeout:
+					t.Error("pod is not restarted")
+					return
+				}
+			}
+		})
+	}
+}
+
+func TestPodProbesStart(t *testing.T) {
+
+	var (
+		pod       = "demo:svc:dp:pod"
+		container = containerNameCreate(pod, "app")
+		fake      = &fakeProbeCRI{unhealthy: make(map[string]bool), restarted: make(chan string, 10)}
+	)
+
+	envs.Get().SetState(state.New())
+	envs.Get().SetCRI(fake)
+
+	probePodSet(pod, container)
+	defer PodProbesStop(pod)
+
+	manifest := func(cmd string) *types.PodManifest {
+		c := new(types.SpecTemplateContainer)
+		c.Name = "app"
+		c.Probes.ReadProbe.Exec.Command = []string{cmd}
+		c.Probes.ReadProbe.PeriodSeconds = 1
+		c.Probes.ReadProbe.ThresholdFailure = 1
+
+		mf := new(types.PodManifest)
+		mf.Template.Containers = append(mf.Template.Containers, c)
+		return mf
+	}
+
+	PodProbesStart(pod, manifest("ok"))
+	if !probeWaitReady(t, pod, container, true) {
+		return
+	}
+
+	task := envs.Get().GetState().Probes().GetProbe(pod)
+	spec := envs.Get().GetState().Probes().GetProbeSpec(pod)
+
+	PodProbesStart(pod, manifest("ok"))
+	assert.Equal(t, spec, envs.Get().GetState().Probes().GetProbeSpec(pod), "probes should not be restarted")
+	assert.NotNil(t, task, "probes should be started")
+
+	// probe command is changed: probes are restarted with failing command
+	PodProbesStart(pod, manifest("fail"))
+	assert.NotEqual(t, spec, envs.Get().GetState().Probes().GetProbeSpec(pod), "probes should be restarted")
+	probeWaitReady(t, pod, container, false)
+}
@@ -201,6 +201,14 @@ func (r *Runtime) Loop(ctx context.Context) {
 						}
 					}
 
+					log.V(logLevel).Debugf("%s> clean up builds", logNodeRuntimePrefix)
+					builds := envs.Get().GetState().Builds().GetBuilds()
+					for b := range builds {
+						if _, ok := spec.Builds[b]; !ok {
+							envs.Get().GetState().Builds().DelBuild(b)
+						}
+					}
+
 					log.V(logLevel).Debugf("%s> clean up networks", logNodeRuntimePrefix)
 					nets := envs.Get().GetState().Networks().GetSubnets()
 
@@ -229,6 +237,9 @@ func (r *Runtime) Loop(ctx context.Context) {
 				log.V(logLevel).Debugf("%s> update secrets", logNodeRuntimePrefix)
 				for s, spec := range spec.Secrets {
 					log.V(logLevel).Debugf("secret: %s > %s", s, spec.State)
+					if err := SecretManage(ctx, s, spec); err != nil {
+						log.Errorf("Secret [%s] manage err: %s", s, err.Error())
+					}
 				}
 
 
@@ -256,6 +267,14 @@ func (r *Runtime) Loop(ctx context.Context) {
 					}
 				}
 
+				log.V(logLevel).Debugf("%s> provision builds", logNodeRuntimePrefix)
+				for b, spec := range spec.Builds {
+					log.V(logLevel).Debugf("build: %s > %s", b, spec.State)
+					if err := BuildManage(ctx, b, spec); err != nil {
+						log.Errorf("Build [%s] manage err: %s", b, err.Error())
+					}
+				}
+
 				log.V(logLevel).Debugf("%s> provision volumes", logNodeRuntimePrefix)
 				for _, v := range spec.Volumes {
 					log.V(logLevel).Debugf("volume: %v", v)
@@ -20,56 +20,62 @@ package runtime
 
 import (
 	"context"
+	"fmt"
+	"strings"
+
+	"github.com/lastbackend/lastbackend/pkg/distribution/errors"
 	"github.com/lastbackend/lastbackend/pkg/distribution/types"
 	"github.com/lastbackend/lastbackend/pkg/log"
 	"github.com/lastbackend/lastbackend/pkg/node/envs"
 )
 
-func SecretGet(ctx context.Context, name string) (*types.Secret, error) {
-	secret := envs.Get().GetState().Secrets().GetSecret(name)
-	if secret != nil {
-		return secret, nil
-	}
+func SecretManage(ctx context.Context, name string, spec *types.SecretManifest) error {
 
-	sr, err := envs.Get().GetRestClient().Secret(name).Get(ctx)
-	if err != nil {
-		log.Errorf("can not receive secret from api, err: %s", err.Error())
-		return nil, err
+	if spec.State == types.StateDestroyed {
+		SecretRemove(ctx, name)
+		return nil
 	}
 
-	return sr.Decode(), nil
+	return SecretSet(ctx, name, spec)
 }
 
-func SecretCreate(ctx context.Context, name string) error {
+// SecretGet returns secret from pod namespace
+func SecretGet(ctx context.Context, pod, name string) (*types.Secret, error) {
 
-	ok := envs.Get().GetState().Secrets().GetSecret(name)
-	if ok != nil {
-		return nil
-	}
+	key := secretKeyCreate(pod, name)
 
-	secret, err := envs.Get().GetRestClient().Secret(name).Get(ctx)
-	if err != nil {
-		log.Errorf("get secret err: %s", err.Error())
-		return err
+	secret := envs.Get().GetState().Secrets().GetSecret(key)
+	if secret == nil {
+		log.Errorf("secret %s not found", key)
+		return nil, errors.New("secret not found")
 	}
 
-	envs.Get().GetState().Secrets().AddSecret(secret.Meta.Name, secret.Decode())
-	return nil
+	return secret, nil
 }
 
-func SecretUpdate(ctx context.Context, name string) error {
+func SecretSet(ctx context.Context, name string, spec *types.SecretManifest) error {
 
-	secret, err := envs.Get().GetRestClient().Secret(name).Get(ctx)
-	if err != nil {
-		log.Errorf("get secret err: %s", err.Error())
-		return err
+	secret := new(types.Secret)
+	secret.Meta.SelfLink = name
+	secret.Meta.Kind = spec.Kind
+	secret.Meta.Created = spec.Created
+	secret.Meta.Updated = spec.Updated
+	secret.Data = spec.Data
+
+	prev := envs.Get().GetState().Secrets().GetSecret(name)
+	envs.Get().GetState().Secrets().SetSecret(name, secret)
+
+	if prev != nil && !prev.Meta.Updated.Equal(secret.Meta.Updated) {
+		VolumesRefresh(ctx, types.KindSecret, name)
 	}
 
-	envs.Get().GetState().Secrets().AddSecret(secret.Meta.Name, secret.Decode())
 	return nil
-
 }
 
 func SecretRemove(ctx context.Context, name string) {
 	envs.Get().GetState().Secrets().DelSecret(name)
 }
+
+func secretKeyCreate(pod, name string) string {
+	return fmt.Sprintf("%s:%s", strings.Split(pod, ":")[0], name)
+}
@@ -0,0 +1,130 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+
+package runtime
+
+import (
+	"context"
+	"time"
+
+	"github.com/lastbackend/lastbackend/pkg/distribution/types"
+	"github.com/lastbackend/lastbackend/pkg/log"
+	"github.com/lastbackend/lastbackend/pkg/node/envs"
+)
+
+const (
+	logStatsPrefix = "node:runtime:stats"
+	statsInterval  = 30 * time.Second
+)
+
+// StatsLoop periodically collects resources usage of pods containers
+func (r *Runtime) StatsLoop(ctx context.Context) {
+
+	log.V(logLevel).Debugf("%s:loop:> start stats loop", logStatsPrefix)
+
+	ticker := time.NewTicker(statsInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			StatsCollect(ctx)
+		}
+	}
+}
+
+// StatsCollect reads running containers stats and calculates pods resources usage.
+// CPU usage is calculated between two sequential samples, so pod usage
+// is stored only when all pod containers have previous sample.
+func StatsCollect(ctx context.Context) {
+
+	var (
+		state      = envs.Get().GetState()
+		pods       = make(map[string]bool, 0)
+		containers = make(map[string]bool, 0)
+	)
+
+	for key, pod := range state.Pods().GetPods() {
+
+		if !pod.Running {
+			continue
+		}
+
+		var (
+			usage = new(types.PodUsage)
+			ready = true
+		)
+
+		usage.Containers = make(map[string]types.PodContainerUsage, 0)
+		usage.Updated = time.Now().UTC()
+
+		for _, c := range pod.Containers {
+
+			if !c.State.Started.Started {
+				continue
+			}
+
+			stats, err := envs.Get().GetCRI().Stats(ctx, c.ID)
+			if err != nil {
+				log.Errorf("%s:collect:> get container %s stats err: %v", logStatsPrefix, c.ID, err)
+				ready = false
+				continue
+			}
+
+			containers[c.ID] = true
+
+			prev := state.Stats().GetContainerStats(c.ID)
+			state.Stats().SetContainerStats(c.ID, stats)
+
+			if prev == nil || !stats.Timestamp.After(prev.Timestamp) || stats.CPUTotal < prev.CPUTotal {
+				ready = false
+				continue
+			}
+
+			period := stats.Timestamp.Sub(prev.Timestamp).Nanoseconds()
+
+			usage.Containers[c.Name] = types.PodContainerUsage{
+				CPU:        int64((stats.CPUTotal - prev.CPUTotal) * 1000 / uint64(period)),
+				RAM:        int64(stats.Memory / 1024 / 1024),
+				NetworkRx:  statsRate(stats.NetworkRx, prev.NetworkRx, period),
+				NetworkTx:  statsRate(stats.NetworkTx, prev.NetworkTx, period),
+				BlockRead:  statsRate(stats.BlockRead, prev.BlockRead, period),
+				BlockWrite: statsRate(stats.BlockWrite, prev.BlockWrite, period),
+			}
+		}
+
+		pods[key] = true
+
+		if ready && len(usage.Containers) > 0 {
+			state.Stats().SetPodUsage(key, usage)
+		}
+	}
+
+	state.Stats().Clean(pods, containers)
+}
+
+// statsRate returns per second rate of counter between two samples,
+// counters are reset on container restart, so decreased counter gives zero rate
+func statsRate(current, previous uint64, period int64) int64 {
+	if current < previous || period <= 0 {
+		return 0
+	}
+	return int64(float64(current-previous) / time.Duration(period).Seconds())
+}
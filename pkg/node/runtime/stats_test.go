@@ -0,0 +1,167 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+
+package runtime
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/lastbackend/lastbackend/pkg/distribution/types"
+	"github.com/lastbackend/lastbackend/pkg/node/envs"
+	"github.com/lastbackend/lastbackend/pkg/node/state"
+	"github.com/lastbackend/lastbackend/pkg/runtime/cri"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeStatsCRI returns prepared container stats sample
+type fakeStatsCRI struct {
+	cri.CRI
+	stats *types.ContainerStats
+}
+
+func (c *fakeStatsCRI) Stats(ctx context.Context, ID string) (*types.ContainerStats, error) {
+	s := *c.stats
+	return &s, nil
+}
+
+func TestStatsRate(t *testing.T) {
+
+	var tests = []struct {
+		name     string
+		current  uint64
+		previous uint64
+		period   time.Duration
+		want     int64
+	}{
+		{
+			name:     "check rate per second",
+			current:  3000,
+			previous: 1000,
+			period:   2 * time.Second,
+			want:     1000,
+		},
+		{
+			name:     "check rate of not changed counter",
+			current:  1000,
+			previous: 1000,
+			period:   time.Second,
+			want:     0,
+		},
+		{
+			name:     "check counter reset gives zero rate",
+			current:  100,
+			previous: 5000,
+			period:   time.Second,
+			want:     0,
+		},
+		{
+			name:     "check zero period gives zero rate",
+			current:  3000,
+			previous: 1000,
+			period:   0,
+			want:     0,
+		},
+		{
+			name:     "check negative period gives zero rate",
+			current:  3000,
+			previous: 1000,
+			period:   -time.Second,
+			want:     0,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, statsRate(tc.current, tc.previous, tc.period.Nanoseconds()), "rate not equal")
+		})
+	}
+}
+
+func TestStatsCollect(t *testing.T) {
+
+	var (
+		ctx  = context.Background()
+		pod  = "demo:redis:redis-1:pod"
+		now  = time.Now().UTC()
+		fake = &fakeStatsCRI{}
+	)
+
+	envs.Get().SetState(state.New())
+	envs.Get().SetCRI(fake)
+
+	status := &types.PodStatus{
+		Running: true,
+		Containers: map[string]*types.PodContainer{
+			"container": {ID: "container", Pod: pod, Name: "redis"},
+		},
+	}
+	status.Containers["container"].State.Started.Started = true
+	envs.Get().GetState().Pods().SetPod(pod, status)
+
+	var tests = []struct {
+		name  string
+		stats types.ContainerStats
+		want  *types.PodContainerUsage
+		count int
+	}{
+		{
+			name:  "check first sample is not used for pod usage",
+			stats: types.ContainerStats{CPUTotal: 1e9, Memory: 64 << 20, NetworkRx: 1000, BlockRead: 2000, Timestamp: now},
+			count: 0,
+		},
+		{
+			name:  "check usage calculated between samples",
+			stats: types.ContainerStats{CPUTotal: 2e9, Memory: 128 << 20, NetworkRx: 3000, BlockRead: 6000, Timestamp: now.Add(2 * time.Second)},
+			want:  &types.PodContainerUsage{CPU: 500, RAM: 128, NetworkRx: 1000, BlockRead: 2000},
+			count: 1,
+		},
+		{
+			name:  "check network counter reset gives zero rate",
+			stats: types.ContainerStats{CPUTotal: 3e9, Memory: 128 << 20, NetworkRx: 100, BlockRead: 8000, Timestamp: now.Add(4 * time.Second)},
+			want:  &types.PodContainerUsage{CPU: 500, RAM: 128, NetworkRx: 0, BlockRead: 1000},
+			count: 2,
+		},
+		{
+			name:  "check cpu counter reset skips sample",
+			stats: types.ContainerStats{CPUTotal: 1e8, Memory: 32 << 20, Timestamp: now.Add(6 * time.Second)},
+			count: 2,
+		},
+		{
+			name:  "check sample with previous timestamp is skipped",
+			stats: types.ContainerStats{CPUTotal: 2e9, Memory: 32 << 20, Timestamp: now.Add(5 * time.Second)},
+			count: 2,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+
+			fake.stats = &tc.stats
+			StatsCollect(ctx)
+
+			history := envs.Get().GetState().Stats().GetPodUsageHistory(pod)
+			if !assert.Len(t, history, tc.count, "history length not equal") || tc.want == nil {
+				return
+			}
+
+			assert.Equal(t, *tc.want, history[len(history)-1].Containers["redis"], "usage not equal")
+		})
+	}
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/lastbackend/lastbackend/pkg/distribution/types"
 	"github.com/lastbackend/lastbackend/pkg/log"
 	"github.com/lastbackend/lastbackend/pkg/node/envs"
+	"github.com/lastbackend/lastbackend/pkg/runtime/csi"
 )
 
 func VolumeCreate(ctx context.Context, name string, mf *types.VolumeManifest) (*types.VolumeState, error) {
@@ -117,47 +118,109 @@ func VolumeRestore(ctx context.Context) error {
 	return nil
 }
 
-func VolumeSetSecretData (ctx context.Context, name string, secret string) error {
-	return nil
+func VolumeSetSecretData(ctx context.Context, name string, secret string) error {
+
+	log.Debugf("set volume secret data: %s > %s", secret, name)
+
+	vol, si, err := volumeFilesInterface(name)
+	if err != nil {
+		return err
+	}
+
+	files, err := secretFiles(secret)
+	if err != nil {
+		return err
+	}
+
+	return si.FilesPut(ctx, vol, files)
 }
 
 func VolumeCheckSecretData(ctx context.Context, name string, secret string) (bool, error) {
+
 	log.Debugf("volume check secret data: %s > %s", secret, name)
-	return true, nil
+
+	vol, si, err := volumeFilesInterface(name)
+	if err != nil {
+		return false, err
+	}
+
+	files, err := secretFiles(secret)
+	if err != nil {
+		return false, err
+	}
+
+	return si.FilesCheck(ctx, vol, files)
 }
 
 func VolumeCheckConfigData(ctx context.Context, name string, config string) (bool, error) {
+
 	log.Debugf("volume check config data: %s > %s", config, name)
 
-	vol := envs.Get().GetState().Volumes().GetVolume(name)
-	cfg := envs.Get().GetState().Configs().GetConfig(config)
+	vol, si, err := volumeFilesInterface(name)
+	if err != nil {
+		return false, err
+	}
 
-	if vol == nil {
-		return  false, errors.New("volume not exists")
+	files, err := configFiles(config)
+	if err != nil {
+		return false, err
 	}
 
-	if vol.Type == types.EmptyString {
-		vol.Type = types.VOLUMETYPELOCAL
+	return si.FilesCheck(ctx, vol, files)
+}
+
+func VolumeSetConfigData(ctx context.Context, name string, config string) error {
+
+	log.Debugf("set volume config data: %s > %s", config, name)
+
+	vol, si, err := volumeFilesInterface(name)
+	if err != nil {
+		return err
 	}
 
-	si, err := envs.Get().GetCSI(vol.Type)
+	files, err := configFiles(config)
 	if err != nil {
-		log.Errorf("Remove volume failed: %s", err.Error())
-		return false, err
+		return err
 	}
 
-	return si.FilesCheck(ctx, vol, cfg.Data)
+	return si.FilesPut(ctx, vol, files)
 }
 
-func VolumeSetConfigData (ctx context.Context, name string, config string) error {
+// VolumesRefresh rewrites files in pods volumes mounted from changed secret or config
+func VolumesRefresh(ctx context.Context, kind, name string) {
 
-	log.Debugf("set volume config data: %s > %s", config, name)
+	for pod, status := range envs.Get().GetState().Pods().GetPods() {
+		for _, v := range status.Volumes {
 
-	vol := envs.Get().GetState().Volumes().GetVolume(name)
-	cfg := envs.Get().GetState().Configs().GetConfig(config)
+			if v == nil {
+				continue
+			}
+
+			var err error
+
+			switch true {
+			case kind == types.KindSecret && v.Secret == name:
+				log.V(logLevel).Debugf("Refresh pod %s volume %s secret data", pod, v.Name)
+				err = VolumeSetSecretData(ctx, podVolumeKeyCreate(pod, v.Name), name)
+			case kind == types.KindConfig && v.Config == name:
+				log.V(logLevel).Debugf("Refresh pod %s volume %s config data", pod, v.Name)
+				err = VolumeSetConfigData(ctx, podVolumeKeyCreate(pod, v.Name), name)
+			default:
+				continue
+			}
 
+			if err != nil {
+				log.Errorf("can not refresh pod %s volume %s data: %s", pod, v.Name, err.Error())
+			}
+		}
+	}
+}
+
+func volumeFilesInterface(name string) (*types.VolumeState, csi.CSI, error) {
+
+	vol := envs.Get().GetState().Volumes().GetVolume(name)
 	if vol == nil {
-		return errors.New("volume not exists")
+		return nil, nil, errors.New("volume not exists")
 	}
 
 	if vol.Type == types.EmptyString {
@@ -166,9 +229,53 @@ func VolumeSetConfigData (ctx context.Context, name string, config string) error
 
 	si, err := envs.Get().GetCSI(vol.Type)
 	if err != nil {
-		log.Errorf("Remove volume failed: %s", err.Error())
-		return err
+		log.Errorf("Get volume storage interface failed: %s", err.Error())
+		return nil, nil, err
+	}
+
+	return vol, si, nil
+}
+
+func secretFiles(name string) (map[string][]byte, error) {
+
+	secret := envs.Get().GetState().Secrets().GetSecret(name)
+	if secret == nil {
+		return nil, errors.New("secret not exists")
+	}
+
+	files := make(map[string][]byte, 0)
+	for key, data := range secret.Data {
+
+		if secret.Meta.Kind != types.KindSecretText {
+			files[key] = data
+			continue
+		}
+
+		value, err := secret.DecodeSecretTextData(key)
+		if err != nil {
+			return nil, err
+		}
+		files[key] = []byte(value)
+	}
+
+	return files, nil
+}
+
+func configFiles(name string) (map[string][]byte, error) {
+
+	cfg := envs.Get().GetState().Configs().GetConfig(name)
+	if cfg == nil {
+		return nil, errors.New("config not exists")
+	}
+
+	files := make(map[string][]byte, 0)
+	for key := range cfg.Data {
+		value, err := cfg.GetValue(key)
+		if err != nil {
+			return nil, err
+		}
+		files[key] = []byte(value)
 	}
 
-	return si.FilesPut(ctx, vol, cfg.Data)
+	return files, nil
 }
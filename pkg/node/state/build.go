@@ -0,0 +1,191 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+
+package state
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"sync"
+
+	"github.com/lastbackend/lastbackend/pkg/distribution/types"
+	"github.com/lastbackend/lastbackend/pkg/log"
+)
+
+type BuildState struct {
+	lock   sync.RWMutex
+	builds map[string]*types.BuildStatus
+	cancel map[string]context.CancelFunc
+	logs   map[string]*BuildLog
+}
+
+func (s *BuildState) GetBuilds() map[string]*types.BuildStatus {
+	log.V(logLevel).Debug("Cache: BuildCache: get builds")
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	builds := make(map[string]*types.BuildStatus, len(s.builds))
+	for k, b := range s.builds {
+		status := *b
+		builds[k] = &status
+	}
+	return builds
+}
+
+func (s *BuildState) GetBuild(key string) *types.BuildStatus {
+	log.V(logLevel).Debugf("Cache: BuildCache: get build: %s", key)
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	b, ok := s.builds[key]
+	if !ok {
+		return nil
+	}
+	status := *b
+	return &status
+}
+
+func (s *BuildState) SetBuild(key string, status *types.BuildStatus) {
+	log.V(logLevel).Debugf("Cache: BuildCache: set build: %s > %s", key, status.State)
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.builds[key] = status
+}
+
+// DelStatus removes build status which is already delivered to api
+func (s *BuildState) DelStatus(key string) {
+	log.V(logLevel).Debugf("Cache: BuildCache: del build status: %s", key)
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	delete(s.builds, key)
+}
+
+func (s *BuildState) SetCancel(key string, cancel context.CancelFunc) {
+	log.V(logLevel).Debugf("Cache: BuildCache: set build cancel func: %s", key)
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.cancel[key] = cancel
+}
+
+func (s *BuildState) GetLog(key string) *BuildLog {
+	log.V(logLevel).Debugf("Cache: BuildCache: get build log: %s", key)
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.logs[key]
+}
+
+func (s *BuildState) SetLog(key string, l *BuildLog) {
+	log.V(logLevel).Debugf("Cache: BuildCache: set build log: %s", key)
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.logs[key] = l
+}
+
+// DelBuild removes build from state and cancels build if it is running
+func (s *BuildState) DelBuild(key string) {
+	log.V(logLevel).Debugf("Cache: BuildCache: del build: %s", key)
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if cancel, ok := s.cancel[key]; ok {
+		cancel()
+		delete(s.cancel, key)
+	}
+
+	if l, ok := s.logs[key]; ok {
+		l.Close()
+		delete(s.logs, key)
+	}
+
+	delete(s.builds, key)
+}
+
+// BuildLog stores build output and streams it to readers until build is finished
+type BuildLog struct {
+	lock   sync.Mutex
+	data   []byte
+	done   bool
+	notify chan struct{}
+}
+
+func (l *BuildLog) Write(p []byte) (int, error) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	l.data = append(l.data, p...)
+	close(l.notify)
+	l.notify = make(chan struct{})
+
+	return len(p), nil
+}
+
+// Close marks build log as finished, readers exit after all data is sent
+func (l *BuildLog) Close() error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if l.done {
+		return nil
+	}
+
+	l.done = true
+	close(l.notify)
+
+	return nil
+}
+
+// Stream writes build log into writer and follows it until build log is closed
+func (l *BuildLog) Stream(ctx context.Context, w io.Writer) error {
+
+	var offset int
+
+	for {
+		l.lock.Lock()
+		data := l.data[offset:]
+		done := l.done
+		notify := l.notify
+		l.lock.Unlock()
+
+		if len(data) > 0 {
+			if _, err := w.Write(data); err != nil {
+				return err
+			}
+
+			if f, ok := w.(http.Flusher); ok {
+				f.Flush()
+			}
+
+			offset += len(data)
+			continue
+		}
+
+		if done {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-notify:
+		}
+	}
+}
+
+func NewBuildLog() *BuildLog {
+	return &BuildLog{notify: make(chan struct{})}
+}
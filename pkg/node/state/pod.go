@@ -69,6 +69,18 @@ func (s *PodState) GetPods() map[string]*types.PodStatus {
 	return s.pods
 }
 
+// GetStatesCount returns count of pods by state
+func (s *PodState) GetStatesCount() map[string]int {
+	log.V(logLevel).Debugf("%s: get pods states count", logPodPrefix)
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	var states = make(map[string]int, 0)
+	for _, p := range s.pods {
+		states[p.State]++
+	}
+	return states
+}
+
 func (s *PodState) SetPods(pods map[string]*types.PodStatus) {
 	log.V(logLevel).Debugf("%s: set pods: %#v", logPodPrefix, pods)
 	for key, pod := range pods {
@@ -135,6 +147,37 @@ func (s *PodState) SetPod(key string, pod *types.PodStatus) {
 	s.dispatch(key)
 }
 
+// GetPodContainer returns copy of pod container state found by container name
+func (s *PodState) GetPodContainer(key, name string) *types.PodContainer {
+	log.V(logLevel).Debugf("%s: get pod %s container: %s", logPodPrefix, key, name)
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	c := podContainer(s.pods[key], name)
+	if c == nil {
+		return nil
+	}
+
+	cs := *c
+	return &cs
+}
+
+// SetContainerReady sets pod container ready state and notifies pod watchers if it is changed
+func (s *PodState) SetContainerReady(key, name string, ready bool) {
+	log.V(logLevel).Debugf("%s: set pod %s container %s ready: %t", logPodPrefix, key, name, ready)
+	s.lock.Lock()
+
+	c := podContainer(s.pods[key], name)
+	if c == nil || c.Ready == ready {
+		s.lock.Unlock()
+		return
+	}
+
+	c.Ready = ready
+	s.lock.Unlock()
+	s.dispatch(key)
+}
+
 func (s *PodState) DelPod(key string) {
 	log.V(logLevel).Debugf("%s: del pod: %s", logPodPrefix, key)
 	s.lock.Lock()
@@ -197,6 +240,22 @@ func (s *PodState) DelContainer(c *types.PodContainer) {
 	s.lock.Unlock()
 }
 
+// podContainer returns pod container by name, should be called under lock
+func podContainer(pod *types.PodStatus, name string) *types.PodContainer {
+
+	if pod == nil {
+		return nil
+	}
+
+	for _, c := range pod.Containers {
+		if c.Name == name {
+			return c
+		}
+	}
+
+	return nil
+}
+
 func state(s *types.PodStatus) {
 
 	var sts = make(map[string]int)
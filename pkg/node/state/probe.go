@@ -0,0 +1,72 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+
+package state
+
+import (
+	"sync"
+
+	"github.com/lastbackend/lastbackend/pkg/distribution/types"
+	"github.com/lastbackend/lastbackend/pkg/log"
+)
+
+const logProbePrefix = "state:probes:>"
+
+type ProbeState struct {
+	lock   sync.RWMutex
+	probes map[string]types.NodeTask
+	// specs - pod containers probes spec which probes are started with
+	specs map[string]string
+}
+
+func (s *ProbeState) GetProbe(pod string) *types.NodeTask {
+	log.V(logLevel).Debugf("%s: get pod probes: %s", logProbePrefix, pod)
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	t, ok := s.probes[pod]
+	if !ok {
+		return nil
+	}
+
+	return &t
+}
+
+// GetProbeSpec returns pod containers probes spec which probes are started with
+func (s *ProbeState) GetProbeSpec(pod string) string {
+	log.V(logLevel).Debugf("%s: get pod probes spec: %s", logProbePrefix, pod)
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.specs[pod]
+}
+
+func (s *ProbeState) SetProbe(pod string, task *types.NodeTask, spec string) {
+	log.V(logLevel).Debugf("%s: set pod probes: %s", logProbePrefix, pod)
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.probes[pod] = *task
+	s.specs[pod] = spec
+}
+
+func (s *ProbeState) DelProbe(pod string) {
+	log.V(logLevel).Debugf("%s: del pod probes: %s", logProbePrefix, pod)
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	delete(s.probes, pod)
+	delete(s.specs, pod)
+}
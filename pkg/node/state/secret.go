@@ -62,11 +62,7 @@ func (s *SecretsState) SetSecret(hash string, secret *types.Secret) {
 	s.lock.Lock()
 	defer s.lock.Unlock()
 
-	if _, ok := s.secrets[secret.SelfLink()]; ok {
-		delete(s.secrets, secret.SelfLink())
-	}
-
-	s.secrets[secret.GetHash()] = *secret
+	s.secrets[hash] = *secret
 }
 
 func (s *SecretsState) DelSecret(hash string) {
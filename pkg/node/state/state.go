@@ -19,6 +19,8 @@
 package state
 
 import (
+	"context"
+
 	"github.com/lastbackend/lastbackend/pkg/distribution/types"
 )
 
@@ -34,6 +36,9 @@ type State struct {
 	endpoints *EndpointState
 	task      *TaskState
 	configs    *ConfigState
+	probes    *ProbeState
+	stats     *StatsState
+	builds    *BuildState
 }
 
 func (s *State) Node() *NodeState {
@@ -72,6 +77,18 @@ func (s *State) Configs() *ConfigState {
 	return s.configs
 }
 
+func (s *State) Probes() *ProbeState {
+	return s.probes
+}
+
+func (s *State) Stats() *StatsState {
+	return s.stats
+}
+
+func (s *State) Builds() *BuildState {
+	return s.builds
+}
+
 type NodeState struct {
 	Info   types.NodeInfo
 	Status types.NodeStatus
@@ -108,6 +125,21 @@ func New() *State {
 		configs: &ConfigState{
 			configs: make(map[string]*types.ConfigManifest, 0),
 		},
+		probes: &ProbeState{
+			probes: make(map[string]types.NodeTask, 0),
+			specs:  make(map[string]string, 0),
+		},
+		stats: &StatsState{
+			containers: make(map[string]*types.ContainerStats, 0),
+			pods:       make(map[string]*types.PodUsage, 0),
+			history:    make(map[string][]*types.PodUsage, 0),
+			updated:    make(map[string]bool, 0),
+		},
+		builds: &BuildState{
+			builds: make(map[string]*types.BuildStatus, 0),
+			cancel: make(map[string]context.CancelFunc, 0),
+			logs:   make(map[string]*BuildLog, 0),
+		},
 	}
 
 
@@ -0,0 +1,157 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+
+package state
+
+import (
+	"sync"
+
+	"github.com/lastbackend/lastbackend/pkg/distribution/types"
+	"github.com/lastbackend/lastbackend/pkg/log"
+)
+
+const (
+	logStatsPrefix = "state:stats:>"
+	// statsWindow - count of pod usage samples kept in memory
+	statsWindow = 20
+)
+
+type StatsState struct {
+	lock       sync.RWMutex
+	containers map[string]*types.ContainerStats
+	pods       map[string]*types.PodUsage
+	history    map[string][]*types.PodUsage
+	updated    map[string]bool
+}
+
+func (s *StatsState) GetContainerStats(id string) *types.ContainerStats {
+	log.V(logLevel).Debugf("%s: get container stats: %s", logStatsPrefix, id)
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	c, ok := s.containers[id]
+	if !ok {
+		return nil
+	}
+	return c
+}
+
+func (s *StatsState) SetContainerStats(id string, stats *types.ContainerStats) {
+	log.V(logLevel).Debugf("%s: set container stats: %s", logStatsPrefix, id)
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.containers[id] = stats
+}
+
+func (s *StatsState) GetPodUsage(pod string) *types.PodUsage {
+	log.V(logLevel).Debugf("%s: get pod usage: %s", logStatsPrefix, pod)
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	u, ok := s.pods[pod]
+	if !ok {
+		return nil
+	}
+	return u
+}
+
+func (s *StatsState) SetPodUsage(pod string, usage *types.PodUsage) {
+	log.V(logLevel).Debugf("%s: set pod usage: %s", logStatsPrefix, pod)
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.pods[pod] = usage
+	s.updated[pod] = true
+
+	h := append(s.history[pod], usage)
+	if len(h) > statsWindow {
+		h = h[len(h)-statsWindow:]
+	}
+	s.history[pod] = h
+}
+
+// GetPodsUsage returns last usage of all pods
+func (s *StatsState) GetPodsUsage() map[string]*types.PodUsage {
+	log.V(logLevel).Debugf("%s: get pods usage", logStatsPrefix)
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	var usage = make(map[string]*types.PodUsage, 0)
+	for pod, u := range s.pods {
+		usage[pod] = u
+	}
+	return usage
+}
+
+// GetPodUsageHistory returns pod usage samples collected in stats window
+func (s *StatsState) GetPodUsageHistory(pod string) []*types.PodUsage {
+	log.V(logLevel).Debugf("%s: get pod usage history: %s", logStatsPrefix, pod)
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	h, ok := s.history[pod]
+	if !ok {
+		return nil
+	}
+	return append(make([]*types.PodUsage, 0, len(h)), h...)
+}
+
+// GetUsageHistory returns usage samples of all pods collected in stats window
+func (s *StatsState) GetUsageHistory() map[string][]*types.PodUsage {
+	log.V(logLevel).Debugf("%s: get usage history", logStatsPrefix)
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	var history = make(map[string][]*types.PodUsage, 0)
+	for pod, h := range s.history {
+		history[pod] = append(make([]*types.PodUsage, 0, len(h)), h...)
+	}
+	return history
+}
+
+// FlushPodUsage returns pods usage updated since previous flush
+func (s *StatsState) FlushPodUsage() map[string]*types.PodUsage {
+	log.V(logLevel).Debugf("%s: flush pods usage", logStatsPrefix)
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	var usage = make(map[string]*types.PodUsage, 0)
+	for pod := range s.updated {
+		if u, ok := s.pods[pod]; ok {
+			usage[pod] = u
+		}
+		delete(s.updated, pod)
+	}
+
+	return usage
+}
+
+// Clean removes stats of containers and pods which are not present on node anymore
+func (s *StatsState) Clean(pods, containers map[string]bool) {
+	log.V(logLevel).Debugf("%s: clean stats", logStatsPrefix)
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for id := range s.containers {
+		if !containers[id] {
+			delete(s.containers, id)
+		}
+	}
+
+	for pod := range s.pods {
+		if !pods[pod] {
+			delete(s.pods, pod)
+			delete(s.history, pod)
+			delete(s.updated, pod)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+
+package state
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lastbackend/lastbackend/pkg/distribution/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func getPodUsageAsset(cpu int64) *types.PodUsage {
+	u := new(types.PodUsage)
+	u.Containers = map[string]types.PodContainerUsage{"redis": {CPU: cpu}}
+	u.Updated = time.Now().UTC()
+	return u
+}
+
+func TestStatsStatePodUsageHistory(t *testing.T) {
+
+	var tests = []struct {
+		name    string
+		samples int
+		length  int
+		first   int64
+		last    int64
+	}{
+		{
+			name:    "check first sample",
+			samples: 1,
+			length:  1,
+			first:   1,
+			last:    1,
+		},
+		{
+			name:    "check samples within window",
+			samples: statsWindow - 1,
+			length:  statsWindow - 1,
+			first:   1,
+			last:    statsWindow - 1,
+		},
+		{
+			name:    "check samples fill window",
+			samples: statsWindow,
+			length:  statsWindow,
+			first:   1,
+			last:    statsWindow,
+		},
+		{
+			name:    "check oldest samples are dropped over window",
+			samples: statsWindow + 5,
+			length:  statsWindow,
+			first:   6,
+			last:    statsWindow + 5,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+
+			s := New().Stats()
+
+			for i := 1; i <= tc.samples; i++ {
+				s.SetPodUsage("pod", getPodUsageAsset(int64(i)))
+			}
+
+			history := s.GetPodUsageHistory("pod")
+			if !assert.Len(t, history, tc.length, "history length not equal") {
+				return
+			}
+
+			assert.Equal(t, tc.first, history[0].Containers["redis"].CPU, "first sample not equal")
+			assert.Equal(t, tc.last, history[len(history)-1].Containers["redis"].CPU, "last sample not equal")
+			assert.Equal(t, tc.last, s.GetPodUsage("pod").Containers["redis"].CPU, "pod usage is not last sample")
+
+			// returned history is a copy and can not change state window
+			history[0] = nil
+			assert.NotNil(t, s.GetPodUsageHistory("pod")[0], "history is not copied")
+		})
+	}
+}
+
+func TestStatsStateClean(t *testing.T) {
+
+	s := New().Stats()
+
+	s.SetContainerStats("c1", new(types.ContainerStats))
+	s.SetContainerStats("c2", new(types.ContainerStats))
+	s.SetPodUsage("p1", getPodUsageAsset(1))
+	s.SetPodUsage("p2", getPodUsageAsset(2))
+
+	s.Clean(map[string]bool{"p1": true}, map[string]bool{"c1": true})
+
+	assert.NotNil(t, s.GetContainerStats("c1"), "present container stats removed")
+	assert.Nil(t, s.GetContainerStats("c2"), "removed container stats kept")
+	assert.NotNil(t, s.GetPodUsage("p1"), "present pod usage removed")
+	assert.Nil(t, s.GetPodUsage("p2"), "removed pod usage kept")
+	assert.Nil(t, s.GetPodUsageHistory("p2"), "removed pod history kept")
+	assert.Len(t, s.GetUsageHistory(), 1, "usage history pods count not equal")
+
+	flushed := s.FlushPodUsage()
+	assert.Len(t, flushed, 1, "flushed pods count not equal")
+	assert.Contains(t, flushed, "p1", "present pod usage not flushed")
+	assert.Len(t, s.FlushPodUsage(), 0, "pod usage flushed twice")
+}
@@ -29,6 +29,17 @@ import (
 	"strconv"
 )
 
+const (
+	// cpu millicores in one core
+	cpuMillicores = 1000
+	// docker default cpu shares for one core
+	cpuSharesPerCore = 1024
+	// cpu cfs period in microseconds
+	cpuPeriod = 100000
+	// minimal cpu cfs quota in microseconds allowed by docker
+	cpuQuotaMin = 1000
+)
+
 func GetConfig(manifest *types.ContainerManifest) *container.Config {
 
 	var (
@@ -63,8 +74,24 @@ func GetHostConfig(manifest *types.ContainerManifest) *container.HostConfig {
 	}
 
 	resources := container.Resources{
-		Memory:    manifest.Resources.Request.RAM * 1024 * 1024,
-		CPUShares: manifest.Resources.Request.CPU,
+		Memory: manifest.Resources.Request.RAM * 1024 * 1024,
+	}
+
+	// cpu resources are set in millicores: 1000 is one core
+	if manifest.Resources.Request.CPU > 0 {
+		resources.CPUShares = manifest.Resources.Request.CPU * cpuSharesPerCore / cpuMillicores
+	}
+
+	// limit container cpu usage by quota, fallback to requested cpu if limit is not set
+	if cpu := manifest.Resources.Limits.CPU; cpu > 0 || manifest.Resources.Request.CPU > 0 {
+		if cpu == 0 {
+			cpu = manifest.Resources.Request.CPU
+		}
+		resources.CPUPeriod = cpuPeriod
+		resources.CPUQuota = cpu * cpuPeriod / cpuMillicores
+		if resources.CPUQuota < cpuQuotaMin {
+			resources.CPUQuota = cpuQuotaMin
+		}
 	}
 
 	var (
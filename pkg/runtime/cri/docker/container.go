@@ -20,10 +20,13 @@ package docker
 
 import (
 	"context"
+	"encoding/json"
 	docker "github.com/docker/docker/api/types"
 	"github.com/lastbackend/lastbackend/pkg/distribution/types"
 	"github.com/lastbackend/lastbackend/pkg/log"
+	"github.com/lastbackend/lastbackend/pkg/util/cleaner"
 	"io"
+	"io/ioutil"
 	"strconv"
 	"strings"
 	"time"
@@ -126,6 +129,7 @@ func (r *Runtime) Inspect(ctx context.Context, ID string) (*types.Container, err
 		Image:    info.Config.Image,
 		Status:   info.State.Status,
 		ExitCode: info.State.ExitCode,
+		OOMKilled: info.State.OOMKilled,
 		Labels:   info.Config.Labels,
 		Envs: info.Config.Env,
 		Binds: info.HostConfig.Binds,
@@ -200,3 +204,154 @@ func (r *Runtime) Copy(ctx context.Context, ID, path string, content io.Reader)
 		AllowOverwriteDirWithFile: true,
 	})
 }
+
+func (r *Runtime) Exec(ctx context.Context, ID string, cmd []string) (int, error) {
+
+	log.V(logLevel).Debugf("Docker: Container exec: %s: %v", ID, cmd)
+
+	exec, err := r.client.ContainerExecCreate(ctx, ID, docker.ExecConfig{
+		Cmd:          cmd,
+		AttachStdout: true,
+		AttachStderr: true,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	resp, err := r.client.ContainerExecAttach(ctx, exec.ID, docker.ExecConfig{})
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Close()
+
+	if _, err := io.Copy(ioutil.Discard, resp.Reader); err != nil {
+		return 0, err
+	}
+
+	info, err := r.client.ContainerExecInspect(ctx, exec.ID)
+	if err != nil {
+		return 0, err
+	}
+
+	return info.ExitCode, nil
+}
+
+// ExecCreate - https://docs.docker.com/engine/api/v1.29/#operation/ContainerExec
+func (r *Runtime) ExecCreate(ctx context.Context, ID string, exec *types.ContainerExec) (string, error) {
+
+	log.V(logLevel).Debugf("Docker: Container exec create: %s: %v", ID, exec.Command)
+
+	resp, err := r.client.ContainerExecCreate(ctx, ID, docker.ExecConfig{
+		Cmd:          exec.Command,
+		Tty:          exec.Tty,
+		AttachStdin:  exec.Stdin,
+		AttachStdout: true,
+		AttachStderr: true,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return resp.ID, nil
+}
+
+// ExecAttach - https://docs.docker.com/engine/api/v1.29/#operation/ExecStart
+func (r *Runtime) ExecAttach(ctx context.Context, ID string, tty bool, stdin io.Reader, stdout, stderr io.Writer) error {
+
+	log.V(logLevel).Debugf("Docker: Container exec attach: %s", ID)
+
+	resp, err := r.client.ContainerExecAttach(ctx, ID, docker.ExecConfig{Tty: tty})
+	if err != nil {
+		return err
+	}
+	defer resp.Close()
+
+	go func() {
+		<-ctx.Done()
+		resp.Close()
+	}()
+
+	if stdin != nil {
+		go func() {
+			if _, err := io.Copy(resp.Conn, stdin); err != nil {
+				log.V(logLevel).Debugf("Docker: Container exec stdin copy err: %s", err.Error())
+			}
+			resp.CloseWrite()
+		}()
+	}
+
+	// without tty docker multiplexes stdout and stderr into one stream
+	if tty {
+		_, err = io.Copy(stdout, resp.Reader)
+	} else {
+		err = cleaner.Demux(stdout, stderr, resp.Reader)
+	}
+
+	if err != nil && ctx.Err() == nil {
+		return err
+	}
+
+	return nil
+}
+
+// ExecResize - https://docs.docker.com/engine/api/v1.29/#operation/ExecResize
+func (r *Runtime) ExecResize(ctx context.Context, ID string, resize *types.ContainerExecResize) error {
+	return r.client.ContainerExecResize(ctx, ID, docker.ResizeOptions{
+		Width:  resize.Width,
+		Height: resize.Height,
+	})
+}
+
+// ExecInspect - https://docs.docker.com/engine/api/v1.29/#operation/ExecInspect
+func (r *Runtime) ExecInspect(ctx context.Context, ID string) (int, error) {
+
+	info, err := r.client.ContainerExecInspect(ctx, ID)
+	if err != nil {
+		return 0, err
+	}
+
+	return info.ExitCode, nil
+}
+
+// Stats - https://docs.docker.com/engine/api/v1.29/#operation/ContainerStats
+func (r *Runtime) Stats(ctx context.Context, ID string) (*types.ContainerStats, error) {
+
+	log.V(logLevel).Debugf("Docker: Container stats: %s", ID)
+
+	res, err := r.client.ContainerStats(ctx, ID, false)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	var info docker.StatsJSON
+	if err := json.NewDecoder(res.Body).Decode(&info); err != nil {
+		return nil, err
+	}
+
+	stats := new(types.ContainerStats)
+	stats.CPUTotal = info.CPUStats.CPUUsage.TotalUsage
+	stats.Memory = info.MemoryStats.Usage
+	stats.Timestamp = info.Read
+
+	// page cache can be reclaimed, so it is not counted as used memory
+	if cache, ok := info.MemoryStats.Stats["total_inactive_file"]; ok && cache < stats.Memory {
+		stats.Memory -= cache
+	}
+
+	for _, n := range info.Networks {
+		stats.NetworkRx += n.RxBytes
+		stats.NetworkTx += n.TxBytes
+	}
+
+	for _, b := range info.BlkioStats.IoServiceBytesRecursive {
+		switch strings.ToLower(b.Op) {
+		case "read":
+			stats.BlockRead += b.Value
+		case "write":
+			stats.BlockWrite += b.Value
+		}
+	}
+
+	return stats, nil
+}
@@ -38,5 +38,11 @@ type CRI interface {
 	Inspect(ctx context.Context, ID string) (*types.Container, error)
 	Logs(ctx context.Context, ID string, stdout, stderr, follow bool) (io.ReadCloser, error)
 	Copy(ctx context.Context, ID, path string, content io.Reader) error
+	Exec(ctx context.Context, ID string, cmd []string) (int, error)
+	ExecCreate(ctx context.Context, ID string, exec *types.ContainerExec) (string, error)
+	ExecAttach(ctx context.Context, ID string, tty bool, stdin io.Reader, stdout, stderr io.Writer) error
+	ExecResize(ctx context.Context, ID string, resize *types.ContainerExecResize) error
+	ExecInspect(ctx context.Context, ID string) (int, error)
+	Stats(ctx context.Context, ID string) (*types.ContainerStats, error)
 	Subscribe(ctx context.Context, container chan *types.Container) error
 }
@@ -31,6 +31,7 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strings"
 )
 
 type Storage struct {
@@ -95,40 +96,107 @@ func (s *Storage) Create(ctx context.Context, name string, manifest *types.Volum
 	return status, nil
 }
 
+// FilesList returns names of files stored in volume, temporary files are skipped
 func (s *Storage) FilesList(ctx context.Context, state *types.VolumeState) ([]string, error) {
 
-	return make([]string, 0), nil
+	var files = make([]string, 0)
+
+	items, err := ioutil.ReadDir(state.Path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return files, nil
+		}
+		return files, err
+	}
+
+	for _, item := range items {
+		if !item.Mode().IsRegular() || strings.HasPrefix(item.Name(), ".") {
+			continue
+		}
+		files = append(files, item.Name())
+	}
+
+	return files, nil
 }
 
+// FilesPut writes files into volume and removes files which are not present in files map.
+// Every file is written into temporary file in the same directory and then renamed,
+// so containers never see partially written data
 func (s *Storage) FilesPut(ctx context.Context, state *types.VolumeState, files map[string][]byte) error {
 
 	for file, data := range files {
 		path := filepath.Join(state.Path, file)
-		var f *os.File
 
-		f, err := os.OpenFile(path, os.O_WRONLY, 0644)
+		f, err := ioutil.TempFile(filepath.Dir(path), "."+filepath.Base(path)+".")
 		if err != nil {
-			if os.IsNotExist(err) {
-				if f, err = os.Create(path); err != nil {
-					return err
-				}
-			} else {
-				return err
-			}
+			return err
 		}
-		f.Close()
 
-		if err := ioutil.WriteFile(path, data, 0644); err != nil {
+		if _, err := f.Write(data); err != nil {
+			f.Close()
+			os.Remove(f.Name())
 			log.Errorf("can not write data to file: %s", err.Error())
+			return err
 		}
 
+		if err := f.Chmod(0644); err != nil {
+			f.Close()
+			os.Remove(f.Name())
+			return err
+		}
+
+		if err := f.Close(); err != nil {
+			os.Remove(f.Name())
+			return err
+		}
+
+		if err := os.Rename(f.Name(), path); err != nil {
+			os.Remove(f.Name())
+			log.Errorf("can not replace file: %s", err.Error())
+			return err
+		}
+	}
+
+	if err := s.filesClean(state, files); err != nil {
+		log.Errorf("can not remove outdated files: %s", err.Error())
+		return err
 	}
 
 	return nil
 }
 
+// filesClean removes volume files which are not present in files map
+func (s *Storage) filesClean(state *types.VolumeState, files map[string][]byte) error {
+
+	list, err := s.FilesList(context.Background(), state)
+	if err != nil {
+		return err
+	}
+
+	var outdated = make([]string, 0)
+	for _, file := range list {
+		if _, ok := files[file]; !ok {
+			outdated = append(outdated, file)
+		}
+	}
+
+	return s.FilesDel(context.Background(), state, outdated)
+}
+
+// FilesCheck checks that volume contains exactly the same files with the same data
 func (s *Storage) FilesCheck(ctx context.Context, state *types.VolumeState, files map[string][]byte) (bool, error) {
 
+	list, err := s.FilesList(ctx, state)
+	if err != nil {
+		return false, err
+	}
+
+	for _, file := range list {
+		if _, ok := files[file]; !ok {
+			return false, nil
+		}
+	}
+
 	for file, data := range files {
 		path := filepath.Join(state.Path, file)
 		var f *os.File
@@ -175,6 +243,7 @@ func (s *Storage) FilesDel(ctx context.Context, state *types.VolumeState, files
 			if os.IsNotExist(err) {
 				continue
 			}
+			return err
 		}
 	}
 
@@ -0,0 +1,141 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+
+package local
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/lastbackend/lastbackend/pkg/distribution/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStorageFilesPut(t *testing.T) {
+
+	var ctx = context.Background()
+
+	dir, err := ioutil.TempDir("", "lb-csi-local")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	s := new(Storage)
+	state := &types.VolumeState{Path: dir}
+
+	tests := []struct {
+		name  string
+		files map[string][]byte
+	}{
+		{
+			"write new files",
+			map[string][]byte{"token": []byte("first"), "user": []byte("demo")},
+		},
+		{
+			"update file data",
+			map[string][]byte{"token": []byte("second"), "user": []byte("demo")},
+		},
+		{
+			"remove files of deleted keys",
+			map[string][]byte{"token": []byte("second")},
+		},
+		{
+			"remove all files",
+			map[string][]byte{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			if !assert.NoError(t, s.FilesPut(ctx, state, tt.files)) {
+				return
+			}
+
+			list, err := s.FilesList(ctx, state)
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			var want = make([]string, 0)
+			for file, data := range tt.files {
+				want = append(want, file)
+
+				got, err := ioutil.ReadFile(filepath.Join(dir, file))
+				if !assert.NoError(t, err) {
+					return
+				}
+				assert.Equal(t, string(data), string(got), "file data not match")
+			}
+
+			sort.Strings(want)
+			sort.Strings(list)
+			assert.Equal(t, want, list, "files list not match")
+
+			ok, err := s.FilesCheck(ctx, state, tt.files)
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.True(t, ok, "files should be in sync")
+		})
+	}
+}
+
+func TestStorageFilesCheck(t *testing.T) {
+
+	var ctx = context.Background()
+
+	dir, err := ioutil.TempDir("", "lb-csi-local")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	s := new(Storage)
+	state := &types.VolumeState{Path: dir}
+
+	if !assert.NoError(t, s.FilesPut(ctx, state, map[string][]byte{"token": []byte("data"), "user": []byte("demo")})) {
+		return
+	}
+
+	tests := []struct {
+		name  string
+		files map[string][]byte
+		want  bool
+	}{
+		{"same files", map[string][]byte{"token": []byte("data"), "user": []byte("demo")}, true},
+		{"changed data", map[string][]byte{"token": []byte("new"), "user": []byte("demo")}, false},
+		{"added key", map[string][]byte{"token": []byte("data"), "user": []byte("demo"), "pass": []byte("x")}, false},
+		{"removed key", map[string][]byte{"token": []byte("data")}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ok, err := s.FilesCheck(ctx, state, tt.files)
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.Equal(t, tt.want, ok, "files check result not match")
+		})
+	}
+}
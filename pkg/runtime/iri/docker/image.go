@@ -19,6 +19,7 @@
 package docker
 
 import (
+	"bytes"
 	"context"
 	"encoding/base64"
 	"encoding/json"
@@ -211,6 +212,7 @@ func (r *Runtime) Build(ctx context.Context, stream io.Reader, spec *types.SpecB
 		Dockerfile:     spec.Dockerfile,
 		ExtraHosts:     spec.ExtraHosts,
 		Context:        spec.Context,
+		RemoteContext:  spec.RemoteContext,
 		NoCache:        spec.NoCache,
 		SuppressOutput: spec.SuppressOutput,
 	}
@@ -227,7 +229,10 @@ func (r *Runtime) Build(ctx context.Context, stream io.Reader, spec *types.SpecB
 	defer res.Body.Close()
 
 	const bufferSize = 1024
-	var buffer = make([]byte, bufferSize)
+	var (
+		buffer = make([]byte, bufferSize)
+		last   []byte
+	)
 
 	for {
 		select {
@@ -240,10 +245,16 @@ func (r *Runtime) Build(ctx context.Context, stream io.Reader, spec *types.SpecB
 				return nil, err
 			}
 			if readBytes == 0 {
+				// docker reports build failure in the last stream message
+				if err := buildError(last); err != nil {
+					return nil, err
+				}
 				// TODO: get image info
-				return new(types.Image), err
+				return new(types.Image), nil
 			}
 
+			last = append(last[:0], buffer[0:readBytes]...)
+
 			_, err = func(p []byte) (n int, err error) {
 
 				if out != nil {
@@ -271,6 +282,32 @@ func (r *Runtime) Build(ctx context.Context, stream io.Reader, spec *types.SpecB
 	}
 }
 
+func buildError(msg []byte) error {
+
+	lines := bytes.Split(bytes.TrimSpace(msg), []byte("\n"))
+
+	result := new(struct {
+		Error       string `json:"error"`
+		ErrorDetail *struct {
+			Message string `json:"message"`
+		} `json:"errorDetail,omitempty"`
+	})
+
+	if err := json.Unmarshal(lines[len(lines)-1], result); err != nil {
+		return nil
+	}
+
+	if result.ErrorDetail != nil {
+		return fmt.Errorf("%s", result.ErrorDetail.Message)
+	}
+
+	if result.Error != types.EmptyString {
+		return fmt.Errorf("%s", result.Error)
+	}
+
+	return nil
+}
+
 func (r *Runtime) Remove(ctx context.Context, ID string) error {
 	log.V(logLevel).Debugf("Docker: Name remove: %s", ID)
 	var options docker.ImageRemoveOptions
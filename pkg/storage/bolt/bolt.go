@@ -0,0 +1,765 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+
+package bolt
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lastbackend/lastbackend/pkg/distribution/errors"
+	"github.com/lastbackend/lastbackend/pkg/log"
+	"github.com/lastbackend/lastbackend/pkg/storage/types"
+	"github.com/lastbackend/lastbackend/pkg/util/converter"
+	"github.com/spf13/viper"
+	bbolt "go.etcd.io/bbolt"
+)
+
+const (
+	logLevel  = 6
+	logPrefix = "storage:bolt"
+)
+
+const (
+	keySeparator = "/"
+	// expireInterval - interval between expired entities cleanups
+	expireInterval = time.Second
+	// openTimeout - time to wait for database file lock
+	openTimeout = 5 * time.Second
+)
+
+// bucket - all entities are stored in single bucket by collection prefixed keys
+var bucket = []byte("lastbackend")
+
+// opened - storages by database path, database file can be opened only once,
+// so processes running in the same binary share storage and its watchers.
+// Shared storage is closed when all its users closed it
+var opened = struct {
+	lock  sync.Mutex
+	items map[string]*Storage
+}{items: make(map[string]*Storage)}
+
+type Storage struct {
+	path     string
+	refs     int
+	db       *bbolt.DB
+	watchers *watchers
+	done     chan bool
+	// lock - serializes writes with watchers notification, so events are delivered in revision order
+	lock sync.Mutex
+}
+
+type Config struct {
+	// Path - database file path
+	Path string
+}
+
+// entry - stored entity with modification revision and expiration time
+type entry struct {
+	Revision int64           `json:"revision"`
+	Expire   int64           `json:"expire,omitempty"`
+	Data     json.RawMessage `json:"data"`
+}
+
+func New() (*Storage, error) {
+
+	log.V(logLevel).Debug("Bolt: define storage")
+
+	var (
+		err    error
+		s      = new(Storage)
+		config = new(Config)
+	)
+
+	if err := viper.UnmarshalKey("bolt", config); err != nil {
+		log.Errorf("%s: error parsing bolt config: %v", logPrefix, err)
+		return nil, err
+	}
+
+	if config.Path == "" {
+		return nil, errors.New("bolt database path is not set")
+	}
+
+	opened.lock.Lock()
+	defer opened.lock.Unlock()
+
+	s.path = filepath.Clean(config.Path)
+
+	if db, ok := opened.items[s.path]; ok {
+		db.refs++
+		return db, nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		log.Errorf("%s: create database dir err: %v", logPrefix, err)
+		return nil, err
+	}
+
+	if s.db, err = bbolt.Open(s.path, 0600, &bbolt.Options{Timeout: openTimeout}); err != nil {
+		log.Errorf("%s: open database err: %v", logPrefix, err)
+		return nil, err
+	}
+
+	err = s.db.Update(func(tx *bbolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(bucket)
+		return err
+	})
+	if err != nil {
+		log.Errorf("%s: create bucket err: %v", logPrefix, err)
+		s.db.Close()
+		return nil, err
+	}
+
+	s.watchers = newWatchers()
+	s.done = make(chan bool)
+	s.refs = 1
+
+	opened.items[s.path] = s
+
+	go s.expire()
+
+	return s, nil
+}
+
+// Close releases shared storage, expired entities cleanup is stopped
+// and database is closed when storage is released by all its users
+func (s *Storage) Close() error {
+	opened.lock.Lock()
+	defer opened.lock.Unlock()
+
+	if s.refs--; s.refs > 0 {
+		return nil
+	}
+
+	delete(opened.items, s.path)
+	close(s.done)
+	return s.db.Close()
+}
+
+func (s *Storage) Info(ctx context.Context, collection string, name string) (*types.Runtime, error) {
+
+	var (
+		key = keyCreate(collection, name)
+		rt  = new(types.Runtime)
+	)
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		e, err := get(tx.Bucket(bucket), key)
+		if err != nil {
+			return err
+		}
+
+		if e == nil {
+			return errors.New(types.ErrEntityNotFound)
+		}
+
+		rt.System.Key = key
+		rt.System.Revision = e.Revision
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return rt, nil
+}
+
+func (s *Storage) Get(ctx context.Context, collection string, name string, obj interface{}, opts *types.Opts) error {
+
+	if reflect.ValueOf(obj).IsNil() {
+		return errors.New(types.ErrStructOutIsNil)
+	}
+
+	return s.db.View(func(tx *bbolt.Tx) error {
+		e, err := get(tx.Bucket(bucket), keyCreate(collection, name))
+		if err != nil {
+			return err
+		}
+
+		if e == nil {
+			return errors.New(types.ErrEntityNotFound)
+		}
+
+		if err := json.Unmarshal(e.Data, obj); err != nil {
+			return err
+		}
+
+		setRuntime(reflect.ValueOf(obj).Elem(), e.Revision)
+		return nil
+	})
+}
+
+func (s *Storage) List(ctx context.Context, collection string, q string, obj interface{}, opts *types.Opts) error {
+
+	if reflect.ValueOf(obj).IsNil() {
+		return errors.New(types.ErrStructOutIsNil)
+	}
+
+	v, err := converter.EnforcePtr(obj)
+	if err != nil {
+		return errors.New(types.ErrStructOutIsNotPointer)
+	}
+
+	f := v.FieldByName("Items")
+	if f.Kind() != reflect.Slice {
+		return errors.New(types.ErrStructOutIsInvalid)
+	}
+
+	if !f.CanSet() {
+		return nil
+	}
+
+	buffer := []byte("[")
+	revisions := make([]int64, 0)
+
+	err = s.db.View(func(tx *bbolt.Tx) error {
+		return scan(tx.Bucket(bucket), keyCreate(collection, q), func(key string, e *entry) {
+			if len(revisions) > 0 {
+				buffer = append(buffer, []byte(",")...)
+			}
+			buffer = append(buffer, e.Data...)
+			revisions = append(revisions, e.Revision)
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	buffer = append(buffer, []byte("]")...)
+
+	items := reflect.New(f.Type()).Interface().(interface{})
+	if err := json.Unmarshal(buffer, items); err != nil {
+		return err
+	}
+
+	f.Set(reflect.ValueOf(items).Elem())
+
+	for i, r := range revisions {
+		setRuntime(reflect.Indirect(f.Index(i)), r)
+	}
+
+	return nil
+}
+
+func (s *Storage) Map(ctx context.Context, collection string, q string, obj interface{}, opts *types.Opts) error {
+
+	if reflect.ValueOf(obj).IsNil() {
+		return errors.New(types.ErrStructOutIsNil)
+	}
+
+	v, err := converter.EnforcePtr(obj)
+	if err != nil {
+		return errors.New(types.ErrStructOutIsNotPointer)
+	}
+
+	f := v.FieldByName("Items")
+	if f.Kind() != reflect.Map {
+		return errors.New(types.ErrStructOutIsInvalid)
+	}
+
+	if !f.CanSet() {
+		return nil
+	}
+
+	buffer := []byte("{")
+	revisions := make(map[string]int64, 0)
+
+	err = s.db.View(func(tx *bbolt.Tx) error {
+		return scan(tx.Bucket(bucket), keyCreate(collection, q), func(key string, e *entry) {
+
+			ks := strings.Split(key, keySeparator)
+			name := ks[len(ks)-1]
+
+			if len(revisions) > 0 {
+				buffer = append(buffer, []byte(",")...)
+			}
+
+			n, _ := json.Marshal(name)
+			buffer = append(buffer, n...)
+			buffer = append(buffer, []byte(":")...)
+			buffer = append(buffer, e.Data...)
+			revisions[name] = e.Revision
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	buffer = append(buffer, []byte("}")...)
+
+	items := reflect.New(f.Type()).Interface().(interface{})
+	if err := json.Unmarshal(buffer, items); err != nil {
+		return err
+	}
+
+	f.Set(reflect.ValueOf(items).Elem())
+
+	for _, key := range f.MapKeys() {
+		if r, ok := revisions[key.String()]; ok {
+			setRuntime(reflect.Indirect(f.MapIndex(key)), r)
+		}
+	}
+
+	return nil
+}
+
+func (s *Storage) Put(ctx context.Context, collection string, name string, obj interface{}, opts *types.Opts) error {
+
+	if opts == nil {
+		opts = new(types.Opts)
+	}
+
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return err
+	}
+
+	var ev *types.Event
+
+	err = s.update(func(b *bbolt.Bucket) ([]*types.Event, error) {
+
+		key := keyCreate(collection, name)
+
+		e, err := get(b, key)
+		if err != nil {
+			return nil, err
+		}
+
+		if e != nil && !opts.Force {
+			return nil, errors.New(types.ErrEntityExists)
+		}
+
+		ev, err = put(b, key, data, opts.Ttl, e)
+		if err != nil {
+			return nil, err
+		}
+
+		return []*types.Event{ev}, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	setRuntime(reflect.Indirect(reflect.ValueOf(obj)), ev.Rev)
+
+	return nil
+}
+
+func (s *Storage) Set(ctx context.Context, collection string, name string, obj interface{}, opts *types.Opts) error {
+
+	var (
+		rev   *int64
+		force bool
+		ttl   uint64
+	)
+
+	if opts != nil {
+		rev = opts.Rev
+		force = opts.Force
+		ttl = opts.Ttl
+	}
+
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return err
+	}
+
+	var ev *types.Event
+
+	err = s.update(func(b *bbolt.Bucket) ([]*types.Event, error) {
+
+		key := keyCreate(collection, name)
+
+		e, err := get(b, key)
+		if err != nil {
+			return nil, err
+		}
+
+		switch true {
+		case rev != nil:
+			if e == nil {
+				return nil, errors.New(types.ErrEntityNotFound)
+			}
+			if e.Revision != *rev {
+				return nil, errors.New(types.ErrEntityRevision)
+			}
+		case !force:
+			if e == nil {
+				return nil, errors.New(types.ErrEntityNotFound)
+			}
+		}
+
+		ev, err = put(b, key, data, ttl, e)
+		if err != nil {
+			return nil, err
+		}
+
+		return []*types.Event{ev}, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	setRuntime(reflect.Indirect(reflect.ValueOf(obj)), ev.Rev)
+
+	return nil
+}
+
+func (s *Storage) Del(ctx context.Context, collection string, name string) error {
+
+	return s.update(func(b *bbolt.Bucket) ([]*types.Event, error) {
+
+		items := make(map[string]*entry)
+
+		if name == "" {
+			err := scan(b, keyCreate(collection, name), func(key string, e *entry) {
+				items[key] = e
+			})
+			if err != nil {
+				return nil, err
+			}
+		} else {
+			key := keyCreate(collection, name)
+			e, err := get(b, key)
+			if err != nil {
+				return nil, err
+			}
+			if e != nil {
+				items[key] = e
+			}
+		}
+
+		return delAll(b, items)
+	})
+}
+
+func (s *Storage) Watch(ctx context.Context, collection string, event chan *types.WatcherEvent, opts *types.Opts) error {
+
+	log.V(logLevel).Debugf("%s:> watch %s", logPrefix, collection)
+
+	const filter = `\b.+\/(.+)\b`
+
+	r, _ := regexp.Compile(filter)
+
+	prefix := keyCreate(collection, "")
+
+	w, err := s.watch(prefix, opts)
+	if err != nil {
+		log.V(logLevel).Errorf("%s:> watch err: %v", logPrefix, err)
+		return err
+	}
+	defer s.watchers.del(w)
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.V(logLevel).Debugf("%s:> the user interrupted watch", logPrefix)
+			return nil
+		case <-w.signal:
+
+			for _, res := range w.pop() {
+
+				keys := r.FindStringSubmatch(res.Key)
+				if len(keys) == 0 {
+					continue
+				}
+
+				e := new(types.WatcherEvent)
+				e.Action = res.Type
+				e.SelfLink = keys[1]
+				e.System.Key = res.Key
+				e.System.Revision = res.Rev
+				e.Data = res.Object
+
+				match := strings.Split(res.Key, ":")
+
+				if len(match) > 0 {
+					e.Name = match[len(match)-1]
+				} else {
+					e.Name = keys[0]
+				}
+
+				select {
+				case event <- e:
+				case <-ctx.Done():
+					log.V(logLevel).Debugf("%s:> the user interrupted watch", logPrefix)
+					return nil
+				}
+			}
+		}
+	}
+}
+
+// watch adds watcher for keys with prefix and sends current state like etcd watcher does, if revision is provided.
+// Writes are locked until current state is sent, so changes are delivered after state in revision order
+func (s *Storage) watch(prefix string, opts *types.Opts) (*watcher, error) {
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	w := s.watchers.add(prefix)
+
+	if opts == nil || opts.Rev == nil {
+		return w, nil
+	}
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		return scan(tx.Bucket(bucket), prefix, func(key string, e *entry) {
+			if e.Revision >= *opts.Rev {
+				w.push(&types.Event{Type: types.STORAGECREATEEVENT, Key: key, Rev: e.Revision, Object: []byte(e.Data)})
+			}
+		})
+	})
+	if err != nil {
+		s.watchers.del(w)
+		return nil, err
+	}
+
+	return w, nil
+}
+
+// Begin starts multi-key transaction
+func (s *Storage) Begin(ctx context.Context) types.TX {
+	t := new(tx)
+	t.storage = s
+	t.ops = make([]*txOp, 0)
+	return t
+}
+
+func (s *Storage) Filter() types.Filter {
+	return new(Filter)
+}
+
+func (s *Storage) Key() types.Key {
+	return new(Key)
+}
+
+func (s *Storage) Collection() types.Collection {
+	return new(Collection)
+}
+
+// expire removes expired entities periodically until storage is closed
+func (s *Storage) expire() {
+
+	ticker := time.NewTicker(expireInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-ticker.C:
+			if err := s.clean(); err != nil {
+				log.Errorf("%s:expire:> remove expired entities err: %v", logPrefix, err)
+			}
+		}
+	}
+}
+
+// clean removes expired entities and notifies watchers about removal
+func (s *Storage) clean() error {
+
+	return s.update(func(b *bbolt.Bucket) ([]*types.Event, error) {
+
+		var (
+			now   = time.Now().UnixNano()
+			items = make(map[string]*entry)
+		)
+
+		c := b.Cursor()
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			e := new(entry)
+			if err := json.Unmarshal(v, e); err != nil {
+				return nil, err
+			}
+			if e.Expire != 0 && e.Expire <= now {
+				items[string(k)] = e
+			}
+		}
+
+		return delAll(b, items)
+	})
+}
+
+// update runs database write transaction and notifies watchers about its events
+// before next write is started, so watchers receive events in revision order
+func (s *Storage) update(fn func(b *bbolt.Bucket) ([]*types.Event, error)) error {
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	var events []*types.Event
+
+	err := s.db.Update(func(tx *bbolt.Tx) error {
+		var err error
+		events, err = fn(tx.Bucket(bucket))
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	s.watchers.notify(events...)
+	return nil
+}
+
+// get returns stored entity, expired entities are treated as not existing
+func get(b *bbolt.Bucket, key string) (*entry, error) {
+
+	v := b.Get([]byte(key))
+	if v == nil {
+		return nil, nil
+	}
+
+	e := new(entry)
+	if err := json.Unmarshal(v, e); err != nil {
+		return nil, err
+	}
+
+	if e.Expire != 0 && e.Expire <= time.Now().UnixNano() {
+		return nil, nil
+	}
+
+	return e, nil
+}
+
+// scan calls fn for each not expired entity with key prefix
+func scan(b *bbolt.Bucket, prefix string, fn func(key string, e *entry)) error {
+
+	var (
+		c   = b.Cursor()
+		p   = []byte(prefix)
+		now = time.Now().UnixNano()
+	)
+
+	for k, v := c.Seek(p); k != nil && bytes.HasPrefix(k, p); k, v = c.Next() {
+
+		e := new(entry)
+		if err := json.Unmarshal(v, e); err != nil {
+			return err
+		}
+
+		if e.Expire != 0 && e.Expire <= now {
+			continue
+		}
+
+		fn(string(k), e)
+	}
+
+	return nil
+}
+
+// put stores entity with next storage revision, prev is previous entity state
+func put(b *bbolt.Bucket, key string, data []byte, ttl uint64, prev *entry) (*types.Event, error) {
+
+	rev, err := b.NextSequence()
+	if err != nil {
+		return nil, err
+	}
+
+	e := new(entry)
+	e.Revision = int64(rev)
+	e.Data = data
+
+	if ttl > 0 {
+		e.Expire = time.Now().Add(time.Duration(ttl) * time.Second).UnixNano()
+	}
+
+	v, err := json.Marshal(e)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := b.Put([]byte(key), v); err != nil {
+		return nil, err
+	}
+
+	ev := &types.Event{Type: types.STORAGEUPDATEEVENT, Key: key, Rev: e.Revision, Object: data}
+	if prev == nil {
+		ev.Type = types.STORAGECREATEEVENT
+	}
+
+	return ev, nil
+}
+
+// delAll removes entities, each removal gets next storage revision
+func delAll(b *bbolt.Bucket, items map[string]*entry) ([]*types.Event, error) {
+
+	events := make([]*types.Event, 0, len(items))
+	for key, e := range items {
+		ev, err := del(b, key, e)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, ev)
+	}
+
+	return events, nil
+}
+
+// del removes entity with next storage revision, prev is removed entity state
+func del(b *bbolt.Bucket, key string, prev *entry) (*types.Event, error) {
+
+	rev, err := b.NextSequence()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := b.Delete([]byte(key)); err != nil {
+		return nil, err
+	}
+
+	return &types.Event{Type: types.STORAGEDELETEEVENT, Key: key, Rev: int64(rev), Object: []byte(prev.Data)}, nil
+}
+
+// setRuntime sets entity modification revision into entity runtime info
+func setRuntime(v reflect.Value, revision int64) {
+
+	if v.Kind() != reflect.Struct {
+		return
+	}
+
+	f := v.FieldByName("Runtime")
+	if !f.IsValid() || !f.CanSet() {
+		return
+	}
+
+	rt := f.FieldByName("System")
+	if !rt.IsValid() {
+		return
+	}
+
+	r := rt.FieldByName("Revision")
+	if !r.IsValid() || !r.CanSet() || r.Kind() != reflect.Int64 {
+		return
+	}
+
+	r.SetInt(revision)
+}
+
+func keyCreate(val ...string) string {
+	return strings.Join(val, keySeparator)
+}
@@ -0,0 +1,272 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+
+package bolt_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lastbackend/lastbackend/pkg/distribution/errors"
+	"github.com/lastbackend/lastbackend/pkg/storage"
+	"github.com/lastbackend/lastbackend/pkg/storage/bolt"
+	"github.com/lastbackend/lastbackend/pkg/storage/types"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStorage_Get(t *testing.T) {
+	stg, clean := newStorage(t)
+	defer clean()
+	storage.StorageGetAssets(t, stg)
+}
+
+func TestStorage_List(t *testing.T) {
+	stg, clean := newStorage(t)
+	defer clean()
+	storage.StorageListAssets(t, stg)
+}
+
+func TestStorage_Map(t *testing.T) {
+	stg, clean := newStorage(t)
+	defer clean()
+	storage.StorageMapAssets(t, stg)
+}
+
+func TestStorage_Put(t *testing.T) {
+	stg, clean := newStorage(t)
+	defer clean()
+	storage.StoragePutAssets(t, stg)
+}
+
+func TestStorage_Set(t *testing.T) {
+	stg, clean := newStorage(t)
+	defer clean()
+	storage.StorageSetAssets(t, stg)
+}
+
+func TestStorage_Del(t *testing.T) {
+	stg, clean := newStorage(t)
+	defer clean()
+	storage.StorageDelAssets(t, stg)
+}
+
+func TestStorage_Tx(t *testing.T) {
+	stg, clean := newStorage(t)
+	defer clean()
+	storage.StorageTxAssets(t, stg)
+}
+
+func TestStorage_Revision(t *testing.T) {
+	stg, clean := newStorage(t)
+	defer clean()
+	storage.StorageRevisionAssets(t, stg)
+}
+
+func TestStorage_Ttl(t *testing.T) {
+	stg, clean := newStorage(t)
+	defer clean()
+
+	var (
+		ctx  = context.Background()
+		opts = storage.GetOpts()
+		out  string
+	)
+
+	opts.Ttl = 1
+
+	err := stg.Put(ctx, stg.Collection().System(), "lead", "demo", opts)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	err = stg.Get(ctx, stg.Collection().System(), "lead", &out, nil)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "demo", out, "object received error")
+	}
+
+	err = stg.Put(ctx, stg.Collection().System(), "process", "demo", nil)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	time.Sleep(1500 * time.Millisecond)
+
+	err = stg.Get(ctx, stg.Collection().System(), "lead", &out, nil)
+	if assert.Error(t, err, "expected err") {
+		assert.Equal(t, errors.ErrEntityNotFound, err.Error(), "err message different")
+	}
+
+	err = stg.Get(ctx, stg.Collection().System(), "process", &out, nil)
+	assert.NoError(t, err, "entity without ttl should not expire")
+
+	err = stg.Put(ctx, stg.Collection().System(), "lead", "test", opts)
+	assert.NoError(t, err, "expired entity should be replaced")
+}
+
+func TestStorage_Watch(t *testing.T) {
+	stg, clean := newStorage(t)
+	defer clean()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	watcher := storage.NewWatcher()
+	go stg.Watch(ctx, stg.Collection().System(), watcher, nil)
+
+	// wait for watcher registration
+	time.Sleep(100 * time.Millisecond)
+
+	opts := storage.GetOpts()
+	opts.Ttl = 1
+
+	err := stg.Put(ctx, stg.Collection().System(), "kind/lead", "demo", opts)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	err = stg.Put(ctx, stg.Collection().Test(), "demo", "demo", nil)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	err = stg.Set(ctx, stg.Collection().System(), "kind/lead", "test", opts)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	want := []struct {
+		action string
+		data   string
+	}{
+		{types.STORAGECREATEEVENT, "demo"},
+		{types.STORAGEUPDATEEVENT, "test"},
+		{types.STORAGEDELETEEVENT, "test"},
+	}
+
+	for _, w := range want {
+		select {
+		case e := <-watcher:
+			assert.Equal(t, w.action, e.Action, "event action different")
+			assert.Equal(t, "lead", e.SelfLink, "event self link different")
+
+			var data string
+			if assert.NoError(t, json.Unmarshal(e.Data.([]byte), &data)) {
+				assert.Equal(t, w.data, data, "event data different")
+			}
+		case <-time.After(3 * time.Second):
+			t.Errorf("event %s not received", w.action)
+			return
+		}
+	}
+}
+
+func TestStorage_WatchOrder(t *testing.T) {
+	stg, clean := newStorage(t)
+	defer clean()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	const (
+		writers = 4
+		writes  = 25
+	)
+
+	watcher := storage.NewWatcher()
+	go stg.Watch(ctx, stg.Collection().Test(), watcher, nil)
+
+	// wait for watcher registration
+	time.Sleep(100 * time.Millisecond)
+
+	var wg sync.WaitGroup
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < writes; j++ {
+				opts := storage.GetOpts()
+				opts.Force = true
+				assert.NoError(t, stg.Set(ctx, stg.Collection().Test(), fmt.Sprintf("%d", i), j, opts))
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	var rev int64
+	for i := 0; i < writers*writes; i++ {
+		select {
+		case e := <-watcher:
+			if !assert.True(t, e.System.Revision > rev, "event revision %d received after %d", e.System.Revision, rev) {
+				return
+			}
+			rev = e.System.Revision
+		case <-time.After(3 * time.Second):
+			t.Errorf("event %d not received", i)
+			return
+		}
+	}
+}
+
+func TestStorage_Shared(t *testing.T) {
+	stg, clean := newStorage(t)
+	defer clean()
+
+	db, err := bolt.New()
+	if !assert.NoError(t, err, "storage initialize err") {
+		return
+	}
+
+	assert.True(t, stg == db, "storage with same database path should be shared")
+
+	// storage is closed only when all its users closed it
+	if !assert.NoError(t, db.Close()) {
+		return
+	}
+
+	err = stg.Put(context.Background(), stg.Collection().Test(), "demo", "demo", nil)
+	assert.NoError(t, err, "shared storage should not be closed by other user")
+}
+
+func newStorage(t *testing.T) (*bolt.Storage, func()) {
+
+	dir, err := ioutil.TempDir("", "lastbackend")
+	if err != nil {
+		t.Fatalf("create temp dir err: %v", err)
+	}
+
+	viper.Set("bolt", bolt.Config{Path: filepath.Join(dir, "storage.db")})
+
+	stg, err := bolt.New()
+	if err != nil {
+		os.RemoveAll(dir)
+		t.Fatalf("storage initialize err: %v", err)
+	}
+
+	return stg, func() {
+		stg.Close()
+		os.RemoveAll(dir)
+	}
+}
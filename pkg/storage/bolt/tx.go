@@ -0,0 +1,144 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+
+package bolt
+
+import (
+	"encoding/json"
+
+	"github.com/lastbackend/lastbackend/pkg/distribution/errors"
+	"github.com/lastbackend/lastbackend/pkg/storage/types"
+	bbolt "go.etcd.io/bbolt"
+)
+
+const (
+	txOpPut = "put"
+	txOpSet = "set"
+	txOpDel = "del"
+)
+
+type tx struct {
+	storage *Storage
+	ops     []*txOp
+}
+
+type txOp struct {
+	action string
+	key    string
+	data   []byte
+	ttl    uint64
+	force  bool
+	rev    *int64
+}
+
+// Put creates entity in transaction, transaction fails if entity exists and force option is not set
+func (t *tx) Put(collection string, name string, obj interface{}, opts *types.Opts) error {
+
+	b, err := json.Marshal(obj)
+	if err != nil {
+		return err
+	}
+
+	op := &txOp{action: txOpPut, key: keyCreate(collection, name), data: b}
+	if opts != nil {
+		op.force = opts.Force
+		op.ttl = opts.Ttl
+	}
+
+	t.ops = append(t.ops, op)
+	return nil
+}
+
+// Set updates entity in transaction, transaction fails if entity not exists and force option is not set
+// or if entity was changed since revision provided in options
+func (t *tx) Set(collection string, name string, obj interface{}, opts *types.Opts) error {
+
+	b, err := json.Marshal(obj)
+	if err != nil {
+		return err
+	}
+
+	op := &txOp{action: txOpSet, key: keyCreate(collection, name), data: b}
+	if opts != nil {
+		op.force = opts.Force
+		op.ttl = opts.Ttl
+		op.rev = opts.Rev
+	}
+
+	t.ops = append(t.ops, op)
+	return nil
+}
+
+// Del removes entity in transaction
+func (t *tx) Del(collection string, name string) {
+	t.ops = append(t.ops, &txOp{action: txOpDel, key: keyCreate(collection, name)})
+}
+
+// Commit applies operations in single database transaction,
+// database transaction is rolled back if any operation condition fails
+func (t *tx) Commit() error {
+
+	return t.storage.update(func(b *bbolt.Bucket) ([]*types.Event, error) {
+
+		events := make([]*types.Event, 0)
+
+		for _, op := range t.ops {
+
+			e, err := get(b, op.key)
+			if err != nil {
+				return nil, err
+			}
+
+			var ev *types.Event
+
+			switch op.action {
+			case txOpPut:
+				if e != nil && !op.force {
+					return nil, errors.New(types.ErrOperationFailure)
+				}
+				ev, err = put(b, op.key, op.data, op.ttl, e)
+			case txOpSet:
+				// entity is updated only if it was not changed since revision
+				if op.rev != nil {
+					if e == nil {
+						return nil, errors.New(types.ErrOperationFailure)
+					}
+					if e.Revision != *op.rev {
+						return nil, errors.New(types.ErrEntityRevision)
+					}
+				} else if e == nil && !op.force {
+					return nil, errors.New(types.ErrOperationFailure)
+				}
+				ev, err = put(b, op.key, op.data, op.ttl, e)
+			case txOpDel:
+				if e == nil {
+					continue
+				}
+				ev, err = del(b, op.key, e)
+			}
+
+			if err != nil {
+				return nil, err
+			}
+
+			events = append(events, ev)
+		}
+
+		return events, nil
+	})
+}
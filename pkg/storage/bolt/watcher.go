@@ -0,0 +1,99 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+
+package bolt
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/lastbackend/lastbackend/pkg/storage/types"
+)
+
+// watchers - registry of active watchers, notified after storage changes are committed
+type watchers struct {
+	lock  sync.Mutex
+	items map[*watcher]bool
+}
+
+// watcher - events queue for keys with prefix,
+// queue is not limited so storage changes are never blocked by slow watch consumers
+type watcher struct {
+	lock   sync.Mutex
+	prefix string
+	queue  []*types.Event
+	signal chan bool
+}
+
+func newWatchers() *watchers {
+	ws := new(watchers)
+	ws.items = make(map[*watcher]bool)
+	return ws
+}
+
+func (ws *watchers) add(prefix string) *watcher {
+	ws.lock.Lock()
+	defer ws.lock.Unlock()
+
+	w := new(watcher)
+	w.prefix = prefix
+	w.queue = make([]*types.Event, 0)
+	w.signal = make(chan bool, 1)
+
+	ws.items[w] = true
+	return w
+}
+
+func (ws *watchers) del(w *watcher) {
+	ws.lock.Lock()
+	defer ws.lock.Unlock()
+	delete(ws.items, w)
+}
+
+func (ws *watchers) notify(events ...*types.Event) {
+	ws.lock.Lock()
+	defer ws.lock.Unlock()
+
+	for w := range ws.items {
+		for _, e := range events {
+			if strings.HasPrefix(e.Key, w.prefix) {
+				w.push(e)
+			}
+		}
+	}
+}
+
+func (w *watcher) push(e *types.Event) {
+	w.lock.Lock()
+	w.queue = append(w.queue, e)
+	w.lock.Unlock()
+
+	select {
+	case w.signal <- true:
+	default:
+	}
+}
+
+func (w *watcher) pop() []*types.Event {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	events := w.queue
+	w.queue = make([]*types.Event, 0)
+	return events
+}
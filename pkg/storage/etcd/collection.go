@@ -26,6 +26,7 @@ import (
 const (
 	namespaceCollection  = "namespace"
 	secretCollection     = "secret"
+	configCollection     = "config"
 	endpointCollection   = "endpoint"
 	serviceCollection    = "service"
 	deploymentCollection = "deployment"
@@ -45,6 +46,8 @@ const (
 
 	systemCollection  = "system"
 	triggerCollection = "trigger"
+	buildCollection   = "build"
+	eventCollection   = "event"
 	testCollection    = "test"
 
 	infoColletion = "info"
@@ -70,6 +73,10 @@ func (Collection) Secret() string {
 	return secretCollection
 }
 
+func (Collection) Config() string {
+	return configCollection
+}
+
 func (Collection) Endpoint() string {
 	return endpointCollection
 }
@@ -110,6 +117,14 @@ func (Collection) Trigger() string {
 	return triggerCollection
 }
 
+func (Collection) Build() string {
+	return buildCollection
+}
+
+func (Collection) Event() string {
+	return eventCollection
+}
+
 func (Collection) Cluster() string {
 	return clusterCollection
 }
@@ -166,6 +181,10 @@ func (ManifestCollection) Secret() string {
 	return fmt.Sprintf("%s/%s/%s", manifestCollection, clusterCollection, secretCollection)
 }
 
+func (ManifestCollection) Config() string {
+	return fmt.Sprintf("%s/%s/%s", manifestCollection, clusterCollection, configCollection)
+}
+
 func (NodeCollection) Info () string {
 	return fmt.Sprintf("%s/%s", nodeCollection, infoColletion)
 }
@@ -232,6 +232,13 @@ func (s Storage) Watch(ctx context.Context, collection string, event chan *types
 	return nil
 }
 
+// Begin starts multi-key transaction
+func (s Storage) Begin(ctx context.Context) types.TX {
+	t := new(tx)
+	t.tx = s.client.store.Begin(ctx)
+	return t
+}
+
 func (s Storage) Filter() types.Filter {
 	return new(Filter)
 }
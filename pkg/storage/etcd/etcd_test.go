@@ -64,6 +64,18 @@ func TestStorage_Del(t *testing.T) {
 	storage.StorageDelAssets(t, stg)
 }
 
+func TestStorage_Tx(t *testing.T) {
+	stg, err := etcd.New()
+	assert.NoError(t, err, "storage initialize err")
+	storage.StorageTxAssets(t, stg)
+}
+
+func TestStorage_Revision(t *testing.T) {
+	stg, err := etcd.New()
+	assert.NoError(t, err, "storage initialize err")
+	storage.StorageRevisionAssets(t, stg)
+}
+
 func init() {
 
 	cfg := v3.Config{}
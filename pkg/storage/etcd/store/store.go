@@ -41,7 +41,7 @@ type Store interface {
 
 type TX interface {
 	Put(key string, obj interface{}, ttl uint64) error
-	Set(key string, obj interface{}, ttl uint64, force bool) error
+	Set(key string, obj interface{}, ttl uint64, force bool, rev *int64) error
 	Del(key string)
 	Commit() error
 }
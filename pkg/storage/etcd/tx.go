@@ -0,0 +1,63 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+
+package etcd
+
+import (
+	"github.com/lastbackend/lastbackend/pkg/storage/etcd/store"
+	"github.com/lastbackend/lastbackend/pkg/storage/types"
+)
+
+type tx struct {
+	tx store.TX
+}
+
+// Put creates entity in transaction, transaction fails if entity exists and force option is not set
+func (t *tx) Put(collection string, name string, obj interface{}, opts *types.Opts) error {
+
+	if opts == nil {
+		opts = new(types.Opts)
+	}
+
+	if opts.Force {
+		return t.tx.Set(keyCreate(collection, name), obj, opts.Ttl, true, nil)
+	}
+
+	return t.tx.Put(keyCreate(collection, name), obj, opts.Ttl)
+}
+
+// Set updates entity in transaction, transaction fails if entity not exists and force option is not set
+// or if entity was changed since revision provided in options
+func (t *tx) Set(collection string, name string, obj interface{}, opts *types.Opts) error {
+
+	if opts == nil {
+		opts = new(types.Opts)
+	}
+
+	return t.tx.Set(keyCreate(collection, name), obj, opts.Ttl, opts.Force, opts.Rev)
+}
+
+// Del removes entity in transaction
+func (t *tx) Del(collection string, name string) {
+	t.tx.Del(keyCreate(collection, name))
+}
+
+// Commit applies transaction operations
+func (t *tx) Commit() error {
+	return t.tx.Commit()
+}
@@ -116,6 +116,12 @@ func (s *dbstore) Put(ctx context.Context, key string, obj, outPtr interface{},
 	if !txnResp.Succeeded {
 		return errors.New(types.ErrEntityExists)
 	}
+
+	// entity revision is changed after write
+	if !validator.IsNil(obj) {
+		setEntityRuntimeInfo(obj, getRuntimeFromResponse(txnResp.Header))
+	}
+
 	if validator.IsNil(outPtr) {
 		log.V(logLevel).Warn("%s:Create: output struct is nil")
 		return nil
@@ -154,7 +160,7 @@ func (s *dbstore) Get(ctx context.Context, key string, outPtr interface{}, rev *
 		return err
 	}
 
-	if err := setEntityRuntimeInfo(outPtr, getRuntimeFromResponse(res.Header)); err != nil {
+	if err := setEntityRuntimeInfo(outPtr, getRuntimeFromValue(res.Kvs[0])); err != nil {
 		log.V(logLevel).Errorf("%s:get:> can not set runtime info err: %v", logPrefix, err)
 		return err
 	}
@@ -263,17 +269,17 @@ func (s *dbstore) Set(ctx context.Context, key string, obj, outPtr interface{},
 
 	txn := s.client.KV.Txn(ctx)
 
-	if !force {
-		rv := int64(0)
-		if rev != nil {
-			rv = *rev
-		}
-
-		txn = txn.If(clientv3.Compare(clientv3.ModRevision(key), "!=", rv))
+	switch true {
+	// entity is updated only if it was not changed since revision
+	case rev != nil:
+		txn = txn.If(clientv3.Compare(clientv3.ModRevision(key), "=", *rev))
+	case !force:
+		txn = txn.If(clientv3.Compare(clientv3.ModRevision(key), "!=", 0))
 	}
 
 	txnResp, err := txn.
 		Then(clientv3.OpPut(key, string(data), opts...)).
+		Else(clientv3.OpGet(key)).
 		Commit()
 
 	if err != nil {
@@ -281,8 +287,17 @@ func (s *dbstore) Set(ctx context.Context, key string, obj, outPtr interface{},
 		return err
 	}
 	if !txnResp.Succeeded {
+		if rev != nil && len(txnResp.Responses) > 0 && txnResp.Responses[0].GetResponseRange().Count > 0 {
+			return errors.New(types.ErrEntityRevision)
+		}
 		return errors.New(types.ErrEntityNotFound)
 	}
+
+	// entity revision is changed after write
+	if !validator.IsNil(obj) {
+		setEntityRuntimeInfo(obj, getRuntimeFromResponse(txnResp.Header))
+	}
+
 	if validator.IsNil(outPtr) {
 		log.V(logLevel).Warnf("%s:Update: output struct is nil", logPrefix)
 		return nil
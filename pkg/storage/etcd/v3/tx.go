@@ -19,8 +19,11 @@
 package v3
 
 import (
+	"errors"
+
 	"github.com/coreos/etcd/clientv3"
 	"github.com/lastbackend/lastbackend/pkg/log"
+	"github.com/lastbackend/lastbackend/pkg/storage/types"
 	"github.com/lastbackend/lastbackend/pkg/util/serializer"
 	"golang.org/x/net/context"
 	"path"
@@ -32,6 +35,7 @@ type tx struct {
 	context context.Context
 	cmp     []clientv3.Cmp
 	ops     []clientv3.Op
+	revs    map[string]int64
 }
 
 type TxResponse struct {
@@ -59,12 +63,22 @@ func (t *tx) Put(key string, obj interface{}, ttl uint64) error {
 	return nil
 }
 
-func (t *tx) Set(key string, obj interface{}, ttl uint64, force bool) error {
+func (t *tx) Set(key string, obj interface{}, ttl uint64, force bool, rev *int64) error {
 	key = path.Join(t.pathPrefix, key)
 
 	log.V(logLevel).Debugf("%s:update:> key: %s, ttl: %d, val: %#v", logPrefix, key, ttl, obj)
 
-	t.cmp = append(t.cmp, clientv3.Compare(clientv3.ModRevision(key), "!=", 0))
+	switch true {
+	// entity is updated only if it was not changed since revision
+	case rev != nil:
+		t.cmp = append(t.cmp, clientv3.Compare(clientv3.ModRevision(key), "=", *rev))
+		if t.revs == nil {
+			t.revs = make(map[string]int64)
+		}
+		t.revs[key] = *rev
+	case !force:
+		t.cmp = append(t.cmp, clientv3.Compare(clientv3.ModRevision(key), "!=", 0))
+	}
 	data, err := serializer.Encode(t.codec, obj)
 	if err != nil {
 		log.V(logLevel).Errorf("%s:update:> encode data err: %v", logPrefix, err)
@@ -92,11 +106,28 @@ func (t *tx) Commit() error {
 
 	log.V(logLevel).Debugf("%s:commit:> commit transaction", logPrefix)
 
-	_, err := t.txn.If(t.cmp...).Then(t.ops...).Commit()
+	// revision compared entities are requested back to detect revision conflict
+	keys := make([]string, 0, len(t.revs))
+	get := make([]clientv3.Op, 0, len(t.revs))
+	for key := range t.revs {
+		keys = append(keys, key)
+		get = append(get, clientv3.OpGet(key))
+	}
+
+	txnResp, err := t.txn.If(t.cmp...).Then(t.ops...).Else(get...).Commit()
 	if err != nil {
 		log.V(logLevel).Errorf("%s:commit:> request err: %v", logPrefix, err)
 		return err
 	}
+	if !txnResp.Succeeded {
+		for i, r := range txnResp.Responses {
+			kvs := r.GetResponseRange().Kvs
+			if len(kvs) > 0 && kvs[0].ModRevision != t.revs[keys[i]] {
+				return errors.New(types.ErrEntityRevision)
+			}
+		}
+		return errors.New(types.ErrOperationFailure)
+	}
 	return nil
 }
 
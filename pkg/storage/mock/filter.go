@@ -54,6 +54,10 @@ func (Filter) Secret() types.SecretFilter {
 	return new(SecretFilter)
 }
 
+func (Filter) Config() types.ConfigFilter {
+	return new(ConfigFilter)
+}
+
 func (Filter) Trigger() types.TriggerFilter {
 	return new(TriggerFilter)
 }
@@ -62,6 +66,14 @@ func (Filter) Volume() types.VolumeFilter {
 	return new(VolumeFilter)
 }
 
+func (Filter) Build() types.BuildFilter {
+	return new(BuildFilter)
+}
+
+func (Filter) Event() types.EventFilter {
+	return new(EventFilter)
+}
+
 type NamespaceFilter struct{}
 
 type ServiceFilter struct{}
@@ -124,6 +136,12 @@ func (SecretFilter) ByNamespace(namespace string) string {
 	return byNamespace(namespace)
 }
 
+type ConfigFilter struct{}
+
+func (ConfigFilter) ByNamespace(namespace string) string {
+	return byNamespace(namespace)
+}
+
 type VolumeFilter struct{}
 
 func (VolumeFilter) ByNamespace(namespace string) string {
@@ -139,3 +157,15 @@ func (TriggerFilter) ByNamespace(namespace string) string {
 func (TriggerFilter) ByService(namespace, service string) string {
 	return byService(namespace, service)
 }
+
+type BuildFilter struct{}
+
+func (BuildFilter) ByNamespace(namespace string) string {
+	return byNamespace(namespace)
+}
+
+type EventFilter struct{}
+
+func (EventFilter) ByNamespace(namespace string) string {
+	return byNamespace(namespace)
+}
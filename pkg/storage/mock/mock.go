@@ -35,6 +35,9 @@ import (
 type Storage struct {
 	lock   sync.RWMutex
 	store map[string]map[string][]byte
+	// revision - last storage revision, revisions - entities modification revisions
+	revision  int64
+	revisions map[string]map[string]int64
 }
 
 func (s *Storage) Info(ctx context.Context, collection string, name string) (*types.Runtime, error) {
@@ -59,6 +62,8 @@ func (s *Storage) Get(ctx context.Context, collection string, name string, obj i
 		return err
 	}
 
+	setRuntime(reflect.ValueOf(obj).Elem(), s.revisions[collection][name])
+
 	return nil
 }
 
@@ -79,6 +84,7 @@ func (s *Storage) List(ctx context.Context, collection string, q string, obj int
 
 	buffer := []byte("[")
 	current := 0
+	keys := make([]string, 0)
 	for k, item := range s.store[collection] {
 		if strings.HasPrefix(k, q) {
 
@@ -87,6 +93,7 @@ func (s *Storage) List(ctx context.Context, collection string, q string, obj int
 			}
 
 			buffer = append(buffer, item...)
+			keys = append(keys, k)
 			current++
 
 		}
@@ -113,6 +120,11 @@ func (s *Storage) List(ctx context.Context, collection string, q string, obj int
 	}
 
 	f.Set(reflect.ValueOf(items).Elem())
+
+	for i, k := range keys {
+		setRuntime(reflect.Indirect(f.Index(i)), s.revisions[collection][k])
+	}
+
 	return nil
 }
 
@@ -133,6 +145,7 @@ func (s *Storage) Map(ctx context.Context, collection string, q string, obj inte
 
 	buffer := []byte("{")
 	current := 0
+	keys := make(map[string]string, 0)
 	for k, item := range s.store[collection] {
 		if strings.HasPrefix(k, q) {
 
@@ -146,6 +159,7 @@ func (s *Storage) Map(ctx context.Context, collection string, q string, obj inte
 			buffer = append(buffer, []byte(ks[len(ks)-1])...)
 			buffer = append(buffer, []byte("\":")...)
 			buffer = append(buffer, item...)
+			keys[ks[len(ks)-1]] = k
 			current++
 
 		}
@@ -173,6 +187,12 @@ func (s *Storage) Map(ctx context.Context, collection string, q string, obj inte
 
 	f.Set(reflect.ValueOf(items).Elem())
 
+	for _, key := range f.MapKeys() {
+		if k, ok := keys[key.String()]; ok {
+			setRuntime(reflect.Indirect(f.MapIndex(key)), s.revisions[collection][k])
+		}
+	}
+
 	return nil
 }
 
@@ -199,6 +219,8 @@ func (s *Storage) Put(ctx context.Context, collection string, name string, obj i
 	}
 
 	s.store[collection][name] = b
+	s.revisions[collection][name] = s.next()
+	setRuntime(reflect.Indirect(reflect.ValueOf(obj)), s.revisions[collection][name])
 	return nil
 }
 
@@ -208,6 +230,13 @@ func (s *Storage) Set(ctx context.Context, collection string, name string, obj i
 	s.lock.Lock()
 	defer s.lock.Unlock()
 
+	if opts != nil && opts.Rev != nil && s.revisions[collection][name] != *opts.Rev {
+		if _, ok := s.store[collection][name]; ok {
+			return errors.New(types.ErrEntityRevision)
+		}
+		return errors.New(types.ErrEntityNotFound)
+	}
+
 	if _, ok := s.store[collection][name]; !ok {
 		if opts != nil && !opts.Force {
 			return errors.New(types.ErrEntityNotFound)
@@ -220,6 +249,8 @@ func (s *Storage) Set(ctx context.Context, collection string, name string, obj i
 	}
 
 	s.store[collection][name] = b
+	s.revisions[collection][name] = s.next()
+	setRuntime(reflect.Indirect(reflect.ValueOf(obj)), s.revisions[collection][name])
 
 	return nil
 }
@@ -232,9 +263,11 @@ func (s *Storage) Del(ctx context.Context, collection string, name string) error
 
 	if name == "" {
 		s.store[collection] = make(map[string][]byte)
+		s.revisions[collection] = make(map[string]int64)
 		return nil
 	}
 	delete(s.store[collection], name)
+	delete(s.revisions[collection], name)
 	return nil
 }
 
@@ -243,15 +276,23 @@ func (s *Storage) Watch(ctx context.Context, collection string, event chan *type
 	return nil
 }
 
-func (s Storage) Filter() types.Filter {
+// Begin starts multi-key transaction
+func (s *Storage) Begin(ctx context.Context) types.TX {
+	t := new(tx)
+	t.storage = s
+	t.ops = make([]*txOp, 0)
+	return t
+}
+
+func (s *Storage) Filter() types.Filter {
 	return new(Filter)
 }
 
-func (s Storage) Key() types.Key {
+func (s *Storage) Key() types.Key {
 	return new(Key)
 }
 
-func (s Storage) Collection() types.Collection {
+func (s *Storage) Collection() types.Collection {
 	return new(Collection)
 }
 
@@ -260,11 +301,44 @@ func (s *Storage) check(kind string) {
 	defer s.lock.Unlock()
 	if _, ok := s.store[kind]; !ok {
 		s.store[kind] = make(map[string][]byte)
+		s.revisions[kind] = make(map[string]int64)
 	}
 }
 
+// next increments storage revision, should be called under lock
+func (s *Storage) next() int64 {
+	s.revision++
+	return s.revision
+}
+
+// setRuntime sets entity modification revision into entity runtime info
+func setRuntime(v reflect.Value, revision int64) {
+
+	if v.Kind() != reflect.Struct {
+		return
+	}
+
+	f := v.FieldByName("Runtime")
+	if !f.IsValid() || !f.CanSet() {
+		return
+	}
+
+	rt := f.FieldByName("System")
+	if !rt.IsValid() {
+		return
+	}
+
+	r := rt.FieldByName("Revision")
+	if !r.IsValid() || !r.CanSet() || r.Kind() != reflect.Int64 {
+		return
+	}
+
+	r.SetInt(revision)
+}
+
 func New() (*Storage, error) {
 	db := new(Storage)
 	db.store = make(map[string]map[string][]byte)
+	db.revisions = make(map[string]map[string]int64)
 	return db, nil
 }
@@ -61,3 +61,15 @@ func TestStorage_Del(t *testing.T) {
 	assert.NoError(t, err, "storage initialize err")
 	storage.StorageDelAssets(t, stg)
 }
+
+func TestStorage_Tx(t *testing.T) {
+	stg, err := mock.New()
+	assert.NoError(t, err, "storage initialize err")
+	storage.StorageTxAssets(t, stg)
+}
+
+func TestStorage_Revision(t *testing.T) {
+	stg, err := mock.New()
+	assert.NoError(t, err, "storage initialize err")
+	storage.StorageRevisionAssets(t, stg)
+}
@@ -0,0 +1,144 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+
+package mock
+
+import (
+	"encoding/json"
+
+	"github.com/lastbackend/lastbackend/pkg/distribution/errors"
+	"github.com/lastbackend/lastbackend/pkg/storage/types"
+)
+
+const (
+	txOpPut = "put"
+	txOpSet = "set"
+	txOpDel = "del"
+)
+
+type tx struct {
+	storage *Storage
+	ops     []*txOp
+}
+
+type txOp struct {
+	action     string
+	collection string
+	name       string
+	data       []byte
+	force      bool
+	rev        *int64
+}
+
+// Put creates entity in transaction, transaction fails if entity exists and force option is not set
+func (t *tx) Put(collection string, name string, obj interface{}, opts *types.Opts) error {
+
+	b, err := json.Marshal(obj)
+	if err != nil {
+		return err
+	}
+
+	t.ops = append(t.ops, &txOp{action: txOpPut, collection: collection, name: name, data: b, force: opts != nil && opts.Force})
+	return nil
+}
+
+// Set updates entity in transaction, transaction fails if entity not exists and force option is not set
+// or if entity was changed since revision provided in options
+func (t *tx) Set(collection string, name string, obj interface{}, opts *types.Opts) error {
+
+	b, err := json.Marshal(obj)
+	if err != nil {
+		return err
+	}
+
+	op := &txOp{action: txOpSet, collection: collection, name: name, data: b}
+	if opts != nil {
+		op.force = opts.Force
+		op.rev = opts.Rev
+	}
+
+	t.ops = append(t.ops, op)
+	return nil
+}
+
+// Del removes entity in transaction
+func (t *tx) Del(collection string, name string) {
+	t.ops = append(t.ops, &txOp{action: txOpDel, collection: collection, name: name})
+}
+
+// Commit checks all operations conditions and applies operations only if all of them pass
+func (t *tx) Commit() error {
+
+	for _, op := range t.ops {
+		t.storage.check(op.collection)
+	}
+
+	t.storage.lock.Lock()
+	defer t.storage.lock.Unlock()
+
+	var exists = make(map[string]map[string]bool)
+
+	for _, op := range t.ops {
+
+		if _, ok := exists[op.collection]; !ok {
+			exists[op.collection] = make(map[string]bool)
+		}
+
+		e, ok := exists[op.collection][op.name]
+		if !ok {
+			_, e = t.storage.store[op.collection][op.name]
+		}
+
+		switch op.action {
+		case txOpPut:
+			if e && !op.force {
+				return errors.New(types.ErrOperationFailure)
+			}
+			exists[op.collection][op.name] = true
+		case txOpSet:
+			// entity is updated only if it was not changed since revision
+			if op.rev != nil {
+				rev, ok := t.storage.revisions[op.collection][op.name]
+				if !ok {
+					return errors.New(types.ErrOperationFailure)
+				}
+				if rev != *op.rev {
+					return errors.New(types.ErrEntityRevision)
+				}
+			} else if !e && !op.force {
+				return errors.New(types.ErrOperationFailure)
+			}
+			exists[op.collection][op.name] = true
+		case txOpDel:
+			exists[op.collection][op.name] = false
+		}
+	}
+
+	for _, op := range t.ops {
+		switch op.action {
+		case txOpPut, txOpSet:
+			t.storage.store[op.collection][op.name] = op.data
+			t.storage.revisions[op.collection][op.name] = t.storage.next()
+		case txOpDel:
+			delete(t.storage.store[op.collection], op.name)
+			delete(t.storage.revisions[op.collection], op.name)
+		}
+	}
+
+	return nil
+}
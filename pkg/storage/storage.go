@@ -21,6 +21,7 @@ package storage
 import (
 	"context"
 
+	"github.com/lastbackend/lastbackend/pkg/storage/bolt"
 	"github.com/lastbackend/lastbackend/pkg/storage/etcd"
 	"github.com/lastbackend/lastbackend/pkg/storage/mock"
 	"github.com/lastbackend/lastbackend/pkg/storage/types"
@@ -38,7 +39,10 @@ const (
 	RouteKind      types.Kind = "route"
 	VolumeKind     types.Kind = "volume"
 	TriggerKind    types.Kind = "trigger"
+	BuildKind      types.Kind = "build"
+	EventKind      types.Kind = "event"
 	SecretKind     types.Kind = "secret"
+	ConfigKind     types.Kind = "config"
 	EndpointKind   types.Kind = "endpoint"
 	UtilsKind      types.Kind = "utils"
 	ManifestKind   types.Kind = "manifest"
@@ -56,6 +60,7 @@ type Storage interface {
 	Set(ctx context.Context, collection, name string, obj interface{}, opts *types.Opts) error
 	Del(ctx context.Context, collection, name string) error
 	Watch(ctx context.Context, collection string, event chan *types.WatcherEvent, opts *types.Opts) error
+	Begin(ctx context.Context) types.TX
 	Key() types.Key
 	Collection() types.Collection
 	Filter() types.Filter
@@ -65,6 +70,8 @@ func Get(driver string) (Storage, error) {
 	switch driver {
 	case "mock":
 		return mock.New()
+	case "bolt":
+		return bolt.New()
 	default:
 		return etcd.New()
 	}
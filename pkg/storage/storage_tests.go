@@ -27,6 +27,7 @@ import (
 	"github.com/lastbackend/lastbackend/pkg/distribution/errors"
 	"github.com/lastbackend/lastbackend/pkg/distribution/types"
 	"github.com/lastbackend/lastbackend/pkg/log"
+	stgtypes "github.com/lastbackend/lastbackend/pkg/storage/types"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -647,3 +648,285 @@ func StorageDelAssets(t *testing.T, stg Storage) {
 	}
 
 }
+
+func StorageTxAssets(t *testing.T, stg Storage) {
+
+	var ctx = context.Background()
+
+	type obj struct {
+		Name string `json:"name"`
+		Desc string `json:"desc"`
+	}
+
+	type op struct {
+		action string
+		obj    *obj
+		opts   *stgtypes.Opts
+	}
+
+	tests := []struct {
+		name    string
+		exists  []*obj
+		ops     []op
+		want    map[string]string
+		wantErr bool
+		err     string
+	}{
+		{
+			"test tx commit put, set and del",
+			[]*obj{{"set", "demo"}, {"del", "demo"}},
+			[]op{{"put", &obj{"put", "test"}, GetOpts()}, {"set", &obj{"set", "test"}, GetOpts()}, {"del", &obj{"del", ""}, nil}},
+			map[string]string{"put": "test", "set": "test", "del": ""},
+			false,
+			"",
+		},
+		{
+			"test tx is not applied if put entity exists",
+			[]*obj{{"put", "demo"}, {"set", "demo"}},
+			[]op{{"set", &obj{"set", "test"}, GetOpts()}, {"put", &obj{"put", "test"}, GetOpts()}},
+			map[string]string{"put": "demo", "set": "demo"},
+			true,
+			errors.ErrOperationFailure,
+		},
+		{
+			"test tx is not applied if set entity not exists",
+			[]*obj{},
+			[]op{{"put", &obj{"put", "test"}, GetOpts()}, {"set", &obj{"set", "test"}, GetOpts()}},
+			map[string]string{"put": "", "set": ""},
+			true,
+			errors.ErrOperationFailure,
+		},
+		{
+			"test tx is not applied if set entity not exists and options are nil",
+			[]*obj{},
+			[]op{{"put", &obj{"put", "test"}, nil}, {"set", &obj{"set", "test"}, nil}},
+			map[string]string{"put": "", "set": ""},
+			true,
+			errors.ErrOperationFailure,
+		},
+		{
+			"test tx commit set with nil options if entity exists",
+			[]*obj{{"set", "demo"}},
+			[]op{{"put", &obj{"put", "test"}, nil}, {"set", &obj{"set", "test"}, nil}},
+			map[string]string{"put": "test", "set": "test"},
+			false,
+			"",
+		},
+		{
+			"test tx commit set with actual revision",
+			[]*obj{{"set", "demo"}},
+			[]op{{"put", &obj{"put", "test"}, GetOpts()}, {"rev", &obj{"set", "test"}, GetOpts()}},
+			map[string]string{"put": "test", "set": "test"},
+			false,
+			"",
+		},
+		{
+			"test tx is not applied if set entity revision changed",
+			[]*obj{{"set", "demo"}},
+			[]op{{"put", &obj{"put", "test"}, GetOpts()}, {"stale", &obj{"set", "test"}, GetOpts()}},
+			map[string]string{"put": "", "set": "demo"},
+			true,
+			errors.ErrEntityRevision,
+		},
+	}
+
+	for _, tt := range tests {
+
+		t.Run(tt.name, func(t *testing.T) {
+
+			err := stg.Del(ctx, stg.Collection().Test(), "")
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			for _, o := range tt.exists {
+				err = stg.Put(ctx, stg.Collection().Test(), o.Name, o, nil)
+				if !assert.NoError(t, err) {
+					return
+				}
+			}
+
+			tx := stg.Begin(ctx)
+			for _, o := range tt.ops {
+				switch o.action {
+				case "put":
+					err = tx.Put(stg.Collection().Test(), o.obj.Name, o.obj, o.opts)
+				case "set":
+					err = tx.Set(stg.Collection().Test(), o.obj.Name, o.obj, o.opts)
+				case "rev", "stale":
+					// set is compared with revision of existing entity, stale revision is changed before commit
+					var rt types.Runtime
+					rt, err = revisionGet(ctx, stg, o.obj.Name)
+					if !assert.NoError(t, err) {
+						return
+					}
+					if o.action == "stale" {
+						err = stg.Set(ctx, stg.Collection().Test(), o.obj.Name, &obj{o.obj.Name, "demo"}, nil)
+						if !assert.NoError(t, err) {
+							return
+						}
+					}
+					o.opts.Rev = &rt.System.Revision
+					err = tx.Set(stg.Collection().Test(), o.obj.Name, o.obj, o.opts)
+				case "del":
+					tx.Del(stg.Collection().Test(), o.obj.Name)
+				}
+				if !assert.NoError(t, err) {
+					return
+				}
+			}
+
+			err = tx.Commit()
+			if tt.wantErr {
+				if assert.Error(t, err, "expected err") {
+					assert.Equal(t, tt.err, err.Error(), "err message different")
+				}
+			} else if !assert.NoError(t, err) {
+				return
+			}
+
+			for name, desc := range tt.want {
+				out := new(obj)
+				err := stg.Get(ctx, stg.Collection().Test(), name, out, nil)
+
+				if desc == "" {
+					if assert.Error(t, err, "expected err") {
+						assert.Equal(t, errors.ErrEntityNotFound, err.Error(), "err message different")
+					}
+					continue
+				}
+
+				if !assert.NoError(t, err) {
+					continue
+				}
+
+				assert.Equal(t, desc, out.Desc, "object received error")
+			}
+		})
+	}
+
+}
+
+// revisionGet returns runtime info of test collection entity
+func revisionGet(ctx context.Context, stg Storage, name string) (types.Runtime, error) {
+
+	item := struct {
+		types.Runtime
+	}{}
+
+	err := stg.Get(ctx, stg.Collection().Test(), name, &item, nil)
+	return item.Runtime, err
+}
+
+func StorageRevisionAssets(t *testing.T, stg Storage) {
+
+	var ctx = context.Background()
+
+	type obj struct {
+		types.Runtime
+		Name string `json:"name"`
+		Desc string `json:"desc"`
+	}
+
+	tests := []struct {
+		name    string
+		exists  bool
+		stale   bool
+		want    string
+		wantErr bool
+		err     string
+	}{
+		{
+			"test set with actual revision",
+			true,
+			false,
+			"test",
+			false,
+			"",
+		},
+		{
+			"test set err revision conflict",
+			true,
+			true,
+			"demo",
+			true,
+			errors.ErrEntityRevision,
+		},
+		{
+			"test set with revision err entity not found",
+			false,
+			false,
+			"",
+			true,
+			errors.ErrEntityNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+
+		t.Run(tt.name, func(t *testing.T) {
+
+			err := stg.Del(ctx, stg.Collection().Test(), "")
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			item := &obj{Name: "demo", Desc: "demo"}
+			item.System.Revision = 1
+
+			if tt.exists {
+				err = stg.Put(ctx, stg.Collection().Test(), item.Name, item, nil)
+				if !assert.NoError(t, err) {
+					return
+				}
+
+				err = stg.Get(ctx, stg.Collection().Test(), item.Name, item, nil)
+				if !assert.NoError(t, err) {
+					return
+				}
+
+				if !assert.NotEqual(t, int64(0), item.System.Revision, "revision not set") {
+					return
+				}
+			}
+
+			if tt.stale {
+				err = stg.Set(ctx, stg.Collection().Test(), item.Name, &obj{Name: "demo", Desc: "demo"}, nil)
+				if !assert.NoError(t, err) {
+					return
+				}
+			}
+
+			var opts = GetOpts()
+			opts.Rev = &item.System.Revision
+
+			upd := &obj{Name: "demo", Desc: "test"}
+			err = stg.Set(ctx, stg.Collection().Test(), item.Name, upd, opts)
+			if tt.wantErr {
+				if assert.Error(t, err, "expected err") {
+					assert.Equal(t, tt.err, err.Error(), "err message different")
+				}
+			} else if !assert.NoError(t, err) {
+				return
+			}
+
+			if !tt.exists {
+				return
+			}
+
+			out := new(obj)
+			err = stg.Get(ctx, stg.Collection().Test(), item.Name, out, nil)
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			assert.Equal(t, tt.want, out.Desc, "object received error")
+			assert.NotEqual(t, item.System.Revision, out.System.Revision, "revision not changed")
+
+			if !tt.wantErr {
+				assert.Equal(t, out.System.Revision, upd.System.Revision, "updated object revision not match")
+			}
+		})
+	}
+
+}
@@ -31,7 +31,10 @@ type Collection interface {
 	Route() string
 	Volume() string
 	Trigger() string
+	Build() string
+	Event() string
 	Secret() string
+	Config() string
 	Endpoint() string
 	Network() string
 	Subnet() string
@@ -47,6 +50,7 @@ type ManifestCollection interface {
 	Ingress() string
 	Subnet() string
 	Secret() string
+	Config() string
 	Endpoint() string
 }
 
@@ -24,6 +24,7 @@ const (
 	ErrEntityExists          = errors.ErrEntityExists
 	ErrOperationFailure      = errors.ErrOperationFailure
 	ErrEntityNotFound        = errors.ErrEntityNotFound
+	ErrEntityRevision        = errors.ErrEntityRevision
 	ErrStructArgIsNil        = errors.ErrStructArgIsNil
 	ErrStructOutIsNil        = errors.ErrStructOutIsNil
 	ErrStructArgIsInvalid    = errors.ErrStructArgIsInvalid
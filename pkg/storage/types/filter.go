@@ -26,8 +26,11 @@ type Filter interface {
 	Endpoint() EndpointFilter
 	Route() RouteFilter
 	Secret() SecretFilter
+	Config() ConfigFilter
 	Trigger() TriggerFilter
 	Volume() VolumeFilter
+	Build() BuildFilter
+	Event() EventFilter
 }
 
 type NamespaceFilter interface {
@@ -60,6 +63,10 @@ type SecretFilter interface {
 	ByNamespace(namespace string) string
 }
 
+type ConfigFilter interface {
+	ByNamespace(namespace string) string
+}
+
 type VolumeFilter interface {
 	ByNamespace(namespace string) string
 }
@@ -68,3 +75,11 @@ type TriggerFilter interface {
 	ByNamespace(namespace string) string
 	ByService(namespace, service string) string
 }
+
+type BuildFilter interface {
+	ByNamespace(namespace string) string
+}
+
+type EventFilter interface {
+	ByNamespace(namespace string) string
+}
@@ -65,6 +65,14 @@ type Opts struct {
 	Rev   *int64
 }
 
+// TX - multi-key transaction, operations are applied on commit all together or not applied at all
+type TX interface {
+	Put(collection, name string, obj interface{}, opts *Opts) error
+	Set(collection, name string, obj interface{}, opts *Opts) error
+	Del(collection, name string)
+	Commit() error
+}
+
 type Runtime struct {
 	types.Runtime
 }
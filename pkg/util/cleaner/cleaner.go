@@ -117,3 +117,49 @@ func (r *reader) clear() error {
 
 	return nil
 }
+
+// Demux copies raw docker stream into stdout and stderr writers according to message headers
+func Demux(stdout, stderr io.Writer, r io.Reader) error {
+
+	var (
+		prefix = make([]byte, stdWriterPrefixLen)
+		buffer = make([]byte, defaultBufferLength)
+	)
+
+	for {
+
+		if _, err := io.ReadFull(r, prefix); err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return fmt.Errorf("reading prefix err: %s", err.Error())
+		}
+
+		var w io.Writer
+		switch prefix[0] {
+		case 0x0, 0x1:
+			w = stdout
+		case 0x2:
+			w = stderr
+		default:
+			return fmt.Errorf("unexpected stream byte: %#x", prefix[0])
+		}
+
+		size := binary.BigEndian.Uint32(prefix[stdWriterSizeIndex : stdWriterSizeIndex+4])
+		if size > defaultDataLength {
+			return fmt.Errorf("exceeded the data limit (%d/%d) bytes", size, defaultDataLength)
+		}
+
+		if int(size) > len(buffer) {
+			buffer = make([]byte, size)
+		}
+
+		if _, err := io.ReadFull(r, buffer[:int(size)]); err != nil {
+			return fmt.Errorf("read message err: %s", err.Error())
+		}
+
+		if _, err := w.Write(buffer[:int(size)]); err != nil {
+			return err
+		}
+	}
+}
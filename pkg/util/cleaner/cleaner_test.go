@@ -0,0 +1,158 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+
+package cleaner
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func frame(stream byte, data string) []byte {
+	prefix := make([]byte, stdWriterPrefixLen)
+	prefix[0] = stream
+	binary.BigEndian.PutUint32(prefix[stdWriterSizeIndex:], uint32(len(data)))
+	return append(prefix, []byte(data)...)
+}
+
+func frames(f ...[]byte) []byte {
+	return bytes.Join(f, nil)
+}
+
+func TestDemux(t *testing.T) {
+
+	large := strings.Repeat("x", defaultBufferLength*2)
+
+	oversized := make([]byte, stdWriterPrefixLen)
+	oversized[0] = 0x1
+	binary.BigEndian.PutUint32(oversized[stdWriterSizeIndex:], defaultDataLength+1)
+
+	tests := []struct {
+		name   string
+		reader func(r io.Reader) io.Reader
+		data   []byte
+		stdout string
+		stderr string
+		err    string
+	}{
+		{
+			name: "empty stream",
+			data: []byte{},
+		},
+		{
+			name:   "stdout frame",
+			data:   frame(0x1, "out"),
+			stdout: "out",
+		},
+		{
+			name:   "stdin frame to stdout",
+			data:   frame(0x0, "in"),
+			stdout: "in",
+		},
+		{
+			name:   "stderr frame",
+			data:   frame(0x2, "err"),
+			stderr: "err",
+		},
+		{
+			name:   "mixed frames",
+			data:   frames(frame(0x1, "a"), frame(0x2, "b"), frame(0x1, "c")),
+			stdout: "ac",
+			stderr: "b",
+		},
+		{
+			name:   "frame larger than buffer",
+			data:   frame(0x1, large),
+			stdout: large,
+		},
+		{
+			name:   "frames split across reads",
+			reader: iotest.OneByteReader,
+			data:   frames(frame(0x1, "split"), frame(0x2, "frames")),
+			stdout: "split",
+			stderr: "frames",
+		},
+		{
+			name:   "frames split by half reads",
+			reader: iotest.HalfReader,
+			data:   frames(frame(0x2, "half"), frame(0x1, "reader")),
+			stdout: "reader",
+			stderr: "half",
+		},
+		{
+			name: "short header",
+			data: []byte{0x1, 0x0, 0x0},
+			err:  "reading prefix err: unexpected EOF",
+		},
+		{
+			name:   "short header after frame",
+			data:   append(frame(0x1, "ok"), 0x1, 0x0),
+			stdout: "ok",
+			err:    "reading prefix err: unexpected EOF",
+		},
+		{
+			name: "short body",
+			data: frame(0x1, "body")[:stdWriterPrefixLen+2],
+			err:  "read message err: unexpected EOF",
+		},
+		{
+			name: "unexpected stream byte",
+			data: frame(0x3, "data"),
+			err:  "unexpected stream byte: 0x3",
+		},
+		{
+			name: "exceeded data limit",
+			data: oversized,
+			err:  "exceeded the data limit",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+
+			var (
+				stdout = new(bytes.Buffer)
+				stderr = new(bytes.Buffer)
+				r      io.Reader
+			)
+
+			r = bytes.NewReader(tc.data)
+			if tc.reader != nil {
+				r = tc.reader(r)
+			}
+
+			err := Demux(stdout, stderr, r)
+			if tc.err != "" {
+				if assert.Error(t, err) {
+					assert.Contains(t, err.Error(), tc.err)
+				}
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, tc.stdout, stdout.String(), "stdout mismatch")
+			assert.Equal(t, tc.stderr, stderr.String(), "stderr mismatch")
+		})
+	}
+}
@@ -0,0 +1,131 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+
+package crypto
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"io"
+)
+
+// KeyProvider encrypts and decrypts data stored at rest.
+// Additional data is authenticated but not encrypted, decryption fails if it differs
+type KeyProvider interface {
+	Encrypt(data, ad []byte) ([]byte, error)
+	Decrypt(data, ad []byte) ([]byte, error)
+}
+
+// AESGCM is a key provider based on AES cipher in Galois/Counter mode.
+// Encrypted data is prefixed with random nonce
+type AESGCM struct {
+	aead cipher.AEAD
+}
+
+func (p *AESGCM) Encrypt(data, ad []byte) ([]byte, error) {
+
+	nonce := make([]byte, p.aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+
+	return p.aead.Seal(nonce, nonce, data, ad), nil
+}
+
+func (p *AESGCM) Decrypt(data, ad []byte) ([]byte, error) {
+
+	size := p.aead.NonceSize()
+	if len(data) < size {
+		return nil, errors.New("encrypted data is too short")
+	}
+
+	return p.aead.Open(nil, data[:size], data[size:], ad)
+}
+
+// NewAESGCM returns key provider for 16, 24 or 32 bytes length key
+func NewAESGCM(key []byte) (*AESGCM, error) {
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AESGCM{aead: aead}, nil
+}
+
+// NewAESGCMFromString returns key provider for base64 encoded key
+func NewAESGCMFromString(key string) (*AESGCM, error) {
+
+	if key == "" {
+		return nil, errors.New("encryption key is empty")
+	}
+
+	k, err := base64.StdEncoding.DecodeString(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewAESGCM(k)
+}
+
+// EncryptMap encrypts every value of the map bound to the owner self link,
+// so values can not be moved to another entity
+func EncryptMap(p KeyProvider, selflink string, data map[string][]byte) (map[string][]byte, error) {
+
+	if p == nil {
+		return nil, errors.New("key provider is not configured")
+	}
+
+	res := make(map[string][]byte, len(data))
+	for k, v := range data {
+		d, err := p.Encrypt(v, []byte(selflink))
+		if err != nil {
+			return nil, err
+		}
+		res[k] = d
+	}
+
+	return res, nil
+}
+
+// DecryptMap decrypts every value of the map encrypted for the owner self link
+func DecryptMap(p KeyProvider, selflink string, data map[string][]byte) (map[string][]byte, error) {
+
+	if p == nil {
+		return nil, errors.New("key provider is not configured")
+	}
+
+	res := make(map[string][]byte, len(data))
+	for k, v := range data {
+		d, err := p.Decrypt(v, []byte(selflink))
+		if err != nil {
+			return nil, err
+		}
+		res[k] = d
+	}
+
+	return res, nil
+}
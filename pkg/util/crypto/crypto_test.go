@@ -0,0 +1,85 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+
+package crypto
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAESGCM(t *testing.T) {
+
+	key := base64.StdEncoding.EncodeToString([]byte("0123456789abcdef0123456789abcdef"))
+
+	tests := []struct {
+		name    string
+		key     string
+		data    map[string][]byte
+		wantErr bool
+	}{
+		{
+			name: "encrypt and decrypt data",
+			key:  key,
+			data: map[string][]byte{"username": []byte("demo"), "password": []byte("secret")},
+		},
+		{
+			name:    "empty key",
+			key:     "",
+			wantErr: true,
+		},
+		{
+			name:    "invalid key length",
+			key:     base64.StdEncoding.EncodeToString([]byte("short")),
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+
+		t.Run(tc.name, func(t *testing.T) {
+
+			p, err := NewAESGCMFromString(tc.key)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+
+			enc, err := EncryptMap(p, "demo:secret", tc.data)
+			assert.NoError(t, err)
+
+			for k, v := range tc.data {
+				assert.NotEqual(t, v, enc[k], "data not encrypted")
+			}
+
+			dec, err := DecryptMap(p, "demo:secret", enc)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.data, dec, "data mismatch")
+
+			_, err = DecryptMap(p, "test:secret", enc)
+			assert.Error(t, err, "data decrypted for another self link")
+
+			enc["password"][len(enc["password"])-1] ^= 0xff
+			_, err = DecryptMap(p, "demo:secret", enc)
+			assert.Error(t, err, "tampered data decrypted")
+		})
+	}
+}
@@ -0,0 +1,359 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+
+package metrics
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	typeCounter   = "counter"
+	typeGauge     = "gauge"
+	typeHistogram = "histogram"
+
+	// ContentType - prometheus text exposition format content type
+	ContentType = "text/plain; version=0.0.4; charset=utf-8"
+)
+
+// DefBuckets - default histogram buckets for request latencies in seconds
+var DefBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+var registry = &Registry{collectors: make(map[string]Collector, 0)}
+
+// Collector writes metric family in prometheus text format
+type Collector interface {
+	Name() string
+	Write(w io.Writer)
+}
+
+// Registry keeps metrics exposed by daemon
+type Registry struct {
+	lock       sync.RWMutex
+	collectors map[string]Collector
+}
+
+// Register adds collector into registry, collector with the same name is replaced
+func (r *Registry) Register(c Collector) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.collectors[c.Name()] = c
+}
+
+// Write writes all registered metrics sorted by name
+func (r *Registry) Write(w io.Writer) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	var names = make([]string, 0, len(r.collectors))
+	for name := range r.collectors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		r.collectors[name].Write(w)
+	}
+}
+
+// Register adds collector into default registry
+func Register(c Collector) {
+	registry.Register(c)
+}
+
+// Handler writes metrics of default registry in prometheus text format
+func Handler(w http.ResponseWriter, _ *http.Request) {
+	var buf bytes.Buffer
+	registry.Write(&buf)
+	w.Header().Set("Content-Type", ContentType)
+	w.WriteHeader(http.StatusOK)
+	w.Write(buf.Bytes())
+}
+
+// Sample is a metric value with label values
+type Sample struct {
+	Labels []string
+	Value  float64
+}
+
+type desc struct {
+	name   string
+	help   string
+	kind   string
+	labels []string
+}
+
+func (d *desc) Name() string {
+	return d.name
+}
+
+func (d *desc) header(w io.Writer) {
+	fmt.Fprintf(w, "# HELP %s %s\n", d.name, escape(d.help, false))
+	fmt.Fprintf(w, "# TYPE %s %s\n", d.name, d.kind)
+}
+
+func (d *desc) line(w io.Writer, name string, labels []string, value float64, extra ...string) {
+	var pairs = make([]string, 0, len(labels)+1)
+	for i, l := range d.labels {
+		var v string
+		if i < len(labels) {
+			v = labels[i]
+		}
+		pairs = append(pairs, fmt.Sprintf("%s=\"%s\"", l, escape(v, true)))
+	}
+	for i := 0; i+1 < len(extra); i += 2 {
+		pairs = append(pairs, fmt.Sprintf("%s=\"%s\"", extra[i], escape(extra[i+1], true)))
+	}
+
+	if len(pairs) == 0 {
+		fmt.Fprintf(w, "%s %s\n", name, format(value))
+		return
+	}
+
+	fmt.Fprintf(w, "%s{%s} %s\n", name, strings.Join(pairs, ","), format(value))
+}
+
+// vector keeps samples by label values
+type vector struct {
+	desc
+	lock    sync.Mutex
+	samples map[string]*Sample
+}
+
+func (v *vector) add(value float64, labels []string) {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+	v.get(labels).Value += value
+}
+
+func (v *vector) set(value float64, labels []string) {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+	v.get(labels).Value = value
+}
+
+func (v *vector) get(labels []string) *Sample {
+	k := key(labels)
+	s, ok := v.samples[k]
+	if !ok {
+		s = &Sample{Labels: append([]string{}, labels...)}
+		v.samples[k] = s
+	}
+	return s
+}
+
+func (v *vector) Write(w io.Writer) {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	v.header(w)
+	for _, k := range keys(v.samples) {
+		s := v.samples[k]
+		v.line(w, v.name, s.Labels, s.Value)
+	}
+}
+
+// Counter is a monotonically increasing metric
+type Counter struct {
+	vector
+}
+
+// Inc increments counter with label values by 1
+func (c *Counter) Inc(labels ...string) {
+	c.add(1, labels)
+}
+
+// Add increments counter with label values by value, negative values are ignored
+func (c *Counter) Add(value float64, labels ...string) {
+	if value < 0 {
+		return
+	}
+	c.add(value, labels)
+}
+
+// NewCounter creates counter and registers it in default registry
+func NewCounter(name, help string, labels ...string) *Counter {
+	c := new(Counter)
+	c.desc = desc{name: name, help: help, kind: typeCounter, labels: labels}
+	c.samples = make(map[string]*Sample, 0)
+	Register(c)
+	return c
+}
+
+// Gauge is a metric which value can go up and down
+type Gauge struct {
+	vector
+}
+
+// Set sets gauge value with label values
+func (g *Gauge) Set(value float64, labels ...string) {
+	g.set(value, labels)
+}
+
+// Inc increments gauge with label values by 1
+func (g *Gauge) Inc(labels ...string) {
+	g.add(1, labels)
+}
+
+// Dec decrements gauge with label values by 1
+func (g *Gauge) Dec(labels ...string) {
+	g.add(-1, labels)
+}
+
+// NewGauge creates gauge and registers it in default registry
+func NewGauge(name, help string, labels ...string) *Gauge {
+	g := new(Gauge)
+	g.desc = desc{name: name, help: help, kind: typeGauge, labels: labels}
+	g.samples = make(map[string]*Sample, 0)
+	Register(g)
+	return g
+}
+
+// GaugeFunc is a gauge which samples are collected on every metrics request
+type GaugeFunc struct {
+	desc
+	fn func() []Sample
+}
+
+func (g *GaugeFunc) Write(w io.Writer) {
+	samples := g.fn()
+	sort.Slice(samples, func(i, j int) bool {
+		return key(samples[i].Labels) < key(samples[j].Labels)
+	})
+
+	g.header(w)
+	for _, s := range samples {
+		g.line(w, g.name, s.Labels, s.Value)
+	}
+}
+
+// NewGaugeFunc creates gauge collected by fn and registers it in default registry
+func NewGaugeFunc(name, help string, labels []string, fn func() []Sample) *GaugeFunc {
+	g := new(GaugeFunc)
+	g.desc = desc{name: name, help: help, kind: typeGauge, labels: labels}
+	g.fn = fn
+	Register(g)
+	return g
+}
+
+// Histogram counts observed values in configured buckets
+type Histogram struct {
+	desc
+	lock    sync.Mutex
+	buckets []float64
+	samples map[string]*histogramSample
+}
+
+type histogramSample struct {
+	labels []string
+	counts []uint64
+	count  uint64
+	sum    float64
+}
+
+// Observe adds value into histogram with label values
+func (h *Histogram) Observe(value float64, labels ...string) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	k := key(labels)
+	s, ok := h.samples[k]
+	if !ok {
+		s = &histogramSample{labels: append([]string{}, labels...), counts: make([]uint64, len(h.buckets))}
+		h.samples[k] = s
+	}
+
+	for i, b := range h.buckets {
+		if value <= b {
+			s.counts[i]++
+		}
+	}
+	s.count++
+	s.sum += value
+}
+
+func (h *Histogram) Write(w io.Writer) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	h.header(w)
+
+	var list = make([]string, 0, len(h.samples))
+	for k := range h.samples {
+		list = append(list, k)
+	}
+	sort.Strings(list)
+
+	for _, k := range list {
+		s := h.samples[k]
+		for i, b := range h.buckets {
+			h.line(w, h.name+"_bucket", s.labels, float64(s.counts[i]), "le", format(b))
+		}
+		h.line(w, h.name+"_bucket", s.labels, float64(s.count), "le", "+Inf")
+		h.line(w, h.name+"_sum", s.labels, s.sum)
+		h.line(w, h.name+"_count", s.labels, float64(s.count))
+	}
+}
+
+// NewHistogram creates histogram and registers it in default registry
+// buckets should be sorted in increasing order, DefBuckets are used if buckets are not set
+func NewHistogram(name, help string, buckets []float64, labels ...string) *Histogram {
+	if len(buckets) == 0 {
+		buckets = DefBuckets
+	}
+
+	h := new(Histogram)
+	h.desc = desc{name: name, help: help, kind: typeHistogram, labels: labels}
+	h.buckets = buckets
+	h.samples = make(map[string]*histogramSample, 0)
+	Register(h)
+	return h
+}
+
+func key(labels []string) string {
+	return strings.Join(labels, "\xff")
+}
+
+func keys(samples map[string]*Sample) []string {
+	var list = make([]string, 0, len(samples))
+	for k := range samples {
+		list = append(list, k)
+	}
+	sort.Strings(list)
+	return list
+}
+
+func format(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+func escape(s string, quote bool) string {
+	s = strings.Replace(s, `\`, `\\`, -1)
+	s = strings.Replace(s, "\n", `\n`, -1)
+	if quote {
+		s = strings.Replace(s, `"`, `\"`, -1)
+	}
+	return s
+}
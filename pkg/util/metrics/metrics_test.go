@@ -0,0 +1,109 @@
+//
+// Last.Backend LLC CONFIDENTIAL
+// __________________
+//
+// [2014] - [2018] Last.Backend LLC
+// All Rights Reserved.
+//
+// NOTICE:  All information contained herein is, and remains
+// the property of Last.Backend LLC and its suppliers,
+// if any.  The intellectual and technical concepts contained
+// herein are proprietary to Last.Backend LLC
+// and its suppliers and may be covered by Russian Federation and Foreign Patents,
+// patents in process, and are protected by trade secret or copyright law.
+// Dissemination of this information or reproduction of this material
+// is strictly forbidden unless prior written permission is obtained
+// from Last.Backend LLC.
+//
+
+package metrics
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegistryWrite(t *testing.T) {
+
+	r := &Registry{collectors: make(map[string]Collector, 0)}
+
+	c := &Counter{}
+	c.desc = desc{name: "test_requests_total", help: "Requests count", kind: typeCounter, labels: []string{"method", "code"}}
+	c.samples = make(map[string]*Sample, 0)
+	c.Inc("GET", "200")
+	c.Inc("GET", "200")
+	c.Add(3, "POST", "400")
+	c.Add(-1, "POST", "400")
+	r.Register(c)
+
+	g := &Gauge{}
+	g.desc = desc{name: "test_queue", help: "Queue \"depth\"", kind: typeGauge, labels: []string{"name"}}
+	g.samples = make(map[string]*Sample, 0)
+	g.Inc("pod")
+	g.Inc("pod")
+	g.Dec("pod")
+	g.Set(5, "a\"b")
+	r.Register(g)
+
+	f := &GaugeFunc{fn: func() []Sample {
+		return []Sample{{Labels: []string{"ready"}, Value: 2}, {Labels: []string{"error"}, Value: 1}}
+	}}
+	f.desc = desc{name: "test_pods", help: "Pods count", kind: typeGauge, labels: []string{"state"}}
+	r.Register(f)
+
+	h := &Histogram{buckets: []float64{0.1, 1}, samples: make(map[string]*histogramSample, 0)}
+	h.desc = desc{name: "test_latency_seconds", help: "Latency", kind: typeHistogram}
+	h.Observe(0.05)
+	h.Observe(0.5)
+	h.Observe(2)
+	r.Register(h)
+
+	var buf bytes.Buffer
+	r.Write(&buf)
+
+	want := strings.Join([]string{
+		"# HELP test_latency_seconds Latency",
+		"# TYPE test_latency_seconds histogram",
+		"test_latency_seconds_bucket{le=\"0.1\"} 1",
+		"test_latency_seconds_bucket{le=\"1\"} 2",
+		"test_latency_seconds_bucket{le=\"+Inf\"} 3",
+		"test_latency_seconds_sum 2.55",
+		"test_latency_seconds_count 3",
+		"# HELP test_pods Pods count",
+		"# TYPE test_pods gauge",
+		"test_pods{state=\"error\"} 1",
+		"test_pods{state=\"ready\"} 2",
+		"# HELP test_queue Queue \"depth\"",
+		"# TYPE test_queue gauge",
+		"test_queue{name=\"a\\\"b\"} 5",
+		"test_queue{name=\"pod\"} 1",
+		"# HELP test_requests_total Requests count",
+		"# TYPE test_requests_total counter",
+		"test_requests_total{method=\"GET\",code=\"200\"} 2",
+		"test_requests_total{method=\"POST\",code=\"400\"} 3",
+		"",
+	}, "\n")
+
+	assert.Equal(t, want, buf.String(), "metrics output not match")
+}
+
+func TestHandler(t *testing.T) {
+
+	c := NewCounter("test_handler_total", "Handler test counter")
+	c.Inc()
+
+	req, err := http.NewRequest("GET", "/metrics", nil)
+	assert.NoError(t, err)
+
+	res := httptest.NewRecorder()
+	Handler(res, req)
+
+	assert.Equal(t, http.StatusOK, res.Code, "status code not equal")
+	assert.Equal(t, ContentType, res.Header().Get("Content-Type"), "content type not equal")
+	assert.Contains(t, res.Body.String(), "test_handler_total 1\n", "counter not found")
+}
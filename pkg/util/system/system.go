@@ -24,6 +24,7 @@ import (
 	"github.com/lastbackend/lastbackend/pkg/util/system/types"
 	"net"
 	"os"
+	"runtime"
 )
 
 func GetHostname() (string, error) {
@@ -34,6 +35,10 @@ func GetPid() int {
 	return os.Getpid()
 }
 
+func GetCPUCount() int {
+	return runtime.NumCPU()
+}
+
 func GetOsInfo() *types.OsInfo {
 	return _os.GetInfo()
 }
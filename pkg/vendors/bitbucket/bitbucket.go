@@ -261,8 +261,17 @@ func (b *BitBucket) PushPayload(data []byte) (*types.VCSBranch, error) {
 		return nil, err
 	}
 
+	if len(payload.Push.Changes) == 0 || len(payload.Push.Changes[0].Commits) == 0 {
+		return nil, errors.New("changes not found in payload")
+	}
+
 	r, _ := regexp.Compile("<(.+)>$")
 
+	var email string
+	if match := r.FindStringSubmatch(payload.Push.Changes[0].Commits[0].Author.Raw); len(match) > 1 {
+		email = match[1]
+	}
+
 	branch := new(types.VCSBranch)
 	branch.Name = payload.Push.Changes[0].New.Name
 	branch.LastCommit = types.Commit{
@@ -270,7 +279,7 @@ func (b *BitBucket) PushPayload(data []byte) (*types.VCSBranch, error) {
 		Date:     payload.Push.Changes[0].Commits[0].Date,
 		Username: payload.Push.Changes[0].Commits[0].Author.User.Username,
 		Message:  payload.Push.Changes[0].Commits[0].Message,
-		Email:    r.FindStringSubmatch(payload.Push.Changes[0].Commits[0].Author.Raw)[1],
+		Email:    email,
 	}
 
 	return branch, nil
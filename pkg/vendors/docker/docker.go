@@ -150,3 +150,35 @@ func ListTag(owner, name string) (*TagList, error) {
 
 	return tags, nil
 }
+
+func PushPayload(data []byte) (*Push, error) {
+
+	var payload = struct {
+		PushData struct {
+			Tag      string `json:"tag"`
+			Pusher   string `json:"pusher"`
+			PushedAt int64  `json:"pushed_at"`
+		} `json:"push_data"`
+		Repository struct {
+			Name     string `json:"repo_name"`
+			Owner    string `json:"owner"`
+			Official bool   `json:"is_official"`
+		} `json:"repository"`
+	}{}
+
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return nil, err
+	}
+
+	if payload.Repository.Name == "" || payload.PushData.Tag == "" {
+		return nil, fmt.Errorf("repository or tag not found in payload")
+	}
+
+	var push = new(Push)
+	push.Repository = payload.Repository.Name
+	push.Tag = payload.PushData.Tag
+	push.Pusher = payload.PushData.Pusher
+	push.Date = time.Unix(payload.PushData.PushedAt, 0)
+
+	return push, nil
+}
@@ -59,6 +59,13 @@ func (list *RepositoryList) ToJson() ([]byte, error) {
 	return buf, nil
 }
 
+type Push struct {
+	Repository string    `json:"repository"`
+	Tag        string    `json:"tag"`
+	Pusher     string    `json:"pusher"`
+	Date       time.Time `json:"date"`
+}
+
 type Tag struct {
 	Name        string    `json:"name"`
 	ID          int64     `json:"id"`
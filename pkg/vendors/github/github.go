@@ -244,12 +244,17 @@ func (g *GitHub) PushPayload(data []byte) (*types.VCSBranch, error) {
 	}{}
 
 	if err = json.Unmarshal(data, &payload); err != nil {
-		return nil, nil
+		return nil, err
 	}
 
 	var branch = new(types.VCSBranch)
 
-	branch.Name = strings.Split(payload.Ref, "/")[2]
+	ref := strings.SplitN(payload.Ref, "/", 3)
+	if len(ref) < 3 {
+		return nil, errors.New("ref not found in payload")
+	}
+
+	branch.Name = ref[2]
 	branch.LastCommit = types.Commit{
 		Username: payload.Commit.Committer.Username,
 		Email:    payload.Commit.Committer.Email,
@@ -246,7 +246,7 @@ func (g *GitLab) PushPayload(data []byte) (*types.VCSBranch, error) {
 	}{}
 
 	if err = json.Unmarshal(data, &payload); err != nil {
-		return nil, nil
+		return nil, err
 	}
 
 	commit := CommitResponse{}
@@ -261,7 +261,12 @@ func (g *GitLab) PushPayload(data []byte) (*types.VCSBranch, error) {
 
 	var branch = new(types.VCSBranch)
 
-	branch.Name = strings.Split(payload.Ref, "/")[2]
+	ref := strings.SplitN(payload.Ref, "/", 3)
+	if len(ref) < 3 {
+		return nil, errors.New("ref not found in payload")
+	}
+
+	branch.Name = ref[2]
 	branch.LastCommit = types.Commit{
 		Username: commit.Committer.Username,
 		Email:    commit.Committer.Email,